@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/cache"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clear the caches of mm",
+	Long: `Inspect and clear the caches mm keeps in ~/.cache/mm/entries, one namespace
+per kind of data:
+
+  lsync     outdated pages found by mm k8s docs lsync, per localization
+  prs       files of open pull requests, per localization
+  links     status of external links checked by mm quality check
+  results   issues of checked files, per checker
+  glossary  glossary refreshed by mm k8s glossary --refresh, per localization
+
+Entries stay fresh for the TTL of their namespace, which .mm.yaml can change:
+
+  cache:
+    ttl:
+      lsync: 1h
+      links: 7d
+      results: 0   # keep until cleared`,
+}
+
+// cacheListCmd lists the entries of the caches
+var cacheListCmd = &cobra.Command{
+	Use:   "list [namespace]",
+	Short: "List the cached entries",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmdutil.SilenceFailure(cmd)
+		entries, err := cache.List(namespaceArg(args))
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println("No cached entries")
+			return nil
+		}
+
+		namespace := ""
+		for _, entry := range entries {
+			if entry.Namespace != namespace {
+				namespace = entry.Namespace
+				fmt.Printf("%s (ttl %s)\n", namespace, formatTTL(cache.TTL(namespace)))
+			}
+			fmt.Printf("  %-24s %9s  %s  %s\n", entry.Key, formatSize(entry.Size),
+				entry.Created.Format("2006-01-02 15:04"), entryState(entry))
+		}
+		return nil
+	},
+}
+
+// cacheShowCmd prints cached entries with their data
+var cacheShowCmd = &cobra.Command{
+	Use:   "show <namespace> [key]",
+	Short: "Print the data of cached entries",
+	Long: `Print the data of the entry of a namespace with the given key, or of every
+entry of the namespace without one.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmdutil.SilenceFailure(cmd)
+		keys := args[1:]
+		if len(keys) == 0 {
+			entries, err := cache.List(args[0])
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				return fmt.Errorf("no cached entries in %s", args[0])
+			}
+			for _, entry := range entries {
+				keys = append(keys, entry.Key)
+			}
+		}
+
+		for i, key := range keys {
+			entry, data, err := cache.Show(args[0], key)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("# %s/%s, %s, %s\n", entry.Namespace, entry.Key,
+				entry.Created.Format("2006-01-02 15:04"), entryState(*entry))
+			fmt.Print(string(data))
+		}
+		return nil
+	},
+}
+
+// cacheClearCmd removes cached entries
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [namespace]",
+	Short: "Remove the cached entries of a namespace, or of all of them",
+	Long: `Remove the cached entries of a namespace, or of every namespace without one.
+The next run of the commands using them fetches and checks everything again.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmdutil.SilenceFailure(cmd)
+		namespace := namespaceArg(args)
+		if err := cache.Clear(namespace); err != nil {
+			return err
+		}
+		if namespace == "" {
+			fmt.Println("Cleared all caches")
+		} else {
+			fmt.Printf("Cleared the %s cache\n", namespace)
+		}
+		return nil
+	},
+}
+
+// namespaceArg returns the optional namespace argument
+func namespaceArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// entryState tells whether an entry is fresh and until when
+func entryState(entry cache.Entry) string {
+	switch {
+	case entry.Expires.IsZero():
+		return "fresh"
+	case entry.Expired():
+		return "expired"
+	default:
+		return "fresh until " + entry.Expires.Format("2006-01-02 15:04")
+	}
+}
+
+// formatTTL prints a TTL as compactly as .mm.yaml takes it
+func formatTTL(ttl time.Duration) string {
+	switch {
+	case ttl == 0:
+		return "none"
+	case ttl%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", ttl/(24*time.Hour))
+	case ttl%time.Hour == 0:
+		return fmt.Sprintf("%dh", ttl/time.Hour)
+	case ttl%time.Minute == 0:
+		return fmt.Sprintf("%dm", ttl/time.Minute)
+	}
+	return ttl.String()
+}
+
+// formatSize prints a size in bytes with a unit
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheShowCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -0,0 +1,12 @@
+// Package cmdutil holds helpers shared by the commands of mm
+package cmdutil
+
+import "github.com/spf13/cobra"
+
+// SilenceFailure marks the error a command is about to return as a failure
+// of the command rather than a usage error: cobra prints neither the usage
+// nor the error, which main reports once
+func SilenceFailure(cmd *cobra.Command) {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+}
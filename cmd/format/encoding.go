@@ -0,0 +1,70 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+const (
+	encodingUTF8    = "UTF-8"
+	encodingGB18030 = "GB18030"
+)
+
+// detectEncoding guesses the encoding of raw file content.
+// GB18030 is a superset of GBK and GB2312, so it covers all of them.
+func detectEncoding(content []byte) (string, error) {
+	if utf8.Valid(content) {
+		return encodingUTF8, nil
+	}
+
+	decoded, err := simplifiedchinese.GB18030.NewDecoder().Bytes(content)
+	if err == nil && utf8.Valid(decoded) && !bytes.ContainsRune(decoded, utf8.RuneError) {
+		return encodingGB18030, nil
+	}
+
+	return "", fmt.Errorf("unable to detect file encoding (not UTF-8 or GBK/GB18030)")
+}
+
+// decodeContent returns the content as UTF-8 text. Non-UTF-8 content is only
+// converted when the encoding rule is enabled; otherwise an error is returned
+// so that other rules never operate on mangled text.
+func decodeContent(content []byte, convert bool) (string, *changeInfo, error) {
+	encoding, err := detectEncoding(content)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if encoding == encodingUTF8 {
+		return string(content), nil, nil
+	}
+
+	if !convert {
+		return "", nil, fmt.Errorf("file is %s-encoded, not UTF-8; add the 'encoding' rule to convert it first", encoding)
+	}
+
+	decoded, err := simplifiedchinese.GB18030.NewDecoder().Bytes(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to convert from %s: %w", encoding, err)
+	}
+
+	return string(decoded), &changeInfo{
+		line:        1,
+		rule:        "encoding",
+		description: fmt.Sprintf("Converted file encoding from %s to UTF-8", encoding),
+		before:      encoding,
+		after:       encodingUTF8,
+	}, nil
+}
+
+// hasRule checks whether a rule was explicitly requested
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// benchmarkPage returns a translated page with the content formatting
+// meets on the website: front matter, comments keeping the English text,
+// code blocks, shortcodes and long lines of mixed Chinese and English
+func benchmarkPage(sections int) string {
+	var b strings.Builder
+	b.WriteString("---\ntitle: 容器运行时接口（CRI）\ncontent_type: concept\nweight: 60\n---\n\n")
+	for i := 0; i < sections; i++ {
+		fmt.Fprintf(&b, "## 第%d节 Section\n\n", i)
+		b.WriteString("<!--\nThe kubelet uses the Container Runtime Interface, so that it can use a variety of container runtimes.\n-->\n")
+		b.WriteString("kubelet使用容器运行时接口CRI,这样它就可以使用各种容器运行时,而无需重新编译集群组件!你可以参阅[文档](/zh-cn/docs/concepts/)了解更多信息,包括Pod、Service和Deployment的用法以及它们之间的关系?\n\n")
+		b.WriteString("{{< note >}}\n节点上的kubelet需要能够访问API服务器;\n{{< /note >}}\n\n")
+		b.WriteString("```yaml\napiVersion: v1\nkind: Pod\nmetadata:\n  name: nginx\n```\n\n")
+		b.WriteString("运行`kubectl get pods`查看Pod的状态,时间为12:30。\n\n")
+	}
+	return b.String()
+}
+
+func BenchmarkApplyFormattingRules(b *testing.B) {
+	content := benchmarkPage(50)
+	b.SetBytes(int64(len(content)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		applyFormattingRules(content, nil)
+	}
+}
+
+func BenchmarkProcessFiles(b *testing.B) {
+	root := b.TempDir()
+	page := benchmarkPage(20)
+	for dir := 0; dir < 20; dir++ {
+		sub := filepath.Join(root, fmt.Sprintf("section%d", dir))
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			b.Fatal(err)
+		}
+		for file := 0; file < 25; file++ {
+			if err := os.WriteFile(filepath.Join(sub, fmt.Sprintf("page%d.md", file)), []byte(page), 0o644); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	// The dry run reports every change; keep it out of the results
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := processFiles(root, &formatOptions{recursive: true, rules: defaultRules}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package format
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOutputPathFor(t *testing.T) {
+	options := &formatOptions{
+		baseDir:   filepath.FromSlash("content/zh-cn/docs"),
+		outputDir: filepath.FromSlash("/tmp/formatted"),
+	}
+	tests := []struct {
+		file    string
+		want    string
+		wantErr bool
+	}{
+		{"content/zh-cn/docs/overview.md", "/tmp/formatted/overview.md", false},
+		{"content/zh-cn/docs/concepts/pods.md", "/tmp/formatted/concepts/pods.md", false},
+		{"content/zh-cn/docs/..foo.md", "/tmp/formatted/..foo.md", false},
+		{"content/zh-cn/docs/..d/a.md", "/tmp/formatted/..d/a.md", false},
+		{"content/zh-cn/other.md", "", true},
+		{"content/zh-cn", "", true},
+	}
+	for _, tt := range tests {
+		got, err := outputPathFor(filepath.FromSlash(tt.file), options)
+		if (err != nil) != tt.wantErr || got != filepath.FromSlash(tt.want) {
+			t.Errorf("outputPathFor(%s) = %q, %v, want %q", tt.file, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultRules are applied when no --rules flag is given
+var defaultRules = []string{"spacing", "punctuation", "linebreaks"}
+
+// Patterns shared by the rules; compiled once instead of per line
+var (
+	hanPattern          = regexp.MustCompile(`[一-龯]`)
+	hanLatinPattern     = regexp.MustCompile(`([一-龯])([a-zA-Z0-9])`)
+	latinHanPattern     = regexp.MustCompile(`([a-zA-Z0-9])([一-龯])`)
+	timePattern         = regexp.MustCompile(`\d+:\d+`)
+	yamlKeyPattern      = regexp.MustCompile(`^\s*\w+:\s`)
+	orderedListPattern  = regexp.MustCompile(`^\s*\d+\.\s`)
+	inlineCodePattern   = regexp.MustCompile("`[^`]*`")
+	hugoShortcodeRegexp = regexp.MustCompile(`\{\{[<%]/?[^>%]*[>%]\}\}`)
+)
+
+// punctuationPairs maps half-width punctuation to its full-width form.
+// A slice keeps the conversion order deterministic.
+var punctuationPairs = []struct {
+	halfWidth string
+	fullWidth string
+}{
+	{",", "，"},
+	{";", "；"},
+	{":", "："},
+	{"!", "！"},
+	{"?", "？"},
+}
+
+// formatRule is a visitor applied to every unprotected line of a document.
+// visit returns nil when the line is left unchanged, otherwise the
+// replacement lines and a description of the change.
+type formatRule interface {
+	name() string
+	visit(line string) ([]string, string)
+}
+
+// documentLine is a single tokenized line of a document
+type documentLine struct {
+	text      string
+	protected bool // inside code blocks, HTML comments or Hugo shortcodes
+}
+
+// tokenizeDocument splits content into lines once and classifies which
+// lines must not be touched by any rule
+func tokenizeDocument(content string) []documentLine {
+	rawLines := strings.Split(content, "\n")
+	lines := make([]documentLine, len(rawLines))
+
+	var inCodeBlock, inHTMLComment, inHugoShortcode bool
+
+	for i, line := range rawLines {
+		lines[i].text = line
+
+		// Fenced code blocks, including the fence lines themselves
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			lines[i].protected = true
+			continue
+		}
+		if inCodeBlock {
+			lines[i].protected = true
+			continue
+		}
+
+		protected := inHTMLComment || inHugoShortcode
+
+		// Multi-line Hugo shortcodes
+		if !inHugoShortcode && (strings.Contains(line, "{{</*") || strings.Contains(line, "{{%/*")) {
+			inHugoShortcode = true
+			protected = true
+		}
+		if inHugoShortcode && (strings.Contains(line, "*/}}") || strings.Contains(line, "*/%}}")) {
+			inHugoShortcode = false
+			protected = true
+		}
+
+		// HTML comments
+		if !inHTMLComment {
+			if start := strings.Index(line, "<!--"); start >= 0 {
+				end := strings.Index(line[start:], "-->")
+				if end < 0 {
+					inHTMLComment = true
+					protected = true
+				} else if start == 0 && start+end+3 == len(line) {
+					protected = true
+				}
+			}
+		} else if end := strings.Index(line, "-->"); end >= 0 {
+			inHTMLComment = false
+			protected = end+3 == len(line)
+		}
+
+		// Lines consisting solely of inline code or a shortcode
+		if !protected && line != "" {
+			protected = isFullMatch(inlineCodePattern, line) || isFullMatch(hugoShortcodeRegexp, line)
+		}
+
+		lines[i].protected = protected
+	}
+
+	return lines
+}
+
+// isFullMatch reports whether the pattern matches the entire line
+func isFullMatch(pattern *regexp.Regexp, line string) bool {
+	loc := pattern.FindStringIndex(line)
+	return loc != nil && loc[0] == 0 && loc[1] == len(line)
+}
+
+// buildRules resolves rule names into visitors, preserving the given order.
+// Unknown names and the encoding rule (handled before tokenizing) are skipped.
+func buildRules(names []string) []formatRule {
+	var rules []formatRule
+	for _, name := range names {
+		switch name {
+		case "spacing":
+			rules = append(rules, spacingRule{})
+		case "punctuation":
+			rules = append(rules, punctuationRule{})
+		case "linebreaks":
+			rules = append(rules, lineBreakRule{maxLength: 120, preferredLength: 80})
+		}
+	}
+	return rules
+}
+
+// applyFormattingRules tokenizes content once and runs every rule over each
+// unprotected line in a single pass
+func applyFormattingRules(content string, ruleNames []string) (string, []changeInfo) {
+	if len(ruleNames) == 0 {
+		ruleNames = defaultRules
+	}
+	rules := buildRules(ruleNames)
+	if len(rules) == 0 {
+		return content, nil
+	}
+
+	var changes []changeInfo
+	var out strings.Builder
+	out.Grow(len(content) + len(content)/16)
+
+	for lineNum, line := range tokenizeDocument(content) {
+		if lineNum > 0 {
+			out.WriteByte('\n')
+		}
+		if line.protected {
+			out.WriteString(line.text)
+			continue
+		}
+
+		// A rule may split a line, so later rules visit every piece
+		pieces := []string{line.text}
+		for _, rule := range rules {
+			next := pieces[:0:0]
+			for _, piece := range pieces {
+				replaced, description := rule.visit(piece)
+				if replaced == nil {
+					next = append(next, piece)
+					continue
+				}
+				changes = append(changes, changeInfo{
+					line:        lineNum + 1,
+					rule:        rule.name(),
+					description: description,
+					before:      piece,
+					after:       strings.Join(replaced, "\n"),
+				})
+				next = append(next, replaced...)
+			}
+			pieces = next
+		}
+
+		for i, piece := range pieces {
+			if i > 0 {
+				out.WriteByte('\n')
+			}
+			out.WriteString(piece)
+		}
+	}
+
+	return out.String(), changes
+}
+
+// spacingRule adds spaces between Chinese and English text
+type spacingRule struct{}
+
+func (spacingRule) name() string { return "spacing" }
+
+func (spacingRule) visit(line string) ([]string, string) {
+	updated := hanLatinPattern.ReplaceAllString(line, "$1 $2")
+	updated = latinHanPattern.ReplaceAllString(updated, "$1 $2")
+	if updated == line {
+		return nil, ""
+	}
+	return []string{updated}, "Added space between Chinese and English text"
+}
+
+// punctuationRule converts half-width to full-width punctuation in Chinese text
+type punctuationRule struct{}
+
+func (punctuationRule) name() string { return "punctuation" }
+
+func (punctuationRule) visit(line string) ([]string, string) {
+	// Skip YAML frontmatter lines
+	trimmed := strings.TrimSpace(line)
+	hasHan := hanPattern.MatchString(line)
+	if strings.HasPrefix(trimmed, "---") || (strings.Contains(trimmed, ": ") && !hasHan) {
+		return nil, ""
+	}
+
+	// Only convert punctuation if line contains Chinese characters
+	if !hasHan {
+		return nil, ""
+	}
+
+	updated := line
+	for _, pair := range punctuationPairs {
+		// Skip colon conversion if it looks like it's part of a URL, time, or YAML
+		if pair.halfWidth == ":" {
+			if strings.Contains(updated, "://") ||
+				timePattern.MatchString(updated) ||
+				yamlKeyPattern.MatchString(updated) {
+				continue
+			}
+		}
+
+		// Skip exclamation mark conversion if it's part of markdown syntax
+		if pair.halfWidth == "!" {
+			if strings.Contains(updated, "![") ||
+				strings.Contains(updated, "<!--") ||
+				strings.Contains(updated, "`!") ||
+				strings.Contains(updated, "!`") ||
+				strings.Contains(updated, "（`！`）") ||
+				strings.Contains(updated, "(`!`)") {
+				continue
+			}
+		}
+
+		updated = strings.ReplaceAll(updated, pair.halfWidth, pair.fullWidth)
+	}
+
+	if updated == line {
+		return nil, ""
+	}
+	return []string{updated}, "Converted half-width to full-width punctuation"
+}
+
+// lineBreakRule enforces the preferred line length with smart breaking
+type lineBreakRule struct {
+	maxLength       int
+	preferredLength int
+}
+
+func (lineBreakRule) name() string { return "linebreaks" }
+
+func (r lineBreakRule) visit(line string) ([]string, string) {
+	if shouldSkipLineBreaking(line) {
+		return nil, ""
+	}
+
+	lineLength := len([]rune(line))
+	if lineLength <= r.preferredLength {
+		return nil, ""
+	}
+
+	brokenLines := smartLineBreak(line, r.maxLength, r.preferredLength)
+	if len(brokenLines) <= 1 {
+		return nil, ""
+	}
+	return brokenLines, fmt.Sprintf("Broke long line (%d chars) into %d lines", lineLength, len(brokenLines))
+}
@@ -0,0 +1,406 @@
+package k8s
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// batchPlan syncs several related pages, like the pages of a subsection, on
+// one branch with a commit per page and one pull request
+type batchPlan struct {
+	// source is the batch as given by the user
+	source string
+	// dir is the localized directory shared by the pages
+	dir     string
+	branch  string
+	prTitle string
+	pages   []syncPlan
+}
+
+// batchPage is a page of a batch workflow in progress, with the commit
+// message it was planned with
+type batchPage struct {
+	Source string `json:"source"`
+	Path   string `json:"path"`
+	Commit string `json:"commit"`
+}
+
+// localizedPath converts a page or directory given as for newSyncPlan to
+// its localized content path. Paths relative to the content directory
+// that name no English page are taken as relative to docs/.
+func localizedPath(loc locale, p string) string {
+	switch {
+	case strings.HasPrefix(p, k8sdocs.UpstreamDir):
+		return loc.localize(p)
+	case strings.HasPrefix(p, loc.contentDir()):
+		return p
+	case k8sdocs.SectionOf(p) != k8sdocs.SectionDocs, strings.HasPrefix(p, "docs/"):
+		return loc.contentDir() + p
+	}
+	if _, err := os.Stat(filepath.FromSlash(k8sdocs.UpstreamDir + p)); err == nil {
+		return loc.contentDir() + p
+	}
+	return loc.contentDir() + "docs/" + p
+}
+
+// batchSources returns the pages of a batch: the pages lsync reports
+// outdated under a directory, the pages listed one per line in a file, or
+// a comma-separated list of pages
+func batchSources(loc locale, batch string) ([]string, error) {
+	batch = strings.TrimSpace(batch)
+	if info, err := os.Stat(filepath.FromSlash(localizedPath(loc, batch))); err == nil && info.IsDir() {
+		dir := localizedPath(loc, batch)
+		result, err := k8sdocs.Lsync(dir, loc.code)
+		if err != nil {
+			return nil, err
+		}
+		var pages []string
+		for _, page := range result.Outdated {
+			pages = append(pages, displayPath(loc.upstream(page.Path)))
+		}
+		sort.Strings(pages)
+		if len(pages) == 0 {
+			return nil, fmt.Errorf("no pages under %s need syncing", dir)
+		}
+		return pages, nil
+	}
+
+	var pages []string
+	if info, err := os.Stat(batch); err == nil && !info.IsDir() && !isMarkdown(batch) {
+		file, err := os.Open(batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", batch, err)
+		}
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				pages = append(pages, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", batch, err)
+		}
+	} else {
+		for _, page := range strings.Split(batch, ",") {
+			if page = strings.TrimSpace(page); page != "" {
+				pages = append(pages, page)
+			}
+		}
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no pages in batch %s", batch)
+	}
+	return pages, nil
+}
+
+// isMarkdown reports whether a path names a markdown page
+func isMarkdown(p string) bool {
+	ext := strings.ToLower(filepath.Ext(p))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// newBatchPlan returns the plan of a batch of pages. The branch and pull
+// request title come from the branch and commit templates of k8s.templates,
+// named after the directory the pages share.
+func newBatchPlan(loc locale, batch string) (batchPlan, error) {
+	sources, err := batchSources(loc, batch)
+	if err != nil {
+		return batchPlan{}, err
+	}
+	plan := batchPlan{source: batch}
+	seen := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		page, err := newSyncPlan(loc, source)
+		if err != nil {
+			return batchPlan{}, err
+		}
+		if seen[page.path] {
+			continue
+		}
+		seen[page.path] = true
+		plan.pages = append(plan.pages, page)
+
+		// Posts in a bundle share the directory of the bundles
+		dir := path.Dir(page.path)
+		if bundle := k8sdocs.BundleDir(page.path); bundle != "" && page.section != k8sdocs.SectionDocs {
+			dir = path.Dir(bundle)
+		}
+		if plan.dir == "" {
+			plan.dir = dir
+		}
+		for plan.dir != "." && dir != plan.dir && !strings.HasPrefix(dir, plan.dir+"/") {
+			plan.dir = path.Dir(plan.dir)
+		}
+	}
+	if !strings.HasPrefix(plan.dir+"/", loc.contentDir()) {
+		return batchPlan{}, fmt.Errorf("the pages of batch %s are not all in %s", batch, loc.contentDir())
+	}
+
+	cfg, err := loadK8sConfig()
+	if err != nil {
+		return batchPlan{}, err
+	}
+	source := strings.TrimPrefix(plan.dir+"/", loc.contentDir())
+	name := path.Base(plan.dir)
+	if source == "" {
+		// Pages of several parts of the site
+		source, name = "pages", "pages"
+	}
+	data := planTemplateData{
+		Lang:         loc.code,
+		BranchLang:   loc.branchLang(),
+		CommitPrefix: loc.commitPrefix(),
+		Base:         loc.baseBranch(),
+		Release:      loc.release(),
+		Section:      string(k8sdocs.SectionOf(source)),
+		Action:       "sync",
+		Kind:         "pages",
+		Name:         name,
+		Source:       source,
+		Path:         plan.dir + "/",
+		Upstream:     loc.upstream(plan.dir + "/"),
+	}
+	templates := cfg.Templates.ForLang(loc.code)
+	if plan.branch, err = renderPlanTemplate("branch", templates.Branch, defaultDocsBranch, data); err != nil {
+		return batchPlan{}, err
+	}
+	if plan.prTitle, err = renderPlanTemplate("commit", templates.Commit, defaultDocsCommit, data); err != nil {
+		return batchPlan{}, err
+	}
+	if strings.ContainsAny(plan.branch, " ~^:?*[\\") || strings.Contains(plan.branch, "..") {
+		return batchPlan{}, fmt.Errorf("k8s.templates.branch renders an invalid branch name: %q", plan.branch)
+	}
+	return plan, nil
+}
+
+// batchPRBody lists the pages synced by the pull request of a batch with
+// the English commits each one catches up with, followed by the checklist
+func batchPRBody(pages []syncPlan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sync %d translations with their English pages:\n\n", len(pages))
+	for _, page := range pages {
+		switch {
+		case page.newPage:
+			fmt.Fprintf(&b, "- %s (new translation of %s)\n", page.path, page.upstream)
+		case len(page.commits) > 0:
+			fmt.Fprintf(&b, "- %s (+%d -%d lines since %s)\n", page.path, page.added, page.deleted, shortHash(page.syncCommit))
+			for _, commit := range page.commits {
+				fmt.Fprintf(&b, "  - [%s](%s) %s\n", commit.Short(), commit.URL(), commit.Subject)
+			}
+		default:
+			fmt.Fprintf(&b, "- %s\n", page.path)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s", localizationChecklist)
+	return b.String()
+}
+
+// assets returns the English bundle files the pages of a batch lack
+func (b batchPlan) assets() []string {
+	var assets []string
+	for _, page := range b.pages {
+		assets = append(assets, page.assets...)
+	}
+	return assets
+}
+
+// generateBatchCommands prints the git workflow commands of a batch: one
+// branch, a commit per page and one pull request from a remote, the fork
+// found when it is empty
+func generateBatchCommands(loc locale, batch, remoteName string) error {
+	plan, err := newBatchPlan(loc, batch)
+	if err != nil {
+		return err
+	}
+	remote, err := resolvePushRemote(remoteName)
+	if err != nil {
+		return err
+	}
+
+	step := 1
+	fmt.Printf("Git workflow commands for %d pages of: %s/\n\n", len(plan.pages), plan.dir)
+	fmt.Printf("# %d. Create and switch to new branch\n", step)
+	fmt.Printf("git %s\n\n", quoteArgs(loc.switchArgs(plan.branch)))
+	if assets := plan.assets(); len(assets) > 0 {
+		step++
+		fmt.Printf("# %d. Copy the images and other files of the English bundles\n", step)
+		for _, asset := range assets {
+			fmt.Printf("mkdir -p %s\n", path.Dir(loc.localize(asset)))
+			fmt.Printf("cp %s %s\n", asset, loc.localize(asset))
+		}
+		fmt.Printf("\n")
+	}
+	step++
+	fmt.Printf("# %d. After translating each page, commit it with signed-off-by\n", step)
+	for _, page := range plan.pages {
+		if page.syncRef == k8sdocs.SyncRefFrontMatter {
+			fmt.Printf("mm k8s docs sync-ref --lang %s %s\n", loc.code, page.path)
+		}
+		fmt.Printf("git add %s\n", page.stagePath())
+		fmt.Printf("git %s\n\n", quoteArgs(page.commitArgs()))
+	}
+	step++
+	fmt.Printf("# %d. Push branch to %s (%s)\n", step, remote.name, remote.repository)
+	fmt.Printf("%s\n\n", remote.pushCommand(plan.branch))
+	step++
+	fmt.Printf("# %d. Create pull request\n", step)
+	fmt.Printf("%s\n", remote.prCreateCommand(loc, plan.branch, plan.prTitle, batchPRBody(plan.pages)))
+	return nil
+}
+
+// runBatchWorkflow runs the git steps of a batch workflow: creating the
+// branch before the translation, then committing each translated page and
+// pushing the branch
+func runBatchWorkflow(loc locale, batch string, runner *stepRunner, remote string) error {
+	state, err := loadWorkflowState(loc)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		sameBatch := batch == state.Source || strings.TrimSuffix(localizedPath(loc, batch), "/") == state.Path
+		if len(state.Pages) == 0 || !sameBatch {
+			return fmt.Errorf("the workflow of %s (branch %s) is in progress; finish it with --run, or drop it with --abandon", state.Path, state.Branch)
+		}
+		return finishBatchWorkflow(loc.onBranch(state.Base), *state, runner, remote)
+	}
+
+	plan, err := newBatchPlan(loc, batch)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Starting the workflow for %d pages of: %s/\n", len(plan.pages), plan.dir)
+	for _, page := range plan.pages {
+		fmt.Printf("  %s\n", page.path)
+	}
+	fmt.Println()
+
+	current, err := k8sdocs.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if current != plan.branch {
+		if ok, err := runner.git("Create and switch to the sync branch", loc.switchArgs(plan.branch)...); !ok {
+			return err
+		}
+	}
+	if assets := plan.assets(); len(assets) > 0 {
+		if ok, err := runner.copyAssets(loc, assets); !ok {
+			return err
+		}
+	}
+	head, err := k8sdocs.HeadCommit()
+	if err != nil {
+		return err
+	}
+	state = &workflowState{
+		Lang:    loc.code,
+		Source:  batch,
+		Path:    plan.dir,
+		Branch:  plan.branch,
+		PRTitle: plan.prTitle,
+		Started: time.Now(),
+		Head:    head,
+		Base:    loc.base,
+	}
+	for _, page := range plan.pages {
+		state.Pages = append(state.Pages, batchPage{Source: page.source, Path: page.path, Commit: page.commitMessage})
+	}
+	if err := saveWorkflowState(loc, *state); err != nil {
+		return err
+	}
+	fmt.Printf("Now translate the pages, then run:\n  mm k8s docs workflow --run --lang %s\n", loc.code)
+	return nil
+}
+
+// finishBatchWorkflow commits each translated page of a batch workflow in
+// progress and pushes its branch. Pages left untranslated are left out of
+// the pull request.
+func finishBatchWorkflow(loc locale, state workflowState, runner *stepRunner, remoteName string) error {
+	fmt.Printf("Resuming the workflow for %d pages of: %s/ (started %s)\n\n", len(state.Pages), state.Path, formatRelativeTime(state.Started))
+
+	current, err := k8sdocs.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if current != state.Branch {
+		if ok, err := runner.git("Switch back to the sync branch", "switch", state.Branch); !ok {
+			return err
+		}
+	}
+
+	var committed, skipped []syncPlan
+	for _, page := range state.Pages {
+		plan, err := newSyncPlan(loc, page.Source)
+		if err != nil {
+			return err
+		}
+		plan.commitMessage = page.Commit
+		changed, err := k8sdocs.UncommittedFiles(plan.stagePath())
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			skipped = append(skipped, plan)
+			continue
+		}
+		warnStale(plan.upstream, state.Head, loc.baseRef())
+
+		if plan.syncRef == k8sdocs.SyncRefFrontMatter && plan.upstreamCommit != "" &&
+			!sameCommit(k8sdocs.RecordedRef(plan.path), plan.upstreamCommit) {
+			if ok, err := runner.recordRef(plan); !ok {
+				return err
+			}
+		}
+		if ok, err := runner.git("Stage "+plan.path, "add", plan.stagePath()); !ok {
+			return err
+		}
+		if ok, err := runner.git("Commit with Signed-off-by", plan.commitArgs()...); !ok {
+			return err
+		}
+		committed = append(committed, plan)
+	}
+	if len(committed) == 0 {
+		fmt.Printf("None of the pages has changes yet; translate them, then run this again\n")
+		return nil
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("Left out the pages without changes:\n")
+		for _, plan := range skipped {
+			fmt.Printf("  %s\n", plan.path)
+		}
+		fmt.Println()
+	}
+
+	remote, err := resolvePushRemote(remoteName)
+	if err != nil {
+		return err
+	}
+	if k8sdocs.Offline() {
+		if err := clearWorkflowState(loc); err != nil {
+			return err
+		}
+		fmt.Printf("Committed %s. Offline, so push it when back online, then open the pull request:\n", state.Branch)
+		fmt.Printf("%s\n", remote.pushCommand(state.Branch))
+		fmt.Printf("%s\n", remote.prCreateCommand(loc, state.Branch, state.PRTitle, batchPRBody(committed)))
+		return nil
+	}
+	if ok, err := runner.git("Push the branch to "+remote.name, "push", "-u", remote.name, state.Branch); !ok {
+		return err
+	}
+	if err := clearWorkflowState(loc); err != nil {
+		return err
+	}
+	fmt.Printf("Pushed %s. Open the pull request with:\n", state.Branch)
+	fmt.Printf("%s\n", remote.prCreateCommand(loc, state.Branch, state.PRTitle, batchPRBody(committed)))
+	return nil
+}
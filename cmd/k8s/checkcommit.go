@@ -0,0 +1,230 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// checkCommitCmd checks the commits of the branch against the conventions
+// of the localization before they are pushed
+var checkCommitCmd = &cobra.Command{
+	Use:   "check-commit",
+	Short: "Check the commits of the branch before pushing",
+	Long: `Check the commits of the current branch since it forked from --branch against
+the conventions of the localization, and print the commands that fix them:
+
+  1. The subject starts with the prefix of the localization, like [zh-cn],
+     and the release branch on one, like [zh-cn] [dev-1.32]
+  2. The commit only changes the localization: not the English pages,
+     which need a pull request of their own, nor other localizations.
+     Translated pages without an English page at the same path are shown
+     but don't fail.
+  3. The author signed the commit off (Signed-off-by), as the DCO check of
+     the website requires
+
+Without --branch, the commits are checked against the branch the workflow
+--run in progress works against, else main.
+
+Examples:
+  mm k8s docs check-commit
+  mm k8s docs check-commit --branch dev-1.32`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, baseGiven := branchFlagValue(cmd)
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		branch, err := k8sdocs.CurrentBranch()
+		if err != nil {
+			return err
+		}
+		// Without --branch, the commits of the workflow in progress are
+		// checked against the branch it works against
+		if state, err := loadWorkflowState(loc); err == nil && state != nil && state.Base != "" &&
+			!baseGiven && state.Branch == branch {
+			base = state.Base
+		}
+		loc = loc.onBranch(base)
+		if err := checkBranch(loc); err != nil {
+			return err
+		}
+		ref, err := k8sdocs.BranchRef(loc.baseBranch())
+		if err != nil {
+			return err
+		}
+
+		commits, err := k8sdocs.BranchCommits(ref)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			fmt.Printf("No commits on %s since %s\n", branch, ref)
+			return nil
+		}
+
+		rules := k8sdocs.CommitRules{Lang: loc.code, Prefix: loc.commitPrefix(), Base: ref}
+		fmt.Printf("Checking %d commits of %s since %s\n\n", len(commits), branch, ref)
+		fixes := &commitFixes{rules: rules, ref: ref, head: commits[len(commits)-1]}
+		failed := 0
+		for _, commit := range commits {
+			problems := rules.Check(commit)
+			fmt.Printf("%s %s\n", commit.Short(), commit.Subject)
+			if len(problems) == 0 {
+				fmt.Printf("  ok\n")
+			}
+			errors := 0
+			for _, problem := range problems {
+				level := "error"
+				if problem.Warning {
+					level = "warning"
+				} else {
+					errors++
+				}
+				fmt.Printf("  %s: %s\n", level, problem.Message)
+				for _, file := range problem.Files {
+					fmt.Printf("    %s\n", file)
+				}
+				if !problem.Warning {
+					fixes.add(commit, problem)
+				}
+			}
+			if errors > 0 {
+				failed++
+			}
+		}
+		fmt.Println()
+
+		if failed == 0 {
+			fmt.Printf("All %d commits follow the conventions of %s\n", len(commits), loc.code)
+			return nil
+		}
+		fmt.Printf("To fix them:\n")
+		for _, fix := range fixes.commands() {
+			fmt.Printf("  %s\n", fix)
+		}
+		cmdutil.SilenceFailure(cmd)
+		return fmt.Errorf("%d of %d commits break the conventions of %s", failed, len(commits), loc.code)
+	},
+}
+
+// commitFixes collects the fixes of the problems of the commits of a
+// branch: the last commit is amended, the older ones rebased
+type commitFixes struct {
+	rules k8sdocs.CommitRules
+	ref   string
+	head  k8sdocs.BranchCommit
+
+	// signOff is set when older commits lack a sign-off, fixed for every
+	// commit by one rebase
+	signOff bool
+	// others are the steps of an interactive rebase fixing older commits,
+	// in the order of the commits
+	others []string
+	// headSubject, headSignOff and headFiles fix the last commit
+	headSubject bool
+	headSignOff bool
+	headFiles   []string
+	// notYours is set when a commit of someone else lacks their sign-off
+	notYours bool
+}
+
+// add records the fix of a problem of a commit
+func (f *commitFixes) add(commit k8sdocs.BranchCommit, problem k8sdocs.CommitProblem) {
+	head := commit.Hash == f.head.Hash
+	switch problem.Kind {
+	case k8sdocs.CommitPrefix:
+		if head {
+			f.headSubject = true
+		} else {
+			f.others = append(f.others, fmt.Sprintf("reword %s to: %s", commit.Short(), f.rules.FixSubject(commit.Subject)))
+		}
+	case k8sdocs.CommitPath:
+		if head {
+			f.headFiles = append(f.headFiles, problem.Files...)
+		} else {
+			f.others = append(f.others, fmt.Sprintf("edit %s to take out %s", commit.Short(), strings.Join(problem.Files, " ")))
+		}
+	case k8sdocs.CommitSignOff:
+		if !strings.EqualFold(commit.AuthorEmail, k8sdocs.GitConfig("user.email")) {
+			f.notYours = true
+		} else if head {
+			f.headSignOff = true
+		} else {
+			f.signOff = true
+		}
+	}
+}
+
+// commands returns the commands of the fixes, in the order to run them,
+// with the steps of an interactive rebase indented under it
+func (f *commitFixes) commands() []string {
+	var commands []string
+	if f.signOff {
+		// Signs off the last commit too
+		commands = append(commands, "git rebase --signoff "+f.ref)
+		f.headSignOff = false
+	}
+	if len(f.others) > 0 {
+		commands = append(commands, "git rebase -i "+f.ref+", and in it:")
+		for _, step := range f.others {
+			commands = append(commands, "  "+step)
+		}
+	}
+	if len(f.headFiles) > 0 {
+		commands = append(commands, fmt.Sprintf("git reset -q HEAD~1 -- %s && git commit --amend --no-edit",
+			strings.Join(f.headFiles, " ")))
+	}
+	switch {
+	case f.headSubject:
+		amend := []string{"git", "commit", "--amend", "-s", "-m", f.rules.FixSubject(f.head.Subject)}
+		if body := bodyWithoutSignOffs(f.head.Body); body != "" {
+			amend = append(amend, "-m", body)
+		}
+		commands = append(commands, shellQuoteArgs(amend))
+	case f.headSignOff:
+		commands = append(commands, "git commit --amend --no-edit -s")
+	}
+	if f.notYours {
+		commands = append(commands, "# Commits of others need their own sign-off; ask them, or take them over with git commit --amend --reset-author -s")
+	}
+	return commands
+}
+
+// bodyWithoutSignOffs returns the body of a commit message without its
+// Signed-off-by lines, which git commit -s adds back
+func bodyWithoutSignOffs(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "Signed-off-by:") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// shellQuoteArgs joins a command line, single-quoting the arguments the
+// shell would split or expand, so that it can be pasted as is
+func shellQuoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'`$\\*?[]#&;|<>()!~{}") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+func init() {
+	docsCmd.AddCommand(checkCommitCmd)
+
+	addBranchFlag(checkCommitCmd, "Branch the branch forked from, e.g. the release branch dev-1.32 (default main)")
+}
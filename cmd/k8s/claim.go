@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// claimCmd claims pages on the tracking issue of the localization team
+var claimCmd = &cobra.Command{
+	Use:   "claim [file-path...]",
+	Short: "Claim pages to translate so others don't translate them too",
+	Long: `Register that you are translating pages, so that two contributors don't
+open pull requests for the same page.
+
+Claims are comments on a tracking issue of kubernetes/website, set up once
+by the localization team in .mm.yaml:
+
+  k8s:
+    lang: zh-cn
+    claims_issue: 12345   # issue number on kubernetes/website
+    claim_days: 14        # claims expire after this many days (default 14)
+
+Once configured, lsync and workflow show who claimed each page, and
+workflow --available-only skips pages claimed by others. Claiming needs
+a GitHub token (see mm k8s docs --help).
+
+Examples:
+  mm k8s docs claim docs/concepts/overview/what-is-kubernetes.md
+  mm k8s docs claim --list                   # Show the active claims
+  mm k8s docs claim --release docs/concepts/overview/what-is-kubernetes.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, _ := cmd.Flags().GetBool("list")
+		release, _ := cmd.Flags().GetBool("release")
+		force, _ := cmd.Flags().GetBool("force")
+		issue, _ := cmd.Flags().GetInt("issue")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadK8sConfig()
+		if err != nil {
+			return err
+		}
+		if issue == 0 {
+			issue = cfg.ClaimsIssue
+		}
+		if issue == 0 {
+			return fmt.Errorf("no claims issue configured; set k8s.claims_issue in .mm.yaml or use --issue")
+		}
+		ttl := claimTTL(cfg.ClaimDays)
+		if k8sdocs.Offline() {
+			cmdutil.SilenceFailure(cmd)
+			return fmt.Errorf("claims are kept on issue #%d on GitHub: %w", issue, k8sdocs.ErrOffline)
+		}
+
+		claims, err := k8sdocs.Claims(issue, ttl)
+		if err != nil {
+			return err
+		}
+		if list {
+			printClaims(loc, claims, ttl)
+			return nil
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("specify the pages to claim, or --list")
+		}
+
+		user, err := k8sdocs.CurrentUser()
+		if err != nil {
+			return err
+		}
+		var paths []string
+		for _, arg := range args {
+			plan, err := newSyncPlan(loc, arg)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, plan.path)
+		}
+
+		if release {
+			var mine []string
+			for _, path := range paths {
+				if claim, ok := claims[path]; ok && claim.User == user {
+					mine = append(mine, path)
+				} else {
+					fmt.Fprintf(os.Stderr, "Warning: %s is not claimed by you, skipping\n", path)
+				}
+			}
+			if len(mine) == 0 {
+				return nil
+			}
+			url, err := k8sdocs.ReleasePages(issue, mine)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Released %d pages: %s\n", len(mine), url)
+			return nil
+		}
+
+		for _, path := range paths {
+			if _, err := os.Stat(loc.upstream(path)); err != nil {
+				return fmt.Errorf("English page not found for %s", path)
+			}
+			if claim, ok := claims[path]; ok && claim.User != user && !force {
+				return fmt.Errorf("%s is claimed by @%s until %s (%s); use --force to claim it anyway",
+					path, claim.User, claim.Expires(ttl).Format("2006-01-02"), claim.URL)
+			}
+		}
+		url, err := k8sdocs.ClaimPages(issue, paths)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Claimed %d pages for @%s until %s: %s\n", len(paths), user, time.Now().Add(ttl).Format("2006-01-02"), url)
+		return nil
+	},
+}
+
+// claimTTL returns how long claims hold
+func claimTTL(days int) time.Duration {
+	if days <= 0 {
+		days = k8sdocs.DefaultClaimDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// printClaims prints the active claims of the localization
+func printClaims(loc locale, claims map[string]k8sdocs.Claim, ttl time.Duration) {
+	var paths []string
+	for path := range claims {
+		if strings.HasPrefix(path, loc.contentDir()) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		fmt.Printf("No pages of %s are claimed\n", loc.contentDir())
+		return
+	}
+	sort.Strings(paths)
+
+	fmt.Printf("%-20s %-12s %s\n", "User", "Expires", "File")
+	fmt.Printf("%-20s %-12s %s\n", "----", "-------", "----")
+	for _, path := range paths {
+		claim := claims[path]
+		fmt.Printf("%-20s %-12s %s\n", "@"+claim.User, claim.Expires(ttl).Format("2006-01-02"), path)
+	}
+}
+
+// loadClaims returns the active claims when a claims issue is configured.
+// Claims only annotate the output, so failures are warnings.
+func loadClaims() map[string]k8sdocs.Claim {
+	cfg, err := loadK8sConfig()
+	if err != nil || cfg.ClaimsIssue == 0 {
+		return nil
+	}
+	if k8sdocs.Offline() {
+		noteOffline("claims of issue #%d not checked", cfg.ClaimsIssue)
+		return nil
+	}
+	claims, err := k8sdocs.Claims(cfg.ClaimsIssue, claimTTL(cfg.ClaimDays))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+	return claims
+}
+
+// claimNote returns a note on who claimed the localized page of an English
+// path, empty when nobody did
+func claimNote(loc locale, claims map[string]k8sdocs.Claim, filePath string) string {
+	claim, ok := claims[loc.localize(filePath)]
+	if !ok {
+		return ""
+	}
+	return "(claimed by @" + claim.User + ")"
+}
+
+func init() {
+	docsCmd.AddCommand(claimCmd)
+
+	claimCmd.Flags().Bool("list", false, "List the active claims")
+	claimCmd.Flags().Bool("release", false, "Release your claims of the pages")
+	claimCmd.Flags().Bool("force", false, "Claim pages even when someone else claimed them")
+	claimCmd.Flags().Int("issue", 0, "Tracking issue of the claims (default k8s.claims_issue in .mm.yaml)")
+}
@@ -0,0 +1,185 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// coverageReport is the JSON output of coverage
+type coverageReport struct {
+	Lang        string                    `json:"lang"`
+	Target      string                    `json:"target"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Sections    []k8sdocs.SectionCoverage `json:"sections"`
+	Total       k8sdocs.SectionCoverage   `json:"total"`
+}
+
+// coverageCmd reports how much of the site is translated per section
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [path]",
+	Short: "Report translation coverage per section of the docs",
+	Long: `Report how many pages of each top-level section of the docs (concepts,
+tasks, reference...) are translated, up to date, stale or missing, as a
+table, or as a Markdown or HTML report to publish.
+
+A page is stale when its English page changed since it was translated, as
+lsync reports it, and missing when it has no translation yet. Translations
+without an English page aren't counted; see orphans.
+
+Without a path, it reports on content/<lang>/docs/; give content/<lang>/
+to include the blog and the other sections of the site.
+
+Examples:
+  mm k8s docs coverage
+  mm k8s docs coverage -o md > coverage.md
+  mm k8s docs coverage content/zh-cn/ --exclude-generated -o html > coverage.html`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		excludeGenerated, _ := cmd.Flags().GetBool("exclude-generated")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		switch output {
+		case outputTable, outputMarkdown, outputHTML, outputJSON:
+		default:
+			return fmt.Errorf("unsupported output format: %s (use table, md, html or json)", output)
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		if err := checkLocaleDir(loc); err != nil {
+			return err
+		}
+
+		target := loc.contentDir() + "docs/"
+		if len(args) > 0 {
+			target = loc.localize(args[0])
+		}
+		sections, total, err := k8sdocs.Coverage(target, loc.code, excludeGenerated)
+		if err != nil {
+			return err
+		}
+		report := coverageReport{
+			Lang:        loc.code,
+			Target:      target,
+			GeneratedAt: time.Now(),
+			Sections:    sections,
+			Total:       total,
+		}
+
+		switch output {
+		case outputJSON:
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		case outputMarkdown:
+			printCoverageMarkdown(report)
+		case outputHTML:
+			printCoverageHTML(report)
+		default:
+			printCoverageTable(report)
+		}
+		return nil
+	},
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+// printCoverageTable prints the coverage per section
+func printCoverageTable(report coverageReport) {
+	fmt.Printf("Translation coverage of %s\n\n", report.Target)
+	if report.Total.Total == 0 {
+		fmt.Printf("No English pages found\n")
+		return
+	}
+	fmt.Printf("%-28s %-7s %-11s %-11s %-7s %-8s %s\n", "Section", "Pages", "Translated", "Up to date", "Stale", "Missing", "Coverage")
+	fmt.Printf("%-28s %-7s %-11s %-11s %-7s %-8s %s\n", "-------", "-----", "----------", "----------", "-----", "-------", "--------")
+	for _, section := range append(report.Sections, report.Total) {
+		fmt.Printf("%-28s %-7d %-11d %-11d %-7d %-8d %s\n", section.Section, section.Total, section.Translated,
+			section.UpToDate, section.Stale, section.Missing, percent(section.Translated, section.Total))
+	}
+}
+
+// printCoverageMarkdown prints the coverage as a Markdown report
+func printCoverageMarkdown(report coverageReport) {
+	fmt.Printf("## %s translation coverage (%s)\n\n", report.Lang, report.GeneratedAt.Format("2006-01-02"))
+	fmt.Printf("%s of the %d English pages of `%s` are translated, %s up to date.\n\n",
+		percent(report.Total.Translated, report.Total.Total), report.Total.Total, report.Target,
+		percent(report.Total.UpToDate, report.Total.Total))
+	if report.Total.Total == 0 {
+		return
+	}
+	fmt.Printf("| Section | Pages | Translated | Up to date | Stale | Missing | Coverage |\n")
+	fmt.Printf("| --- | ---: | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, section := range report.Sections {
+		fmt.Printf("| %s | %d | %d | %d | %d | %d | %s |\n", markdownCell(section.Section), section.Total, section.Translated,
+			section.UpToDate, section.Stale, section.Missing, percent(section.Translated, section.Total))
+	}
+	total := report.Total
+	fmt.Printf("| **Total** | **%d** | **%d** | **%d** | **%d** | **%d** | **%s** |\n", total.Total, total.Translated,
+		total.UpToDate, total.Stale, total.Missing, percent(total.Translated, total.Total))
+}
+
+// printCoverageHTML prints the coverage as a standalone HTML page
+func printCoverageHTML(report coverageReport) {
+	title := html.EscapeString(fmt.Sprintf("%s translation coverage (%s)", report.Lang, report.GeneratedAt.Format("2006-01-02")))
+	fmt.Printf(`<!DOCTYPE html>
+<html lang="%s">
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; }
+td { text-align: right; }
+td:first-child { text-align: left; }
+tfoot { font-weight: bold; }
+progress { width: 8em; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<p>%s of the %d English pages of <code>%s</code> are translated, %s up to date.</p>
+`, html.EscapeString(report.Lang), title, title,
+		percent(report.Total.Translated, report.Total.Total), report.Total.Total, html.EscapeString(report.Target),
+		percent(report.Total.UpToDate, report.Total.Total))
+	if report.Total.Total > 0 {
+		fmt.Printf("<table>\n<thead>\n<tr><th>Section</th><th>Pages</th><th>Translated</th><th>Up to date</th><th>Stale</th><th>Missing</th><th>Coverage</th></tr>\n</thead>\n<tbody>\n")
+		for _, section := range report.Sections {
+			printCoverageRow(section)
+		}
+		fmt.Printf("</tbody>\n<tfoot>\n")
+		printCoverageRow(report.Total)
+		fmt.Printf("</tfoot>\n</table>\n")
+	}
+	fmt.Printf("</body>\n</html>\n")
+}
+
+// printCoverageRow prints the HTML table row of a section
+func printCoverageRow(section k8sdocs.SectionCoverage) {
+	fmt.Printf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td><progress max=\"%d\" value=\"%d\"></progress> %s</td></tr>\n",
+		html.EscapeString(section.Section), section.Total, section.Translated, section.UpToDate, section.Stale,
+		section.Missing, section.Total, section.Translated, percent(section.Translated, section.Total))
+}
+
+func init() {
+	docsCmd.AddCommand(coverageCmd)
+
+	coverageCmd.Flags().StringP("output", "o", outputTable, "Output format (table, md, html, json)")
+	coverageCmd.Flags().Bool("exclude-generated", false, "Skip generated reference pages")
+}
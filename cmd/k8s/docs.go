@@ -1,16 +1,16 @@
 package k8s
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/cache"
+	"github.com/samzong/mm/internal/k8sdocs"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +18,100 @@ import (
 var docsCmd = &cobra.Command{
 	Use:   "docs",
 	Short: "Documentation management commands",
-	Long:  `Commands for managing Kubernetes documentation synchronization.`,
+	Long: `Commands for managing Kubernetes documentation synchronization.
+
+The commands work on the Chinese localization (content/zh-cn) by default.
+Other localization teams select theirs with --lang (ja, ko, fr, pt-br, ...)
+or set a default in .mm.yaml:
+
+  k8s:
+    lang: ja
+
+The commands run from the root of the repository of the site, found from
+the directory they are started in: paths given relative to a directory
+of it, like a.md in content/zh-cn/docs/, are taken from there.
+
+The same commands work on other localized Hugo and Docusaurus sites, like
+the websites of CNCF projects, described by k8s.project in .mm.yaml: a
+type, hugo (content/en/ translated to content/<lang>/) or docusaurus (docs/
+translated to i18n/<lang>/docusaurus-plugin-content-docs/current/), and
+the settings that differ from it. k8s.sync_ref selects how translations
+record the English version they were made from.
+
+  k8s:
+    lang: zh-Hans
+    project:
+      type: docusaurus
+      repository: example/website
+      branch: master                      # default main
+      source_dir: docs/
+      target_dir: i18n/{lang}/docusaurus-plugin-content-docs/current/
+      label: "lang/{short_lang}"          # label of the pull requests, if any
+      generated: [api/]                   # directories of generated pages
+
+Commands that call the GitHub API authenticate with GITHUB_TOKEN or
+GH_TOKEN, else with k8s.github_token in .mm.yaml, else with the token of
+the GitHub CLI (gh auth login).
+
+--offline works from local git and cached data alone, on a flight or a
+flaky network: GitHub isn't called and remotes aren't fetched. Pull request
+checks use the open pull requests cached by the last online run, the git
+steps of workflow --run and pr create stop before pushing, and notes
+starting with "Offline:" tell which data is stale or was left out.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadK8sConfig()
+		if err != nil {
+			return err
+		}
+		k8sdocs.SetGitHubToken(cfg.GitHubToken)
+		offline, _ := cmd.Flags().GetBool("offline")
+		k8sdocs.SetOffline(offline)
+		project, err := k8sdocs.NewProject(cfg.Project.Type, k8sdocs.Project{
+			Repository: cfg.Project.Repository,
+			SourceDir:  cfg.Project.SourceDir,
+			TargetDir:  cfg.Project.TargetDir,
+			Branch:     cfg.Project.Branch,
+			Label:      cfg.Project.Label,
+			Generated:  cfg.Project.Generated,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid k8s.project in .mm.yaml: %w", err)
+		}
+		k8sdocs.UseProject(project)
+		return enterProjectRoot(cmd, args)
+	},
+}
+
+// enterProjectRoot makes the commands started in a directory of the site
+// run from its root, rewriting the paths of args and --batch given
+// relative to the directory. Outside a site it does nothing, leaving the
+// commands to report it.
+func enterProjectRoot(cmd *cobra.Command, args []string) error {
+	wd, err := os.Getwd()
+	if err != nil || k8sdocs.IsRoot(wd) {
+		return nil
+	}
+	root, ok := k8sdocs.FindRoot(wd)
+	if !ok {
+		return nil
+	}
+	// cobra passes the same args to RunE
+	for i, arg := range args {
+		args[i] = k8sdocs.RootRelative(root, wd, arg)
+	}
+	if flag := cmd.Flags().Lookup("batch"); flag != nil && flag.Changed {
+		parts := strings.Split(flag.Value.String(), ",")
+		for i, part := range parts {
+			parts[i] = k8sdocs.RootRelative(root, wd, strings.TrimSpace(part))
+		}
+		if err := flag.Value.Set(strings.Join(parts, ",")); err != nil {
+			return err
+		}
+	}
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("failed to change to the root of the site %s: %w", root, err)
+	}
+	return nil
 }
 
 // lsyncCmd represents the lsync command
@@ -26,19 +119,143 @@ var lsyncCmd = &cobra.Command{
 	Use:   "lsync [path]",
 	Short: "Language synchronization for documentation",
 	Long: `Check documentation synchronization between different languages.
-This command calls the Kubernetes lsync.sh script to identify outdated translations.
+Like the scripts/lsync.sh script of kubernetes/website, this finds the localized
+pages whose English original changed since the page was last committed, or
+since the English commit recorded for it with sync-ref, and shows the
+English changes of a single page. It only needs git.
+
+English pages renamed since a page was translated are followed to their
+new path: the page is compared with the renamed English page, marked
+"renamed from" the old path, and when the translation is still at the old
+path, lsync suggests the git mv that moves it along.
 
 Examples:
   mm k8s docs lsync                                      # Check all documents
   mm k8s docs lsync content/zh-cn/docs/concepts/        # Check specific directory
-  mm k8s docs lsync content/zh-cn/docs/concepts/cri.md  # Check specific file`,
+  mm k8s docs lsync content/zh-cn/docs/concepts/cri.md  # Check specific file
+  mm k8s docs lsync content/zh-cn/docs/concepts/cri.md --side-by-side
+  mm k8s docs lsync content/zh-cn/docs/concepts/cri.md --apply
+  mm k8s docs lsync --lang ja                            # Check the Japanese localization
+  mm k8s docs lsync -i                                   # Browse the results and pick files to translate
+  mm k8s docs lsync -o csv > outdated.csv                # Export for a spreadsheet (also json, md)
+  mm k8s docs lsync --path concepts/ --min-changed 10    # Concepts pages with 10+ changed lines
+  mm k8s docs lsync --scope mine                         # The parts of the site you maintain
+  mm k8s docs lsync --modified-within 30d --include-generated
+  mm k8s docs lsync --sort priority                      # Highest-impact pages first
+  mm k8s docs lsync --sort added --limit 20              # The 20 pages with the most added lines
+  mm k8s docs lsync --section blog                       # Check the blog posts only
+  mm k8s docs lsync --include-missing                    # Also list pages not translated yet
+  mm k8s docs lsync --branch dev-1.32                    # The release branch of Kubernetes 1.32
+
+The results are sorted by the time of the English change, newest first;
+--sort added and --sort deleted put the pages with the most added or
+deleted English lines first, and --sort path sorts them by path.
+--limit N shows the first N pages only; the results cached for workflow
+keep them all.
+
+--sort priority ranks pages by the size of the English changes, how long
+the translation has been outdated and the importance of the page, which is
+configurable in .mm.yaml:
+
+  k8s:
+    priority:
+      weights:                 # by path under content/en/, default 1
+        docs/concepts/: 2
+        docs/reference/: 0.5
+      analytics: views.csv     # optional page views: path,views
+      size_weight: 0.6
+      staleness_weight: 0.4
+
+--scope selects the paths of a scope named in .mm.yaml, for the parts of
+the site a contributor keeps up to date:
+
+  k8s:
+    scopes:
+      mine:
+        - concepts/
+        - tasks/administer-cluster/
+
+During a release, --branch dev-1.32 works against the release branch of
+the upcoming version: lsync compares the pages checked out, which must be on
+it (upstream/dev-1.32, or origin/dev-1.32 without an upstream remote), and
+caches its results apart from those of main for workflow --branch.
+
+Generated reference pages, like the API and kubectl references, are listed
+apart from the pages to translate, with how to sync them; --include-generated
+lists them with the others. A page is generated when it is under a
+directory of k8s.project.generated in .mm.yaml (the reference directories
+of kubernetes/website by default), its front matter says
+auto_generated: true, or a comment at its top says it is auto-generated.
+
+For a single page, lsync shows the English paragraphs that changed with the
+changed words highlighted; --side-by-side puts the current translation of
+each paragraph next to it, found from the English text the translation
+keeps in HTML comments. --diff line shows the plain git diff instead.
+
+--apply goes through the English changes of a single page one block at a
+time and offers to make those that need no translating to the translated
+page as they are: changed, added and removed code blocks, YAML manifests
+among them, which translations keep as in English, and paragraphs whose
+link targets changed and nothing else. The prose changes are left to
+translate, listed at the end with the changes that couldn't be applied.
+
+In the interactive picker, type to search, Tab selects files, Ctrl-S changes
+the sort column, Ctrl-R reverses it and Ctrl-P previews the English changes
+of the current file.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		checkPR, _ := cmd.Flags().GetBool("check-pr")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		output, _ := cmd.Flags().GetString("output")
+		sortOrder, _ := cmd.Flags().GetString("sort")
+		limit, _ := cmd.Flags().GetInt("limit")
+		sectionName, _ := cmd.Flags().GetString("section")
+		includeMissing, _ := cmd.Flags().GetBool("include-missing")
+		diffMode, _ := cmd.Flags().GetString("diff")
+		sideBySide, _ := cmd.Flags().GetBool("side-by-side")
+		apply, _ := cmd.Flags().GetBool("apply")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if loc, err = resolveBranch(cmd, loc); err != nil {
+			return err
+		}
+		if err := checkOutputFormat(output); err != nil {
+			return err
+		}
+		if err := checkSortOrder(sortOrder); err != nil {
+			return err
+		}
+		if err := checkDiffMode(diffMode); err != nil {
+			return err
+		}
+		if limit < 0 {
+			return fmt.Errorf("--limit must be 0 (no limit) or more")
+		}
+		if sideBySide && diffMode != diffWord {
+			return fmt.Errorf("--side-by-side only works with --diff word")
+		}
+		if apply && (output != outputTable || interactive || sideBySide || diffMode != diffWord) {
+			return fmt.Errorf("--apply only works with the table output and --diff word, without --interactive or --side-by-side")
+		}
+		if output != outputTable && (interactive || checkPR) {
+			return fmt.Errorf("--interactive and --check-pr only work with the table output")
+		}
+		filter, err := newLsyncFilter(cmd, loc)
+		if err != nil {
+			return err
+		}
 		
 		// Check if we're in a k8s project directory
 		if !isK8sProject() {
-			return fmt.Errorf("scripts/lsync.sh not found. Please make sure scripts/lsync.sh is in project root")
+			return notProjectError()
+		}
+		if err := checkLocaleDir(loc); err != nil {
+			return err
+		}
+		if ref := loc.baseRef(); loc.base != "" && !k8sdocs.OnBranch(ref) {
+			return fmt.Errorf("the pages checked out are not on %s; check out the release branch first:\n  git switch -C %s %s", ref, loc.base, ref)
 		}
 
 		// Determine the path to check
@@ -46,37 +263,130 @@ Examples:
 		if len(args) > 0 {
 			inputPath := args[0]
 			// If user provides English path, convert to corresponding localized path
-			if strings.HasPrefix(inputPath, "content/en/") {
-				// Try to find corresponding zh-cn file
-				zhPath := strings.Replace(inputPath, "content/en/", "content/zh-cn/", 1)
-				if _, err := os.Stat(zhPath); err == nil {
-					targetPath = zhPath
+			if strings.HasPrefix(inputPath, k8sdocs.UpstreamDir) {
+				// Try to find corresponding localized file
+				localPath := loc.localize(inputPath)
+				if _, err := os.Stat(localPath); err == nil {
+					targetPath = localPath
 				} else {
-					return fmt.Errorf("corresponding Chinese file not found: %s", zhPath)
+					return fmt.Errorf("corresponding %s file not found: %s", loc.code, localPath)
 				}
 			} else {
 				targetPath = inputPath
 			}
+		} else if sectionName != "" {
+			section, err := k8sdocs.ParseSection(sectionName)
+			if err != nil {
+				return err
+			}
+			targetPath = loc.contentDir() + string(section) + "/"
 		} else {
-			targetPath = "content/zh-cn/"
+			targetPath = loc.contentDir()
 		}
 
 		// Execute lsync.sh
-		result, err := executeLsync(targetPath)
+		result, err := executeLsync(loc, targetPath)
 		if err != nil {
 			return fmt.Errorf("failed to execute lsync: %w", err)
 		}
+		if apply && !result.isSingleFile {
+			return fmt.Errorf("--apply works on a single page; give the path of one")
+		}
+		// Generated reference pages are listed apart, with how to sync them
+		var generated []fileChange
+		if !result.isSingleFile {
+			result.files, generated = filter.splitGenerated(result.files)
+			generated = filter.apply(generated)
+		}
+		total := len(result.files)
+		result.files = filter.apply(result.files)
+		result.hasChanges = len(result.files) > 0
+		if err := sortFiles(result.files, sortOrder); err != nil {
+			return err
+		}
+		// --limit only shortens what is shown; the cache keeps every file
+		shown := result.files
+		if !result.isSingleFile {
+			shown = limitFiles(result.files, limit)
+		}
+		if output == outputTable && !result.isSingleFile {
+			switch {
+			case len(shown) < len(result.files) && filter.active():
+				fmt.Printf("Showing the first %d of %d outdated files matching the filters (%d in all)\n\n", len(shown), len(result.files), total)
+			case len(shown) < len(result.files):
+				fmt.Printf("Showing the first %d of %d outdated files\n\n", len(shown), len(result.files))
+			case filter.active():
+				fmt.Printf("Showing %d of %d outdated files matching the filters\n\n", len(result.files), total)
+			}
+		}
+
+		// English pages without a translation
+		var missing []k8sdocs.MissingPage
+		if includeMissing && !result.isSingleFile {
+			missing, err = k8sdocs.Missing(targetPath, loc.code)
+			if err != nil {
+				return fmt.Errorf("failed to find untranslated pages: %w", err)
+			}
+			missing = filter.applyMissing(missing)
+		}
+
+		// Structured output for other tools
+		if output != outputTable {
+			if len(generated) > 0 {
+				fmt.Fprintf(os.Stderr, "Left out %d outdated generated reference pages; --include-generated lists them\n", len(generated))
+			}
+			if err := saveCache(loc, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to save cache: %v\n", err)
+			}
+			return writeLsyncOutput(loc, output, shown, missing)
+		}
 
 		// Display results
 		if result.hasChanges {
-			if result.isSingleFile {
+			if interactive && !result.isSingleFile {
+				// Pick files to translate from the results
+				if err := saveCache(loc, result); err != nil {
+					fmt.Printf("Warning: Failed to save cache: %v\n", err)
+				}
+				title := fmt.Sprintf("Found %d files needing translation", len(result.files))
+				if len(shown) < len(result.files) {
+					title = fmt.Sprintf("Found %d files needing translation, showing the first %d", len(result.files), len(shown))
+				}
+				selected, err := selectFiles(loc, title, shown)
+				if err != nil {
+					return err
+				}
+				return generateSelectedWorkflows(loc, selected, "")
+			} else if result.isSingleFile {
 				// For single file, show detailed diff directly
-				fmt.Print(result.rawOutput)
+				localized := loc.localize(result.files[0].FilePath)
+				if result.files[0].MoveFrom != "" {
+					localized = result.files[0].MoveFrom
+				}
+				if diffMode == diffLine {
+					fmt.Print(result.rawOutput)
+				} else if apply {
+					if err := applyUpstreamHunks(loc, localized); err != nil {
+						return err
+					}
+				} else if err := printWordDiff(loc, localized, sideBySide); err != nil {
+					return err
+				}
 			} else {
 				// For multiple files, show summary table with modification time
+				claims := loadClaims()
+				if sortOrder == sortPriority {
+					fmt.Printf("%-6s ", "Score")
+				}
 				fmt.Printf("%-8s %-8s %-12s %-8s %s\n", "Added", "Deleted", "LastModified", "Commit", "File")
+				if sortOrder == sortPriority {
+					fmt.Printf("%-6s ", "-----")
+				}
 				fmt.Printf("%-8s %-8s %-12s %-8s %s\n", "-----", "-------", "------------", "------", "----")
-				for _, file := range result.files {
+				for _, file := range shown {
+					if sortOrder == sortPriority {
+						fmt.Printf("%-6.1f ", file.Priority)
+					}
 					// Format time as relative (e.g., "2 days ago") 
 					timeStr := formatRelativeTime(file.LastModified)
 					fmt.Printf("%-8d %-8d %-12s %-8s %s\n", 
@@ -84,24 +394,32 @@ Examples:
 						file.DeletedLines, 
 						timeStr,
 						file.LastCommit,
-						file.FilePath)
+						strings.TrimSpace(file.FilePath+" "+renameNote(file)+" "+claimNote(loc, claims, file.FilePath)))
 				}
+				printMoveCommands(loc, shown)
 			}
 		} else {
 			fmt.Printf("All files are up to date\n")
 		}
+		if includeMissing && !result.isSingleFile {
+			printMissingTable(loc, newMissingRows(loc, missing))
+		}
+		printGeneratedPages(loc, generated)
+		if result.orphaned > 0 {
+			fmt.Printf("\n%d translations have no English page; run mm k8s docs orphans to fix them\n", result.orphaned)
+		}
 
 		// Check PR if requested
-		if checkPR && len(result.files) > 0 {
+		if checkPR && len(shown) > 0 {
 			fmt.Printf("\nChecking related PRs...\n")
-			err := checkRelatedPRs(result.files)
+			err := checkRelatedPRs(loc, shown)
 			if err != nil {
 				fmt.Printf("  Error checking PRs: %v\n", err)
 			}
 		}
 
 		// Save to cache
-		if err := saveCache(result); err != nil {
+		if err := saveCache(loc, result); err != nil {
 			fmt.Printf("Warning: Failed to save cache: %v\n", err)
 		}
 
@@ -116,6 +434,10 @@ type fileChange struct {
 	FilePath     string    `json:"file_path"`
 	LastCommit   string    `json:"last_commit"`    // commit hash
 	LastModified time.Time `json:"last_modified"` // last modification time
+	SyncCommit   string    `json:"sync_commit,omitempty"` // last commit of the translation
+	Priority     float64   `json:"priority,omitempty"`    // set by --sort priority
+	RenamedFrom  string    `json:"renamed_from,omitempty"` // English path at the sync commit
+	MoveFrom     string    `json:"move_from,omitempty"`    // translation still at the old path
 }
 
 // lsyncResult represents the result of lsync execution
@@ -124,33 +446,28 @@ type lsyncResult struct {
 	hasChanges bool
 	rawOutput  string  // Store raw output for single file diff display
 	isSingleFile bool  // Track if this was a single file check
+	orphaned   int     // Localized pages without an English page
 }
 
 // lsyncCache represents cached lsync results
 type lsyncCache struct {
+	Lang      string       `json:"lang"`
 	Timestamp time.Time    `json:"timestamp"`
 	GitCommit string       `json:"git_commit"`
 	Files     []fileChange `json:"files"`
-	TTL       time.Duration `json:"ttl"`
+	// expired is set when the entry outlived the TTL of the lsync cache
+	expired bool
 }
 
 // isK8sProject checks if current directory is a k8s project
 func isK8sProject() bool {
-	_, err := os.Stat("./scripts/lsync.sh")
-	return err == nil
+	return k8sdocs.IsRoot(".")
 }
 
-// getCacheFilePath returns the path to the cache file
-func getCacheFilePath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	cacheDir := filepath.Join(homeDir, ".cache", "mm")
-	if err := os.MkdirAll(cacheDir, 0755); err != nil {
-		return "", err
-	}
-	return filepath.Join(cacheDir, "k8s-docs-lsync.json"), nil
+// notProjectError is the error of the commands run outside the repository
+// of the site
+func notProjectError() error {
+	return fmt.Errorf("%s not found here or in a parent directory. Please run from the %s repository", k8sdocs.UpstreamDir, k8sdocs.Repository)
 }
 
 // getCurrentGitCommit gets the current git HEAD commit hash
@@ -165,16 +482,7 @@ func getCurrentGitCommit() string {
 
 // isValidCache checks if the cache is still valid
 func (c *lsyncCache) isValid() bool {
-	if c.Timestamp.IsZero() {
-		return false
-	}
-	
-	// Check TTL (30 minutes default)
-	ttl := c.TTL
-	if ttl == 0 {
-		ttl = 30 * time.Minute
-	}
-	if time.Since(c.Timestamp) > ttl {
+	if c.Timestamp.IsZero() || c.expired {
 		return false
 	}
 	
@@ -188,175 +496,139 @@ func (c *lsyncCache) isValid() bool {
 }
 
 // saveCache saves the lsync result to cache
-func saveCache(result *lsyncResult) error {
+func saveCache(loc locale, result *lsyncResult) error {
 	if result.isSingleFile || !result.hasChanges {
 		// Don't cache single file results or empty results
 		return nil
 	}
 	
-	cacheFile, err := getCacheFilePath()
-	if err != nil {
-		return err
-	}
-	
-	cache := lsyncCache{
+	return cache.New(cache.Lsync).Put(loc.cacheKey(), lsyncCache{
+		Lang:      loc.code,
 		Timestamp: time.Now(),
 		GitCommit: getCurrentGitCommit(),
 		Files:     result.files,
-		TTL:       30 * time.Minute,
-	}
-	
-	data, err := json.MarshalIndent(cache, "", "  ")
-	if err != nil {
-		return err
-	}
-	
-	return os.WriteFile(cacheFile, data, 0644)
+	})
 }
 
-// loadCache loads the cached lsync result
-func loadCache() (*lsyncCache, error) {
-	cacheFile, err := getCacheFilePath()
+// loadCache loads the cached lsync result of a localization
+func loadCache(loc locale) (*lsyncCache, error) {
+	var cached lsyncCache
+	entry, err := cache.New(cache.Lsync).Load(loc.cacheKey(), &cached)
 	if err != nil {
 		return nil, err
 	}
-	
-	data, err := os.ReadFile(cacheFile)
-	if err != nil {
-		return nil, err
+	if entry == nil {
+		return nil, fmt.Errorf("no lsync cache for %s", loc.code)
 	}
-	
-	var cache lsyncCache
-	if err := json.Unmarshal(data, &cache); err != nil {
-		return nil, err
-	}
-	
-	return &cache, nil
+	cached.expired = entry.Expired()
+	return &cached, nil
 }
 
-// clearCache removes the cached lsync result
-func clearCache() error {
-	cacheFile, err := getCacheFilePath()
-	if err != nil {
-		return err
-	}
-	
-	if _, err := os.Stat(cacheFile); os.IsNotExist(err) {
-		return nil // Cache doesn't exist
-	}
-	
-	return os.Remove(cacheFile)
+// clearCache removes the cached lsync result of a localization
+func clearCache(loc locale) error {
+	return cache.New(cache.Lsync).Delete(loc.cacheKey())
 }
 
-// executeLsync runs the lsync.sh script and parses the output
-func executeLsync(path string) (*lsyncResult, error) {
-	cmd := exec.Command("./scripts/lsync.sh", path)
-	output, _ := cmd.CombinedOutput()
-	
-	
-	// Check if this is a single file (ends with .md and is a file)
-	isSingleFile := strings.HasSuffix(path, ".md")
+// executeLsync compares the localized pages at path with their English
+// originals
+func executeLsync(loc locale, path string) (*lsyncResult, error) {
+	// Check if this is a single file (a .md or .html page)
+	isSingleFile := k8sdocs.IsPage(path)
 	if isSingleFile {
 		if stat, err := os.Stat(path); err != nil || stat.IsDir() {
 			isSingleFile = false
 		}
 	}
 	
-	// Parse output to extract file list
 	result := &lsyncResult{
 		files:        []fileChange{},
-		rawOutput:    string(output),
 		isSingleFile: isSingleFile,
 	}
-
-	lines := strings.Split(string(output), "\n")
-	var hasNumstat bool
 	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			// Check if this is numstat format: "added_lines deleted_lines filename" (tab separated)
-			parts := strings.Split(line, "\t")
-			if len(parts) == 3 && strings.HasPrefix(parts[2], "content/") {
-				// Parse the numbers
-				added, err1 := strconv.Atoi(parts[0])
-				deleted, err2 := strconv.Atoi(parts[1])
-				
-				if err1 == nil && err2 == nil {
-					// Get last modification time for the file
-					lastCommit, lastModified := getLastModificationTime(parts[2])
-					
-					fileChange := fileChange{
-						AddedLines:   added,
-						DeletedLines: deleted,
-						FilePath:     parts[2],
-						LastCommit:   lastCommit,
-						LastModified: lastModified,
-					}
-					result.files = append(result.files, fileChange)
-					hasNumstat = true
-				}
-			}
+	var pages []k8sdocs.Page
+	if isSingleFile {
+		// For a single file, keep the diff to show it
+		page, diff, err := k8sdocs.UpstreamDiff(path, loc.code)
+		if err != nil {
+			return nil, err
 		}
+		if diff != "" {
+			pages = append(pages, page)
+			result.rawOutput = diff
+		}
+	} else {
+		sync, err := k8sdocs.Lsync(path, loc.code)
+		if err != nil {
+			return nil, err
+		}
+		pages = sync.Outdated
+		result.orphaned = len(sync.Orphaned)
 	}
 	
-	// If no numstat found, check if output contains diff content (single file mode)
-	if !hasNumstat && len(strings.TrimSpace(string(output))) > 0 {
-		// Look for "diff --git" lines to extract file path and count changes
-		diffFound := false
-		var filePath string
-		addedCount := 0
-		deletedCount := 0
-		
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			
-			// Extract file path from diff header
-			if strings.HasPrefix(line, "diff --git") && strings.Contains(line, "content/") {
-				// Extract the "b/path" part
-				parts := strings.Fields(line)
-				for _, part := range parts {
-					if strings.HasPrefix(part, "b/content/") {
-						filePath = strings.TrimPrefix(part, "b/")
-						diffFound = true
-						break
-					}
-				}
-			}
-			
-			// Count added/deleted lines
-			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-				addedCount++
-			} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-				deletedCount++
-			}
+	for _, page := range pages {
+		// Get last modification time for the English file
+		lastCommit, lastModified := getLastModificationTime(page.Upstream)
+		file := fileChange{
+			AddedLines:   page.Added,
+			DeletedLines: page.Deleted,
+			FilePath:     page.Upstream,
+			LastCommit:   lastCommit,
+			LastModified: lastModified,
+			SyncCommit:   page.SyncCommit,
+			RenamedFrom:  page.RenamedFrom,
 		}
-		
-		if diffFound && filePath != "" && (addedCount > 0 || deletedCount > 0) {
-			// Get last modification time for the file
-			lastCommit, lastModified := getLastModificationTime(filePath)
-			
-			result.files = append(result.files, fileChange{
-				AddedLines:   addedCount,
-				DeletedLines: deletedCount,
-				FilePath:     filePath,
-				LastCommit:   lastCommit,
-				LastModified: lastModified,
-			})
-		}
-	}
-
-	// Sort by last modification time (descending - newest first)
-	sort.Slice(result.files, func(i, j int) bool {
-		return result.files[i].LastModified.After(result.files[j].LastModified)
-	})
+		if page.Path != loc.localize(page.Upstream) {
+			file.MoveFrom = page.Path
+		}
+		result.files = append(result.files, file)
+	}
 
 	result.hasChanges = len(result.files) > 0
-	
-	// Return success even if lsync.sh exits with non-zero (it's normal behavior)
 	return result, nil
 }
 
+// printGeneratedPages lists the outdated generated reference pages apart
+// from the pages to translate, with how to sync them
+func printGeneratedPages(loc locale, files []fileChange) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Printf("\n%d outdated generated reference pages are left out (--include-generated lists them with the others):\n", len(files))
+	for _, file := range files {
+		fmt.Printf("  %-10s %s\n", fmt.Sprintf("+%d -%d", file.AddedLines, file.DeletedLines), loc.localize(file.FilePath))
+	}
+	fmt.Printf("They follow the sources they are generated from: rather than translating their changes line by line,\n")
+	fmt.Printf("refresh each one from the English page, then translate what the localization translates, like the title:\n")
+	fmt.Printf("  cp %s %s\n", files[0].FilePath, loc.localize(files[0].FilePath))
+}
+
+// renameNote tells where the English page of a file was before it was
+// renamed, empty when it wasn't
+func renameNote(file fileChange) string {
+	if file.RenamedFrom == "" {
+		return ""
+	}
+	return "(renamed from " + file.RenamedFrom + ")"
+}
+
+// printMoveCommands prints how to move the translations whose English page
+// was renamed to the new path
+func printMoveCommands(loc locale, files []fileChange) {
+	var moves []fileChange
+	for _, file := range files {
+		if file.MoveFrom != "" {
+			moves = append(moves, file)
+		}
+	}
+	if len(moves) == 0 {
+		return
+	}
+	fmt.Printf("\n%d English pages were renamed; move their translations along:\n", len(moves))
+	for _, file := range moves {
+		fmt.Printf("  %s\n", orphanSuggestion(loc, k8sdocs.Orphan{Path: file.MoveFrom, MovedTo: file.FilePath}))
+	}
+}
+
 // getLastModificationTime gets the last commit time for a file
 func getLastModificationTime(filePath string) (string, time.Time) {
 	// Get commit hash and timestamp
@@ -428,124 +700,37 @@ func formatRelativeTime(t time.Time) string {
 	}
 }
 
-// prInfo represents pull request information
-type prInfo struct {
-	number int
-	title  string
-	url    string
-	files  []string
-}
-
-// checkRelatedPRs checks if there are existing PRs for the files
-func checkRelatedPRs(files []fileChange) error {
-	const pageSize = 5
-	
-	// Process files in batches of 5
-	for offset := 0; offset < len(files); offset += pageSize {
-		end := offset + pageSize
-		if end > len(files) {
-			end = len(files)
-		}
-		
-		batch := files[offset:end]
-		fmt.Printf("\nChecking batch %d-%d of %d files:\n", offset+1, end, len(files))
-		
-		availableFiles, err := checkBatchPRs(batch)
-		if err != nil {
-			return err
-		}
-		
-		// If we found files to work on, show them and stop
-		if len(availableFiles) > 0 {
-			fmt.Printf("\nFound %d files available for contribution in this batch\n", len(availableFiles))
-			break
-		} else {
-			fmt.Printf("\nAll files in this batch already have PRs, checking next batch...\n")
-		}
+// checkRelatedPRs checks if there are existing PRs for the localized files
+func checkRelatedPRs(loc locale, files []fileChange) error {
+	prFiles, err := openPRFiles(loc)
+	if err != nil {
+		return err
 	}
 	
-	return nil
-}
-
-// checkBatchPRs checks a batch of files for existing PRs
-func checkBatchPRs(batch []fileChange) ([]fileChange, error) {
-	var availableFiles []fileChange
-	
 	// Print table header
 	fmt.Printf("%-80s %-15s %s\n", "File", "Status", "PR Link")
 	fmt.Printf("%-80s %-15s %s\n", strings.Repeat("-", 80), strings.Repeat("-", 15), strings.Repeat("-", 50))
 	
-	for _, file := range batch {
-		// Convert English path to Chinese path for PR search
-		zhPath := file.FilePath
-		if strings.HasPrefix(file.FilePath, "content/en/") {
-			zhPath = strings.Replace(file.FilePath, "content/en/", "content/zh-cn/", 1)
-		}
-		
-		// Search for PRs containing this Chinese file
-		prs, err := searchPRsForFile(zhPath)
-		if err != nil {
-			fmt.Printf("%-80s %-15s %s\n", zhPath, "Error", fmt.Sprintf("Error: %v", err))
-			continue
-		}
-		
-		if len(prs) == 0 {
-			// No PRs found, this file is available
-			availableFiles = append(availableFiles, file)
-			fmt.Printf("%-80s %-15s %s\n", zhPath, "Available", "-")
-		} else {
+	available := 0
+	for _, file := range files {
+		localPath := loc.localize(file.FilePath)
+		if prs := prFiles[localPath]; len(prs) > 0 {
 			// Found existing PRs - show the first one
-			pr := prs[0]
-			fmt.Printf("%-80s %-15s %s\n", zhPath, "In Progress", pr.url)
+			fmt.Printf("%-80s %-15s %s\n", localPath, "In Progress", prs[0].HTMLURL)
+		} else if prFiles == nil {
+			// Offline without cached pull requests
+			fmt.Printf("%-80s %-15s %s\n", localPath, "Not Checked", "-")
+		} else {
+			available++
+			fmt.Printf("%-80s %-15s %s\n", localPath, "Available", "-")
 		}
 	}
 	
-	return availableFiles, nil
-}
-
-// searchPRsForFile searches for PRs that contain the specified Chinese file
-func searchPRsForFile(zhPath string) ([]prInfo, error) {
-	// Search for open PRs that contain this Chinese file
-	query := fmt.Sprintf("repo:kubernetes/website type:pr state:open %s in:files", zhPath)
-	
-	return searchPRs(query)
-}
-
-// searchPRs executes a GitHub search query for PRs
-func searchPRs(query string) ([]prInfo, error) {
-	cmd := exec.Command("gh", "api", "-X", "GET", "search/issues", "-f", fmt.Sprintf("q=%s", query), "--jq", ".items[] | {number: .number, title: .title, html_url: .html_url}")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("gh api failed: %w", err)
-	}
-	
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var prs []prInfo
-	
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		
-		// Parse JSON line
-		var prData struct {
-			Number  int    `json:"number"`
-			Title   string `json:"title"`
-			HTMLURL string `json:"html_url"`
-		}
-		
-		if err := json.Unmarshal([]byte(line), &prData); err != nil {
-			continue // Skip invalid lines
-		}
-		
-		prs = append(prs, prInfo{
-			number: prData.Number,
-			title:  prData.Title,
-			url:    prData.HTMLURL,
-		})
+	if prFiles == nil {
+		return nil
 	}
-	
-	return prs, nil
+	fmt.Printf("\nFound %d of %d files available for contribution\n", available, len(files))
+	return nil
 }
 
 // truncateString truncates a string to the specified length
@@ -563,29 +748,129 @@ var workflowCmd = &cobra.Command{
 	Long: `Generate standardized git commands for Kubernetes documentation translation workflow.
 
 This command can work in two modes:
-1. Interactive mode (no arguments): Select from cached lsync results
+1. Interactive mode (no arguments): Select from cached lsync results in a
+   full-screen picker with search, multi-select, sorting and a diff preview
+   (falls back to a numbered list when not run in a terminal)
 2. Direct mode (with file path): Generate commands for specific file
 
 Examples:
   mm k8s docs workflow                                       # Interactive selection from cache
   mm k8s docs workflow docs/concepts/overview/what-is-kubernetes.md  # Direct file specification
   mm k8s docs workflow --available-only                     # Show only files without existing PRs
+  mm k8s docs workflow --lang ko docs/concepts/overview/what-is-kubernetes.md  # Korean localization
+  mm k8s docs workflow blog/_posts/2024-08-13-kubernetes-1.31-release/index.md # Blog post
+  mm k8s docs workflow --batch docs/tasks/debug/                # Outdated pages of a subsection
+  mm k8s docs workflow --branch dev-1.32 docs/reference/command-line-tools-reference/feature-gates/foo.md
+
+Branch format: docs/sync/{language}/{filename} (zh for zh-cn, pt for pt-br)
+Commit format: [{lang}] sync {filepath}
+PR format: Same as commit message, with a body listing the English commits
+since the last sync with their diff stats, and the localization checklist
+
+Blog posts and case studies (blog/..., case-studies/...) are named after
+their date-prefixed post instead, and the images of their page bundle are
+copied to the translation:
 
-Branch format: docs/sync/zh/{filename}
-Commit format: [zh-cn] sync {filepath}
-PR format: Same as commit message with full content path`,
+Branch format: blog/{sync|translate}/{language}/{post}
+Commit format: [{lang}] {sync|translate} blog post {post}
+
+Teams with other conventions set Go templates in .mm.yaml, for every
+localization or for one:
+
+  k8s:
+    templates:
+      branch: "l10n/{{.BranchLang}}/{{.Name}}"
+      commit: "{{.CommitPrefix}} {{.Action}} {{.Path}}"
+      pr_title: "{{.Commit}}"
+      pr_body: "Sync {{.Path}} with {{.Upstream}}"
+      langs:
+        ja:
+          commit: "[ja] {{.Action}} {{.Source}}"
+
+Branches are pushed to the remote of your fork: --remote, else k8s.remote
+in .mm.yaml, else the remote whose GitHub repository is a fork of the site,
+origin or fork first, so that an origin cloned from kubernetes/website is
+left alone. Pull requests from a fork are opened on the site repository
+with --head owner:branch.
+
+With --run, workflow runs the steps instead, confirming each: it creates
+the branch and stops for the translation, and once run again commits and
+pushes it. The workflow in progress is kept in the git directory until it
+is pushed or dropped with --abandon.
+
+With --branch dev-1.32, the translation targets the release branch of the
+upcoming version: the sync branch is created from it and named with a
+-dev-1.32 suffix, commits and the pull request title are tagged
+[{lang}] [dev-1.32], and the pull request is opened into dev-1.32.
+
+With --batch, several small related pages are synced on one branch, with a
+commit per page and one pull request listing each of them, the way
+maintainers batch small updates. The batch is a directory, for the pages
+lsync reports outdated under it, a file listing one page per line, or a
+comma-separated list of pages. The branch and pull request title are named
+after the directory the pages share, e.g. docs/sync/zh/debug; with --run,
+the pages not translated yet when the workflow resumes are left out.
+
+Templates can use .Lang, .BranchLang, .CommitPrefix, .Base (the branch
+targeted), .Release (its version, e.g. 1.32, empty on main), .Section,
+.Action (sync or translate), .Kind (page, blog post or case study), .Name,
+.Source (the page as given), .Path, .Upstream, and in the pull request
+templates .Branch, .Commit, .SyncCommit (the English version last
+translated), .Commits (each with .Hash, .Short, .Subject and .URL), .Added
+and .Deleted (the English lines changed since) and .Checklist.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fresh, _ := cmd.Flags().GetBool("fresh")
 		availableOnly, _ := cmd.Flags().GetBool("available-only")
+		run, _ := cmd.Flags().GetBool("run")
+		abandon, _ := cmd.Flags().GetBool("abandon")
+		batch, _ := cmd.Flags().GetString("batch")
+		remote, _ := cmd.Flags().GetString("remote")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if loc, err = resolveBranch(cmd, loc); err != nil {
+			return err
+		}
+		
+		if abandon {
+			if err := clearWorkflowState(loc); err != nil {
+				return err
+			}
+			fmt.Printf("Dropped the workflow in progress for %s\n", loc.code)
+			return nil
+		}
+		if batch != "" && len(args) > 0 {
+			return fmt.Errorf("give either a page or --batch, not both")
+		}
+		if run {
+			yes, _ := cmd.Flags().GetBool("yes")
+			filePath := ""
+			if len(args) > 0 {
+				filePath = args[0]
+			}
+			cmdutil.SilenceFailure(cmd)
+			if batch != "" {
+				return runBatchWorkflow(loc, batch, &stepRunner{yes: yes}, remote)
+			}
+			return runWorkflow(loc, filePath, &stepRunner{yes: yes}, remote)
+		}
 		
+		if batch != "" {
+			return generateBatchCommands(loc, batch, remote)
+		}
 		if len(args) > 0 {
 			// Direct mode: generate commands for specific file
-			return generateWorkflowCommands(args[0])
+			pushTo, err := resolvePushRemote(remote)
+			if err != nil {
+				return err
+			}
+			return generateWorkflowCommands(loc, args[0], pushTo)
 		}
 		
 		// Interactive mode: use cached results
-		cache, err := loadCache()
+		cache, err := loadCache(loc)
 		if err != nil || !cache.isValid() || fresh {
 			if fresh {
 				fmt.Printf("Refreshing cache...\n")
@@ -594,149 +879,107 @@ PR format: Same as commit message with full content path`,
 			} else {
 				fmt.Printf("Cache expired (last updated: %s)\n", cache.Timestamp.Format("15:04"))
 			}
-			fmt.Printf("Please run: mm k8s docs lsync\n")
+			fmt.Printf("Please run: mm k8s docs lsync --lang %s%s\n", loc.code, loc.branchFlag())
 			return nil
 		}
 		
 		// Filter files if --available-only is specified
 		if availableOnly {
-			return showAvailableFiles(cache)
+			return showAvailableFiles(loc, cache, remote)
 		}
 		
 		// Show cached results and let user select
-		return showInteractiveSelection(cache)
+		return showInteractiveSelection(loc, cache, remote)
 	},
 }
 
-// generateWorkflowCommands generates git workflow commands for a specific file
-func generateWorkflowCommands(filePath string) error {
-	// Remove leading/trailing spaces and normalize path
-	filePath = strings.TrimSpace(filePath)
-	
-	// Extract filename without extension for branch name
-	filename := filepath.Base(filePath)
-	if strings.HasSuffix(filename, ".md") {
-		filename = filename[:len(filename)-3]
-	}
-	
-	// Generate components
-	branchName := fmt.Sprintf("docs/sync/zh/%s", filename)
-	commitMessage := fmt.Sprintf("[zh-cn] sync %s", filePath)
-	
-	// Convert path to Chinese equivalent
-	var fullPath string
-	if strings.HasPrefix(filePath, "content/en/") {
-		fullPath = strings.Replace(filePath, "content/en/", "content/zh-cn/", 1)
-	} else if strings.HasPrefix(filePath, "docs/") {
-		fullPath = fmt.Sprintf("content/zh-cn/%s", filePath)
-	} else {
-		fullPath = fmt.Sprintf("content/zh-cn/docs/%s", filePath)
+// generateWorkflowCommands generates git workflow commands for a specific
+// file, pushing the branch to remote
+func generateWorkflowCommands(loc locale, filePath string, remote pushRemote) error {
+	plan, err := newSyncPlan(loc, filePath)
+	if err != nil {
+		return err
 	}
 	
 	// Display the commands
-	fmt.Printf("Git workflow commands for: %s\n\n", filePath)
-	fmt.Printf("# 1. Create and switch to new branch\n")
-	fmt.Printf("git switch -c %s\n\n", branchName)
-	fmt.Printf("# 2. After translation, add file to staging area\n")
-	fmt.Printf("git add %s\n\n", fullPath)
-	fmt.Printf("# 3. Commit changes with signed-off-by\n")
-	fmt.Printf("git commit -s -m \"%s\"\n\n", commitMessage)
-	fmt.Printf("# 4. Push branch to remote\n")
-	fmt.Printf("git push origin %s\n\n", branchName)
-	fmt.Printf("# 5. Create pull request\n")
-	
-	// Check if this is a fork repository
-	cmd := exec.Command("git", "remote", "get-url", "origin")
-	remoteURL, err := cmd.Output()
-	if err == nil && !strings.Contains(string(remoteURL), "kubernetes/website") {
-		// This is a fork, provide fork-compatible command
-		fmt.Printf("# For fork repositories:\n")
-		fmt.Printf("gh pr create --repo kubernetes/website --title \"%s\" --body \"Sync translation for %s\"\n", commitMessage, fullPath)
-	} else {
-		// This is the main repository or error getting remote
-		fmt.Printf("gh pr create --title \"%s\" --body \"Sync translation for %s\"\n", commitMessage, fullPath)
+	step := 1
+	fmt.Printf("Git workflow commands for: %s\n\n", plan.source)
+	fmt.Printf("# %d. Create and switch to new branch\n", step)
+	fmt.Printf("git %s\n\n", quoteArgs(loc.switchArgs(plan.branch)))
+	if len(plan.assets) > 0 {
+		step++
+		fmt.Printf("# %d. Copy the images and other files of the English bundle\n", step)
+		fmt.Printf("mkdir -p %s\n", loc.localize(k8sdocs.BundleDir(plan.upstream)))
+		for _, asset := range plan.assets {
+			fmt.Printf("cp %s %s\n", asset, loc.localize(asset))
+		}
+		fmt.Printf("\n")
 	}
-	
+	if plan.syncRef == k8sdocs.SyncRefFrontMatter {
+		step++
+		fmt.Printf("# %d. After translation, record the English version translated\n", step)
+		fmt.Printf("mm k8s docs sync-ref --lang %s %s\n\n", loc.code, plan.path)
+	}
+	step++
+	fmt.Printf("# %d. After translation, add file to staging area\n", step)
+	fmt.Printf("git add %s\n\n", plan.stagePath())
+	step++
+	fmt.Printf("# %d. Commit changes with signed-off-by\n", step)
+	fmt.Printf("git %s\n\n", quoteArgs(plan.commitArgs()))
+	step++
+	fmt.Printf("# %d. Push branch to %s (%s)\n", step, remote.name, remote.repository)
+	fmt.Printf("%s\n\n", remote.pushCommand(plan.branch))
+	step++
+	fmt.Printf("# %d. Create pull request (or run: mm k8s docs pr create --lang %s%s %s)\n", step, loc.code, loc.branchFlag(), plan.source)
+	fmt.Printf("%s\n", remote.prCreateCommand(loc, plan.branch, plan.prTitle, plan.prBody))
 	return nil
 }
 
 // showInteractiveSelection shows cached files and lets user select one
-func showInteractiveSelection(cache *lsyncCache) error {
+func showInteractiveSelection(loc locale, cache *lsyncCache, remote string) error {
 	if len(cache.Files) == 0 {
 		fmt.Printf("No files need translation (cache from %s)\n", cache.Timestamp.Format("15:04"))
 		return nil
 	}
 	
-	fmt.Printf("Found %d files needing translation (cached at %s):\n\n", 
+	title := fmt.Sprintf("Found %d files needing translation (cached at %s)",
 		len(cache.Files), cache.Timestamp.Format("15:04"))
-	
-	// Display files with numbers
-	for i, file := range cache.Files {
-		// Convert English path to display path
-		displayPath := file.FilePath
-		if strings.HasPrefix(file.FilePath, "content/en/") {
-			displayPath = strings.TrimPrefix(file.FilePath, "content/en/")
-		}
-		
-		timeStr := formatRelativeTime(file.LastModified)
-		fmt.Printf("[%2d] %-60s (modified %s)\n", i+1, displayPath, timeStr)
-	}
-	
-	fmt.Printf("\nSelect a file number (1-%d), or press Enter to exit: ", len(cache.Files))
-	
-	var input string
-	fmt.Scanln(&input)
-	
-	if input == "" {
-		return nil
-	}
-	
-	// Parse selection
-	selection, err := strconv.Atoi(input)
-	if err != nil || selection < 1 || selection > len(cache.Files) {
-		return fmt.Errorf("invalid selection: %s", input)
-	}
-	
-	// Get selected file
-	selectedFile := cache.Files[selection-1]
-	
-	// Convert English path to docs path for command generation
-	filePath := selectedFile.FilePath
-	if strings.HasPrefix(filePath, "content/en/") {
-		filePath = strings.TrimPrefix(filePath, "content/en/")
+	selected, err := selectFiles(loc, title, cache.Files)
+	if err != nil {
+		return err
 	}
-	
-	fmt.Printf("\n")
-	return generateWorkflowCommands(filePath)
+	return generateSelectedWorkflows(loc, selected, remote)
 }
 
 // showAvailableFiles shows only files that don't have existing PRs
-func showAvailableFiles(cache *lsyncCache) error {
+func showAvailableFiles(loc locale, cache *lsyncCache, remote string) error {
 	if len(cache.Files) == 0 {
 		fmt.Printf("No files need translation (cache from %s)\n", cache.Timestamp.Format("15:04"))
 		return nil
 	}
 	
-	fmt.Printf("Checking for existing PRs... (this may take a moment)\n\n")
+	fmt.Printf("Checking for existing PRs...\n\n")
+	prFiles, err := openPRFiles(loc)
+	if err != nil {
+		return err
+	}
 	
 	var availableFiles []fileChange
 	
+	// Pages claimed by others are not available either
+	claims := loadClaims()
+	user := ""
+	if len(claims) > 0 {
+		user, _ = k8sdocs.CurrentUser()
+	}
+	
 	// Check each file for existing PRs
 	for _, file := range cache.Files {
-		// Convert English path to Chinese path for PR search
-		zhPath := file.FilePath
-		if strings.HasPrefix(file.FilePath, "content/en/") {
-			zhPath = strings.Replace(file.FilePath, "content/en/", "content/zh-cn/", 1)
-		}
-		
-		// Search for PRs containing this Chinese file
-		prs, err := searchPRsForFile(zhPath)
-		if err != nil {
-			fmt.Printf("Error checking PRs for %s: %v\n", zhPath, err)
+		if claim, ok := claims[loc.localize(file.FilePath)]; ok && claim.User != user {
 			continue
 		}
 		
-		if len(prs) == 0 {
+		if len(prFiles[loc.localize(file.FilePath)]) == 0 {
 			// No PRs found, this file is available
 			availableFiles = append(availableFiles, file)
 		}
@@ -747,56 +990,30 @@ func showAvailableFiles(cache *lsyncCache) error {
 		return nil
 	}
 	
-	fmt.Printf("Found %d files available for translation (cached at %s):\n\n", 
+	title := fmt.Sprintf("Found %d files available for translation (cached at %s)",
 		len(availableFiles), cache.Timestamp.Format("15:04"))
-	
-	// Display available files with numbers
-	for i, file := range availableFiles {
-		// Convert English path to display path
-		displayPath := file.FilePath
-		if strings.HasPrefix(file.FilePath, "content/en/") {
-			displayPath = strings.TrimPrefix(file.FilePath, "content/en/")
-		}
-		
-		timeStr := formatRelativeTime(file.LastModified)
-		fmt.Printf("[%2d] %-60s (modified %s)\n", i+1, displayPath, timeStr)
-	}
-	
-	fmt.Printf("\nSelect a file number (1-%d), or press Enter to exit: ", len(availableFiles))
-	
-	var input string
-	fmt.Scanln(&input)
-	
-	if input == "" {
-		return nil
-	}
-	
-	// Parse selection
-	selection, err := strconv.Atoi(input)
-	if err != nil || selection < 1 || selection > len(availableFiles) {
-		return fmt.Errorf("invalid selection: %s", input)
-	}
-	
-	// Get selected file
-	selectedFile := availableFiles[selection-1]
-	
-	// Convert English path to docs path for command generation
-	filePath := selectedFile.FilePath
-	if strings.HasPrefix(filePath, "content/en/") {
-		filePath = strings.TrimPrefix(filePath, "content/en/")
+	selected, err := selectFiles(loc, title, availableFiles)
+	if err != nil {
+		return err
 	}
-	
-	fmt.Printf("\n")
-	return generateWorkflowCommands(filePath)
+	return generateSelectedWorkflows(loc, selected, remote)
 }
 
 // clearCacheCmd represents the clear-cache command
 var clearCacheCmd = &cobra.Command{
 	Use:   "clear-cache",
-	Short: "Clear the cached lsync results",
-	Long:  `Remove the cached lsync results to force fresh scanning on next workflow command.`,
+	Short: "Clear the cached lsync results and open pull requests",
+	Long:  `Remove the cached lsync results to force fresh scanning on next workflow command,
+and the cached files of open pull requests to search them again.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := clearCache(); err != nil {
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if err := clearCache(loc); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		if err := cache.New(cache.PullRequests).Delete(loc.code); err != nil {
 			return fmt.Errorf("failed to clear cache: %w", err)
 		}
 		fmt.Printf("Cache cleared successfully\n")
@@ -809,11 +1026,30 @@ func init() {
 	docsCmd.AddCommand(lsyncCmd)
 	docsCmd.AddCommand(workflowCmd)
 	docsCmd.AddCommand(clearCacheCmd)
+	docsCmd.PersistentFlags().String("lang", "", "Localization to work on (zh-cn, ja, ko, fr, pt-br, ...; default k8s.lang in .mm.yaml or zh-cn)")
+	docsCmd.PersistentFlags().Bool("offline", false, "Don't call GitHub or fetch remotes; work from local git and cached data")
 	
 	// Add flags for lsync
 	lsyncCmd.Flags().Bool("check-pr", false, "Check for related pull requests")
+	addFilterFlags(lsyncCmd)
+	lsyncCmd.Flags().String("section", "", "Only check a section of the site (docs, blog, case-studies)")
+	lsyncCmd.Flags().String("sort", sortModified, "Order of the results (modified, priority, added, deleted, path)")
+	lsyncCmd.Flags().Int("limit", 0, "Show only the first N results (0 shows all)")
+	lsyncCmd.Flags().StringP("output", "o", outputTable, "Output format (table, json, csv, md)")
+	lsyncCmd.Flags().String("diff", diffWord, "How a single page shows its English changes: word (changed words by paragraph) or line (git diff)")
+	lsyncCmd.Flags().Bool("side-by-side", false, "Show the current translation next to each changed paragraph of a single page")
+	lsyncCmd.Flags().Bool("apply", false, "Go through the English changes of a single page and apply those of code blocks and link targets to the translation")
+	lsyncCmd.Flags().Bool("include-missing", false, "Also list the English pages without a translation, grouped by part of the site")
+	lsyncCmd.Flags().BoolP("interactive", "i", false, "Pick files to translate from the results and show their workflow commands")
+	addBranchFlag(lsyncCmd, "Branch of the site to work against, e.g. the release branch dev-1.32 (default main)")
 	
 	// Add flags for workflow
 	workflowCmd.Flags().Bool("fresh", false, "Force refresh cache before showing selection")
 	workflowCmd.Flags().Bool("available-only", false, "Show only files without existing PRs")
+	workflowCmd.Flags().Bool("run", false, "Run the git steps: create the branch, then after translation run again to commit and push")
+	workflowCmd.Flags().Bool("abandon", false, "Drop the workflow in progress started with --run")
+	workflowCmd.Flags().BoolP("yes", "y", false, "With --run, run every step without asking for confirmation")
+	workflowCmd.Flags().String("remote", "", "Remote of your fork to push the branch to (default k8s.remote in .mm.yaml, else found among the remotes)")
+	workflowCmd.Flags().String("batch", "", "Sync several pages on one branch and pull request: a directory, a file listing pages, or a comma-separated list")
+	addBranchFlag(workflowCmd, "Branch of the site to work against, e.g. the release branch dev-1.32 (default main)")
 }
\ No newline at end of file
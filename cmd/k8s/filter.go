@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// lsyncFilter narrows the outdated pages of lsync
+type lsyncFilter struct {
+	// paths are prefixes of the pages to keep, relative to the content
+	// directory or to its docs/ directory
+	paths      []string
+	minChanged int
+	maxChanged int
+	// within keeps pages whose English original changed this recently
+	within time.Duration
+	// includeGenerated keeps the generated reference pages with the others
+	// instead of listing them apart
+	includeGenerated bool
+}
+
+// addFilterFlags adds the lsync filter flags to a command
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("path", nil, "Only pages under these paths, e.g. concepts/ or docs/tasks/ (repeatable)")
+	cmd.Flags().StringSlice("scope", nil, "Only pages under the paths of these scopes of k8s.scopes in .mm.yaml (repeatable)")
+	cmd.Flags().Int("min-changed", 0, "Only pages with at least this many changed English lines")
+	cmd.Flags().Int("max-changed", 0, "Only pages with at most this many changed English lines")
+	cmd.Flags().String("modified-within", "", "Only pages whose English original changed within this time, e.g. 30d, 2w or 12h")
+	cmd.Flags().Bool("include-generated", false, "List generated reference pages with the others instead of apart")
+	cmd.Flags().Bool("exclude-generated", false, "Skip generated reference pages")
+	cmd.Flags().MarkDeprecated("exclude-generated", "generated reference pages are left out by default")
+}
+
+// newLsyncFilter reads the filter flags of a command
+func newLsyncFilter(cmd *cobra.Command, loc locale) (lsyncFilter, error) {
+	var filter lsyncFilter
+	paths, _ := cmd.Flags().GetStringSlice("path")
+	filter.minChanged, _ = cmd.Flags().GetInt("min-changed")
+	filter.maxChanged, _ = cmd.Flags().GetInt("max-changed")
+	filter.includeGenerated, _ = cmd.Flags().GetBool("include-generated")
+
+	scopes, _ := cmd.Flags().GetStringSlice("scope")
+	if len(scopes) > 0 {
+		cfg, err := loadK8sConfig()
+		if err != nil {
+			return filter, err
+		}
+		for _, name := range scopes {
+			scope, ok := cfg.Scopes[name]
+			if !ok {
+				return filter, fmt.Errorf("unknown scope %q; define it under k8s.scopes in .mm.yaml%s", name, knownScopes(cfg.Scopes))
+			}
+			paths = append(paths, scope...)
+		}
+	}
+	for _, path := range paths {
+		path = strings.TrimPrefix(strings.TrimSpace(path), "/")
+		// Accept English and localized content paths too
+		if rest, ok := strings.CutPrefix(path, k8sdocs.UpstreamDir); ok {
+			path = rest
+		} else if rest, ok := strings.CutPrefix(path, loc.contentDir()); ok {
+			path = rest
+		}
+		if path != "" {
+			filter.paths = append(filter.paths, path)
+		}
+	}
+
+	if within, _ := cmd.Flags().GetString("modified-within"); within != "" {
+		duration, err := parseAge(within)
+		if err != nil {
+			return filter, err
+		}
+		filter.within = duration
+	}
+	if filter.maxChanged > 0 && filter.minChanged > filter.maxChanged {
+		return filter, fmt.Errorf("--min-changed %d is larger than --max-changed %d", filter.minChanged, filter.maxChanged)
+	}
+	return filter, nil
+}
+
+// knownScopes lists the names of the configured scopes for error messages
+func knownScopes(scopes map[string][]string) string {
+	if len(scopes) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(scopes))
+	for name := range scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return " (scopes: " + strings.Join(names, ", ") + ")"
+}
+
+// active reports whether the filter removes any page
+func (f lsyncFilter) active() bool {
+	return len(f.paths) > 0 || f.minChanged > 0 || f.maxChanged > 0 || f.within > 0
+}
+
+// apply returns the pages the filter keeps
+func (f lsyncFilter) apply(files []fileChange) []fileChange {
+	if !f.active() {
+		return files
+	}
+	var kept []fileChange
+	for _, file := range files {
+		if f.keep(file) {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+// keep reports whether the filter keeps a page
+func (f lsyncFilter) keep(file fileChange) bool {
+	if !f.keepPath(file.FilePath) {
+		return false
+	}
+
+	changed := file.AddedLines + file.DeletedLines
+	if changed < f.minChanged || (f.maxChanged > 0 && changed > f.maxChanged) {
+		return false
+	}
+	return f.within == 0 || (!file.LastModified.IsZero() && time.Since(file.LastModified) <= f.within)
+}
+
+// splitGenerated separates the generated reference pages from the others,
+// unless they are kept with them
+func (f lsyncFilter) splitGenerated(files []fileChange) (others, generated []fileChange) {
+	if f.includeGenerated {
+		return files, nil
+	}
+	for _, file := range files {
+		if k8sdocs.IsGenerated(file.FilePath) {
+			generated = append(generated, file)
+		} else {
+			others = append(others, file)
+		}
+	}
+	return others, generated
+}
+
+// applyMissing returns the untranslated pages the path filters keep,
+// without the generated ones unless they are included; the other filters
+// are about English changes and don't apply to them
+func (f lsyncFilter) applyMissing(pages []k8sdocs.MissingPage) []k8sdocs.MissingPage {
+	var kept []k8sdocs.MissingPage
+	for _, page := range pages {
+		if f.keepPath(page.Upstream) && (f.includeGenerated || !k8sdocs.IsGenerated(page.Upstream)) {
+			kept = append(kept, page)
+		}
+	}
+	return kept
+}
+
+// keepPath reports whether an English page is under one of the paths of
+// the filter
+func (f lsyncFilter) keepPath(upstream string) bool {
+	if len(f.paths) == 0 {
+		return true
+	}
+	path := displayPath(upstream)
+	for _, prefix := range f.paths {
+		if strings.HasPrefix(path, prefix) || strings.HasPrefix(path, "docs/"+prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAge parses a duration in days (30d) or weeks (2w), or a Go
+// duration such as 12h
+func parseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid age: %s (use e.g. 30d, 2w or 12h)", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid age: %s (use e.g. 30d, 2w or 12h)", value)
+	}
+	return duration, nil
+}
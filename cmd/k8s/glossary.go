@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/cache"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// glossaryCmd looks up and enforces the terms of the localization glossary
+var glossaryCmd = &cobra.Command{
+	Use:   "glossary [term]",
+	Short: "Look up the approved translation of Kubernetes terms",
+	Long: `Look up how the localization team translates a Kubernetes term, or check
+translated files against the glossary.
+
+The glossary ships with mm and can be refreshed from the glossary pages of
+the website (content/<lang>/docs/reference/glossary/) with --refresh, run
+from the root of the kubernetes/website repository. Refreshed terms are
+cached and take precedence over the shipped ones.
+
+A term matches by its English source, its approved translation or a
+variant to avoid, in part and ignoring case.
+
+Examples:
+  mm k8s glossary namespace
+  mm k8s glossary 命名空间
+  mm k8s glossary --refresh               # Rebuild from the website glossary
+  mm k8s glossary --check                 # Check the uncommitted translations
+  mm k8s glossary --check content/zh-cn/docs/concepts/overview/_index.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		check, _ := cmd.Flags().GetBool("check")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+
+		if refresh {
+			count, err := refreshGlossary(loc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Refreshed the %s glossary with %d terms from %s\n", loc.code, count, loc.contentDir()+k8sdocs.GlossaryDir)
+			if len(args) == 0 && !check {
+				return nil
+			}
+		}
+
+		glossary, err := loadGlossary(loc)
+		if err != nil {
+			return err
+		}
+
+		if check {
+			files := args
+			if len(files) == 0 {
+				if files, err = k8sdocs.UncommittedFiles(loc.contentDir()); err != nil {
+					return err
+				}
+			}
+			issues, err := checkGlossary(glossary, files)
+			if err != nil {
+				return err
+			}
+			printGlossaryIssues(issues)
+			if len(issues) > 0 {
+				cmdutil.SilenceFailure(cmd)
+				return fmt.Errorf("found %d deviations from the %s glossary", len(issues), loc.code)
+			}
+			fmt.Printf("%d files follow the %s glossary\n", len(files), loc.code)
+			return nil
+		}
+
+		if len(args) != 1 {
+			return fmt.Errorf("specify a term to look up, or --check or --refresh")
+		}
+		terms := k8sdocs.LookupTerm(glossary, args[0])
+		if len(terms) == 0 {
+			fmt.Printf("No %s glossary term matches %q\n", loc.code, args[0])
+			return nil
+		}
+		for _, term := range terms {
+			line := fmt.Sprintf("%s → %s", term.Source, term.Approved)
+			if len(term.Forbidden) > 0 {
+				line += " (avoid: " + strings.Join(term.Forbidden, ", ") + ")"
+			}
+			fmt.Println(line)
+			if term.Note != "" {
+				fmt.Printf("    %s\n", term.Note)
+			}
+		}
+		return nil
+	},
+}
+
+// refreshGlossary rebuilds the glossary of a localization from the website
+// and caches it, returning the number of terms
+func refreshGlossary(loc locale) (int, error) {
+	glossary, err := k8sdocs.GlossaryFromRepo(loc.code)
+	if err != nil {
+		return 0, err
+	}
+	if len(glossary.Terms) == 0 {
+		return 0, fmt.Errorf("no translated glossary terms found in %s", loc.contentDir()+k8sdocs.GlossaryDir)
+	}
+
+	if err := cache.New(cache.Glossary).Put(loc.code, glossary); err != nil {
+		return 0, err
+	}
+	return len(glossary.Terms), nil
+}
+
+// loadGlossary returns the glossary shipped with mm for a localization,
+// updated with the terms last refreshed from the website
+func loadGlossary(loc locale) (*checker.Glossary, error) {
+	embedded := k8sdocs.EmbeddedGlossary(loc.code)
+
+	var refreshed *checker.Glossary
+	var cached checker.Glossary
+	if cache.New(cache.Glossary).Get(loc.code, &cached) {
+		refreshed = &cached
+	}
+
+	if embedded == nil && refreshed == nil {
+		return nil, fmt.Errorf("no glossary for %s; run mm k8s glossary --refresh --lang %s in the website repository", loc.code, loc.code)
+	}
+	return k8sdocs.MergeGlossaries(embedded, refreshed), nil
+}
+
+// checkGlossary returns the terms of files that deviate from the glossary
+func checkGlossary(glossary *checker.Glossary, files []string) ([]checker.Issue, error) {
+	terminology := checker.NewTerminologyChecker(glossary)
+	var issues []checker.Issue
+	for _, file := range files {
+		if !k8sdocs.IsPage(file) {
+			continue
+		}
+		fileIssues, err := terminology.CheckFile(file)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, fileIssues...)
+	}
+	return issues, nil
+}
+
+// printGlossaryIssues prints deviations from the glossary like compiler
+// errors
+func printGlossaryIssues(issues []checker.Issue) {
+	for _, issue := range issues {
+		fmt.Printf("%s:%d:%d: %s\n", issue.File, issue.Line, issue.Column, issue.Message)
+	}
+}
+
+func init() {
+	K8sCmd.AddCommand(glossaryCmd)
+
+	glossaryCmd.Flags().String("lang", "", "Localization of the glossary (default k8s.lang in .mm.yaml or zh-cn)")
+	glossaryCmd.Flags().Bool("refresh", false, "Rebuild the glossary from the glossary pages of the website")
+	glossaryCmd.Flags().Bool("check", false, "Check files, or the uncommitted translations, against the glossary")
+}
@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/tui"
+)
+
+// applyUpstreamHunks goes through the English changes of a page since its
+// translation one block at a time, offering to make the changes of code
+// blocks and link targets to the translation as they are, and leaving the
+// prose to translate
+func applyUpstreamHunks(loc locale, localized string) error {
+	page, hunks, err := k8sdocs.UpstreamHunks(localized, loc.code)
+	if err != nil {
+		return err
+	}
+	if len(hunks) == 0 {
+		fmt.Printf("No English changes since the last translation\n")
+		return nil
+	}
+	data, err := os.ReadFile(filepath.FromSlash(page.Path))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", page.Path, err)
+	}
+	translated := string(data)
+	color := tui.IsTerminalOutput()
+	width := 0
+	if color {
+		width = tui.Width()
+	}
+	fmt.Printf("%s: %d blocks changed since %s (+%d -%d lines)\n",
+		page.Upstream, len(hunks), shortHash(page.SyncCommit), page.Added, page.Deleted)
+
+	applied, skipped, structural := 0, 0, 0
+	var manual []string
+	for i, hunk := range hunks {
+		fmt.Println()
+		fmt.Println(paint(fmt.Sprintf("[%d/%d] %s, %s", i+1, len(hunks), changeHeader(hunk.ParagraphChange), hunk.Kind), styleHeader, color))
+		spans := make([]tui.Span, 0, len(hunk.Edits))
+		for _, edit := range hunk.Edits {
+			spans = append(spans, editSpan(edit, color))
+		}
+		for _, line := range tui.Wrap(spans, width) {
+			fmt.Println(line)
+		}
+
+		updated, err := k8sdocs.ApplyHunk(translated, hunk, loc.code)
+		if errors.Is(err, k8sdocs.ErrProseHunk) {
+			fmt.Printf("Translate this by hand\n")
+			manual = append(manual, changeHeader(hunk.ParagraphChange))
+			continue
+		}
+		structural++
+		if err != nil {
+			fmt.Printf("Skipped: %v\n", err)
+			skipped++
+			manual = append(manual, changeHeader(hunk.ParagraphChange))
+			continue
+		}
+		answer := askApply(page.Path)
+		if answer == "q" {
+			for _, rest := range hunks[i:] {
+				manual = append(manual, changeHeader(rest.ParagraphChange))
+			}
+			break
+		}
+		if answer != "y" {
+			manual = append(manual, changeHeader(hunk.ParagraphChange))
+			continue
+		}
+		translated = updated
+		applied++
+	}
+
+	fmt.Println()
+	if applied > 0 {
+		if err := os.WriteFile(filepath.FromSlash(page.Path), []byte(translated), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", page.Path, err)
+		}
+		fmt.Printf("Applied %d of %d code block and link changes to %s\n", applied, structural, page.Path)
+	} else {
+		fmt.Printf("Left %s as it was\n", page.Path)
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d code block and link changes the translation doesn't have as in English\n", skipped)
+	}
+	if len(manual) > 0 {
+		fmt.Printf("Left to update by hand, by line of %s:\n", page.Upstream)
+		for _, header := range manual {
+			fmt.Printf("  %s\n", strings.TrimPrefix(header, "@@ "))
+		}
+	}
+	return nil
+}
+
+// askApply asks whether to apply a change to a page: y, n or q to stop;
+// Enter means no
+func askApply(path string) string {
+	fmt.Printf("Apply to %s? [y/N/q] ", path)
+	var input string
+	fmt.Scanln(&input)
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "y", "yes":
+		return "y"
+	case "q", "quit":
+		return "q"
+	}
+	return "n"
+}
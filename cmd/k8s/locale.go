@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// defaultLang is the localization the docs commands work on by default
+const defaultLang = "zh-cn"
+
+// locale is a localization of the Kubernetes website, named by its content
+// directory (zh-cn, ja, ko, fr, pt-br, ...)
+type locale struct {
+	code string
+	// base is the branch of kubernetes/website worked against when it is
+	// not main, like the release branch dev-1.32 (see onBranch)
+	base string
+}
+
+// contentDir returns the content directory of the localization, e.g.
+// content/zh-cn/
+func (l locale) contentDir() string {
+	return k8sdocs.LocalizedDir(l.code)
+}
+
+// branchLang returns the language used in sync branch names, e.g. zh for
+// zh-cn and pt for pt-br
+func (l locale) branchLang() string {
+	lang, _, _ := strings.Cut(l.code, "-")
+	return lang
+}
+
+// label returns the label of the pull requests of the localization, e.g.
+// language/zh, empty when the project doesn't label them
+func (l locale) label() string {
+	return k8sdocs.LanguageLabel(l.code)
+}
+
+// commitPrefix returns the prefix of commit messages and PR titles, e.g.
+// [zh-cn], or [zh-cn] [dev-1.32] on a release branch
+func (l locale) commitPrefix() string {
+	if l.release() != "" {
+		return "[" + l.code + "] [" + l.base + "]"
+	}
+	return "[" + l.code + "]"
+}
+
+// onBranch returns the localization worked against a branch of
+// kubernetes/website
+func (l locale) onBranch(branch string) locale {
+	l.base = strings.TrimSpace(branch)
+	if l.base == k8sdocs.DefaultBranch {
+		l.base = ""
+	}
+	return l
+}
+
+// baseBranch returns the branch pull requests target: main, or the
+// branch worked against
+func (l locale) baseBranch() string {
+	if l.base == "" {
+		return k8sdocs.DefaultBranch
+	}
+	return l.base
+}
+
+// release returns the Kubernetes version of the release branch worked
+// against, e.g. 1.32, or "" on main
+func (l locale) release() string {
+	return k8sdocs.ReleaseOf(l.base)
+}
+
+// baseRef returns the ref of the branch worked against, preferring the
+// upstream remote-tracking branch
+func (l locale) baseRef() string {
+	if l.base == "" {
+		return k8sdocs.DefaultBranch
+	}
+	if ref, err := k8sdocs.BranchRef(l.base); err == nil {
+		return ref
+	}
+	return l.base
+}
+
+// cacheKey names the cached lsync results of the localization, per branch
+func (l locale) cacheKey() string {
+	if l.base == "" {
+		return l.code
+	}
+	return l.code + "@" + l.base
+}
+
+// baseFlag returns the --base flag of gh pr create for branches other than
+// main, with a leading space
+func (l locale) baseFlag() string {
+	if l.base == "" {
+		return ""
+	}
+	return " --base " + l.base
+}
+
+// branchFlag returns the --branch flag of the docs commands for branches
+// other than main, with a leading space
+func (l locale) branchFlag() string {
+	if l.base == "" {
+		return ""
+	}
+	return " --branch " + l.base
+}
+
+// switchArgs returns the git arguments that switch to a sync branch,
+// creating it from the branch worked against when it doesn't exist. On
+// main it is created from the commit checked out.
+func (l locale) switchArgs(branch string) []string {
+	switch {
+	case k8sdocs.BranchExists(branch):
+		return []string{"switch", branch}
+	case l.base != "":
+		return []string{"switch", "--no-track", "-c", branch, l.baseRef()}
+	default:
+		return []string{"switch", "-c", branch}
+	}
+}
+
+// localize converts an English content path to the localized one. Other
+// paths are returned as is.
+func (l locale) localize(path string) string {
+	return k8sdocs.Localize(path, l.code)
+}
+
+// upstream converts a localized content path to the English one. Other
+// paths are returned as is.
+func (l locale) upstream(path string) string {
+	return k8sdocs.Upstream(path, l.code)
+}
+
+// resolveLocale returns the localization selected by --lang, the k8s.lang
+// setting of .mm.yaml, or zh-cn
+func resolveLocale(cmd *cobra.Command) (locale, error) {
+	lang, _ := cmd.Flags().GetString("lang")
+	if lang == "" {
+		cfg, err := loadK8sConfig()
+		if err != nil {
+			return locale{}, err
+		}
+		lang = cfg.Lang
+	}
+	if lang == "" {
+		lang = defaultLang
+	}
+
+	// Codes are lowercase on the Kubernetes website, but not on every site,
+	// like zh-Hans on Docusaurus sites
+	lang = strings.TrimSpace(lang)
+	if info, err := os.Stat(filepath.FromSlash(k8sdocs.LocalizedDir(lang))); err != nil || !info.IsDir() {
+		lang = strings.ToLower(lang)
+	}
+	if strings.EqualFold(lang, "en") {
+		return locale{}, fmt.Errorf("--lang must be a localization, not en")
+	}
+	if strings.ContainsAny(lang, `/\ `) {
+		return locale{}, fmt.Errorf("invalid language: %s", lang)
+	}
+	return locale{code: lang}, nil
+}
+
+// resolveBranch applies --branch to a localization: main, or a branch of
+// an upcoming release like dev-1.32, which must have been fetched
+func resolveBranch(cmd *cobra.Command, loc locale) (locale, error) {
+	branch, _ := branchFlagValue(cmd)
+	loc = loc.onBranch(branch)
+	if loc.base == "" {
+		return loc, nil
+	}
+	if err := checkBranch(loc); err != nil {
+		return loc, err
+	}
+	ref, err := k8sdocs.BranchRef(loc.base)
+	if err != nil {
+		return loc, err
+	}
+	noteStaleRef(ref)
+	return loc, nil
+}
+
+// checkBranch rejects a branch worked against other than main or a
+// release branch like dev-1.32, the branches --branch accepts
+func checkBranch(loc locale) error {
+	if loc.base != "" && loc.release() == "" {
+		return fmt.Errorf("--branch must be main or a release branch like dev-1.32, not %s", loc.base)
+	}
+	return nil
+}
+
+// addBranchFlag adds --branch, the branch of the site a docs command works
+// against
+func addBranchFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().String("branch", "", usage)
+}
+
+// branchFlagValue returns the --branch of a command added by addBranchFlag
+// and whether it was given
+func branchFlagValue(cmd *cobra.Command) (string, bool) {
+	branch, _ := cmd.Flags().GetString("branch")
+	return branch, cmd.Flags().Changed("branch")
+}
+
+// loadK8sConfig returns the k8s settings of .mm.yaml, empty when there is
+// no configuration file
+func loadK8sConfig() (config.K8sConfig, error) {
+	cfg, err := config.Discover(".")
+	if err != nil || cfg == nil {
+		return config.K8sConfig{}, err
+	}
+	return cfg.K8s, nil
+}
+
+// checkLocaleDir reports an error when the content directory of the
+// localization doesn't exist in the working directory
+func checkLocaleDir(loc locale) error {
+	info, err := os.Stat(filepath.FromSlash(loc.contentDir()))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("%s not found; is %s a localization of this site?", loc.contentDir(), loc.code)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// Notification channels of watch
+const (
+	notifyConsole = "console"
+	notifyDesktop = "desktop"
+	notifyWebhook = "webhook"
+)
+
+// notification tells that translated pages fell out of sync
+type notification struct {
+	Title string   `json:"title"`
+	Text  string   `json:"text"`
+	Lang  string   `json:"lang"`
+	Pages []string `json:"pages"`
+}
+
+// notifier sends notifications to the configured channels
+type notifier struct {
+	channels []string
+	webhook  string
+}
+
+// newNotifier checks the channels and returns a notifier for them
+func newNotifier(channels []string, webhook string) (*notifier, error) {
+	for _, channel := range channels {
+		switch channel {
+		case notifyConsole, notifyDesktop:
+		case notifyWebhook:
+			if webhook == "" {
+				return nil, fmt.Errorf("--notify webhook needs --webhook URL")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported notification channel: %s (use console, desktop or webhook)", channel)
+		}
+	}
+	return &notifier{channels: channels, webhook: webhook}, nil
+}
+
+// notify sends a notification to every channel. A failing channel doesn't
+// stop the others; its error is printed as a warning.
+func (n *notifier) notify(note notification) {
+	for _, channel := range n.channels {
+		var err error
+		switch channel {
+		case notifyConsole:
+			fmt.Printf("[%s] %s\n", time.Now().Format("2006-01-02 15:04"), note.Text)
+			for _, page := range note.Pages {
+				fmt.Printf("  %s\n", page)
+			}
+		case notifyDesktop:
+			err = desktopNotification(note.Title, note.Text)
+		case notifyWebhook:
+			if k8sdocs.Offline() {
+				noteOffline("webhook notifications not sent")
+				continue
+			}
+			err = postWebhook(n.webhook, note)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send %s notification: %v\n", channel, err)
+		}
+	}
+}
+
+// desktopNotification shows a notification with the notifier of the
+// desktop: osascript on macOS, notify-send on Linux
+func desktopNotification(title, text string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", text, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("notify-send", title, text)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %s", cmd.Args[0], strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// postWebhook posts a notification as JSON. The text field makes it a
+// valid message for Slack-compatible incoming webhooks.
+func postWebhook(url string, note notification) error {
+	body, err := json.Marshal(note)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// offlineNotes are the notes of offline mode already printed
+var offlineNotes = make(map[string]bool)
+
+// noteOffline tells on stderr, once per note, what offline mode left out
+// or which data may be stale
+func noteOffline(format string, args ...any) {
+	note := fmt.Sprintf(format, args...)
+	if offlineNotes[note] {
+		return
+	}
+	offlineNotes[note] = true
+	fmt.Fprintf(os.Stderr, "Offline: %s\n", note)
+}
+
+// noteStaleRef notes that a remote-tracking branch is as last fetched
+func noteStaleRef(ref string) {
+	if !k8sdocs.Offline() || !k8sdocs.IsRemoteRef(ref) {
+		return
+	}
+	last := k8sdocs.LastFetch()
+	if last.IsZero() {
+		noteOffline("%s was never fetched", ref)
+		return
+	}
+	noteOffline("%s is as last fetched %s", ref, formatRelativeTime(last))
+}
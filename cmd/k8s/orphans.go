@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// orphanRow is an orphaned translation in the JSON output of orphans
+type orphanRow struct {
+	File       string     `json:"file"`
+	Upstream   string     `json:"upstream"`
+	Status     string     `json:"status"`
+	MovedTo    string     `json:"moved_to,omitempty"`
+	Commit     string     `json:"commit,omitempty"`
+	RemovedAt  *time.Time `json:"removed_at,omitempty"`
+	Suggestion string     `json:"suggestion"`
+}
+
+// orphansCmd finds translations whose English page was deleted or moved
+var orphansCmd = &cobra.Command{
+	Use:   "orphans [path]",
+	Short: "Find translations whose English page was deleted or moved",
+	Long: `Find localized pages whose English page no longer exists, because it was
+removed or renamed upstream, and suggest how to fix each one. Renames are
+followed through the git history to the current English page, so moved
+pages can be moved along instead of translated again.
+
+A page moved with git mv keeps its translation, but lsync then compares
+the English page from the commit of the move: review the page against
+the English version before committing it.
+
+Examples:
+  mm k8s docs orphans
+  mm k8s docs orphans content/zh-cn/docs/concepts/
+  mm k8s docs orphans -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if output != outputTable && output != outputJSON {
+			return fmt.Errorf("unsupported output format: %s (use table or json)", output)
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		if err := checkLocaleDir(loc); err != nil {
+			return err
+		}
+
+		target := loc.contentDir()
+		if len(args) > 0 {
+			target = loc.localize(args[0])
+		}
+		orphans, err := k8sdocs.Orphans(target, loc.code)
+		if err != nil {
+			return err
+		}
+
+		rows := make([]orphanRow, 0, len(orphans))
+		for _, orphan := range orphans {
+			row := orphanRow{
+				File:       orphan.Path,
+				Upstream:   orphan.Upstream,
+				Status:     "deleted",
+				MovedTo:    orphan.MovedTo,
+				Commit:     orphan.Commit,
+				Suggestion: orphanSuggestion(loc, orphan),
+			}
+			switch {
+			case orphan.Moved():
+				row.Status = "moved"
+			case orphan.Commit == "":
+				row.Status = "unknown"
+			}
+			if orphan.Commit != "" {
+				row.RemovedAt = &orphan.Time
+			}
+			rows = append(rows, row)
+		}
+
+		if output == outputJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(rows)
+		}
+
+		if len(rows) == 0 {
+			fmt.Printf("Every page of %s has an English page\n", target)
+			return nil
+		}
+		fmt.Printf("Found %d translations without an English page\n", len(rows))
+		for _, row := range rows {
+			fmt.Printf("\n%s\n", row.File)
+			switch row.Status {
+			case "moved":
+				fmt.Printf("  English page moved to %s in %s (%s)\n", row.MovedTo, shortHash(row.Commit), formatRelativeTime(*row.RemovedAt))
+			case "deleted":
+				fmt.Printf("  English page deleted in %s (%s)\n", shortHash(row.Commit), formatRelativeTime(*row.RemovedAt))
+			default:
+				fmt.Printf("  %s is not in the git history\n", row.Upstream)
+			}
+			fmt.Printf("  %s\n", row.Suggestion)
+		}
+		return nil
+	},
+}
+
+// orphanSuggestion returns how to fix an orphaned translation: the command
+// to move or delete it, or what to check
+func orphanSuggestion(loc locale, orphan k8sdocs.Orphan) string {
+	switch {
+	case orphan.Moved():
+		target := loc.localize(orphan.MovedTo)
+		if _, err := os.Stat(target); err == nil {
+			return fmt.Sprintf("$ git rm %s   # %s is translated already", orphan.Path, target)
+		}
+		if _, err := os.Stat(path.Dir(target)); err != nil {
+			return fmt.Sprintf("$ mkdir -p %s && git mv %s %s", path.Dir(target), orphan.Path, target)
+		}
+		return fmt.Sprintf("$ git mv %s %s", orphan.Path, target)
+	case orphan.Commit != "":
+		return fmt.Sprintf("$ git rm %s", orphan.Path)
+	}
+	return "Check whether the page only exists in this localization, or was never in English"
+}
+
+func init() {
+	docsCmd.AddCommand(orphansCmd)
+
+	orphansCmd.Flags().StringP("output", "o", outputTable, "Output format (table, json)")
+}
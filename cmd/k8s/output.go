@@ -0,0 +1,254 @@
+package k8s
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// Output formats of lsync and the other reports
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputCSV      = "csv"
+	outputMarkdown = "md"
+	outputHTML     = "html"
+)
+
+// lsyncRow is an outdated page in the structured output of lsync
+type lsyncRow struct {
+	// File is the English page
+	File string `json:"file"`
+	// Localized is the page to update
+	Localized    string    `json:"localized"`
+	Added        int       `json:"added_lines"`
+	Deleted      int       `json:"deleted_lines"`
+	LastCommit   string    `json:"last_commit"`
+	LastModified time.Time `json:"last_modified"`
+	ClaimedBy    string    `json:"claimed_by,omitempty"`
+	Priority     float64   `json:"priority,omitempty"`
+	// RenamedFrom is the English page before it was renamed, and MoveFrom
+	// the translation to move to Localized
+	RenamedFrom string `json:"renamed_from,omitempty"`
+	MoveFrom    string `json:"move_from,omitempty"`
+}
+
+// missingRow is an untranslated page in the structured output of lsync
+type missingRow struct {
+	File      string `json:"file"`
+	Localized string `json:"localized"`
+	Group     string `json:"group"`
+	Lines     int    `json:"lines"`
+	Words     int    `json:"words"`
+}
+
+// lsyncReport is the JSON output of lsync
+type lsyncReport struct {
+	Lang        string       `json:"lang"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Files       []lsyncRow   `json:"files"`
+	Missing     []missingRow `json:"missing,omitempty"`
+}
+
+// checkOutputFormat reports an error for an unknown lsync output format
+func checkOutputFormat(format string) error {
+	switch format {
+	case outputTable, outputJSON, outputCSV, outputMarkdown:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format: %s (use table, json, csv or md)", format)
+}
+
+// writeLsyncOutput prints the outdated pages, and the untranslated ones
+// given by --include-missing, as JSON, CSV or Markdown tables, for
+// dashboards, spreadsheets and issue bodies
+func writeLsyncOutput(loc locale, format string, files []fileChange, missing []k8sdocs.MissingPage) error {
+	claims := loadClaims()
+	rows := make([]lsyncRow, 0, len(files))
+	for _, file := range files {
+		localized := loc.localize(file.FilePath)
+		rows = append(rows, lsyncRow{
+			File:         file.FilePath,
+			Localized:    localized,
+			Added:        file.AddedLines,
+			Deleted:      file.DeletedLines,
+			LastCommit:   file.LastCommit,
+			LastModified: file.LastModified,
+			ClaimedBy:    claims[localized].User,
+			Priority:     math.Round(file.Priority*10) / 10,
+			RenamedFrom:  file.RenamedFrom,
+			MoveFrom:     file.MoveFrom,
+		})
+	}
+
+	missingRows := newMissingRows(loc, missing)
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lsyncReport{Lang: loc.code, GeneratedAt: time.Now(), Files: rows, Missing: missingRows})
+	case outputCSV:
+		return writeLsyncCSV(rows, missingRows)
+	case outputMarkdown:
+		writeLsyncMarkdown(loc, rows)
+		writeMissingMarkdown(loc, missingRows)
+		return nil
+	}
+	return checkOutputFormat(format)
+}
+
+// newMissingRows returns the rows of untranslated pages
+func newMissingRows(loc locale, missing []k8sdocs.MissingPage) []missingRow {
+	rows := make([]missingRow, 0, len(missing))
+	for _, page := range missing {
+		rows = append(rows, missingRow{
+			File:      page.Upstream,
+			Localized: loc.localize(page.Upstream),
+			Group:     page.Group,
+			Lines:     page.Lines,
+			Words:     page.Words,
+		})
+	}
+	return rows
+}
+
+// writeLsyncCSV prints rows as CSV with a header. Untranslated pages follow
+// the outdated ones with all their English lines added.
+func writeLsyncCSV(rows []lsyncRow, missingRows []missingRow) error {
+	writer := csv.NewWriter(os.Stdout)
+	_ = writer.Write([]string{"file", "localized", "added_lines", "deleted_lines", "last_commit", "last_modified", "claimed_by", "priority", "status"})
+	for _, row := range rows {
+		modified := ""
+		if !row.LastModified.IsZero() {
+			modified = row.LastModified.Format(time.RFC3339)
+		}
+		priority := ""
+		if row.Priority > 0 {
+			priority = strconv.FormatFloat(row.Priority, 'f', 1, 64)
+		}
+		status := "outdated"
+		if row.MoveFrom != "" {
+			status = "renamed"
+		}
+		_ = writer.Write([]string{
+			row.File,
+			row.Localized,
+			strconv.Itoa(row.Added),
+			strconv.Itoa(row.Deleted),
+			row.LastCommit,
+			modified,
+			row.ClaimedBy,
+			priority,
+			status,
+		})
+	}
+	for _, row := range missingRows {
+		_ = writer.Write([]string{row.File, row.Localized, strconv.Itoa(row.Lines), "0", "", "", "", "", "missing"})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeLsyncMarkdown prints rows as a Markdown table linking the English
+// pages on GitHub
+func writeLsyncMarkdown(loc locale, rows []lsyncRow) {
+	fmt.Printf("%d outdated pages in %s\n\n", len(rows), loc.contentDir())
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Printf("| File | Added | Deleted | Last modified | Commit | Claimed by |\n")
+	fmt.Printf("| --- | ---: | ---: | --- | --- | --- |\n")
+	for _, row := range rows {
+		link := fmt.Sprintf("[%s](https://github.com/%s/blob/%s/%s)",
+			markdownCell(displayPath(row.File)), k8sdocs.Repository, k8sdocs.DefaultBranch, row.File)
+		modified := ""
+		if !row.LastModified.IsZero() {
+			modified = row.LastModified.Format("2006-01-02")
+		}
+		if row.RenamedFrom != "" {
+			link += " (renamed from " + markdownCell(displayPath(row.RenamedFrom)) + ")"
+		}
+		claimedBy := ""
+		if row.ClaimedBy != "" {
+			claimedBy = "@" + row.ClaimedBy
+		}
+		fmt.Printf("| %s | %d | %d | %s | %s | %s |\n", link, row.Added, row.Deleted, modified, row.LastCommit, claimedBy)
+	}
+}
+
+// writeMissingMarkdown prints untranslated pages as a Markdown table per
+// group, linking the English pages on GitHub
+func writeMissingMarkdown(loc locale, rows []missingRow) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Printf("\n%d untranslated pages in %s\n", len(rows), loc.contentDir())
+	for _, group := range groupMissing(rows) {
+		fmt.Printf("\n### %s (%s)\n\n", markdownCell(group.name), group.summary())
+		fmt.Printf("| File | Lines | Words |\n")
+		fmt.Printf("| --- | ---: | ---: |\n")
+		for _, row := range group.rows {
+			link := fmt.Sprintf("[%s](https://github.com/%s/blob/%s/%s)",
+				markdownCell(displayPath(row.File)), k8sdocs.Repository, k8sdocs.DefaultBranch, row.File)
+			fmt.Printf("| %s | %d | %d |\n", link, row.Lines, row.Words)
+		}
+	}
+}
+
+// printMissingTable prints untranslated pages grouped by part of the site,
+// with the size of each group
+func printMissingTable(loc locale, rows []missingRow) {
+	if len(rows) == 0 {
+		fmt.Printf("\nAll English pages have a %s translation\n", loc.code)
+		return
+	}
+	total := missingGroup{rows: rows}
+	fmt.Printf("\nUntranslated pages: %s\n", total.summary())
+	for _, group := range groupMissing(rows) {
+		fmt.Printf("\n%s (%s)\n", group.name, group.summary())
+		for _, row := range group.rows {
+			fmt.Printf("  %6d %7d  %s\n", row.Lines, row.Words, row.File)
+		}
+	}
+}
+
+// missingGroup is the untranslated pages of a part of the site
+type missingGroup struct {
+	name string
+	rows []missingRow
+}
+
+// summary returns the number of pages and the size of the group
+func (g missingGroup) summary() string {
+	lines, words := 0, 0
+	for _, row := range g.rows {
+		lines += row.Lines
+		words += row.Words
+	}
+	return fmt.Sprintf("%d pages, %d lines, %d words", len(g.rows), lines, words)
+}
+
+// groupMissing splits rows, ordered by group, into their groups
+func groupMissing(rows []missingRow) []missingGroup {
+	var groups []missingGroup
+	for _, row := range rows {
+		if len(groups) == 0 || groups[len(groups)-1].name != row.Group {
+			groups = append(groups, missingGroup{name: row.Group})
+		}
+		last := &groups[len(groups)-1]
+		last.rows = append(last.rows, row)
+	}
+	return groups
+}
+
+// markdownCell escapes the pipes of a Markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
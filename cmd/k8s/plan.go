@@ -0,0 +1,261 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// syncPlan names the branch, commit and pull request that sync a localized
+// page with its English original
+type syncPlan struct {
+	// source is the page as given by the user
+	source string
+	// path is the localized page, e.g. content/zh-cn/docs/home/_index.md
+	path string
+	// upstream is the English page
+	upstream      string
+	section       k8sdocs.Section
+	branch        string
+	commitMessage string
+	prTitle       string
+	prBody        string
+	// assets are English files of the page bundle, like the featured image
+	// of a blog post, that the localized bundle lacks
+	assets []string
+	// newPage is set for posts that are not translated yet
+	newPage bool
+	// upstreamCommit is the last commit of the English page, recorded as
+	// the version translated (see sync-ref)
+	upstreamCommit string
+	// syncRef is how the team records it: trailer or front-matter
+	syncRef string
+	// syncCommit is the English version the page was last translated
+	// from, and commits, added and deleted the English changes since
+	syncCommit string
+	commits    []k8sdocs.Commit
+	added      int
+	deleted    int
+}
+
+// Default templates of the names of a sync plan (see planTemplateData)
+const (
+	defaultDocsBranch = "docs/sync/{{.BranchLang}}/{{.Name}}{{if .Release}}-{{.Base}}{{end}}"
+	defaultDocsCommit = "{{.CommitPrefix}} sync {{.Source}}"
+	defaultPostBranch = "{{.Section}}/{{.Action}}/{{.BranchLang}}/{{.Name}}{{if .Release}}-{{.Base}}{{end}}"
+	defaultPostCommit = "{{.CommitPrefix}} {{.Action}} {{.Kind}} {{.Name}}"
+	defaultPRTitle    = "{{.Commit}}"
+	defaultPRBody     = `{{if eq .Action "translate"}}Translate {{.Upstream}} to {{.Path}}{{else}}Sync translation for {{.Path}}{{end}}
+{{- if .Release}}
+
+For the Kubernetes v{{.Release}} release, on {{.Base}}.
+{{- end}}
+{{- if .Commits}}
+
+English changes since {{.SyncCommit.Short}} (+{{.Added}} -{{.Deleted}} lines):
+{{range .Commits}}
+- [{{.Short}}]({{.URL}}) {{.Subject}}
+{{- end}}
+{{- end}}
+
+{{.Checklist}}`
+)
+
+// localizationChecklist is the checklist of the pull requests of a
+// translation
+const localizationChecklist = `### Checklist
+
+- [ ] The translation covers every change of the English page
+- [ ] The front matter matches the English page (weight, content_type, ...)
+- [ ] Terms follow the glossary of the localization
+- [ ] Links point to the localized pages where they exist
+- [ ] The page renders in a local preview of the site
+- [ ] The commits are signed off (DCO)`
+
+// planTemplateData is what the templates of a sync plan can refer to
+type planTemplateData struct {
+	// Lang is the localization, e.g. zh-cn
+	Lang string
+	// BranchLang is its language in branch names, e.g. zh
+	BranchLang string
+	// CommitPrefix is e.g. [zh-cn], or [zh-cn] [dev-1.32] on a release
+	// branch
+	CommitPrefix string
+	// Base is the branch the pull request targets, main or a release
+	// branch like dev-1.32, and Release the version of the release branch,
+	// e.g. 1.32, empty on main
+	Base    string
+	Release string
+	// Section is docs, blog or case-studies
+	Section string
+	// Action is sync, or translate for posts not translated yet
+	Action string
+	// Kind is page, blog post or case study
+	Kind string
+	// Name is the file name of a page without extension, or the
+	// date-prefixed name of a post
+	Name string
+	// Source is the page as given, Path the localized page and Upstream
+	// the English one
+	Source   string
+	Path     string
+	Upstream string
+	// Branch and Commit are the rendered branch and commit message, for
+	// the pull request templates
+	Branch string
+	Commit string
+	// SyncCommit is the English version last translated, Commits the
+	// English commits since (with .Hash, .Short, .Subject and .URL), and
+	// Added and Deleted the lines they changed
+	SyncCommit k8sdocs.Commit
+	Commits    []k8sdocs.Commit
+	Added      int
+	Deleted    int
+	// Checklist is the standard checklist of localization pull requests
+	Checklist string
+}
+
+// newSyncPlan returns the sync plan of a page, given as an English or
+// localized content path, a path relative to the content directory (docs/,
+// blog/ or case-studies/) or a path relative to docs/. The names of its
+// branch, commit and pull request come from k8s.templates of .mm.yaml.
+func newSyncPlan(loc locale, filePath string) (syncPlan, error) {
+	filePath = strings.TrimSpace(filePath)
+
+	fullPath := localizedPath(loc, filePath)
+
+	cfg, err := loadK8sConfig()
+	if err != nil {
+		return syncPlan{}, err
+	}
+	plan := syncPlan{
+		source:   filePath,
+		path:     fullPath,
+		upstream: loc.upstream(fullPath),
+		section:  k8sdocs.SectionOf(strings.TrimPrefix(fullPath, loc.contentDir())),
+		syncRef:  syncRefStyle(cfg),
+	}
+	plan.upstreamCommit, _ = k8sdocs.LatestUpstreamCommit(plan.upstream)
+
+	data := planTemplateData{
+		Lang:         loc.code,
+		BranchLang:   loc.branchLang(),
+		CommitPrefix: loc.commitPrefix(),
+		Base:         loc.baseBranch(),
+		Release:      loc.release(),
+		Section:      string(plan.section),
+		Action:       "sync",
+		Kind:         "page",
+		Source:       filePath,
+		Path:         plan.path,
+		Upstream:     plan.upstream,
+	}
+	branch, commit := defaultDocsBranch, defaultDocsCommit
+	switch plan.section {
+	case k8sdocs.SectionBlog, k8sdocs.SectionCaseStudies:
+		// Posts are new translations more often than syncs, and are
+		// named after their date-prefixed bundle or file
+		data.Name = k8sdocs.PostName(fullPath)
+		data.Kind = "blog post"
+		if plan.section == k8sdocs.SectionCaseStudies {
+			data.Kind = "case study"
+		}
+		if _, err := os.Stat(filepath.FromSlash(fullPath)); os.IsNotExist(err) {
+			data.Action = "translate"
+			plan.newPage = true
+		}
+		branch, commit = defaultPostBranch, defaultPostCommit
+		plan.assets, _ = k8sdocs.MissingAssets(plan.upstream, loc.code)
+	default:
+		// Branch names use the file name without extension
+		data.Name = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
+	templates := cfg.Templates.ForLang(loc.code)
+	if plan.branch, err = renderPlanTemplate("branch", templates.Branch, branch, data); err != nil {
+		return syncPlan{}, err
+	}
+	if plan.commitMessage, err = renderPlanTemplate("commit", templates.Commit, commit, data); err != nil {
+		return syncPlan{}, err
+	}
+	data.Branch, data.Commit = plan.branch, plan.commitMessage
+	if !plan.newPage {
+		// The English changes are best effort: pages not committed yet
+		// have none
+		if page, _, err := k8sdocs.UpstreamDiff(plan.path, loc.code); err == nil {
+			plan.syncCommit, plan.added, plan.deleted = page.SyncCommit, page.Added, page.Deleted
+			plan.commits, _ = k8sdocs.UpstreamCommits(page)
+		}
+	}
+	data.SyncCommit = k8sdocs.Commit{Hash: plan.syncCommit}
+	data.Commits, data.Added, data.Deleted = plan.commits, plan.added, plan.deleted
+	data.Checklist = localizationChecklist
+	if plan.prTitle, err = renderPlanTemplate("pr_title", templates.PRTitle, defaultPRTitle, data); err != nil {
+		return syncPlan{}, err
+	}
+	if plan.prBody, err = renderPlanTemplate("pr_body", templates.PRBody, defaultPRBody, data); err != nil {
+		return syncPlan{}, err
+	}
+	if strings.ContainsAny(plan.branch, " ~^:?*[\\") || strings.Contains(plan.branch, "..") {
+		return syncPlan{}, fmt.Errorf("k8s.templates.branch renders an invalid branch name: %q", plan.branch)
+	}
+	return plan, nil
+}
+
+// renderPlanTemplate renders a template of k8s.templates, or the default
+// one when it isn't configured
+func renderPlanTemplate(key, configured, fallback string, data planTemplateData) (string, error) {
+	text := configured
+	if text == "" {
+		text = fallback
+	}
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid k8s.templates.%s: %w", key, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid k8s.templates.%s: %w", key, err)
+	}
+	value := strings.TrimSpace(b.String())
+	if value == "" {
+		return "", fmt.Errorf("k8s.templates.%s renders empty for %s", key, data.Path)
+	}
+	return value, nil
+}
+
+// stagePath returns what to stage: the localized bundle of a bundled post,
+// with its assets, or the page
+func (p syncPlan) stagePath() string {
+	if dir := k8sdocs.BundleDir(p.path); dir != "" && p.section != k8sdocs.SectionDocs {
+		return dir
+	}
+	return p.path
+}
+
+// commitArgs returns the git arguments that commit the translation, with
+// the English commit it was made from as a trailer when the team records
+// it that way
+func (p syncPlan) commitArgs() []string {
+	args := []string{"commit", "-s", "-m", p.commitMessage}
+	if p.syncRef == k8sdocs.SyncRefTrailer && p.upstreamCommit != "" {
+		args = append(args, "-m", k8sdocs.SyncRefTrailerKey+": "+p.upstreamCommit)
+	}
+	return args
+}
+
+// syncRefStyle returns how translations record their English commit, from
+// k8s.sync_ref of .mm.yaml
+func syncRefStyle(cfg config.K8sConfig) string {
+	style, err := k8sdocs.ParseSyncRefStyle(cfg.SyncRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return k8sdocs.SyncRefNone
+	}
+	return style
+}
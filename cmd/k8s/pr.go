@@ -0,0 +1,413 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// prCmd groups the pull request commands
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Pull request commands for documentation translation",
+}
+
+// prCreateCmd runs the translation workflow up to an open pull request
+var prCreateCmd = &cobra.Command{
+	Use:   "create <file-path>",
+	Short: "Commit a translated file, push it and open the pull request",
+	Long: `Run the git workflow of a translated file and open its pull request on
+kubernetes/website, instead of printing the commands like workflow does:
+
+  1. Create or switch to the sync branch
+  2. Stage the localized file
+  3. Commit it with Signed-off-by, recording the English commit translated
+     (see sync-ref)
+  4. Push the branch to your fork: --remote, else k8s.remote in .mm.yaml,
+     else the remote that is a fork of the site (see workflow --help)
+  5. Open the pull request through the GitHub API (needs a GitHub token)
+  6. Suggest reviewers to /cc, from the OWNERS files of the page and who
+     reviewed the recent pull requests of the localization, the most
+     active first; --cc comments the /cc on the pull request
+
+Each step is confirmed before it runs unless --yes is given. --dry-run
+shows the steps without running anything. --check-glossary first shows the
+terms that deviate from the glossary of the localization (see mm k8s
+glossary) and asks whether to go on. It warns, showing the changes, when
+the English page changed on --branch since the translation started, with the
+workflow --run in progress or where the branch forked.
+
+Pull requests into a release branch like dev-1.32 (--branch, by default the
+branch of the workflow --run in progress) are named after it, as the
+release team expects: the branch gets a -dev-1.32 suffix, the commit and
+title a [dev-1.32] tag after the language, and a missing sync branch is
+created from the release branch.
+
+Examples:
+  mm k8s docs pr create docs/concepts/overview/what-is-kubernetes.md --dry-run
+  mm k8s docs pr create content/zh-cn/docs/concepts/overview/what-is-kubernetes.md
+  mm k8s docs pr create --lang ja docs/concepts/overview/what-is-kubernetes.md --yes
+
+Branch format: docs/sync/{language}/{filename} (zh for zh-cn, pt for pt-br)
+Commit format: [{lang}] sync {filepath}
+PR format: Same as commit message, with a body listing the English commits
+since the last sync with their diff stats, and the localization checklist
+
+k8s.templates in .mm.yaml changes these formats (see mm k8s docs workflow
+--help).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		remote, _ := cmd.Flags().GetString("remote")
+		base, baseGiven := branchFlagValue(cmd)
+		draft, _ := cmd.Flags().GetBool("draft")
+		checkTerms, _ := cmd.Flags().GetBool("check-glossary")
+		cc, _ := cmd.Flags().GetBool("cc")
+		reviewerCount, _ := cmd.Flags().GetInt("reviewers")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		// Without --branch, the pull request targets the branch the workflow
+		// in progress works against
+		if state, err := loadWorkflowState(loc); err == nil && state != nil && state.Base != "" &&
+			!baseGiven && state.covers(localizedPath(loc, args[0])) {
+			base = state.Base
+		}
+		loc = loc.onBranch(base)
+		if err := checkBranch(loc); err != nil {
+			return err
+		}
+		base = loc.baseBranch()
+		plan, err := newSyncPlan(loc, args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(plan.path); err != nil {
+			return fmt.Errorf("translated file not found: %s", plan.path)
+		}
+
+		pushTo, err := resolvePushRemote(remote)
+		if err != nil {
+			return err
+		}
+		// Pull requests from a fork name the branch as owner:branch
+		head := pushTo.head(plan.branch)
+
+		runner := &stepRunner{dryRun: dryRun, yes: yes}
+		if dryRun {
+			fmt.Printf("Dry run, nothing will be changed\n\n")
+		}
+
+		if checkTerms {
+			if ok, err := runner.checkGlossary(loc, plan.path); !ok {
+				return err
+			}
+		}
+
+		// Translations already behind the English page are worth a look
+		// before the pull request opens
+		warnStale(plan.upstream, workflowHead(loc, plan.path), loc.baseRef())
+
+		// 1. Create or switch to the sync branch
+		current, err := k8sdocs.CurrentBranch()
+		if err != nil {
+			return err
+		}
+		if current != plan.branch {
+			if ok, err := runner.git("Switch to the sync branch", loc.switchArgs(plan.branch)...); !ok {
+				return err
+			}
+		}
+
+		// Copy the files of the English bundle the translation lacks
+		if len(plan.assets) > 0 {
+			if ok, err := runner.copyAssets(loc, plan.assets); !ok {
+				return err
+			}
+		}
+
+		// Record the English version translated, unless it is current
+		if plan.syncRef == k8sdocs.SyncRefFrontMatter && plan.upstreamCommit != "" &&
+			!sameCommit(k8sdocs.RecordedRef(plan.path), plan.upstreamCommit) {
+			if ok, err := runner.recordRef(plan); !ok {
+				return err
+			}
+		}
+
+		// 2. Stage the localized file
+		if ok, err := runner.git("Stage the translated file", "add", plan.stagePath()); !ok {
+			return err
+		}
+
+		// 3. Commit it with Signed-off-by
+		if dryRun || k8sdocs.HasStagedChanges() {
+			if ok, err := runner.git("Commit with Signed-off-by", plan.commitArgs()...); !ok {
+				return err
+			}
+		} else {
+			fmt.Printf("Nothing to commit, pushing the existing commits of %s\n\n", plan.branch)
+		}
+
+		if k8sdocs.Offline() {
+			fmt.Printf("Offline, so %s is not pushed. Back online, run this again to push it and open the pull request\n", plan.branch)
+			return nil
+		}
+
+		// 4. Push the branch
+		if ok, err := runner.git("Push the branch to "+pushTo.name, "push", "-u", pushTo.name, plan.branch); !ok {
+			return err
+		}
+
+		// 5. Open the pull request
+		opts := k8sdocs.PullRequestOptions{
+			Head:  head,
+			Base:  base,
+			Title: plan.prTitle,
+			Body:  plan.prBody,
+			Draft: draft,
+		}
+		description := fmt.Sprintf("Open a pull request on %s from %s into %s", k8sdocs.Repository, head, base)
+		fmt.Printf("# %s\n", description)
+		fmt.Printf("  Title: %s\n  Body:\n", opts.Title)
+		for _, line := range strings.Split(opts.Body, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+		reviewers := suggestReviewers(loc, plan.path, reviewerCount)
+		if len(reviewers) > 0 {
+			fmt.Printf("  Suggested reviewers: %s\n", describeReviewers(reviewers))
+		}
+		if dryRun {
+			return nil
+		}
+		if !runner.confirm() {
+			fmt.Printf("Aborted\n")
+			return nil
+		}
+		pr, err := k8sdocs.CreatePullRequest(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nCreated pull request #%d: %s\n", pr.Number, pr.HTMLURL)
+		if len(reviewers) == 0 {
+			return nil
+		}
+		if !cc {
+			fmt.Printf("Ask for a review by commenting: %s\n", ccCommand(reviewers))
+			return nil
+		}
+		url, err := k8sdocs.CommentOnPullRequest(pr.Number, ccCommand(reviewers))
+		if err != nil {
+			return fmt.Errorf("failed to cc the reviewers: %w", err)
+		}
+		fmt.Printf("Commented %s: %s\n", ccCommand(reviewers), url)
+		return nil
+	},
+}
+
+// suggestReviewers returns the reviewers to cc on the pull request of a
+// page: its owners and the recent reviewers of the localization, the most
+// active first. It is best effort, with the owners alone when GitHub can't
+// be searched.
+func suggestReviewers(loc locale, page string, limit int) []k8sdocs.Reviewer {
+	if limit <= 0 {
+		return nil
+	}
+	owners, err := k8sdocs.PageOwners(page, loc.code)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if k8sdocs.Offline() {
+		noteOffline("recent reviewers not searched, suggesting the owners of the page")
+		return k8sdocs.SuggestReviewers(owners, nil, "", limit)
+	}
+	reviews, err := k8sdocs.RecentReviewers(loc.label())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	author, _ := k8sdocs.CurrentUser()
+	return k8sdocs.SuggestReviewers(owners, reviews, author, limit)
+}
+
+// describeReviewers lists reviewers with why they are suggested
+func describeReviewers(reviewers []k8sdocs.Reviewer) string {
+	var parts []string
+	for _, reviewer := range reviewers {
+		var why []string
+		if reviewer.Owner {
+			why = append(why, "OWNERS")
+		}
+		switch {
+		case reviewer.Reviews == 1:
+			why = append(why, "1 recent review")
+		case reviewer.Reviews > 1:
+			why = append(why, fmt.Sprintf("%d recent reviews", reviewer.Reviews))
+		}
+		parts = append(parts, fmt.Sprintf("@%s (%s)", reviewer.Login, strings.Join(why, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ccCommand returns the Prow command that requests a review from reviewers
+func ccCommand(reviewers []k8sdocs.Reviewer) string {
+	logins := make([]string, len(reviewers))
+	for i, reviewer := range reviewers {
+		logins[i] = "@" + reviewer.Login
+	}
+	return "/cc " + strings.Join(logins, " ")
+}
+
+// stepRunner runs the git commands of a workflow one by one, asking for
+// confirmation before each unless yes is set
+type stepRunner struct {
+	dryRun bool
+	yes    bool
+}
+
+// git shows a git command and runs it. It returns false when the workflow
+// must stop, because the user declined the step or the command failed; err
+// is nil when the user declined.
+func (r *stepRunner) git(description string, args ...string) (bool, error) {
+	fmt.Printf("# %s\n", description)
+	fmt.Printf("$ git %s\n", quoteArgs(args))
+	if r.dryRun {
+		fmt.Println()
+		return true, nil
+	}
+	if !r.confirm() {
+		fmt.Printf("Aborted\n")
+		return false, nil
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	fmt.Println()
+	return true, nil
+}
+
+// copyAssets copies English bundle files to the localized bundle, like
+// git shows a step and runs it when confirmed
+func (r *stepRunner) copyAssets(loc locale, assets []string) (bool, error) {
+	fmt.Printf("# Copy the files of the English bundle\n")
+	for _, asset := range assets {
+		fmt.Printf("$ cp %s %s\n", asset, loc.localize(asset))
+	}
+	if r.dryRun {
+		fmt.Println()
+		return true, nil
+	}
+	if !r.confirm() {
+		fmt.Printf("Aborted\n")
+		return false, nil
+	}
+
+	for _, asset := range assets {
+		if err := k8sdocs.CopyAsset(asset, loc.code); err != nil {
+			return false, err
+		}
+	}
+	fmt.Println()
+	return true, nil
+}
+
+// recordRef prints the mm k8s docs sync-ref step that records the English
+// commit translated in the front matter of the page, and runs it when
+// confirmed unless this is a dry run
+func (r *stepRunner) recordRef(plan syncPlan) (bool, error) {
+	fmt.Printf("# Record the English version translated\n")
+	fmt.Printf("$ mm k8s docs sync-ref %s   # %s: %s\n", plan.path, k8sdocs.SyncRefField, plan.upstreamCommit)
+	if r.dryRun {
+		fmt.Println()
+		return true, nil
+	}
+	if !r.confirm() {
+		fmt.Printf("Aborted\n")
+		return false, nil
+	}
+	if err := k8sdocs.SetRecordedRef(plan.path, plan.upstreamCommit); err != nil {
+		return false, err
+	}
+	fmt.Println()
+	return true, nil
+}
+
+// checkGlossary shows where the translated file deviates from the glossary
+// and asks whether to go on anyway
+func (r *stepRunner) checkGlossary(loc locale, path string) (bool, error) {
+	fmt.Printf("# Check the terms against the %s glossary\n", loc.code)
+	glossary, err := loadGlossary(loc)
+	if err != nil {
+		return false, err
+	}
+	issues, err := checkGlossary(glossary, []string{path})
+	if err != nil {
+		return false, err
+	}
+	if len(issues) == 0 {
+		fmt.Printf("No deviations\n\n")
+		return true, nil
+	}
+	printGlossaryIssues(issues)
+	if r.dryRun {
+		fmt.Println()
+		return true, nil
+	}
+	fmt.Printf("Found %d deviations from the glossary. ", len(issues))
+	if !r.confirm() {
+		fmt.Printf("Aborted\n")
+		return false, nil
+	}
+	fmt.Println()
+	return true, nil
+}
+
+// confirm asks whether to run the step just shown; Enter means yes
+func (r *stepRunner) confirm() bool {
+	if r.yes {
+		return true
+	}
+	fmt.Printf("Run this step? [Y/n] ")
+	var input string
+	fmt.Scanln(&input)
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == "" || input == "y" || input == "yes"
+}
+
+// quoteArgs joins command arguments for display, quoting those with spaces
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\"") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+func init() {
+	docsCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prCreateCmd)
+
+	prCreateCmd.Flags().Bool("dry-run", false, "Show the steps without running them")
+	prCreateCmd.Flags().BoolP("yes", "y", false, "Run every step without asking for confirmation")
+	prCreateCmd.Flags().String("remote", "", "Remote of your fork to push the branch to (default k8s.remote in .mm.yaml, else found among the remotes)")
+	addBranchFlag(prCreateCmd, "Branch of the site the pull request targets (default main)")
+	prCreateCmd.Flags().Bool("draft", false, "Open the pull request as a draft")
+	prCreateCmd.Flags().Bool("check-glossary", false, "Check the translation against the glossary before committing it")
+	prCreateCmd.Flags().Bool("cc", false, "Comment /cc with the suggested reviewers on the pull request")
+	prCreateCmd.Flags().Int("reviewers", 3, "Number of reviewers to suggest from the OWNERS files and recent reviews (0 for none)")
+}
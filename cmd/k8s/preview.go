@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// previewStartTimeout is how long the first build of the site may take
+// before the preview gives up waiting for the server
+const previewStartTimeout = 5 * time.Minute
+
+// previewCmd serves the site with Hugo to check the rendering of a page
+var previewCmd = &cobra.Command{
+	Use:   "preview <file-path>",
+	Short: "Serve the site with Hugo and open the localized page under work",
+	Long: `Run hugo server for English and the localization only, which keeps the
+first build short, and print the URL of the localized page once the site is
+served. Hugo rebuilds the site and reloads the browser whenever a page is
+saved, so broken shortcodes, anchors and layouts show up while translating.
+
+The page is given like for workflow: as an English or localized content
+path, or relative to the content directory or to docs/. --open opens it in
+the browser. Stop the server with Ctrl+C.
+
+Examples:
+  mm k8s docs preview docs/concepts/overview/what-is-kubernetes.md --open
+  mm k8s docs preview content/zh-cn/docs/tasks/_index.md --port 1414`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		port, _ := cmd.Flags().GetInt("port")
+		bind, _ := cmd.Flags().GetString("bind")
+		open, _ := cmd.Flags().GetBool("open")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		if !k8sdocs.HasHugo() {
+			return fmt.Errorf("hugo not found in PATH; install Hugo extended to serve the site")
+		}
+		page := localizedPath(loc, args[0])
+		if _, err := os.Stat(page); err != nil {
+			return fmt.Errorf("translated file not found: %s", page)
+		}
+
+		host := bind
+		if ip := net.ParseIP(bind); ip != nil && ip.IsUnspecified() {
+			host = "localhost"
+		}
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(host, strconv.Itoa(port)), k8sdocs.LocalizedPageURL(page, loc.code))
+
+		// Ctrl+C stops hugo, and preview once hugo is gone
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+
+		fmt.Printf("Starting hugo server for en and %s...\n", loc.code)
+		stop, err := k8sdocs.StartPreview(loc.code, bind, port)
+		if err != nil {
+			return err
+		}
+		done := make(chan error, 1)
+		go func() { done <- stop() }()
+
+		cmdutil.SilenceFailure(cmd)
+		interrupted := false
+		ready := time.After(0)
+		deadline := time.After(previewStartTimeout)
+		for {
+			select {
+			case err := <-done:
+				if interrupted {
+					return nil
+				}
+				return err
+			case <-interrupt:
+				interrupted = true
+			case <-deadline:
+				fmt.Fprintf(os.Stderr, "Warning: the site is not served after %s; open %s once it is\n", previewStartTimeout, url)
+				ready = nil
+			case <-ready:
+				conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), time.Second)
+				if err != nil {
+					ready = time.After(time.Second)
+					continue
+				}
+				conn.Close()
+				ready, deadline = nil, nil
+				fmt.Printf("\nPreview of %s:\n  %s\n\n", page, url)
+				if open {
+					if err := openBrowser(url); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to open the browser: %v\n", err)
+					}
+				}
+			}
+		}
+	},
+}
+
+// openBrowser opens a URL with the browser of the desktop
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+func init() {
+	docsCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().Int("port", 1313, "Port to serve the site on")
+	previewCmd.Flags().String("bind", "127.0.0.1", "Interface to serve the site on")
+	previewCmd.Flags().Bool("open", false, "Open the page in the browser once the site is served")
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samzong/mm/internal/cache"
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// prFilesCache holds the open pull requests of a localization keyed by
+// the files they change
+type prFilesCache struct {
+	Label string                           `json:"label"`
+	Files map[string][]k8sdocs.PullRequest `json:"files"`
+}
+
+// openPRFiles returns the open pull requests of the localization keyed by
+// the localized files they change, from the cache while it is fresh.
+// Offline it returns the cached ones however old, or nil when there are
+// none.
+func openPRFiles(loc locale) (map[string][]k8sdocs.PullRequest, error) {
+	label := loc.label()
+	store := cache.New(cache.PullRequests)
+	var cached prFilesCache
+	if k8sdocs.Offline() {
+		entry, _ := store.Load(loc.code, &cached)
+		if entry == nil || cached.Label != label {
+			noteOffline("open pull requests not checked, none are cached")
+			return nil, nil
+		}
+		stale := ""
+		if entry.Expired() {
+			stale = ", may be stale"
+		}
+		noteOffline("open pull requests as cached %s%s", formatRelativeTime(entry.Created), stale)
+		return cached.Files, nil
+	}
+	if store.Get(loc.code, &cached) && cached.Label == label {
+		return cached.Files, nil
+	}
+
+	files, err := k8sdocs.PullRequestFiles(label)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Put(loc.code, prFilesCache{Label: label, Files: files}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache open pull requests: %v\n", err)
+	}
+	return files, nil
+}
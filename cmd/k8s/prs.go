@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// prsCmd shows the open translation pull requests of a contributor
+var prsCmd = &cobra.Command{
+	Use:   "prs",
+	Short: "Show your open translation pull requests with their CI and review state",
+	Long: `Show your open pull requests of the localization on kubernetes/website
+in one table: CI checks, reviews and the lgtm and approved labels of Prow,
+changes requested, whether the branch needs a rebase, and age.
+
+Pull requests are found by the language/<lang> label of the localization;
+--all shows the pull requests of every language. It needs a GitHub token
+(see mm k8s docs --help).
+
+Examples:
+  mm k8s docs prs
+  mm k8s docs prs --author someone --lang ja
+  mm k8s docs prs -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		author, _ := cmd.Flags().GetString("author")
+		all, _ := cmd.Flags().GetBool("all")
+		output, _ := cmd.Flags().GetString("output")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if output != outputTable && output != outputJSON {
+			return fmt.Errorf("unsupported output format: %s (use table or json)", output)
+		}
+		if k8sdocs.Offline() {
+			cmdutil.SilenceFailure(cmd)
+			return fmt.Errorf("pull requests are listed from GitHub: %w", k8sdocs.ErrOffline)
+		}
+
+		if author == "" {
+			if author, err = k8sdocs.CurrentUser(); err != nil {
+				return err
+			}
+		}
+		label := loc.label()
+		if all {
+			label = ""
+		}
+		prs, err := k8sdocs.OpenPullRequests(author, label)
+		if err != nil {
+			return err
+		}
+
+		if output == outputJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(prs)
+		}
+
+		if len(prs) == 0 {
+			if label == "" {
+				fmt.Printf("@%s has no open pull requests on %s\n", author, k8sdocs.Repository)
+			} else {
+				fmt.Printf("@%s has no open %s pull requests on %s\n", author, label, k8sdocs.Repository)
+			}
+			return nil
+		}
+		fmt.Printf("%-8s %-14s %-14s %-9s %-28s %-7s %s\n", "PR", "Opened", "Updated", "CI", "Review", "Rebase", "Title")
+		fmt.Printf("%-8s %-14s %-14s %-9s %-28s %-7s %s\n", "--", "------", "-------", "--", "------", "------", "-----")
+		for _, pr := range prs {
+			rebase := ""
+			if pr.NeedsRebase() {
+				rebase = "needed"
+			}
+			title := pr.Title
+			if pr.Draft {
+				title = "[draft] " + title
+			}
+			fmt.Printf("%-8s %-14s %-14s %-9s %-28s %-7s %s\n",
+				fmt.Sprintf("#%d", pr.Number),
+				formatRelativeTime(pr.CreatedAt),
+				formatRelativeTime(pr.UpdatedAt),
+				checksState(pr.Checks),
+				truncateString(reviewState(pr), 28),
+				rebase,
+				title)
+		}
+		return nil
+	},
+}
+
+// checksState describes the combined state of the CI checks
+func checksState(state string) string {
+	switch state {
+	case "SUCCESS":
+		return "passing"
+	case "FAILURE", "ERROR":
+		return "failing"
+	case "PENDING", "EXPECTED":
+		return "pending"
+	}
+	return "-"
+}
+
+// reviewState describes where the review of a pull request stands: changes
+// requested, on hold, or the lgtm and approved labels the website merges on
+func reviewState(pr k8sdocs.OpenPullRequest) string {
+	var states []string
+	if len(pr.ChangesRequestedBy) > 0 {
+		states = append(states, "changes by @"+strings.Join(pr.ChangesRequestedBy, ", @"))
+	}
+	if pr.HasLabel(k8sdocs.LabelHold) {
+		states = append(states, "hold")
+	}
+	if pr.HasLabel(k8sdocs.LabelLGTM) {
+		states = append(states, "lgtm")
+	}
+	if pr.HasLabel(k8sdocs.LabelApproved) || pr.Review == "APPROVED" {
+		states = append(states, "approved")
+	}
+	if len(states) == 0 {
+		return "waiting for review"
+	}
+	return strings.Join(states, ", ")
+}
+
+func init() {
+	docsCmd.AddCommand(prsCmd)
+
+	prsCmd.Flags().String("author", "", "GitHub user whose pull requests to show (default the user gh is logged in as)")
+	prsCmd.Flags().Bool("all", false, "Show the pull requests of every language")
+	prsCmd.Flags().StringP("output", "o", outputTable, "Output format (table, json)")
+}
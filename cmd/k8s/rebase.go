@@ -0,0 +1,341 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// rebaseCmd brings a translation branch up to date with the branch its
+// pull request targets, the usual fix of a needs-rebase pull request
+var rebaseCmd = &cobra.Command{
+	Use:   "rebase [branch]",
+	Short: "Rebase a translation branch onto the latest upstream branch",
+	Long: `Rebase a translation branch, the current one by default, onto the latest
+upstream branch its pull request targets, the fix of pull requests labeled
+needs-rebase:
+
+  1. Fetch --branch (main, or the branch of the workflow --run in progress
+     on the branch) from --upstream (upstream, or origin when there is none)
+  2. Rebase the branch onto it
+  3. When the rebase stops at conflicts, show those of the translated pages
+     of the localization, and how to take the upstream version of the other
+     files, which translation branches shouldn't change
+  4. Once the conflicts are resolved and staged, rebase --continue checks
+     the pages that conflicted with the format rules and quality checks of
+     verify, then continues the rebase; conflicts of later commits are
+     shown the same way
+  5. Show the git push --force-with-lease that updates the pull request
+
+git rebase --abort gives up and puts the branch back as it was.
+
+Examples:
+  mm k8s docs rebase
+  mm k8s docs rebase docs/sync/zh/what-is-kubernetes
+  mm k8s docs rebase --continue`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resume, _ := cmd.Flags().GetBool("continue")
+		base, baseGiven := branchFlagValue(cmd)
+		upstream, _ := cmd.Flags().GetString("upstream")
+		remote, _ := cmd.Flags().GetString("remote")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if resume && len(args) > 0 {
+			return fmt.Errorf("--continue goes on with the rebase in progress; give no branch")
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		cmdutil.SilenceFailure(cmd)
+		if resume {
+			return continueRebase(loc, remote)
+		}
+
+		if k8sdocs.RebaseInProgress() {
+			return fmt.Errorf("a rebase is in progress; resolve it and run mm k8s docs rebase --continue, or git rebase --abort")
+		}
+		branch := ""
+		if len(args) > 0 {
+			branch = args[0]
+		} else if branch, err = k8sdocs.CurrentBranch(); err != nil {
+			return err
+		}
+		if !k8sdocs.BranchExists(branch) {
+			return fmt.Errorf("branch %s not found", branch)
+		}
+		if changed, err := k8sdocs.HasTrackedChanges(); err != nil {
+			return err
+		} else if changed {
+			return fmt.Errorf("commit or stash the uncommitted changes before rebasing")
+		}
+
+		// Without --branch, the branch of the workflow in progress is
+		// rebased onto the branch it works against
+		if state, err := loadWorkflowState(loc); err == nil && state != nil && state.Base != "" &&
+			!baseGiven && state.Branch == branch {
+			base = state.Base
+		}
+		loc = loc.onBranch(base)
+		if err := checkBranch(loc); err != nil {
+			return err
+		}
+		if upstream == "" {
+			upstream = "upstream"
+			if !k8sdocs.RemoteExists(upstream) {
+				upstream = "origin"
+			}
+		}
+		ref := upstream + "/" + loc.baseBranch()
+		if k8sdocs.Offline() {
+			noteOffline("%s not fetched, rebasing onto it as last fetched", ref)
+			noteStaleRef(ref)
+		} else {
+			fmt.Printf("Fetching %s...\n", ref)
+			if err := k8sdocs.Fetch(upstream, loc.baseBranch()); err != nil {
+				return fmt.Errorf("failed to fetch %s: %w", ref, err)
+			}
+		}
+
+		behind, err := k8sdocs.CommitsBehind(branch, ref)
+		if err != nil {
+			return err
+		}
+		if behind == 0 {
+			fmt.Printf("%s is up to date with %s\n", branch, ref)
+			return nil
+		}
+		fmt.Printf("Rebasing %s onto %s, %d commits behind it\n\n", branch, ref, behind)
+		state := &rebaseState{Lang: loc.code, Branch: branch, Onto: ref}
+		return afterRebase(loc, state, remote, k8sdocs.Rebase(ref, branch))
+	},
+}
+
+// rebaseState is a rebase started by rebase that stopped at conflicts
+type rebaseState struct {
+	Lang   string `json:"lang"`
+	Branch string `json:"branch"`
+	Onto   string `json:"onto"`
+	// Pages are the translated pages that conflicted, checked before the
+	// rebase continues
+	Pages []string `json:"pages,omitempty"`
+}
+
+// rebaseStatePath returns where the rebase in progress of a localization
+// is kept, in the git directory of the repository
+func rebaseStatePath(loc locale) (string, error) {
+	gitDir, err := k8sdocs.GitDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the git directory: %w", err)
+	}
+	return filepath.Join(gitDir, fmt.Sprintf("mm-rebase-%s.json", loc.code)), nil
+}
+
+// loadRebaseState returns the rebase in progress of a localization, nil
+// when there is none
+func loadRebaseState(loc locale) (*rebaseState, error) {
+	path, err := rebaseStatePath(loc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rebase state: %w", err)
+	}
+	var state rebaseState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse rebase state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// saveRebaseState keeps the rebase in progress of a localization
+func saveRebaseState(loc locale, state rebaseState) error {
+	path, err := rebaseStatePath(loc)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write rebase state: %w", err)
+	}
+	return nil
+}
+
+// clearRebaseState drops the rebase in progress of a localization
+func clearRebaseState(loc locale) error {
+	path, err := rebaseStatePath(loc)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove rebase state: %w", err)
+	}
+	return nil
+}
+
+// continueRebase checks the translated pages that conflicted once they are
+// resolved, then continues the rebase
+func continueRebase(loc locale, remote string) error {
+	state, err := loadRebaseState(loc)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("no rebase of a %s branch in progress; start one with mm k8s docs rebase", loc.code)
+	}
+	if files, err := k8sdocs.ConflictedFiles(); err != nil {
+		return err
+	} else if len(files) > 0 {
+		return fmt.Errorf("resolve the conflicts and git add the files first:\n  %s", strings.Join(files, "\n  "))
+	}
+
+	var pages []string
+	markers := 0
+	for _, page := range state.Pages {
+		if _, err := os.Stat(page); err != nil {
+			continue
+		}
+		hunks, err := k8sdocs.ConflictHunks(page)
+		if err != nil {
+			return err
+		}
+		for _, hunk := range hunks {
+			fmt.Printf("%s:%d: conflict markers left\n", page, hunk.Start)
+		}
+		markers += len(hunks)
+		pages = append(pages, page)
+	}
+	if markers > 0 {
+		return fmt.Errorf("%d conflicts are still marked; resolve them, git add the pages and run this again", markers)
+	}
+	if len(pages) > 0 {
+		fmt.Printf("Checking the %d pages that conflicted\n\n", len(pages))
+		problems, err := verifyPages(loc, pages)
+		if err != nil {
+			return err
+		}
+		if problems > 0 {
+			return fmt.Errorf("found %d problems in the pages that conflicted; fix them, git add them and run this again, or git rebase --continue to go on without the checks", problems)
+		}
+	}
+
+	state.Pages = nil
+	if !k8sdocs.RebaseInProgress() {
+		// Continued with git already
+		return afterRebase(loc, state, remote, nil)
+	}
+	fmt.Printf("Continuing the rebase of %s\n\n", state.Branch)
+	return afterRebase(loc, state, remote, k8sdocs.ContinueRebase())
+}
+
+// afterRebase reports how a rebase went: the conflicts it stopped at, kept
+// to check the pages once resolved, or how to push the rebased branch
+func afterRebase(loc locale, state *rebaseState, remote string, err error) error {
+	if errors.Is(err, k8sdocs.ErrRebaseConflicts) {
+		pages, err := showConflicts(loc)
+		if err != nil {
+			return err
+		}
+		for _, page := range pages {
+			if !slices.Contains(state.Pages, page) {
+				state.Pages = append(state.Pages, page)
+			}
+		}
+		if err := saveRebaseState(loc, *state); err != nil {
+			return err
+		}
+		return fmt.Errorf("the rebase of %s onto %s stopped at conflicts", state.Branch, state.Onto)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := clearRebaseState(loc); err != nil {
+		return err
+	}
+	pushTo, err := resolvePushRemote(remote)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rebased %s onto %s. Update its pull request with:\n", state.Branch, state.Onto)
+	fmt.Printf("  git push --force-with-lease %s %s\n", pushTo.name, state.Branch)
+	return nil
+}
+
+// showConflicts shows the conflicts a rebase stopped at: the conflicts of
+// the translated pages of the localization, which it returns, and how to
+// take the upstream version of the other files
+func showConflicts(loc locale) ([]string, error) {
+	files, err := k8sdocs.ConflictedFiles()
+	if err != nil {
+		return nil, err
+	}
+	if commit, err := k8sdocs.RebaseHead(); err == nil {
+		fmt.Printf("Conflicts applying %s %s\n\n", commit.Short(), commit.Subject)
+	}
+
+	var pages, others []string
+	for _, file := range files {
+		if k8sdocs.LocalizationOf(file) == loc.code {
+			pages = append(pages, file)
+		} else {
+			others = append(others, file)
+		}
+	}
+	for _, page := range pages {
+		hunks, err := k8sdocs.ConflictHunks(page)
+		if err != nil {
+			// Deleted on one side: no markers to show
+			fmt.Printf("%s: changed on one side and deleted on the other\n", page)
+			continue
+		}
+		fmt.Printf("%s: %d conflicts\n", page, len(hunks))
+		for _, hunk := range hunks {
+			fmt.Printf("  lines %d-%d: %d lines upstream, %d lines yours\n", hunk.Start, hunk.End, hunk.Upstream, hunk.Yours)
+		}
+	}
+	if len(others) > 0 {
+		if len(pages) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Other files conflict too. Translation branches shouldn't change them, so take their upstream version:\n")
+		for _, file := range others {
+			fmt.Printf("  git checkout --ours -- %s && git add %s\n", quoteArgs([]string{file}), quoteArgs([]string{file}))
+		}
+	}
+	if len(pages) > 0 {
+		fmt.Printf("\nResolve the conflicts of the translated pages, keeping the translation in line with the English page upstream has now, then:\n")
+		fmt.Printf("  git add %s\n", quoteArgs(pages))
+	} else {
+		fmt.Printf("\nThen:\n")
+	}
+	fmt.Printf("  mm k8s docs rebase --continue\n")
+	fmt.Printf("or give up with: git rebase --abort\n\n")
+	return pages, nil
+}
+
+func init() {
+	docsCmd.AddCommand(rebaseCmd)
+
+	rebaseCmd.Flags().Bool("continue", false, "Check the pages that conflicted once resolved and staged, then continue the rebase")
+	addBranchFlag(rebaseCmd, "Branch to rebase onto, e.g. the release branch dev-1.32 (default main)")
+	rebaseCmd.Flags().String("upstream", "", "Remote to fetch the branch from (default upstream, or origin when there is none)")
+	rebaseCmd.Flags().String("remote", "", "Remote of your fork the rebased branch is pushed to (default k8s.remote in .mm.yaml, else found among the remotes)")
+}
@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/quality/detector"
+)
+
+// forkRemoteNames are the names a fork remote usually has, looked at first
+var forkRemoteNames = []string{"origin", "fork"}
+
+// pushRemote is the git remote sync branches are pushed to
+type pushRemote struct {
+	name string
+	// repository is the GitHub repository of the remote, owner/name
+	repository string
+}
+
+// fork reports whether the remote is a fork rather than the repository
+// of the site
+func (r pushRemote) fork() bool {
+	return !strings.EqualFold(r.repository, k8sdocs.Repository)
+}
+
+// head returns the head of the pull request of a branch pushed to the
+// remote: owner:branch from a fork, the branch itself otherwise
+func (r pushRemote) head(branch string) string {
+	if !r.fork() {
+		return branch
+	}
+	owner, _, _ := strings.Cut(r.repository, "/")
+	return owner + ":" + branch
+}
+
+// pushCommand returns the git push of a branch to the remote
+func (r pushRemote) pushCommand(branch string) string {
+	return fmt.Sprintf("git push -u %s %s", r.name, branch)
+}
+
+// prCreateCommand returns the gh pr create that opens the pull request of
+// a branch pushed to the remote on the repository of the site
+func (r pushRemote) prCreateCommand(loc locale, branch, title, body string) string {
+	head := ""
+	if r.fork() {
+		head = " --head " + r.head(branch)
+	}
+	return fmt.Sprintf("gh pr create --repo %s%s --title %q%s --body-file - <<'EOF'\n%s\nEOF",
+		k8sdocs.Repository, head, title, loc.baseFlag(), body)
+}
+
+// resolvePushRemote returns the remote to push sync branches to: the one
+// given, else k8s.remote of .mm.yaml, else the remote of a fork of the
+// site, origin or fork first. Without one, origin is used with a warning,
+// for contributors who push to the site repository itself.
+func resolvePushRemote(name string) (pushRemote, error) {
+	if name == "" {
+		cfg, err := loadK8sConfig()
+		if err != nil {
+			return pushRemote{}, err
+		}
+		name = cfg.Remote
+	}
+	if name != "" {
+		url, err := k8sdocs.RemoteURL(name)
+		if err != nil {
+			return pushRemote{}, fmt.Errorf("failed to get remote %s: %w", name, err)
+		}
+		repo := detector.RemoteRepository(url)
+		if repo == "" {
+			return pushRemote{}, fmt.Errorf("failed to parse the GitHub repository of remote %s: %s", name, url)
+		}
+		return pushRemote{name: name, repository: repo}, nil
+	}
+
+	names, err := k8sdocs.Remotes()
+	if err != nil {
+		return pushRemote{}, fmt.Errorf("failed to list the git remotes: %w", err)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return remoteRank(names[i]) < remoteRank(names[j])
+	})
+	_, siteName, _ := strings.Cut(strings.ToLower(k8sdocs.Repository), "/")
+	var others []pushRemote
+	for _, name := range names {
+		// upstream names the site by convention, wherever it points
+		if name == "upstream" {
+			continue
+		}
+		url, err := k8sdocs.RemoteURL(name)
+		if err != nil {
+			continue
+		}
+		remote := pushRemote{name: name, repository: detector.RemoteRepository(url)}
+		if remote.repository == "" || !remote.fork() {
+			continue
+		}
+		// A fork keeps the name of the repository unless it was renamed
+		if _, repoName, _ := strings.Cut(remote.repository, "/"); repoName == siteName {
+			return remote, nil
+		}
+		others = append(others, remote)
+	}
+	if len(others) > 0 {
+		return others[0], nil
+	}
+	fmt.Fprintf(os.Stderr, "Warning: no remote of a fork of %s found, pushing to origin; add your fork with git remote add fork <url>, or set k8s.remote in .mm.yaml\n", k8sdocs.Repository)
+	return pushRemote{name: "origin", repository: k8sdocs.Repository}, nil
+}
+
+// remoteRank orders the remotes looked at for a fork: origin and fork
+// first, then the others by name
+func remoteRank(name string) int {
+	for i, known := range forkRemoteNames {
+		if name == known {
+			return i
+		}
+	}
+	return len(forkRemoteNames)
+}
@@ -0,0 +1,278 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// workflowState is a workflow started with --run, waiting for the page to
+// be translated. The names are kept from the start, since the plan of a
+// new post changes once its translation exists.
+type workflowState struct {
+	Lang    string    `json:"lang"`
+	Source  string    `json:"source"`
+	Path    string    `json:"path"`
+	Branch  string    `json:"branch"`
+	Commit  string    `json:"commit"`
+	PRTitle string    `json:"pr_title"`
+	PRBody  string    `json:"pr_body"`
+	Started time.Time `json:"started"`
+	// Head is the commit checked out when the workflow started, to tell
+	// the English changes made since
+	Head string `json:"head,omitempty"`
+	// Base is the release branch the workflow works against, empty for
+	// main
+	Base string `json:"base,omitempty"`
+	// Pages are the pages of a batch workflow, whose Path is the directory
+	// they share
+	Pages []batchPage `json:"pages,omitempty"`
+}
+
+// workflowStatePath returns where the workflow in progress of a
+// localization is kept, in the git directory of the repository
+func workflowStatePath(loc locale) (string, error) {
+	gitDir, err := k8sdocs.GitDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the git directory: %w", err)
+	}
+	return filepath.Join(gitDir, fmt.Sprintf("mm-workflow-%s.json", loc.code)), nil
+}
+
+// loadWorkflowState returns the workflow in progress of a localization,
+// nil when there is none
+func loadWorkflowState(loc locale) (*workflowState, error) {
+	path, err := workflowStatePath(loc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow state: %w", err)
+	}
+	var state workflowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// saveWorkflowState keeps the workflow in progress of a localization
+func saveWorkflowState(loc locale, state workflowState) error {
+	path, err := workflowStatePath(loc)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write workflow state: %w", err)
+	}
+	return nil
+}
+
+// clearWorkflowState forgets the workflow in progress of a localization
+func clearWorkflowState(loc locale) error {
+	path, err := workflowStatePath(loc)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove workflow state: %w", err)
+	}
+	return nil
+}
+
+// covers reports whether a localized page is translated by the workflow
+func (s workflowState) covers(path string) bool {
+	if s.Path == path {
+		return true
+	}
+	for _, page := range s.Pages {
+		if page.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowHead returns the commit the workflow in progress of a page
+// started from, empty when there is none
+func workflowHead(loc locale, path string) string {
+	state, err := loadWorkflowState(loc)
+	if err != nil || state == nil || !state.covers(path) {
+		return ""
+	}
+	return state.Head
+}
+
+// warnStale warns when the English page of a translation changed on base
+// since the translation started from since, or since the branch forked
+// from base, and shows the changes. It reports whether it warned.
+func warnStale(upstream, since, base string) bool {
+	noteStaleRef(base)
+	change, err := k8sdocs.StaleSince(upstream, since, base)
+	if err != nil || change == nil {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s changed on %s since the translation started (+%d -%d lines); sync these changes too:\n\n%s\n",
+		upstream, base, change.Added, change.Deleted, change.Diff)
+	return true
+}
+
+// runWorkflow runs the git steps of the workflow of a page in two parts:
+// creating the branch before the translation, then committing and pushing
+// it once the page is translated. The workflow in progress is resumed when
+// filePath is empty or names its page.
+func runWorkflow(loc locale, filePath string, runner *stepRunner, remote string) error {
+	state, err := loadWorkflowState(loc)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		if filePath == "" {
+			return fmt.Errorf("no workflow in progress for %s; give the page to translate", loc.code)
+		}
+		return startWorkflow(loc, filePath, runner)
+	}
+	// The workflow goes on against the branch it started on
+	loc = loc.onBranch(state.Base)
+	if len(state.Pages) > 0 {
+		if filePath != "" {
+			return fmt.Errorf("the workflow of %s/ (branch %s) is in progress; finish it with --run, or drop it with --abandon", state.Path, state.Branch)
+		}
+		return finishBatchWorkflow(loc, *state, runner, remote)
+	}
+
+	if filePath != "" {
+		plan, err := newSyncPlan(loc, filePath)
+		if err != nil {
+			return err
+		}
+		if plan.path != state.Path {
+			return fmt.Errorf("the workflow of %s (branch %s) is in progress; finish it with --run, or drop it with --abandon", state.Path, state.Branch)
+		}
+	}
+	return finishWorkflow(loc, *state, runner, remote)
+}
+
+// startWorkflow creates the branch of a page, copies the files of the
+// English bundle it lacks, and keeps the workflow for after the
+// translation
+func startWorkflow(loc locale, filePath string, runner *stepRunner) error {
+	plan, err := newSyncPlan(loc, filePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Starting the workflow for: %s\n\n", plan.path)
+
+	current, err := k8sdocs.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if current != plan.branch {
+		if ok, err := runner.git("Create and switch to the sync branch", loc.switchArgs(plan.branch)...); !ok {
+			return err
+		}
+	}
+	if len(plan.assets) > 0 {
+		if ok, err := runner.copyAssets(loc, plan.assets); !ok {
+			return err
+		}
+	}
+	head, err := k8sdocs.HeadCommit()
+	if err != nil {
+		return err
+	}
+	state := workflowState{
+		Lang:    loc.code,
+		Source:  plan.source,
+		Path:    plan.path,
+		Branch:  plan.branch,
+		Commit:  plan.commitMessage,
+		PRTitle: plan.prTitle,
+		PRBody:  plan.prBody,
+		Started: time.Now(),
+		Head:    head,
+		Base:    loc.base,
+	}
+	if err := saveWorkflowState(loc, state); err != nil {
+		return err
+	}
+	fmt.Printf("Now translate %s, then run:\n  mm k8s docs workflow --run --lang %s\n", plan.path, loc.code)
+	return nil
+}
+
+// finishWorkflow commits the translated page of a workflow in progress and
+// pushes its branch
+func finishWorkflow(loc locale, state workflowState, runner *stepRunner, remoteName string) error {
+	plan, err := newSyncPlan(loc, state.Source)
+	if err != nil {
+		return err
+	}
+	plan.branch, plan.commitMessage, plan.prTitle, plan.prBody = state.Branch, state.Commit, state.PRTitle, state.PRBody
+	fmt.Printf("Resuming the workflow for: %s (started %s)\n\n", plan.path, formatRelativeTime(state.Started))
+
+	current, err := k8sdocs.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if current != plan.branch {
+		if ok, err := runner.git("Switch back to the sync branch", "switch", plan.branch); !ok {
+			return err
+		}
+	}
+	changed, err := k8sdocs.UncommittedFiles(plan.stagePath())
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		fmt.Printf("%s has no changes yet; translate it, then run this again\n", plan.path)
+		return nil
+	}
+	warnStale(plan.upstream, state.Head, loc.baseRef())
+
+	if plan.syncRef == k8sdocs.SyncRefFrontMatter && plan.upstreamCommit != "" &&
+		!sameCommit(k8sdocs.RecordedRef(plan.path), plan.upstreamCommit) {
+		if ok, err := runner.recordRef(plan); !ok {
+			return err
+		}
+	}
+	if ok, err := runner.git("Stage the translated file", "add", plan.stagePath()); !ok {
+		return err
+	}
+	if ok, err := runner.git("Commit with Signed-off-by", plan.commitArgs()...); !ok {
+		return err
+	}
+	remote, err := resolvePushRemote(remoteName)
+	if err != nil {
+		return err
+	}
+	if k8sdocs.Offline() {
+		if err := clearWorkflowState(loc); err != nil {
+			return err
+		}
+		fmt.Printf("Committed %s. Offline, so push it when back online, then open the pull request:\n", plan.branch)
+		fmt.Printf("%s\n", remote.pushCommand(plan.branch))
+		fmt.Printf("%s\n", remote.prCreateCommand(loc, plan.branch, plan.prTitle, plan.prBody))
+		return nil
+	}
+	if ok, err := runner.git("Push the branch to "+remote.name, "push", "-u", remote.name, plan.branch); !ok {
+		return err
+	}
+	if err := clearWorkflowState(loc); err != nil {
+		return err
+	}
+	fmt.Printf("Pushed %s. Open the pull request with:\n", plan.branch)
+	fmt.Printf("%s\n", remote.prCreateCommand(loc, plan.branch, plan.prTitle, plan.prBody))
+	return nil
+}
@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/tui"
+)
+
+// selectFiles lets the user pick files to translate: in the full-screen
+// picker on a terminal, by number otherwise. It returns nil when the user
+// picks nothing.
+func selectFiles(loc locale, title string, files []fileChange) ([]fileChange, error) {
+	claims := loadClaims()
+	if !tui.IsTerminal() {
+		return selectFileByNumber(loc, title, files, claims)
+	}
+
+	picker := &tui.Picker{
+		Title: title,
+		Columns: []tui.Column{
+			{Title: "Added", Width: 7, Right: true},
+			{Title: "Deleted", Width: 7, Right: true},
+			{Title: "Modified", Width: 14},
+			{Title: "File"},
+		},
+		Sorts: []tui.Sort{
+			{Name: "modified", Less: func(a, b tui.Item) bool {
+				return a.Value.(fileChange).LastModified.After(b.Value.(fileChange).LastModified)
+			}},
+			{Name: "added", Less: func(a, b tui.Item) bool {
+				return a.Value.(fileChange).AddedLines > b.Value.(fileChange).AddedLines
+			}},
+			{Name: "deleted", Less: func(a, b tui.Item) bool {
+				return a.Value.(fileChange).DeletedLines > b.Value.(fileChange).DeletedLines
+			}},
+			{Name: "file", Less: func(a, b tui.Item) bool {
+				return a.Value.(fileChange).FilePath < b.Value.(fileChange).FilePath
+			}},
+		},
+		Multi: true,
+		Preview: func(item tui.Item) (string, error) {
+			file := item.Value.(fileChange)
+			_, diff, err := k8sdocs.UpstreamDiff(loc.localize(file.FilePath), loc.code)
+			if err != nil {
+				return "", err
+			}
+			if diff == "" {
+				return "No English changes since the last translation", nil
+			}
+			return diff, nil
+		},
+	}
+	for _, file := range files {
+		picker.Items = append(picker.Items, tui.Item{
+			Cells: []string{
+				strconv.Itoa(file.AddedLines),
+				strconv.Itoa(file.DeletedLines),
+				formatRelativeTime(file.LastModified),
+				strings.TrimSpace(displayPath(file.FilePath) + " " + claimNote(loc, claims, file.FilePath)),
+			},
+			Value: file,
+		})
+	}
+
+	items, err := picker.Run()
+	if err != nil {
+		return nil, err
+	}
+	var selected []fileChange
+	for _, item := range items {
+		selected = append(selected, item.Value.(fileChange))
+	}
+	return selected, nil
+}
+
+// selectFileByNumber lists files with numbers and reads the number of one
+func selectFileByNumber(loc locale, title string, files []fileChange, claims map[string]k8sdocs.Claim) ([]fileChange, error) {
+	fmt.Printf("%s:\n\n", title)
+	for i, file := range files {
+		timeStr := formatRelativeTime(file.LastModified)
+		fmt.Printf("[%2d] %-60s (modified %s) %s\n", i+1, displayPath(file.FilePath), timeStr, claimNote(loc, claims, file.FilePath))
+	}
+
+	fmt.Printf("\nSelect a file number (1-%d), or press Enter to exit: ", len(files))
+
+	var input string
+	fmt.Scanln(&input)
+
+	if input == "" {
+		return nil, nil
+	}
+
+	selection, err := strconv.Atoi(input)
+	if err != nil || selection < 1 || selection > len(files) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+	fmt.Printf("\n")
+	return files[selection-1 : selection], nil
+}
+
+// displayPath shortens an English content path to the path under it
+func displayPath(filePath string) string {
+	return strings.TrimPrefix(filePath, k8sdocs.UpstreamDir)
+}
+
+// generateSelectedWorkflows prints the workflow commands of the selected
+// files, pushing their branches to a remote, the fork found when it is
+// empty
+func generateSelectedWorkflows(loc locale, files []fileChange, remoteName string) error {
+	if len(files) == 0 {
+		return nil
+	}
+	remote, err := resolvePushRemote(remoteName)
+	if err != nil {
+		return err
+	}
+	for i, file := range files {
+		if i > 0 {
+			fmt.Printf("\n")
+		}
+		if err := generateWorkflowCommands(loc, displayPath(file.FilePath), remote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
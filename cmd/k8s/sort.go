@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+)
+
+// Orders of the lsync results
+const (
+	sortModified = "modified"
+	sortPriority = "priority"
+	sortAdded    = "added"
+	sortDeleted  = "deleted"
+	sortPath     = "path"
+)
+
+// limitFiles returns the first limit lsync results, all of them when limit
+// is 0
+func limitFiles(files []fileChange, limit int) []fileChange {
+	if limit > 0 && len(files) > limit {
+		return files[:limit]
+	}
+	return files
+}
+
+// checkSortOrder reports an error for an unknown lsync order
+func checkSortOrder(order string) error {
+	switch order {
+	case sortModified, sortPriority, sortAdded, sortDeleted, sortPath:
+		return nil
+	}
+	return fmt.Errorf("unsupported sort order: %s (use modified, priority, added, deleted or path)", order)
+}
+
+// sortFiles orders the lsync results: newest English change first, highest
+// priority first, most lines added or deleted first, or by path. Ties keep
+// the newest change first.
+func sortFiles(files []fileChange, order string) error {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].LastModified.After(files[j].LastModified)
+	})
+	switch order {
+	case sortPriority:
+		if err := scorePriority(files); err != nil {
+			return err
+		}
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].Priority > files[j].Priority
+		})
+	case sortAdded:
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].AddedLines > files[j].AddedLines
+		})
+	case sortDeleted:
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].DeletedLines > files[j].DeletedLines
+		})
+	case sortPath:
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].FilePath < files[j].FilePath
+		})
+	}
+	return nil
+}
+
+// scorePriority sets the priority of the files from the size of their
+// English changes, how long ago they were translated and their importance,
+// weighted as configured in k8s.priority of .mm.yaml
+func scorePriority(files []fileChange) error {
+	cfg, err := loadK8sConfig()
+	if err != nil {
+		return err
+	}
+	var views map[string]float64
+	if cfg.Priority.Analytics != "" {
+		views, err = k8sdocs.LoadPageViews(cfg.Priority.Analytics)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring page views: %v\n", err)
+		}
+	}
+	priority := k8sdocs.NewPriority(cfg.Priority.Weights, views, cfg.Priority.SizeWeight, cfg.Priority.StalenessWeight)
+
+	// Translations are as old as their sync commits
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		if file.SyncCommit != "" && !seen[file.SyncCommit] {
+			seen[file.SyncCommit] = true
+			hashes = append(hashes, file.SyncCommit)
+		}
+	}
+	synced, err := k8sdocs.CommitTimes(hashes)
+	if err != nil {
+		return fmt.Errorf("failed to get the times of the sync commits: %w", err)
+	}
+
+	now := time.Now()
+	for i, file := range files {
+		var stale time.Duration
+		if syncTime, ok := synced[file.SyncCommit]; ok {
+			stale = now.Sub(syncTime)
+		}
+		files[i].Priority = priority.Score(file.FilePath, file.AddedLines+file.DeletedLines, stale)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// cycleStats sums the contributions of a release cycle
+type cycleStats struct {
+	Cycle        string                      `json:"cycle"`
+	PullRequests []k8sdocs.MergedPullRequest `json:"pull_requests"`
+	Commits      int                         `json:"commits"`
+	Files        int                         `json:"files"`
+	Added        int                         `json:"lines_added"`
+	Deleted      int                         `json:"lines_deleted"`
+
+	files map[string]bool
+}
+
+// myStatsReport is the JSON output of my-stats
+type myStatsReport struct {
+	Lang       string        `json:"lang"`
+	Author     string        `json:"author"`
+	GitHubUser string        `json:"github_user,omitempty"`
+	Since      time.Time     `json:"since"`
+	Cycles     []*cycleStats `json:"cycles"`
+	Total      *cycleStats   `json:"total"`
+}
+
+// myStatsCmd summarizes your localization contributions
+var myStatsCmd = &cobra.Command{
+	Use:   "my-stats",
+	Short: "Summarize your localization contributions per release cycle",
+	Long: `Summarize your contributions to a localization per Kubernetes release
+cycle: pull requests merged, commits, files translated and lines changed,
+ready to paste into SIG Docs localization reports.
+
+Commits are read from the git history of the working directory, so fetch
+the latest upstream main first. Merged pull requests are searched on GitHub
+by their language/<lang> label; use --no-github to skip them.
+
+A release cycle is named by the release that ends it: v1.34 is the work
+after the 1.33 release. Release dates come from data/releases/schedule.yaml
+of the website.
+
+--stale lists the pages you translated in the period instead that went
+stale again: their English page changed on upstream main since your pull
+request merged, or since the page was last synced after it. It only needs
+git, so keep upstream fetched; --cycles 0 looks at all your translations.
+
+Examples:
+  mm k8s docs my-stats                        # The last 3 release cycles
+  mm k8s docs my-stats --cycles 1 -o md       # The current cycle as Markdown
+  mm k8s docs my-stats --since 2025-01-01 --author me@example.com
+  mm k8s docs my-stats --stale --cycles 0     # Your pages to sync again`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		author, _ := cmd.Flags().GetString("author")
+		githubUser, _ := cmd.Flags().GetString("github-user")
+		cycles, _ := cmd.Flags().GetInt("cycles")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		noGitHub, _ := cmd.Flags().GetBool("no-github")
+		output, _ := cmd.Flags().GetString("output")
+		stale, _ := cmd.Flags().GetBool("stale")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if output != outputTable && output != outputMarkdown && output != outputJSON {
+			return fmt.Errorf("unsupported output format: %s (use table, md or json)", output)
+		}
+
+		if author == "" {
+			author = k8sdocs.GitConfig("user.email")
+		}
+		if author == "" {
+			return fmt.Errorf("no git user.email configured; use --author")
+		}
+
+		releases := k8sdocs.Releases()
+		since := cycleStart(releases, cycles)
+		if sinceFlag != "" {
+			since, err = time.Parse("2006-01-02", sinceFlag)
+			if err != nil {
+				return fmt.Errorf("invalid --since date %s (use YYYY-MM-DD)", sinceFlag)
+			}
+		}
+
+		if stale {
+			return printStaleAgain(loc, author, since, output)
+		}
+
+		report := &myStatsReport{Lang: loc.code, Author: author, Since: since}
+		byCycle := make(map[string]*cycleStats)
+		cycleOf := func(t time.Time) *cycleStats {
+			name := k8sdocs.CycleOf(t, releases)
+			if byCycle[name] == nil {
+				byCycle[name] = &cycleStats{Cycle: name, PullRequests: []k8sdocs.MergedPullRequest{}, files: make(map[string]bool)}
+				report.Cycles = append(report.Cycles, byCycle[name])
+			}
+			return byCycle[name]
+		}
+
+		contributions, err := k8sdocs.Contributions(author, loc.code, since)
+		if err != nil {
+			return err
+		}
+		for _, contribution := range contributions {
+			stats := cycleOf(contribution.Time)
+			stats.Commits++
+			stats.Added += contribution.Added
+			stats.Deleted += contribution.Deleted
+			for _, file := range contribution.Files {
+				stats.files[file] = true
+			}
+		}
+
+		if !noGitHub && k8sdocs.Offline() {
+			noteOffline("merged pull requests not counted")
+		} else if !noGitHub {
+			if githubUser == "" {
+				githubUser, err = k8sdocs.CurrentUser()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping pull requests: %v\n", err)
+			} else {
+				report.GitHubUser = githubUser
+				prs, err := k8sdocs.MergedPullRequests(githubUser, loc.label(), since)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: skipping pull requests: %v\n", err)
+				}
+				for _, pr := range prs {
+					stats := cycleOf(pr.MergedAt)
+					stats.PullRequests = append(stats.PullRequests, pr)
+				}
+			}
+		}
+
+		// Newest cycle first, and the total over all of them
+		sort.Slice(report.Cycles, func(i, j int) bool {
+			return cycleIndex(releases, report.Cycles[i].Cycle) > cycleIndex(releases, report.Cycles[j].Cycle)
+		})
+		report.Total = &cycleStats{Cycle: "Total", PullRequests: []k8sdocs.MergedPullRequest{}, files: make(map[string]bool)}
+		for _, stats := range report.Cycles {
+			stats.Files = len(stats.files)
+			sort.Slice(stats.PullRequests, func(i, j int) bool {
+				return stats.PullRequests[i].MergedAt.Before(stats.PullRequests[j].MergedAt)
+			})
+			report.Total.PullRequests = append(report.Total.PullRequests, stats.PullRequests...)
+			report.Total.Commits += stats.Commits
+			report.Total.Added += stats.Added
+			report.Total.Deleted += stats.Deleted
+			for file := range stats.files {
+				report.Total.files[file] = true
+			}
+		}
+		report.Total.Files = len(report.Total.files)
+
+		switch output {
+		case outputJSON:
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		case outputMarkdown:
+			printStatsMarkdown(loc, report)
+		default:
+			printStatsTable(loc, report)
+		}
+		return nil
+	},
+}
+
+// cycleStart returns when the last n release cycles started, the date of
+// the release before them
+func cycleStart(releases []k8sdocs.Release, n int) time.Time {
+	if n <= 0 || len(releases) < n {
+		return time.Time{}
+	}
+	return releases[len(releases)-n].Date
+}
+
+// cycleIndex orders cycle names by their release
+func cycleIndex(releases []k8sdocs.Release, cycle string) int {
+	for i, release := range releases {
+		if "v"+release.Version == cycle {
+			return i
+		}
+	}
+	return len(releases)
+}
+
+// printStatsTable prints the contributions per cycle
+func printStatsTable(loc locale, report *myStatsReport) {
+	fmt.Printf("Contributions of %s to %s%s\n\n", report.Author, loc.contentDir(), sinceNote(report.Since))
+	if len(report.Cycles) == 0 {
+		fmt.Printf("No contributions found\n")
+		return
+	}
+	fmt.Printf("%-8s %-10s %-8s %-8s %-12s %s\n", "Cycle", "PRs", "Commits", "Files", "Lines added", "Lines deleted")
+	fmt.Printf("%-8s %-10s %-8s %-8s %-12s %s\n", "-----", "---", "-------", "-----", "-----------", "-------------")
+	for _, stats := range append(report.Cycles, report.Total) {
+		fmt.Printf("%-8s %-10d %-8d %-8d %-12d %d\n", stats.Cycle, len(stats.PullRequests), stats.Commits, stats.Files, stats.Added, stats.Deleted)
+	}
+}
+
+// printStatsMarkdown prints the contributions as a Markdown report with
+// the merged pull requests of each cycle
+func printStatsMarkdown(loc locale, report *myStatsReport) {
+	who := report.Author
+	if report.GitHubUser != "" {
+		who = "@" + report.GitHubUser
+	}
+	fmt.Printf("## %s localization contributions of %s%s\n\n", loc.code, who, sinceNote(report.Since))
+	if len(report.Cycles) == 0 {
+		fmt.Printf("No contributions found.\n")
+		return
+	}
+	fmt.Printf("| Cycle | PRs merged | Commits | Files | Lines added | Lines deleted |\n")
+	fmt.Printf("| --- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, stats := range append(report.Cycles, report.Total) {
+		fmt.Printf("| %s | %d | %d | %d | %d | %d |\n", stats.Cycle, len(stats.PullRequests), stats.Commits, stats.Files, stats.Added, stats.Deleted)
+	}
+
+	for _, stats := range report.Cycles {
+		if len(stats.PullRequests) == 0 {
+			continue
+		}
+		fmt.Printf("\n### %s\n\n", stats.Cycle)
+		for _, pr := range stats.PullRequests {
+			fmt.Printf("- [#%d](%s) %s\n", pr.Number, pr.HTMLURL, markdownCell(pr.Title))
+		}
+	}
+}
+
+// printStaleAgain lists the pages an author translated since a time whose
+// English page changed on the default branch after
+func printStaleAgain(loc locale, author string, since time.Time, output string) error {
+	base, err := k8sdocs.BranchRef(k8sdocs.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	noteStaleRef(base)
+	pages, err := k8sdocs.StaleAgain(author, loc.code, base, since)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case outputJSON:
+		if pages == nil {
+			pages = []k8sdocs.StalePage{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(pages)
+	case outputMarkdown:
+		fmt.Printf("## %s pages of %s that went stale again on %s%s\n\n", loc.code, author, base, sinceNote(since))
+		if len(pages) == 0 {
+			fmt.Printf("No stale pages found.\n")
+			return nil
+		}
+		fmt.Printf("| Page | Pull request | Merged | English commits | Lines changed |\n")
+		fmt.Printf("| --- | --- | --- | ---: | ---: |\n")
+		for _, page := range pages {
+			pr := shortHash(page.Commit)
+			if page.PullRequest != 0 {
+				pr = fmt.Sprintf("[#%d](https://github.com/%s/pull/%d)", page.PullRequest, k8sdocs.Repository, page.PullRequest)
+			}
+			fmt.Printf("| %s | %s | %s | %d | +%d -%d |\n", markdownCell(page.Page), pr,
+				page.MergedAt.Format("2006-01-02"), page.Commits, page.Added, page.Deleted)
+		}
+	default:
+		fmt.Printf("Pages of %s translated by %s that went stale again on %s%s\n\n", loc.contentDir(), author, base, sinceNote(since))
+		if len(pages) == 0 {
+			fmt.Printf("No stale pages found\n")
+			return nil
+		}
+		fmt.Printf("%-70s %-10s %-11s %-8s %s\n", "Page", "PR", "Merged", "Commits", "Lines")
+		fmt.Printf("%-70s %-10s %-11s %-8s %s\n", "----", "--", "------", "-------", "-----")
+		for _, page := range pages {
+			pr := shortHash(page.Commit)
+			if page.PullRequest != 0 {
+				pr = fmt.Sprintf("#%d", page.PullRequest)
+			}
+			fmt.Printf("%-70s %-10s %-11s %-8d +%d -%d\n", page.Page, pr,
+				page.MergedAt.Format("2006-01-02"), page.Commits, page.Added, page.Deleted)
+		}
+		fmt.Printf("\nSee the English changes of a page with: mm k8s docs lsync <page>\n")
+	}
+	return nil
+}
+
+// sinceNote describes the start of the report period
+func sinceNote(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	return " since " + since.Format("2006-01-02")
+}
+
+func init() {
+	docsCmd.AddCommand(myStatsCmd)
+
+	myStatsCmd.Flags().String("author", "", "Commit author to count, as git log --author (default git user.email)")
+	myStatsCmd.Flags().String("github-user", "", "GitHub login of the merged pull requests (default the gh user)")
+	myStatsCmd.Flags().Int("cycles", 3, "Number of release cycles to report, the current one included")
+	myStatsCmd.Flags().String("since", "", "Report contributions since this date (YYYY-MM-DD) instead of --cycles")
+	myStatsCmd.Flags().Bool("no-github", false, "Don't search merged pull requests on GitHub")
+	myStatsCmd.Flags().Bool("stale", false, "List the pages you translated whose English page changed since your pull request merged")
+	myStatsCmd.Flags().StringP("output", "o", outputTable, "Output format (table, md, json)")
+}
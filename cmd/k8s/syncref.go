@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// syncRefCmd records the English commit a translation was made from
+var syncRefCmd = &cobra.Command{
+	Use:   "sync-ref <file-path>...",
+	Short: "Record the English version a translation was made from",
+	Long: `Record the English commit a page was translated from, so lsync compares
+the English page from that commit instead of from the last commit of the
+translation. This stops lsync from flagging pages updated from a newer
+English version than their branch started from, and from hiding English
+changes behind later typo fixes of the translation.
+
+Teams choose how the commit is recorded in .mm.yaml:
+
+  k8s:
+    sync_ref: trailer        # Upstream-Commit: <hash> in the commit message
+    # sync_ref: front-matter # upstream_commit: <hash> in the page
+
+With front-matter, sync-ref writes the last commit of the English page to
+the front matter of the translation. With trailer, it shows the trailer to
+commit with; workflow, pr create and batch add it for you. lsync reads both.
+Without sync_ref, nothing is recorded unless you run sync-ref, which then
+shows the trailer.
+
+--check verifies that the recorded version is current, as pr create does
+before committing.
+
+Examples:
+  mm k8s docs sync-ref docs/concepts/overview/what-is-kubernetes.md
+  mm k8s docs sync-ref --check content/zh-cn/docs/concepts/overview/what-is-kubernetes.md`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+
+		outdated := 0
+		for _, arg := range args {
+			plan, err := newSyncPlan(loc, arg)
+			if err != nil {
+				return err
+			}
+			if plan.syncRef == k8sdocs.SyncRefNone {
+				// Asked to record it; a trailer changes no file
+				plan.syncRef = k8sdocs.SyncRefTrailer
+			}
+			if _, err := os.Stat(plan.path); err != nil {
+				return fmt.Errorf("translated file not found: %s", plan.path)
+			}
+			if plan.upstreamCommit == "" {
+				return fmt.Errorf("no English commit found for %s", plan.upstream)
+			}
+
+			if check {
+				page, _, err := k8sdocs.UpstreamDiff(plan.path, loc.code)
+				if err != nil {
+					fmt.Printf("%s: %v\n", plan.path, err)
+					outdated++
+					continue
+				}
+				if page.Added+page.Deleted > 0 {
+					fmt.Printf("%s: English changed since %s (+%d -%d lines); run mm k8s docs sync-ref after updating it\n",
+						plan.path, shortHash(page.SyncCommit), page.Added, page.Deleted)
+					outdated++
+					continue
+				}
+				fmt.Printf("%s: up to date with %s\n", plan.path, shortHash(plan.upstreamCommit))
+				continue
+			}
+
+			if plan.syncRef == k8sdocs.SyncRefFrontMatter {
+				if err := k8sdocs.SetRecordedRef(plan.path, plan.upstreamCommit); err != nil {
+					return err
+				}
+				fmt.Printf("%s: recorded %s: %s\n", plan.path, k8sdocs.SyncRefField, plan.upstreamCommit)
+				continue
+			}
+			fmt.Printf("%s: commit the translation with the trailer\n  %s: %s\n", plan.path, k8sdocs.SyncRefTrailerKey, plan.upstreamCommit)
+			fmt.Printf("  $ git %s\n", quoteArgs(plan.commitArgs()))
+		}
+
+		if outdated > 0 {
+			cmdutil.SilenceFailure(cmd)
+			return fmt.Errorf("%d of %d pages are behind their English version", outdated, len(args))
+		}
+		return nil
+	},
+}
+
+// sameCommit reports whether a recorded commit, full or abbreviated, is
+// the full commit hash
+func sameCommit(recorded, hash string) bool {
+	return len(recorded) >= 7 && strings.HasPrefix(hash, recorded)
+}
+
+func init() {
+	docsCmd.AddCommand(syncRefCmd)
+
+	syncRefCmd.Flags().Bool("check", false, "Verify that the recorded English version is current instead of recording it")
+}
@@ -0,0 +1,225 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/cmd/format"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/quality/checker"
+	qualitylocale "github.com/samzong/mm/internal/quality/locale"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd checks translations the way the website CI does before they
+// are pushed
+var verifyCmd = &cobra.Command{
+	Use:   "verify [file-path]...",
+	Short: "Check translations for CI failures before pushing",
+	Long: `Check translated pages before pushing them, so problems show up locally
+rather than on the pull request:
+
+  1. The format rules of mm format k8s, in check mode (Chinese only)
+  2. The Chinese quality checks of mm quality chinese (Chinese only)
+  3. Hugo shortcodes, heading anchors and assets, with the images the
+     English page replaced or added
+  4. That the headings, code blocks, shortcodes and tables match the
+     English page, as mm quality structure does
+  5. That the English pages didn't change on --branch since the translation
+     started, with the workflow --run in progress or where the branch
+     forked, showing the changes to sync too
+  6. With --hugo, a hugo --minify build of the site in English and the
+     localization only, and that each docs page rendered
+
+Without files, it checks the pages of the localization changed on the
+branch since it forked from --branch, and the uncommitted ones. It fails when
+any check finds a problem; quality warnings and English changes are shown
+but don't fail.
+
+Examples:
+  mm k8s docs verify
+  mm k8s docs verify content/zh-cn/docs/concepts/overview/_index.md
+  mm k8s docs verify --hugo --branch dev-1.32`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, _ := branchFlagValue(cmd)
+		hugo, _ := cmd.Flags().GetBool("hugo")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		loc = loc.onBranch(base)
+		if err := checkBranch(loc); err != nil {
+			return err
+		}
+		base = loc.baseRef()
+		if hugo && !k8sdocs.HasHugo() {
+			return fmt.Errorf("hugo not found in PATH; install Hugo extended to build the site")
+		}
+
+		files := args
+		if len(files) == 0 {
+			if files, err = k8sdocs.BranchFiles(loc.contentDir(), base); err != nil {
+				return err
+			}
+		}
+		var pages []string
+		for _, file := range files {
+			if !k8sdocs.IsPage(file) {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				return fmt.Errorf("file not found: %s", file)
+			}
+			pages = append(pages, file)
+		}
+		if len(pages) == 0 {
+			fmt.Printf("No changed pages in %s to verify\n", loc.contentDir())
+			return nil
+		}
+
+		problems, err := verifyPages(loc, pages)
+		if err != nil {
+			return err
+		}
+		verifyUpstream(loc, pages, base)
+		if hugo {
+			problems += verifyBuild(loc, pages)
+		}
+
+		if problems > 0 {
+			cmdutil.SilenceFailure(cmd)
+			return fmt.Errorf("found %d problems in %d pages", problems, len(pages))
+		}
+		fmt.Printf("✅ %d pages verified\n", len(pages))
+		return nil
+	},
+}
+
+// verifyPages runs the format rules and quality checks of verify on pages
+// and returns the number of problems
+func verifyPages(loc locale, pages []string) (int, error) {
+	chinese := qualitylocale.IsChinese(loc.code)
+	problems := 0
+	if chinese {
+		count, err := verifyFormat(pages)
+		if err != nil {
+			return 0, err
+		}
+		problems += count
+	}
+	checkers := []checker.Checker{checker.NewShortcodeChecker(), checker.NewAnchorChecker(), checker.NewAssetChecker(), checker.NewStructureChecker()}
+	if chinese {
+		checkers = append([]checker.Checker{checker.NewChineseChecker()}, checkers...)
+	}
+	for _, c := range checkers {
+		count, err := verifyQuality(c, pages)
+		if err != nil {
+			return 0, err
+		}
+		problems += count
+	}
+	return problems, nil
+}
+
+// verifyFormat reports the changes mm format k8s would make to pages and
+// returns their number
+func verifyFormat(pages []string) (int, error) {
+	changes := 0
+	var unformatted []string
+	for _, page := range pages {
+		if !strings.HasSuffix(page, ".md") {
+			continue
+		}
+		pageChanges, err := format.Check(page, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check the format of %s: %w", page, err)
+		}
+		for _, change := range pageChanges {
+			fmt.Printf("%s:%d: %s (%s)\n", page, change.Line, change.Description, change.Rule)
+		}
+		if len(pageChanges) > 0 {
+			unformatted = append(unformatted, page)
+		}
+		changes += len(pageChanges)
+	}
+	fmt.Printf("== format: %d changes needed in %d pages ==\n", changes, len(unformatted))
+	for _, page := range unformatted {
+		fmt.Printf("  $ mm format k8s %s --apply\n", page)
+	}
+	fmt.Println()
+	return changes, nil
+}
+
+// verifyQuality reports the issues a quality checker finds in pages and
+// returns the number of errors
+func verifyQuality(c checker.Checker, pages []string) (int, error) {
+	if err := c.SetProject("k8s"); err != nil {
+		return 0, err
+	}
+	errors, warnings := 0, 0
+	for _, page := range pages {
+		issues, err := c.CheckFile(page)
+		if err != nil {
+			return 0, err
+		}
+		for _, issue := range issues {
+			if issue.Severity.AtLeast(checker.ErrorSeverity) {
+				errors++
+			} else {
+				warnings++
+			}
+			fmt.Printf("%s:%d:%d: %s: %s\n", issue.File, issue.Line, issue.Column, issue.Severity, issue.Message)
+		}
+	}
+	fmt.Printf("== %s: %d errors, %d warnings ==\n\n", c.Type(), errors, warnings)
+	return errors, nil
+}
+
+// verifyUpstream shows the changes of the English pages on base since the
+// translation of each page started
+func verifyUpstream(loc locale, pages []string, base string) {
+	noteStaleRef(base)
+	stale := 0
+	for _, page := range pages {
+		change, err := k8sdocs.StaleSince(loc.upstream(page), workflowHead(loc, page), base)
+		if err != nil {
+			fmt.Printf("%v\n== upstream: not checked ==\n\n", err)
+			return
+		}
+		if change == nil {
+			continue
+		}
+		stale++
+		fmt.Printf("%s: %s changed on %s since the translation started (+%d -%d lines)\n%s\n",
+			page, change.Upstream, base, change.Added, change.Deleted, change.Diff)
+	}
+	fmt.Printf("== upstream: %d pages behind %s ==\n\n", stale, base)
+}
+
+// verifyBuild builds the site with Hugo and reports the pages that failed
+// to render, returning 1 for a failed build or the number of those pages
+func verifyBuild(loc locale, pages []string) int {
+	fmt.Printf("Building the site in en and %s with hugo --minify...\n", loc.code)
+	missing, err := k8sdocs.BuildSite(loc.code, pages)
+	if err != nil {
+		fmt.Printf("%v\n== hugo: build failed ==\n\n", err)
+		return 1
+	}
+	for _, page := range missing {
+		fmt.Printf("%s: not rendered by Hugo\n", page)
+	}
+	fmt.Printf("== hugo: %d pages not rendered ==\n\n", len(missing))
+	return len(missing)
+}
+
+func init() {
+	docsCmd.AddCommand(verifyCmd)
+
+	addBranchFlag(verifyCmd, "Branch the changed pages are found against (default main)")
+	verifyCmd.Flags().Bool("hugo", false, "Also build the site with hugo --minify (slow)")
+}
@@ -0,0 +1,271 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/samzong/mm/internal/cache"
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/spf13/cobra"
+)
+
+// watchState is the last upstream commit watch checked, kept between runs
+type watchState struct {
+	Ref       string    `json:"ref"`
+	Commit    string    `json:"commit"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// watchCmd notifies when translated pages fall out of sync
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Get notified when pages you translated fall out of sync",
+	Long: `Watch the English pages upstream and notify you when pages you translated
+change, so that you can update the translations early.
+
+watch fetches the main branch of the upstream remote (upstream, or origin
+when there is none) and compares it with the commit it checked last time,
+which is remembered between runs. Pages you translated are the localized
+pages you committed, found by your git user.email; use --all to watch
+every translated page.
+
+Notifications go to the console by default, to desktop notifications
+(osascript on macOS, notify-send on Linux) and to webhooks, which receive
+a JSON body with a Slack-compatible text field.
+
+Examples:
+  mm k8s docs watch                                # Check every hour
+  mm k8s docs watch --interval 30m --notify console,desktop
+  mm k8s docs watch --once --notify webhook --webhook https://hooks.slack.com/...`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		once, _ := cmd.Flags().GetBool("once")
+		remote, _ := cmd.Flags().GetString("remote")
+		branch, _ := cmd.Flags().GetString("branch")
+		noFetch, _ := cmd.Flags().GetBool("no-fetch")
+		all, _ := cmd.Flags().GetBool("all")
+		author, _ := cmd.Flags().GetString("author")
+		channels, _ := cmd.Flags().GetStringSlice("notify")
+		webhook, _ := cmd.Flags().GetString("webhook")
+		loc, err := resolveLocale(cmd)
+		if err != nil {
+			return err
+		}
+
+		if !isK8sProject() {
+			return notProjectError()
+		}
+		if err := checkLocaleDir(loc); err != nil {
+			return err
+		}
+		if interval < time.Minute && !once {
+			return fmt.Errorf("--interval must be at least 1m")
+		}
+		notifier, err := newNotifier(channels, webhook)
+		if err != nil {
+			return err
+		}
+
+		if branch == "" {
+			branch = k8sdocs.DefaultBranch
+		}
+		if remote == "" {
+			remote = "upstream"
+			if !k8sdocs.RemoteExists(remote) {
+				remote = "origin"
+			}
+		}
+		ref := remote + "/" + branch
+		if k8sdocs.Offline() && !noFetch {
+			noteOffline("%s not fetched, checking it as last fetched", ref)
+			noFetch = true
+		}
+		if !all && author == "" {
+			author = k8sdocs.GitConfig("user.email")
+			if author == "" {
+				return fmt.Errorf("no git user.email configured; use --author or --all")
+			}
+		}
+
+		state, err := loadWatchState(loc)
+		if err != nil {
+			return err
+		}
+		if state.Ref != ref {
+			// Start over when watching another branch
+			state = &watchState{Ref: ref}
+		}
+
+		for {
+			if err := checkUpstream(loc, state, notifier, !noFetch, remote, branch, author); err != nil {
+				if once {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			if err := saveWatchState(loc, state); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save watch state: %v\n", err)
+			}
+			if once {
+				return nil
+			}
+			time.Sleep(interval)
+		}
+	},
+}
+
+// checkUpstream fetches upstream and notifies about the translated pages
+// whose English original changed since the last check
+func checkUpstream(loc locale, state *watchState, notifier *notifier, fetch bool, remote, branch, author string) error {
+	if fetch {
+		if err := k8sdocs.Fetch(remote, branch); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", state.Ref, err)
+		}
+	}
+	head, err := k8sdocs.RevParse(state.Ref)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", state.Ref, err)
+	}
+	defer func() {
+		state.Commit = head
+		state.CheckedAt = time.Now()
+	}()
+
+	if state.Commit == "" {
+		fmt.Printf("Watching %s from %s for changes to pages of %s\n", state.Ref, shortHash(head), loc.contentDir())
+		return nil
+	}
+	if head == state.Commit {
+		return nil
+	}
+
+	changed, err := k8sdocs.ChangedUpstream(state.Commit, head)
+	if err != nil {
+		return err
+	}
+	watched, err := watchedPages(loc, author)
+	if err != nil {
+		return err
+	}
+
+	var outdated []string
+	for _, upstream := range changed {
+		localized := loc.localize(upstream)
+		if watched[localized] {
+			outdated = append(outdated, localized)
+		}
+	}
+	if len(outdated) == 0 {
+		return nil
+	}
+	sort.Strings(outdated)
+
+	notifier.notify(notification{
+		Title: "mm k8s docs watch",
+		Text: fmt.Sprintf("%d translated %s pages fell out of sync with %s (%s..%s)",
+			len(outdated), loc.code, state.Ref, shortHash(state.Commit), shortHash(head)),
+		Lang:  loc.code,
+		Pages: outdated,
+	})
+	return nil
+}
+
+// watchedPages returns the localized pages an author committed, or every
+// localized page when author is empty
+func watchedPages(loc locale, author string) (map[string]bool, error) {
+	pages := make(map[string]bool)
+	if author == "" {
+		err := filepath.WalkDir(filepath.FromSlash(loc.contentDir()), func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				pages[filepath.ToSlash(path)] = true
+			}
+			return err
+		})
+		return pages, err
+	}
+
+	contributions, err := k8sdocs.Contributions(author, loc.code, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	for _, contribution := range contributions {
+		for _, file := range contribution.Files {
+			pages[file] = true
+		}
+	}
+	return pages, nil
+}
+
+// shortHash abbreviates a commit hash
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// getWatchStatePath returns the state file of watch for a localization. It
+// is kept next to the cache entries rather than among them, so clearing the
+// caches doesn't make watch report every change again.
+func getWatchStatePath(loc locale) (string, error) {
+	entriesDir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(entriesDir), fmt.Sprintf("k8s-docs-watch-%s.json", loc.code)), nil
+}
+
+// loadWatchState reads the state of the last watch, empty on the first run
+func loadWatchState(loc locale) (*watchState, error) {
+	stateFile, err := getWatchStatePath(loc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return &watchState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state watchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return &watchState{}, nil
+	}
+	return &state, nil
+}
+
+// saveWatchState writes the state of watch for the next run
+func saveWatchState(loc locale, state *watchState) error {
+	stateFile, err := getWatchStatePath(loc)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(stateFile, data, 0644)
+}
+
+func init() {
+	docsCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().Duration("interval", time.Hour, "Time between checks")
+	watchCmd.Flags().Bool("once", false, "Check once and exit, e.g. from cron")
+	watchCmd.Flags().String("remote", "", "Remote to fetch (default upstream, or origin when there is none)")
+	watchCmd.Flags().String("branch", "", "Branch of the remote to watch (default main)")
+	watchCmd.Flags().Bool("no-fetch", false, "Don't fetch, only compare with the local remote-tracking branch")
+	watchCmd.Flags().Bool("all", false, "Watch every translated page, not only yours")
+	watchCmd.Flags().String("author", "", "Watch the pages of this commit author (default git user.email)")
+	watchCmd.Flags().StringSlice("notify", []string{notifyConsole}, "Notification channels (console, desktop, webhook)")
+	watchCmd.Flags().String("webhook", "", "URL to post notifications to with --notify webhook")
+}
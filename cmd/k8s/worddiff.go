@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/samzong/mm/internal/k8sdocs"
+	"github.com/samzong/mm/internal/tui"
+)
+
+// Diff views of a single page in lsync
+const (
+	diffWord = "word"
+	diffLine = "line"
+)
+
+// Styles of the word diff on a terminal
+const (
+	styleDeleted  = "\x1b[31;9m"
+	styleInserted = "\x1b[32m"
+	styleHeader   = "\x1b[36m"
+)
+
+// checkDiffMode reports an error for an unknown diff view
+func checkDiffMode(mode string) error {
+	switch mode {
+	case diffWord, diffLine:
+		return nil
+	}
+	return fmt.Errorf("unsupported diff view: %s (use word or line)", mode)
+}
+
+// printWordDiff shows the paragraphs of the English page that changed since
+// the translation, with the changed words highlighted, and optionally the
+// current translation of each paragraph next to it
+func printWordDiff(loc locale, localized string, sideBySide bool) error {
+	page, changes, err := k8sdocs.UpstreamChanges(localized, loc.code)
+	if err != nil {
+		return err
+	}
+	color := tui.IsTerminalOutput()
+	width := 0
+	if color || sideBySide {
+		width = tui.Width()
+	}
+	if page.RenamedFrom != "" {
+		fmt.Printf("%s was renamed from %s\n", page.Upstream, page.RenamedFrom)
+		if page.Path != loc.localize(page.Upstream) {
+			fmt.Printf("  %s\n", orphanSuggestion(loc, k8sdocs.Orphan{Path: page.Path, MovedTo: page.Upstream}))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Printf("No English changes since the last translation\n")
+		return nil
+	}
+	fmt.Printf("%s: %d paragraphs changed since %s (+%d -%d lines)\n",
+		page.Upstream, len(changes), shortHash(page.SyncCommit), page.Added, page.Deleted)
+
+	for _, change := range changes {
+		fmt.Println()
+		fmt.Println(paint(changeHeader(change), styleHeader, color))
+
+		spans := make([]tui.Span, 0, len(change.Edits))
+		for _, edit := range change.Edits {
+			spans = append(spans, editSpan(edit, color))
+		}
+		if !sideBySide {
+			for _, line := range tui.Wrap(spans, width) {
+				fmt.Println(line)
+			}
+			continue
+		}
+
+		column := (width - 3) / 2
+		translation := change.Translation
+		switch {
+		case change.Old.Text == "":
+			translation = "(new paragraph)"
+		case translation == "":
+			translation = "(no " + loc.code + " paragraph found)"
+		}
+		english := tui.Wrap(spans, column)
+		localizedLines := tui.Wrap([]tui.Span{{Text: translation}}, column)
+		for _, line := range tui.SideBySide(english, localizedLines, column) {
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+// changeHeader describes where a paragraph changed
+func changeHeader(change k8sdocs.ParagraphChange) string {
+	switch {
+	case change.Old.Text == "":
+		return fmt.Sprintf("@@ line %d, added", change.New.Line)
+	case change.New.Text == "":
+		return fmt.Sprintf("@@ removed, was line %d", change.Old.Line)
+	}
+	if change.New.Line != change.Old.Line {
+		return fmt.Sprintf("@@ line %d, changed (was line %d)", change.New.Line, change.Old.Line)
+	}
+	return fmt.Sprintf("@@ line %d, changed", change.New.Line)
+}
+
+// editSpan returns how a word edit is drawn: in color on a terminal, with
+// the markers of git diff --word-diff=plain otherwise
+func editSpan(edit k8sdocs.WordEdit, color bool) tui.Span {
+	switch edit.Op {
+	case k8sdocs.EditDelete:
+		if color {
+			return tui.Span{Text: edit.Text, Style: styleDeleted}
+		}
+		return tui.Span{Text: "[-" + edit.Text + "-]"}
+	case k8sdocs.EditInsert:
+		if color {
+			return tui.Span{Text: edit.Text, Style: styleInserted}
+		}
+		return tui.Span{Text: "{+" + edit.Text + "+}"}
+	}
+	return tui.Span{Text: edit.Text}
+}
+
+// paint styles text when colors are on
+func paint(text, style string, color bool) string {
+	if !color {
+		return text
+	}
+	return style + text + "\x1b[0m"
+}
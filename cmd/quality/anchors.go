@@ -0,0 +1,36 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// anchorsCmd represents the anchor check command
+var anchorsCmd = &cobra.Command{
+	Use:   "anchors [files/directories...]",
+	Short: "Check for duplicate headings and colliding anchors",
+	Long: `Check the anchors of markdown pages as Hugo renders them.
+
+A heading that repeats an earlier one gets a numbered anchor (#example-1),
+which silently moves to another section when headings are added or removed.
+An explicit {#id} or HTML id that is defined twice, or that takes the anchor
+of another heading, breaks deep links outright. Every issue suggests a unique
+anchor, qualified by the enclosing heading where possible; --fix pins
+repeated headings to it with an explicit {#id}.
+
+Rules:
+  anchor-duplicate  Heading repeats an earlier heading and gets a numbered anchor
+  anchor-collision  Anchor is defined twice or taken by an explicit {#id}
+
+Examples:
+  mm quality anchors content/en/docs/        # Check directory recursively
+  mm quality anchors --fix content/en/docs/  # Add explicit anchors to repeated headings`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecker(cmd, checker.NewAnchorChecker(), args)
+	},
+}
+
+func init() {
+	addCheckFlags(anchorsCmd)
+}
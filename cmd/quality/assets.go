@@ -0,0 +1,52 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// assetsCmd represents the asset check command
+var assetsCmd = &cobra.Command{
+	Use:   "assets [files/directories...]",
+	Short: "Check that referenced images and assets exist",
+	Long: `Check that the images and other assets referenced by markdown pages exist.
+
+Markdown images, HTML img/video/audio/source tags and the src, image and poster
+parameters of Hugo shortcodes such as figure are resolved the way Hugo
+publishes them: relative to the page or its page bundle, and site-absolute
+paths under static/ or the content directory. Localized pages share the
+resources of the English page bundle they translate.
+
+When a localized page references a missing asset that its English page no
+longer uses, the asset was removed or moved upstream and the translation needs
+to catch up; if the English page references a file of the same name
+elsewhere, that path is suggested.
+
+Localized pages are compared with their English page too, by file name and
+leaving out HTML comments and the translation's own assets, such as
+/images/docs/zh-cn/ or files only its page bundle has. An asset the English
+page no longer references is paired with one the English page added in its
+place, and --fix follows the new one; the files of a localized page bundle
+that differ from the English bundle's are shown too. These are warnings.
+
+Rules:
+  asset-missing            Referenced image or asset does not exist
+  asset-removed-upstream   Localized page references an asset the English page no longer uses
+  asset-replaced-upstream  Localized page references an asset the English page replaced with another
+  asset-unused-upstream    Localized page references an existing asset the English page no longer uses
+  asset-added-upstream     English page references an asset its translation doesn't
+  asset-bundle-outdated    File of a localized page bundle differs from the English bundle's
+
+Examples:
+  mm quality assets content/en/docs/          # Check directory recursively
+  mm quality assets content/zh-cn/docs/       # Find images removed upstream
+  mm quality assets --fix content/zh-cn/docs/ # Follow assets moved or replaced upstream`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecker(cmd, checker.NewAssetChecker(), args)
+	},
+}
+
+func init() {
+	addCheckFlags(assetsCmd)
+}
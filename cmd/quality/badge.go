@@ -0,0 +1,79 @@
+package quality
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// badgeCmd writes a documentation quality badge
+var badgeCmd = &cobra.Command{
+	Use:   "badge [files/directories...]",
+	Short: "Generate a documentation quality badge",
+	Long: `Score the documentation and write a shields.io endpoint badge.
+
+The score falls from 100 as issues per 1000 words grow, weighting errors 3,
+warnings 1 and info 0.25: 10 weighted issues per 1000 words score 50. The
+checkers are chosen as in mm quality check.
+
+Commit the badge JSON, or publish it from CI, and point shields.io at it:
+
+  ![docs quality](https://img.shields.io/endpoint?url=<raw URL of shields.json>)
+
+Examples:
+  mm quality badge docs/ --output shields.json
+  mm quality badge -c spell,links --label "docs" content/en/ -o badge.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		label, _ := cmd.Flags().GetString("label")
+
+		checkers, err := selectCheckers(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		run, err := runChecks(cmd, checkers, args)
+		if err != nil {
+			return err
+		}
+
+		words, err := checker.CountWords(run.files)
+		if err != nil {
+			return err
+		}
+		score := checker.ComputeScore(run.result, words)
+
+		if output == "" || output == "-" {
+			return score.OutputShields(os.Stdout, label)
+		}
+
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create badge %s: %w", output, err)
+		}
+		if err := score.OutputShields(file, label); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write badge %s: %w", output, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Score %d (%d issues in %d words, %.2f weighted per 1000 words), written to %s\n",
+			score.Value, score.Issues, score.Words, score.Density, output)
+		return nil
+	},
+}
+
+func init() {
+	badgeCmd.Flags().StringP("project", "p", "", "Project type (k8s, go, docker, hugo, docusaurus, mkdocs, sphinx, vitepress, generic or an adapter in .mm.yaml)")
+	badgeCmd.Flags().StringSliceP("checkers", "c", defaultCheckers, "Checkers to score with (built-in or plugin names)")
+	badgeCmd.Flags().StringP("output", "o", "", "Badge JSON file (default stdout)")
+	badgeCmd.Flags().String("label", "docs quality", "Badge label")
+	badgeCmd.Flags().StringSlice("severity", nil, "Override the severity of a rule as rule=level, where level is error, warning, info or off; repeatable")
+	badgeCmd.Flags().BoolP("verbose", "v", false, "Verbose output")
+	badgeCmd.Flags().Bool("no-cache", false, "Ignore cached results and check everything again")
+}
@@ -0,0 +1,42 @@
+package quality
+
+import (
+	"fmt"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// casingCmd represents the proper noun casing command
+var casingCmd = &cobra.Command{
+	Use:   "casing [files/directories...]",
+	Short: "Check the casing of product names and terms",
+	Long: `Check that known product names and terms keep their canonical casing,
+such as GitHub, Kubernetes, kubectl and DaemonSet.
+
+Terms come from the project type (Kubernetes projects know kubectl,
+DaemonSet, ConfigMap, ...) and from the mixed-case words of the project's
+dictionaries. A miscased term is reported with its canonical form, which
+--fix applies. Words in capitals, host names, paths and hyphenated names
+are not checked.
+
+Examples:
+  mm quality casing docs/                        # Check directory recursively
+  mm quality casing --project=k8s content/en/    # Use the Kubernetes terms
+  mm quality casing --fix README.md              # Apply the canonical casing`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		casingChecker, err := checker.NewCasingChecker()
+		if err != nil {
+			return fmt.Errorf("failed to initialize casing checker: %w", err)
+		}
+		casingChecker.DataKeys, _ = cmd.Flags().GetStringSlice("data-keys")
+
+		return runChecker(cmd, casingChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(casingCmd)
+	casingCmd.Flags().StringSlice("data-keys", nil, "Glob patterns of the keys checked in data files (default title, description, summary, message, ...)")
+}
@@ -0,0 +1,126 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// defaultCheckers are run by the check command unless --checkers is given
+var defaultCheckers = []string{"spell", "markdown", "links"}
+
+// checkCmd runs several checkers in one pass
+var checkCmd = &cobra.Command{
+	Use:   "check [files/directories...]",
+	Short: "Run several quality checkers in one pass",
+	Long: `Run a set of quality checkers over the same files and report their issues
+together, with one section per checker and a single exit status.
+
+Built-in checkers: spell, markdown, links, anchors, assets, shortcodes,
+fences, structure, chinese, zh-variant, cjk-leak, terminology (terms),
+frontmatter, grammar, casing. Each runs with its default settings; use the individual
+commands for checker-specific flags. --list shows them all, including
+plugins. Without --checkers, the checkers listed in .mm.yaml run.
+
+Plugins are executables named mm-quality-<name> in PATH, selected with
+--checkers <name>. mm writes a JSON request to the plugin's stdin:
+
+  {"version": 1, "project_type": "k8s", "files": ["docs/a.md"]}
+
+and reads the issues from its stdout, in the same form as --format json:
+
+  {"rules": [{"id": "acme-banned-word", "severity": "error",
+              "description": "Word is banned by the style guide"}],
+   "issues": [{"file": "docs/a.md", "line": 3, "column": 5,
+               "rule_id": "acme-banned-word", "message": "...",
+               "word": "...", "suggestions": ["..."]}]}
+
+Examples:
+  mm quality check docs/                                  # spell, markdown and links
+  mm quality check --checkers spell,chinese content/zh-cn/
+  mm quality check -c spell,markdown,frontmatter --format sarif docs/ > quality.sarif
+  mm quality check --format rdjson docs/ | reviewdog -f=rdjson -reporter=github-pr-review
+  mm quality check -c spell,acme docs/                    # Also run the mm-quality-acme plugin
+  mm quality check --list                                 # Show available checkers`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, _ := cmd.Flags().GetBool("list")
+		if list {
+			listCheckers()
+			return nil
+		}
+
+		checkers, err := selectCheckers(cmd, args)
+		if err != nil {
+			return err
+		}
+		return runCheckers(cmd, checkers, args)
+	},
+}
+
+// selectCheckers creates the checkers named by --checkers, or by the project
+// config when the flag is not given
+func selectCheckers(cmd *cobra.Command, args []string) ([]checker.Checker, error) {
+	names, _ := cmd.Flags().GetStringSlice("checkers")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+
+	if !cmd.Flags().Changed("checkers") {
+		cfg, err := loadQualityConfig(args, false)
+		if err != nil {
+			return nil, err
+		}
+		if cfg != nil && len(cfg.Quality.Checkers) > 0 {
+			names = cfg.Quality.Checkers
+		}
+	}
+
+	var checkers []checker.Checker
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		c, err := checker.NewChecker(name)
+		if err != nil {
+			return nil, err
+		}
+		if linkChecker, ok := c.(*checker.LinkChecker); ok {
+			linkChecker.UseCache = !noCache
+		}
+		checkers = append(checkers, c)
+	}
+	if len(checkers) == 0 {
+		return nil, fmt.Errorf("no checkers selected")
+	}
+	return checkers, nil
+}
+
+// listCheckers prints the built-in checkers and the plugins found in PATH
+func listCheckers() {
+	for _, registration := range checker.Checkers() {
+		name := registration.Name
+		if len(registration.Aliases) > 0 {
+			name += " (" + strings.Join(registration.Aliases, ", ") + ")"
+		}
+		fmt.Printf("%-24s %s\n", name, registration.Description)
+	}
+	for _, name := range checker.ExternalCheckerNames() {
+		fmt.Printf("%-24s plugin mm-quality-%s\n", name, name)
+	}
+}
+
+func init() {
+	addCheckFlags(checkCmd)
+	checkCmd.Flags().StringSliceP("checkers", "c", defaultCheckers, "Checkers to run (built-in or plugin names)")
+	checkCmd.Flags().Bool("list", false, "List the available checkers and exit")
+}
@@ -0,0 +1,35 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// chineseCmd represents the Chinese style check command
+var chineseCmd = &cobra.Command{
+	Use:   "chinese [files/directories...]",
+	Short: "Check Chinese writing style",
+	Long: `Check Chinese documents with zhlint-style rules.
+
+Code blocks, inline code, front matter, URLs, shortcodes and HTML comments
+are skipped. Suggestions match what 'mm format k8s' would produce.
+
+Rules:
+  zh-spacing               Missing space between Chinese and English text
+  zh-punctuation-width     Half-width punctuation in Chinese text
+  zh-de-di-de              Possible 的/地/得 confusion
+  zh-repeated-punctuation  Repeated punctuation marks
+  zh-fullwidth-alnum       Full-width digits or letters
+
+Examples:
+  mm quality chinese content/zh-cn/docs/           # Check directory recursively
+  mm quality chinese --format=json content/zh-cn/ > report.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecker(cmd, checker.NewChineseChecker(), args)
+	},
+}
+
+func init() {
+	addCheckFlags(chineseCmd)
+}
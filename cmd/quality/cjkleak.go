@@ -0,0 +1,36 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// cjkLeakCmd represents the CJK leak check command for English docs
+var cjkLeakCmd = &cobra.Command{
+	Use:   "cjk-leak [files/directories...]",
+	Short: "Find Chinese punctuation and characters in English docs",
+	Long: `Find text from localized pages that leaked into English documents, as
+happens when fixes are back-ported from zh-cn pages upstream.
+
+This is the inverse of the punctuation rule of 'mm format k8s': full-width
+punctuation gets the matching ASCII punctuation as a suggestion, full-width
+spaces a normal space. Code, URLs, shortcodes and HTML comments are skipped,
+as are files under zh*, ja* and ko* locale directories.
+
+Rules:
+  en-fullwidth-punctuation  Full-width or CJK punctuation in English text
+  en-fullwidth-space        Full-width space in English text
+  en-cjk-character          Chinese, Japanese or Korean characters in English text
+
+Examples:
+  mm quality cjk-leak content/en/docs/           # Check directory recursively
+  mm quality cjk-leak --fix content/en/          # Replace full-width punctuation`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecker(cmd, checker.NewCJKLeakChecker(), args)
+	},
+}
+
+func init() {
+	addCheckFlags(cjkLeakCmd)
+}
@@ -0,0 +1,475 @@
+package quality
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/gitdiff"
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/samzong/mm/internal/quality/collect"
+	"github.com/samzong/mm/internal/quality/detector"
+	"github.com/spf13/cobra"
+)
+
+// addCheckFlags adds the flags shared by all checker commands
+func addCheckFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("project", "p", "", "Project type (k8s, go, docker, hugo, docusaurus, mkdocs, sphinx, vitepress, generic or an adapter in .mm.yaml)")
+	cmd.Flags().StringP("format", "f", "console", "Output format (console, json, sarif, checkstyle, rdjson, rdjsonl, html)")
+	cmd.Flags().BoolP("verbose", "v", false, "Verbose output")
+	cmd.Flags().Bool("fix", false, "Rewrite files with high-confidence suggestions")
+	cmd.Flags().BoolP("interactive", "i", false, "With --fix, choose a suggestion for every issue")
+	cmd.Flags().Bool("backup", false, "With --fix, keep the original file as <file>.backup")
+	cmd.Flags().String("diff-base", "", "Only report issues on lines changed since this git ref (e.g. origin/main)")
+	cmd.Flags().Bool("no-cache", false, "Ignore cached results and check everything again")
+	cmd.Flags().Int("max-issues", 0, "Show at most this many issues in console output (0 for all)")
+	cmd.Flags().Int("max-issues-per-file", 0, "Show at most this many issues per file in console output (0 for all)")
+	cmd.Flags().Bool("stats", false, "Show the most frequent flagged words instead of the issues (console or json)")
+	cmd.Flags().String("fail-on", "error", "Exit with an error when issues of this severity or above are found (error, warning, info, never)")
+	cmd.Flags().StringSlice("severity", nil, "Override the severity of a rule as rule=level, where level is error, warning, info or off; repeatable")
+	cmd.Flags().String("filename", "", "Name reported for content read from stdin (-), also used to detect its type (default "+defaultStdinName+")")
+	cmd.Flags().StringSlice("exclude", nil, "Glob patterns of files or directories not to check, like "+collect.IgnoreFileName+" entries; repeatable")
+	cmd.Flags().StringSlice("report", nil, "Also write a report to a file as format=path (e.g. html=report.html); repeatable")
+}
+
+// runChecker detects the project, collects files from args and runs the
+// checker over them, writing the result in the requested format
+func runChecker(cmd *cobra.Command, c checker.Checker, args []string) error {
+	return runCheckers(cmd, []checker.Checker{c}, args)
+}
+
+// runCheckers runs several checkers over the same files and writes their
+// merged result. A single checker's result is written as is.
+func runCheckers(cmd *cobra.Command, checkers []checker.Checker, args []string) error {
+	// Get flags
+	outputFormat, _ := cmd.Flags().GetString("format")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	reportSpecs, _ := cmd.Flags().GetStringSlice("report")
+	fixMode, _ := cmd.Flags().GetBool("fix")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	backup, _ := cmd.Flags().GetBool("backup")
+	maxIssues, _ := cmd.Flags().GetInt("max-issues")
+	maxIssuesPerFile, _ := cmd.Flags().GetInt("max-issues-per-file")
+	stats, _ := cmd.Flags().GetBool("stats")
+
+	if !isOutputFormat(outputFormat) {
+		return fmt.Errorf("unsupported --format %q (supported: %s)", outputFormat, strings.Join(outputFormats, ", "))
+	}
+	if stats && outputFormat != "console" && outputFormat != "json" {
+		return fmt.Errorf("--stats supports console and json output, not %s", outputFormat)
+	}
+	if interactive && !fixMode {
+		return fmt.Errorf("--interactive requires --fix")
+	}
+	if fixMode && hasStdinArg(args) {
+		return fmt.Errorf("--fix cannot rewrite content read from stdin")
+	}
+
+	reports, err := parseReports(reportSpecs)
+	if err != nil {
+		return err
+	}
+
+	run, err := runChecks(cmd, checkers, args)
+	if err != nil {
+		return err
+	}
+	result := run.result
+
+	threshold, err := failThreshold(cmd, run.config)
+	if err != nil {
+		return err
+	}
+
+	// Suggestions are only found for the issues written or fixed
+	suggest := checker.SuggestFunc(checkers)
+
+	if fixMode {
+		result.FillSuggestions(suggest)
+		options := fixOptions{
+			interactive: interactive,
+			backup:      backup,
+			in:          bufio.NewReader(os.Stdin),
+			out:         os.Stderr,
+		}
+		if err := runFix(result, options); err != nil {
+			return err
+		}
+	}
+
+	// Write file reports before the console output so it stays last
+	for _, report := range reports {
+		if err := writeReportFile(result, report, suggest); err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Wrote %s report to %s\n", report.format, report.path)
+		}
+	}
+
+	// Output results
+	if stats {
+		if err := result.OutputStats(os.Stdout, outputFormat, maxIssues); err != nil {
+			return err
+		}
+		return failOnIssues(cmd, result, threshold)
+	}
+
+	console := checker.ConsoleOptions{
+		Verbose:          verbose,
+		MaxIssues:        maxIssues,
+		MaxIssuesPerFile: maxIssuesPerFile,
+		Suggest:          suggest,
+	}
+	if err := writeResult(os.Stdout, result, outputFormat, console); err != nil {
+		return err
+	}
+	return failOnIssues(cmd, result, threshold)
+}
+
+// checkRun is the outcome of running checkers over the target files
+type checkRun struct {
+	result *checker.CheckResult
+	files  []string
+	config *config.Config
+}
+
+// runChecks detects the project, collects files from args and runs the
+// checkers over them, merging their results
+func runChecks(cmd *cobra.Command, checkers []checker.Checker, args []string) (*checkRun, error) {
+	projectType, _ := cmd.Flags().GetString("project")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	diffBase, _ := cmd.Flags().GetString("diff-base")
+
+	cfg, err := loadQualityConfig(args, verbose)
+	if err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		if projectType == "" {
+			projectType = cfg.Quality.Project
+		}
+		for _, c := range checkers {
+			applyConfig(cmd, cfg, c)
+		}
+	}
+
+	overrides, err := severityOverrides(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parts of a monorepo with a project type of their own, such as a docs
+	// site next to Go code, are checked with their own adapter
+	var subtrees detector.Subtrees
+	if projectType == "" {
+		var rootProject string
+		rootProject, subtrees = detector.DetectLayout(".", detector.SubtreeDepth)
+		if len(subtrees) > 0 {
+			projectType = rootProject
+			if verbose {
+				fmt.Printf("Detected project type: %s\n", projectType)
+				for _, subtree := range subtrees {
+					fmt.Printf("Detected project type %s in %s\n", subtree.Project, subtree.Dir)
+				}
+			}
+		}
+	}
+	projectType = resolveProject(projectType, verbose)
+
+	// Set project type for the checkers
+	for _, c := range checkers {
+		if err := c.SetProject(projectType); err != nil {
+			if len(checkers) > 1 {
+				return nil, fmt.Errorf("failed to set project type for %s: %w", c.Type(), err)
+			}
+			return nil, fmt.Errorf("failed to set project type: %w", err)
+		}
+	}
+
+	// Collect files to check
+	collector, err := newCollector(cmd, cfg, projectType, subtrees, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var filesToCheck []string
+	for _, arg := range args {
+		if arg == stdinArg {
+			filename, err := readStdin(cmd)
+			if err != nil {
+				return nil, err
+			}
+			filesToCheck = append(filesToCheck, filename)
+			continue
+		}
+
+		files, err := collector.Collect(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files from %s: %w", arg, err)
+		}
+		filesToCheck = append(filesToCheck, files...)
+	}
+
+	if len(filesToCheck) == 0 {
+		return nil, fmt.Errorf("no files found to check")
+	}
+
+	// Only check files changed on the current branch
+	var changes gitdiff.Changes
+	if diffBase != "" {
+		changes, err = gitdiff.Since(diffBase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff against %s: %w", diffBase, err)
+		}
+		filesToCheck = changedFiles(filesToCheck, changes)
+		if verbose {
+			fmt.Printf("%d files changed since %s\n", len(filesToCheck), diffBase)
+		}
+	}
+
+	groups := groupByProject(filesToCheck, projectType, subtrees)
+
+	var results []*checker.CheckResult
+	for _, c := range checkers {
+		// Reuse results of unchanged files from earlier runs
+		checker.EnableCache(nil)
+		if !noCache {
+			cache, err := checker.LoadResultCache(c.Type())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Result cache disabled: %v\n", err)
+			} else {
+				checker.EnableCache(cache)
+			}
+		}
+
+		// Run the check over the files of every project type
+		var parts []*checker.CheckResult
+		for _, group := range groups {
+			if verbose {
+				fmt.Printf("Checking %d files with %s (%s project)\n", len(group.files), c.Name(), group.project)
+			}
+			if group.project != projectType || len(groups) > 1 {
+				if err := c.SetProject(group.project); err != nil {
+					return nil, fmt.Errorf("failed to set project type %s for %s: %w", group.project, c.Type(), err)
+				}
+			}
+			part, err := c.CheckFiles(group.files)
+			if err != nil {
+				return nil, fmt.Errorf("%s failed: %w", c.Type(), err)
+			}
+			parts = append(parts, part)
+		}
+		result := joinResults(parts)
+
+		applySeverityOverrides(overrides, result)
+		if changes != nil {
+			filterChangedLines(result, changes)
+		}
+		results = append(results, result)
+	}
+
+	warnUnknownRules(overrides)
+
+	result := results[0]
+	if len(results) > 1 {
+		result = checker.MergeResults(projectType, results)
+	}
+
+	return &checkRun{result: result, files: filesToCheck, config: cfg}, nil
+}
+
+// projectFiles are the files checked with the adapter of a project type
+type projectFiles struct {
+	project string
+	files   []string
+}
+
+// groupByProject splits the files by the project type of the subtree they
+// are in. The first group is that of projectType, the root project.
+func groupByProject(files []string, projectType string, subtrees detector.Subtrees) []projectFiles {
+	groups := []projectFiles{{project: projectType}}
+	index := map[string]int{projectType: 0}
+	for _, file := range files {
+		project := subtrees.ProjectOf(file, projectType)
+		i, ok := index[project]
+		if !ok {
+			i = len(groups)
+			index[project] = i
+			groups = append(groups, projectFiles{project: project})
+		}
+		groups[i].files = append(groups[i].files, file)
+	}
+	if len(groups) > 1 && len(groups[0].files) == 0 {
+		groups = groups[1:]
+	}
+	return groups
+}
+
+// joinResults combines the results of one checker over groups of files
+func joinResults(parts []*checker.CheckResult) *checker.CheckResult {
+	joined := parts[0]
+	issues := joined.Issues
+	for _, part := range parts[1:] {
+		joined.TotalFiles += part.TotalFiles
+		joined.CheckedFiles += part.CheckedFiles
+		joined.Suppressed += part.Suppressed
+		issues = append(issues, part.Issues...)
+	}
+	joined.SetIssues(issues)
+	return joined
+}
+
+// stdinArg is the argument that reads the content to check from stdin
+const stdinArg = "-"
+
+// defaultStdinName is the name of content read from stdin without --filename
+const defaultStdinName = "stdin.md"
+
+// hasStdinArg reports whether the content is read from stdin
+func hasStdinArg(args []string) bool {
+	for _, arg := range args {
+		if arg == stdinArg {
+			return true
+		}
+	}
+	return false
+}
+
+// readStdin reads the content to check from stdin and makes the checkers
+// see it under the name given by --filename, which it returns
+func readStdin(cmd *cobra.Command) (string, error) {
+	filename, _ := cmd.Flags().GetString("filename")
+	if filename == "" {
+		filename = defaultStdinName
+	}
+
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	checker.SetSource(filename, content)
+	return filename, nil
+}
+
+// changedFiles keeps the files that have changes
+func changedFiles(files []string, changes gitdiff.Changes) []string {
+	var changed []string
+	for _, file := range files {
+		if changes.Changed(file) {
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+// filterChangedLines drops the issues on lines that did not change
+func filterChangedLines(result *checker.CheckResult, changes gitdiff.Changes) {
+	var kept []checker.Issue
+	for _, issue := range result.Issues {
+		if changes.LineChanged(issue.File, issue.Line) {
+			kept = append(kept, issue)
+		}
+	}
+	result.SetIssues(kept)
+}
+
+// resolveProject returns the project type, detecting it from the working
+// directory when none is given
+func resolveProject(projectType string, verbose bool) string {
+	if projectType != "" {
+		return projectType
+	}
+
+	detectedProject, err := detector.DetectProject(".")
+	if err != nil {
+		if verbose {
+			fmt.Printf("Warning: Could not detect project type: %v\n", err)
+		}
+		return "generic"
+	}
+	if verbose {
+		fmt.Printf("Detected project type: %s\n", detectedProject)
+	}
+	return detectedProject
+}
+
+// outputFormats lists the formats accepted by --format and --report
+var outputFormats = []string{"console", "json", "sarif", "checkstyle", "rdjson", "rdjsonl", "html"}
+
+// reportSpec is a parsed --report format=path value
+type reportSpec struct {
+	format string
+	path   string
+}
+
+// parseReports parses --report values of the form format=path
+func parseReports(specs []string) ([]reportSpec, error) {
+	var reports []reportSpec
+	for _, spec := range specs {
+		format, path, ok := strings.Cut(spec, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid --report %q, expected format=path", spec)
+		}
+		if !isOutputFormat(format) {
+			return nil, fmt.Errorf("unsupported report format %q (supported: %s)", format, strings.Join(outputFormats, ", "))
+		}
+		reports = append(reports, reportSpec{format: format, path: path})
+	}
+	return reports, nil
+}
+
+// isOutputFormat checks whether a format is supported
+func isOutputFormat(format string) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// writeReportFile writes the result to a report file
+func writeReportFile(result *checker.CheckResult, report reportSpec, suggest func(*checker.Issue)) error {
+	file, err := os.Create(report.path)
+	if err != nil {
+		return fmt.Errorf("failed to create report %s: %w", report.path, err)
+	}
+
+	if err := writeResult(file, result, report.format, checker.ConsoleOptions{Suggest: suggest}); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write report %s: %w", report.path, err)
+	}
+	return file.Close()
+}
+
+// writeResult writes the result in the given format. The console options
+// only apply to console output, but for Suggest, which the other formats
+// call for every issue.
+func writeResult(w io.Writer, result *checker.CheckResult, format string, console checker.ConsoleOptions) error {
+	if format != "console" {
+		result.FillSuggestions(console.Suggest)
+	}
+	switch format {
+	case "json":
+		return result.OutputJSON(w)
+	case "sarif":
+		return result.OutputSARIF(w)
+	case "checkstyle":
+		return result.OutputCheckstyle(w)
+	case "rdjson":
+		return result.OutputRDJSON(w)
+	case "rdjsonl":
+		return result.OutputRDJSONL(w)
+	case "html":
+		return result.OutputHTML(w)
+	case "console":
+		return result.OutputConsole(w, console)
+	default:
+		return fmt.Errorf("unsupported format %q (supported: %s)", format, strings.Join(outputFormats, ", "))
+	}
+}
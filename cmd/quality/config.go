@@ -0,0 +1,126 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// loadQualityConfig discovers .mm.yaml by walking up from the first target
+// path. It returns nil when the project has none.
+func loadQualityConfig(args []string, verbose bool) (*config.Config, error) {
+	start := "."
+	if len(args) > 0 && args[0] != stdinArg {
+		start = args[0]
+	}
+
+	cfg, err := config.Discover(start)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	if verbose {
+		fmt.Printf("Using config %s\n", cfg.Path)
+	}
+	if err := registerAdapters(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// registerAdapters makes the project types defined in the config available
+// to the checkers
+func registerAdapters(cfg *config.Config) error {
+	for _, settings := range cfg.Quality.Adapters {
+		projectAdapter, err := adapter.NewCustomAdapter(settings.Name, settings.Extends)
+		if err != nil {
+			return fmt.Errorf("invalid adapter in %s: %w", cfg.Path, err)
+		}
+		projectAdapter.Dictionaries = settings.Dictionaries
+		projectAdapter.IgnorePatterns = settings.Ignore
+		projectAdapter.Rules = settings.Rules
+		projectAdapter.ProperNouns = settings.ProperNouns
+		for _, ext := range settings.Extensions {
+			ext = strings.TrimSpace(ext)
+			if ext == "" {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			projectAdapter.Extensions = append(projectAdapter.Extensions, ext)
+		}
+		adapter.Register(projectAdapter)
+	}
+	return nil
+}
+
+// flagChanged reports whether a flag exists on cmd and was set explicitly
+func flagChanged(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flags().Lookup(name)
+	return flag != nil && flag.Changed
+}
+
+// applyConfig copies the settings of the config to a checker, leaving
+// those given as flags alone
+func applyConfig(cmd *cobra.Command, cfg *config.Config, c checker.Checker) {
+	settings := cfg.Quality
+
+	switch c := c.(type) {
+	case *checker.SpellChecker:
+		if settings.Lang != "" && !flagChanged(cmd, "lang") {
+			c.Lang = settings.Lang
+		}
+		if settings.Engine != "" && !flagChanged(cmd, "engine") {
+			c.EngineName = settings.Engine
+		}
+		c.Dictionaries = append(c.Dictionaries, settings.Dictionaries...)
+		if len(settings.DataKeys) > 0 && !flagChanged(cmd, "data-keys") {
+			c.DataKeys = settings.DataKeys
+		}
+	case *checker.CasingChecker:
+		c.Dictionaries = append(c.Dictionaries, settings.Dictionaries...)
+		if len(settings.DataKeys) > 0 && !flagChanged(cmd, "data-keys") {
+			c.DataKeys = settings.DataKeys
+		}
+	case *checker.MarkdownChecker:
+		headings := settings.Headings
+		if headings.Case != "" && !flagChanged(cmd, "heading-case") {
+			c.HeadingCase = headings.Case
+		}
+		if headings.MaxLength > 0 && !flagChanged(cmd, "max-heading-length") {
+			c.MaxHeadingLength = headings.MaxLength
+		}
+		if headings.Punctuation != "" && !flagChanged(cmd, "heading-punctuation") {
+			c.HeadingPunctuation = headings.Punctuation
+		}
+		c.HeadingProperNouns = append(c.HeadingProperNouns, headings.ProperNouns...)
+	case *checker.ShortcodeChecker:
+		shortcodes := settings.Shortcodes
+		c.Known = append(c.Known, shortcodes.Known...)
+		for name, params := range shortcodes.Required {
+			if _, ok := c.RequiredParams[name]; !ok {
+				c.RequiredParams[name] = params
+			}
+		}
+	case *checker.CodeFenceChecker:
+		c.Languages = append(c.Languages, settings.Fences.Languages...)
+		if settings.Fences.RequireLanguage && !flagChanged(cmd, "require-language") {
+			c.RequireLanguage = true
+		}
+	case *checker.TerminologyChecker:
+		if settings.Glossary != "" && !flagChanged(cmd, "glossary") {
+			c.GlossaryFile = settings.Glossary
+		}
+	case *checker.FrontMatterChecker:
+		if settings.Schema != "" && !flagChanged(cmd, "schema") {
+			c.SchemaFile = settings.Schema
+		}
+	}
+}
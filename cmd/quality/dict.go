@@ -0,0 +1,348 @@
+package quality
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/dictionary"
+	"github.com/samzong/mm/internal/quality/spell"
+	"github.com/spf13/cobra"
+)
+
+// dictCmd represents the dictionary management command
+var dictCmd = &cobra.Command{
+	Use:   "dict",
+	Short: "Manage spell checking dictionaries",
+	Long: `Manage the personal and project dictionaries used by the spell checker.
+
+Words are added to and removed from the personal dictionary
+(~/.cache/mm/dictionaries/personal.txt) unless --repo selects the repository
+dictionary or --file names another dictionary file.
+
+The repository dictionary is .mm/dictionary.txt at the root of the git
+repository. Commit it so every contributor and CI share the project's
+jargon; it is loaded after the project type's dictionaries and before user
+dictionaries.
+
+Examples:
+  mm quality dict add kubelet kubeadm          # Add words to the personal dictionary
+  mm quality dict add --repo Kustomize         # Add a word to .mm/dictionary.txt
+  mm quality dict remove kubelet               # Remove a word
+  mm quality dict list                         # Show the dictionaries in use
+  mm quality dict list --words -p k8s          # Print every word of the K8s dictionaries
+  mm quality dict search 'kube*'               # Find words matching a pattern
+  mm quality dict which kubelet                # Show which dictionary knows a word
+  mm quality dict import --format cspell cspell.json    # Import cSpell words
+  mm quality dict export --format aspell -o .aspell.en.pws  # Export for aspell`,
+}
+
+// dictAddCmd adds words to a dictionary
+var dictAddCmd = &cobra.Command{
+	Use:   "add <word>...",
+	Short: "Add words to a dictionary",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := dictFile(cmd)
+		if err != nil {
+			return err
+		}
+
+		added, err := dictionary.AddWords(path, args)
+		if err != nil {
+			return err
+		}
+		for _, word := range added {
+			fmt.Printf("Added %s to %s\n", word, path)
+		}
+		if len(added) < len(args) {
+			fmt.Printf("%d word(s) already in %s\n", len(args)-len(added), path)
+		}
+		return nil
+	},
+}
+
+// dictRemoveCmd removes words from a dictionary
+var dictRemoveCmd = &cobra.Command{
+	Use:   "remove <word>...",
+	Short: "Remove words from a dictionary",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := dictFile(cmd)
+		if err != nil {
+			return err
+		}
+
+		removed, err := dictionary.RemoveWords(path, args)
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return fmt.Errorf("none of the words are in %s", path)
+		}
+		for _, word := range removed {
+			fmt.Printf("Removed %s from %s\n", word, path)
+		}
+		return nil
+	},
+}
+
+// dictListCmd lists the dictionaries in use
+var dictListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the dictionaries used for the project",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showWords, _ := cmd.Flags().GetBool("words")
+
+		manager, err := loadDictionaries(cmd)
+		if err != nil {
+			return err
+		}
+
+		for _, dict := range manager.Dictionaries() {
+			if showWords {
+				for _, word := range dict.Words {
+					fmt.Println(word)
+				}
+				continue
+			}
+			fmt.Printf("%-40s %6d words  %s (%s)\n", dict.Name, len(dict.Words), dict.Path, dict.Source)
+		}
+		return nil
+	},
+}
+
+// dictSearchCmd searches the dictionaries in use
+var dictSearchCmd = &cobra.Command{
+	Use:   "search <pattern>",
+	Short: "Search the dictionaries for words",
+	Long: `Search the dictionaries used for the project. The pattern matches any word
+containing it (case-insensitive); * and ? are shell-style wildcards.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := loadDictionaries(cmd)
+		if err != nil {
+			return err
+		}
+
+		pattern := strings.ToLower(args[0])
+		found := 0
+		for _, dict := range manager.Dictionaries() {
+			for _, word := range dict.Words {
+				if matchWord(pattern, strings.ToLower(word)) {
+					fmt.Printf("%s\t%s\n", word, dict.Name)
+					found++
+				}
+			}
+		}
+		if found == 0 {
+			return fmt.Errorf("no words match %q", args[0])
+		}
+		return nil
+	},
+}
+
+// dictWhichCmd shows where a word is defined
+var dictWhichCmd = &cobra.Command{
+	Use:   "which <word>",
+	Short: "Show which dictionaries contain a word",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		word := args[0]
+
+		manager, err := loadDictionaries(cmd)
+		if err != nil {
+			return err
+		}
+
+		dicts := manager.Which(word)
+		for _, dict := range dicts {
+			fmt.Printf("%s: %s (%s)\n", dict.Name, dict.Path, dict.Source)
+		}
+
+		builtin, err := spell.NewBuiltinEngine(spell.DefaultLang)
+		if err != nil {
+			return err
+		}
+		known := builtin.Known(word)
+		if known {
+			fmt.Printf("%s: built-in %s word list\n", word, spell.DefaultLang)
+		}
+
+		if len(dicts) == 0 && !known {
+			return fmt.Errorf("%s is not in any dictionary", word)
+		}
+		return nil
+	},
+}
+
+// dictImportCmd imports words from another tool's word list
+var dictImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import words from a cSpell, aspell, hunspell or text word list",
+	Long: `Import the words of another tool's word list into a dictionary.
+
+Formats:
+  text      one word per line
+  cspell    cspell.json or cspell.yaml (words and ignoreWords)
+  aspell    aspell personal dictionary (.pws)
+  hunspell  hunspell .dic file (affix flags are dropped)
+
+Words that mm dictionaries cannot hold (anything but letters and
+apostrophes) are skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+		words, err := dictionary.ParseWords(format, data)
+		if err != nil {
+			return err
+		}
+
+		var valid []string
+		for _, word := range words {
+			if dictionary.ValidWord(word) {
+				valid = append(valid, word)
+			}
+		}
+
+		path, err := dictFile(cmd)
+		if err != nil {
+			return err
+		}
+		added, err := dictionary.AddWords(path, valid)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %d new word(s) from %s into %s\n", len(added), args[0], path)
+		if skipped := len(words) - len(valid); skipped > 0 {
+			fmt.Printf("Skipped %d word(s) with characters other than letters and apostrophes\n", skipped)
+		}
+		return nil
+	},
+}
+
+// dictExportCmd exports a dictionary for another tool
+var dictExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a dictionary as a cSpell, aspell, hunspell or text word list",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		lang, _ := cmd.Flags().GetString("lang")
+
+		path, err := dictFile(cmd)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read dictionary %s: %w", path, err)
+		}
+		words, err := dictionary.ParseWords(dictionary.FormatText, data)
+		if err != nil {
+			return err
+		}
+
+		exported, err := dictionary.FormatWords(format, words, lang)
+		if err != nil {
+			return err
+		}
+
+		if output == "" || output == "-" {
+			_, err = os.Stdout.Write(exported)
+			return err
+		}
+		if err := os.WriteFile(output, exported, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d word(s) to %s\n", len(words), output)
+		return nil
+	},
+}
+
+// dictFile returns the dictionary file to edit
+func dictFile(cmd *cobra.Command) (string, error) {
+	path, _ := cmd.Flags().GetString("file")
+	repo, _ := cmd.Flags().GetBool("repo")
+
+	switch {
+	case path != "" && repo:
+		return "", fmt.Errorf("--file and --repo cannot be used together")
+	case path != "":
+		return path, nil
+	case repo:
+		return dictionary.RepoDictionaryPath()
+	default:
+		return dictionary.PersonalWordsPath()
+	}
+}
+
+// loadDictionaries loads the dictionaries of the selected project
+func loadDictionaries(cmd *cobra.Command) (*dictionary.Manager, error) {
+	projectType, _ := cmd.Flags().GetString("project")
+	cfg, err := loadQualityConfig(nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if projectType == "" && cfg != nil {
+		projectType = cfg.Quality.Project
+	}
+	projectType = resolveProject(projectType, false)
+
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	manager, err := dictionary.NewManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dictionary manager: %w", err)
+	}
+	if err := manager.LoadDictionaries(projectAdapter.GetDictionaries()); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// matchWord matches a lowercase word against a search pattern
+func matchWord(pattern, word string) bool {
+	if strings.ContainsAny(pattern, "*?") {
+		matched, _ := filepath.Match(pattern, word)
+		return matched
+	}
+	return strings.Contains(word, pattern)
+}
+
+func init() {
+	dictCmd.AddCommand(dictAddCmd)
+	dictCmd.AddCommand(dictRemoveCmd)
+	dictCmd.AddCommand(dictListCmd)
+	dictCmd.AddCommand(dictSearchCmd)
+	dictCmd.AddCommand(dictWhichCmd)
+	dictCmd.AddCommand(dictImportCmd)
+	dictCmd.AddCommand(dictExportCmd)
+
+	for _, cmd := range []*cobra.Command{dictAddCmd, dictRemoveCmd, dictImportCmd, dictExportCmd} {
+		cmd.Flags().String("file", "", "Dictionary file to edit (default ~/.cache/mm/dictionaries/personal.txt)")
+		cmd.Flags().Bool("repo", false, "Use the repository dictionary ("+dictionary.RepoDictionaryFile+")")
+	}
+	formatUsage := "Word list format (" + strings.Join(dictionary.Formats, ", ") + ")"
+	dictImportCmd.Flags().String("format", dictionary.FormatText, formatUsage)
+	dictExportCmd.Flags().String("format", dictionary.FormatText, formatUsage)
+	dictExportCmd.Flags().StringP("output", "o", "", "Output file (default stdout)")
+	dictExportCmd.Flags().String("lang", "en", "Language written to aspell and cSpell headers")
+	for _, cmd := range []*cobra.Command{dictListCmd, dictSearchCmd, dictWhichCmd} {
+		cmd.Flags().StringP("project", "p", "", "Project type (k8s, go, docker, hugo, docusaurus, mkdocs, sphinx, vitepress, generic or an adapter in .mm.yaml)")
+	}
+	dictListCmd.Flags().Bool("words", false, "Print the words instead of a summary")
+}
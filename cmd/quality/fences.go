@@ -0,0 +1,40 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// fencesCmd represents the code fence check command
+var fencesCmd = &cobra.Command{
+	Use:   "fences [files/directories...]",
+	Short: "Check code block languages",
+	Long: `Check the languages of fenced code blocks against those Hugo highlights: the
+names and aliases of the Chroma lexers, plus mermaid, goat and math blocks.
+Hugo renders a block with an unknown language without highlighting, so a typo
+such as ymal or trash goes unnoticed; the closest known languages are
+suggested.
+
+Rules:
+  fence-language-unknown  Code block language is not known to Chroma or Hugo
+  MD040                   Code block has no language (--require-language)
+
+Examples:
+  mm quality fences content/en/docs/                      # Check directory recursively
+  mm quality fences --require-language docs/              # Every block needs a language
+  mm quality fences --languages output,release-note docs/ # Languages of render hooks`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fenceChecker := checker.NewCodeFenceChecker()
+		fenceChecker.Languages, _ = cmd.Flags().GetStringSlice("languages")
+		fenceChecker.RequireLanguage, _ = cmd.Flags().GetBool("require-language")
+
+		return runChecker(cmd, fenceChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(fencesCmd)
+	fencesCmd.Flags().StringSlice("languages", []string{}, "Code block languages to accept besides those Hugo highlights")
+	fencesCmd.Flags().Bool("require-language", false, "Report code blocks without a language")
+}
@@ -0,0 +1,75 @@
+package quality
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/collect"
+	"github.com/samzong/mm/internal/quality/detector"
+	"github.com/samzong/mm/internal/quality/extract"
+	"github.com/spf13/cobra"
+)
+
+// newCollector sets up the file collection of the quality commands. Files
+// are collected by the document extensions, those of the project type and
+// those enabled by --code and --data. Ignored are, in increasing
+// precedence, the ignore patterns of the project type and of .mm.yaml
+// (both relative to the project root), the nearest .mmignore and --exclude
+// (relative to the working directory). Subtree projects add their
+// extensions and ignore patterns, relative to their directories.
+func newCollector(cmd *cobra.Command, cfg *config.Config, projectType string, subtrees detector.Subtrees, args []string) (*collect.Collector, error) {
+	code, _ := cmd.Flags().GetBool("code")
+	data, _ := cmd.Flags().GetBool("data")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	extensions := [][]string{collect.DocumentExtensions, projectAdapter.GetFileExtensions()}
+	subtreeAdapters := make([]adapter.ProjectAdapter, len(subtrees))
+	for i, subtree := range subtrees {
+		subtreeAdapters[i], err = adapter.GetAdapter(subtree.Project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get adapter for project type %s: %w", subtree.Project, err)
+		}
+		extensions = append(extensions, subtreeAdapters[i].GetFileExtensions())
+	}
+	if code {
+		extensions = append(extensions, extract.CodeExtensions())
+	}
+	if data {
+		extensions = append(extensions, extract.DataExtensions())
+	}
+	collector := collect.New(extensions...)
+
+	start := "."
+	if len(args) > 0 && args[0] != stdinArg {
+		start = args[0]
+	}
+
+	root := "."
+	if cfg != nil {
+		root = cfg.Dir()
+	} else if ignoreFile, ok := config.FindFile(start, collect.IgnoreFileName); ok {
+		root = filepath.Dir(ignoreFile)
+	}
+	collector.Ignore(root, projectAdapter.GetIgnorePatterns())
+	for i, subtree := range subtrees {
+		collector.Ignore(subtree.Dir, subtreeAdapters[i].GetIgnorePatterns())
+	}
+	if cfg != nil {
+		collector.Ignore(cfg.Dir(), cfg.Quality.Ignore)
+	}
+
+	if ignoreFile, ok := config.FindFile(start, collect.IgnoreFileName); ok {
+		if err := collector.IgnoreFile(ignoreFile); err != nil {
+			return nil, err
+		}
+	}
+	collector.Ignore(".", exclude)
+	return collector, nil
+}
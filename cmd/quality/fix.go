@@ -0,0 +1,152 @@
+package quality
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/samzong/mm/internal/quality/fix"
+)
+
+// fixOptions controls how issues are fixed
+type fixOptions struct {
+	interactive bool
+	backup      bool
+	in          *bufio.Reader
+	out         io.Writer
+}
+
+// issueKey identifies an issue position across fix passes
+func issueKey(issue checker.Issue) string {
+	return fmt.Sprintf("%s:%d:%d:%s:%s", issue.File, issue.Line, issue.Column, issue.RuleID, issue.Word)
+}
+
+// runFix rewrites files with suggestions and removes fixed issues from the
+// result. Without interactive mode only high-confidence suggestions are used.
+func runFix(result *checker.CheckResult, options fixOptions) error {
+	edits := make(map[string][]fix.Edit)
+	var files []string
+
+	for _, issue := range result.Issues {
+		if issue.Word == "" || len(issue.Suggestions) == 0 {
+			continue
+		}
+
+		var replacement string
+		if options.interactive {
+			choice, quit, err := promptFix(issue, options)
+			if err != nil {
+				return err
+			}
+			if quit {
+				break
+			}
+			if choice == "" {
+				continue
+			}
+			replacement = choice
+		} else {
+			top, ok := fix.HighConfidence(issue)
+			if !ok {
+				continue
+			}
+			replacement = top
+		}
+
+		if _, ok := edits[issue.File]; !ok {
+			files = append(files, issue.File)
+		}
+		edits[issue.File] = append(edits[issue.File], fix.Edit{Issue: issue, Replacement: replacement})
+	}
+
+	fixed := make(map[string]bool)
+	fixedFiles, skipped := 0, 0
+	sort.Strings(files)
+	for _, file := range files {
+		fileResult, err := fix.Apply(file, edits[file], options.backup)
+		if err != nil {
+			return err
+		}
+		if len(fileResult.Applied) > 0 {
+			fixedFiles++
+		}
+		for _, edit := range fileResult.Applied {
+			fixed[issueKey(edit.Issue)] = true
+		}
+		skipped += len(fileResult.Skipped)
+	}
+
+	remaining := result.Issues[:0]
+	for _, issue := range result.Issues {
+		if !fixed[issueKey(issue)] {
+			remaining = append(remaining, issue)
+		}
+	}
+	result.SetIssues(remaining)
+
+	fmt.Fprintf(os.Stderr, "🔧 Fixed %d issues in %d files\n", len(fixed), fixedFiles)
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Skipped %d overlapping or outdated fixes; run again to apply them\n", skipped)
+	}
+	return nil
+}
+
+// promptFix asks which suggestion to apply for an issue. It returns the
+// chosen replacement ("" to skip) and whether the user asked to quit.
+func promptFix(issue checker.Issue, options fixOptions) (string, bool, error) {
+	fmt.Fprintf(options.out, "\n%s:%d:%d - %s\n", issue.File, issue.Line, issue.Column, issue.Message)
+	if line := readLine(issue.File, issue.Line); line != "" {
+		fmt.Fprintf(options.out, "  %s\n", strings.TrimSpace(line))
+	}
+
+	for i, suggestion := range issue.Suggestions {
+		fmt.Fprintf(options.out, "  [%d] %s\n", i+1, fix.MatchCase(issue.Word, suggestion))
+	}
+	fmt.Fprintf(options.out, "  [s] skip  [c] custom  [q] quit\n")
+
+	for {
+		fmt.Fprintf(options.out, "Replace '%s' with: ", issue.Word)
+		answer, err := options.in.ReadString('\n')
+		if err != nil && answer == "" {
+			if err == io.EOF {
+				return "", true, nil
+			}
+			return "", false, fmt.Errorf("failed to read answer: %w", err)
+		}
+		answer = strings.TrimSpace(answer)
+
+		switch answer {
+		case "", "s":
+			return "", false, nil
+		case "q":
+			return "", true, nil
+		case "c":
+			fmt.Fprintf(options.out, "Custom replacement: ")
+			custom, _ := options.in.ReadString('\n')
+			return strings.TrimRight(custom, "\r\n"), false, nil
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(issue.Suggestions) {
+			return fix.MatchCase(issue.Word, issue.Suggestions[n-1]), false, nil
+		}
+		fmt.Fprintf(options.out, "Please enter 1-%d, s, c or q\n", len(issue.Suggestions))
+	}
+}
+
+// readLine returns a 1-based line of a file, or "" if it cannot be read
+func readLine(path string, lineNum int) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(content), "\n")
+	if lineNum < 1 || lineNum > len(lines) {
+		return ""
+	}
+	return lines[lineNum-1]
+}
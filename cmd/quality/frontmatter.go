@@ -0,0 +1,52 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// frontMatterCmd represents the front matter validation command
+var frontMatterCmd = &cobra.Command{
+	Use:   "frontmatter [files/directories...]",
+	Short: "Validate Hugo front matter against a schema",
+	Long: `Validate the YAML front matter of markdown files against a schema.
+
+Kubernetes projects have a built-in schema (title, content_type, weight,
+date, ...). Other projects, or projects with their own conventions, pass a
+schema file:
+
+  fields:
+    title:        {required: true, type: string}
+    content_type: {type: string, allowed: [concept, task, tutorial, reference]}
+    weight:       {type: int, min: 0, max: 1000}
+    date:         {type: date, formats: ["2006-01-02"]}
+    slug:         {type: string, pattern: "^[a-z0-9-]+$"}
+  strict: false   # true also reports fields not listed above
+  parity: [weight, content_type]
+
+Field types are string, int, number, bool, date, list and map.
+
+The fields under parity must match between a localized page, such as
+content/zh-cn/docs/foo.md, and its English page content/en/docs/foo.md;
+drift is reported as fm-drift. The k8s schema checks weight, content_type,
+min-kubernetes-server-version and aliases, since a weight that differs
+from the English page silently reorders the navigation. Aliases match
+with or without the /<lang>/ prefix.
+
+Examples:
+  mm quality frontmatter content/en/docs/                       # Built-in k8s schema
+  mm quality frontmatter content/zh-cn/docs/                    # Also compares with content/en
+  mm quality frontmatter --schema schema.yaml content/          # Custom schema`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		frontMatterChecker := checker.NewFrontMatterChecker()
+		frontMatterChecker.SchemaFile, _ = cmd.Flags().GetString("schema")
+
+		return runChecker(cmd, frontMatterChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(frontMatterCmd)
+	frontMatterCmd.Flags().String("schema", "", "Front matter schema file (YAML); defaults to the project's built-in schema")
+}
@@ -0,0 +1,56 @@
+package quality
+
+import (
+	"os"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// grammarCmd represents the grammar command
+var grammarCmd = &cobra.Command{
+	Use:   "grammar [files/directories...]",
+	Short: "Check grammar using a LanguageTool server",
+	Long: `Check grammar and style with LanguageTool.
+
+A LanguageTool server is required. Run one locally, for example:
+  docker run -d -p 8081:8010 erikvl87/languagetool
+
+The server URL can also be set with MM_LANGUAGETOOL_URL. For the hosted premium
+API set MM_LANGUAGETOOL_USERNAME and MM_LANGUAGETOOL_API_KEY.
+
+Spelling matches are disabled by default since 'mm quality spell' covers them.
+
+Examples:
+  mm quality grammar README.md
+  mm quality grammar --server https://api.languagetool.org docs/
+  mm quality grammar --lang en-GB --disable-rules WHITESPACE_RULE docs/`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server, _ := cmd.Flags().GetString("server")
+		lang, _ := cmd.Flags().GetString("lang")
+		disabledRules, _ := cmd.Flags().GetStringSlice("disable-rules")
+		includeSpelling, _ := cmd.Flags().GetBool("include-spelling")
+
+		if server == "" {
+			server = os.Getenv("MM_LANGUAGETOOL_URL")
+		}
+
+		grammarChecker := checker.NewGrammarChecker(server)
+		grammarChecker.Language = lang
+		grammarChecker.DisabledRules = disabledRules
+		if !includeSpelling {
+			grammarChecker.DisabledCategories = []string{"TYPOS"}
+		}
+
+		return runChecker(cmd, grammarChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(grammarCmd)
+	grammarCmd.Flags().String("server", "", "LanguageTool server URL (default "+checker.DefaultLanguageToolServer+")")
+	grammarCmd.Flags().String("lang", "en-US", "Language code passed to LanguageTool")
+	grammarCmd.Flags().StringSlice("disable-rules", []string{}, "LanguageTool rule IDs to disable")
+	grammarCmd.Flags().Bool("include-spelling", false, "Also report LanguageTool spelling matches")
+}
@@ -0,0 +1,90 @@
+package quality
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// linksCmd represents the links command
+var linksCmd = &cobra.Command{
+	Use:   "links [files/directories...]",
+	Short: "Check for dead links and broken anchors",
+	Long: `Check links in markdown files: external URLs, relative links between pages
+and in-page anchors.
+
+External URLs are checked concurrently with retry and backoff, and results are
+kept in the links cache for 24 hours (1 hour for failures; see mm cache to
+change that or clear it). Use --allow or an
+allowlist file (one URL prefix or glob per line) to skip known-good or
+unreachable hosts.
+
+Examples:
+  mm quality links docs/                                  # Check all links
+  mm quality links --skip-external content/zh-cn/docs/    # Only relative links and anchors
+  mm quality links --allow 'https://localhost*' docs/     # Skip matching URLs
+  mm quality links --allowlist .mm-links-allow docs/      # Read allowlist from file`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allow, _ := cmd.Flags().GetStringSlice("allow")
+		allowlistFile, _ := cmd.Flags().GetString("allowlist")
+		skipExternal, _ := cmd.Flags().GetBool("skip-external")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		retries, _ := cmd.Flags().GetInt("retries")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		if allowlistFile != "" {
+			patterns, err := readAllowlist(allowlistFile)
+			if err != nil {
+				return fmt.Errorf("failed to read allowlist: %w", err)
+			}
+			allow = append(allow, patterns...)
+		}
+
+		linkChecker := checker.NewLinkChecker()
+		linkChecker.Allowlist = allow
+		linkChecker.SkipExternal = skipExternal
+		linkChecker.Concurrency = concurrency
+		linkChecker.Retries = retries
+		linkChecker.UseCache = !noCache
+		linkChecker.SetTimeout(timeout)
+
+		return runChecker(cmd, linkChecker, args)
+	},
+}
+
+// readAllowlist reads URL patterns from a file, one per line
+func readAllowlist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, scanner.Err()
+}
+
+func init() {
+	addCheckFlags(linksCmd)
+	linksCmd.Flags().StringSlice("allow", []string{}, "URL prefixes or glob patterns to skip")
+	linksCmd.Flags().String("allowlist", "", "File with URL prefixes or glob patterns to skip, one per line")
+	linksCmd.Flags().Bool("skip-external", false, "Only check relative links and anchors")
+	linksCmd.Flags().Int("concurrency", 8, "Number of concurrent external requests")
+	linksCmd.Flags().Duration("timeout", 10*time.Second, "Timeout for each external request")
+	linksCmd.Flags().Int("retries", 2, "Retries for transient failures (with exponential backoff)")
+}
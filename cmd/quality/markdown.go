@@ -0,0 +1,56 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// markdownCmd represents the markdown lint command
+var markdownCmd = &cobra.Command{
+	Use:   "markdown [files/directories...]",
+	Short: "Lint markdown structure and style",
+	Long: `Lint markdown files with markdownlint-style rules.
+
+Rules:
+  MD001  Heading levels should only increment by one level at a time
+  MD007  Nested unordered list indentation
+  MD013  Line length
+  MD024  Duplicate headings
+  MD026  Trailing punctuation in headings (--heading-punctuation)
+  MD034  Bare URLs
+  MD036  Emphasis used instead of a heading
+
+  heading-case    Headings in sentence or title case (--heading-case)
+  heading-length  Heading length (--max-heading-length)
+
+Heading case leaves code, acronyms, camelCase names and the proper nouns of
+the project type alone; list others in the headings.proper_nouns setting of
+.mm.yaml.
+
+Examples:
+  mm quality markdown README.md                 # Lint single file
+  mm quality markdown docs/                     # Lint directory recursively
+  mm quality markdown --max-line-length=0 docs/ # Disable line length rule
+  mm quality markdown --heading-case=sentence --max-heading-length=60 docs/
+  mm quality markdown --format=json docs/ > report.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		maxLineLength, _ := cmd.Flags().GetInt("max-line-length")
+
+		markdownChecker := checker.NewMarkdownChecker()
+		markdownChecker.MaxLineLength = maxLineLength
+		markdownChecker.HeadingCase, _ = cmd.Flags().GetString("heading-case")
+		markdownChecker.MaxHeadingLength, _ = cmd.Flags().GetInt("max-heading-length")
+		markdownChecker.HeadingPunctuation, _ = cmd.Flags().GetString("heading-punctuation")
+
+		return runChecker(cmd, markdownChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(markdownCmd)
+	markdownCmd.Flags().Int("max-line-length", 120, "Maximum line length for MD013 (0 disables the rule)")
+	markdownCmd.Flags().String("heading-case", "", "Case of headings: sentence or title (empty disables the rule)")
+	markdownCmd.Flags().Int("max-heading-length", 0, "Maximum heading length (0 disables the rule)")
+	markdownCmd.Flags().String("heading-punctuation", checker.DefaultHeadingPunctuation, "Characters headings must not end with (MD026)")
+}
@@ -10,10 +10,90 @@ var QualityCmd = &cobra.Command{
 	Short: "Quality checking tools for documentation and code",
 	Long: `Quality checking tools that help improve documentation and code quality.
 Supports spell checking, grammar checking, and various file format validations.
-Automatically adapts to different project types and loads appropriate dictionaries.`,
+Automatically adapts to different project types and loads appropriate dictionaries.
+Hugo, Docusaurus, MkDocs, Sphinx and VitePress sites are detected by their
+configuration files, and their shortcodes, admonitions, containers and roles
+are not spell checked as prose.
+Clones and forks of well-known repositories (kubernetes/website, docker/docs,
+helm/helm-www) are recognized by their origin or upstream git remote, even in
+sparse checkouts.
+In a monorepo, directories up to three levels deep that are projects of their
+own (a Hugo site in website/, a Go module in sdk/) are checked with their own
+project type, unless one is set with --project or in .mm.yaml.
+
+Directories are searched for documents and the file types of the project
+type, skipping hidden entries and the paths the project type ignores (such as
+node_modules or a generated public/ site). Add ignore globs to .mm.yaml,
+to a .mmignore file (one pattern per line, "!" re-includes, found by walking up
+like .mm.yaml) or with --exclude.
+
+Settings shared by a project can be committed in .mm.yaml, found by walking
+up from the first file or directory checked. Flags override it:
+
+  quality:
+    project: k8s
+    checkers: [spell, markdown, links]   # run by mm quality check
+    lang: auto                           # or en, fr, ... for every file
+    engine: builtin
+    ignore: ["content/*/blog/**"]
+    dictionaries: [.mm/words.txt]
+    data_keys: ["*title", "*description"]  # spell checked in YAML/JSON/TOML
+    glossary: .mm/glossary.yaml
+    schema: .mm/frontmatter.yaml
+    headings:                            # heading style of mm quality markdown
+      case: sentence                     # or title
+      max_length: 60
+      punctuation: ".,;:!"
+      proper_nouns: [Pod, Service]
+    shortcodes:                          # mm quality shortcodes
+      known: [feature-state]             # defined by Hugo modules
+      required: {tab: [name]}            # by name or position ("0")
+    fences:                              # mm quality fences
+      languages: [output]                # besides those Hugo highlights
+      require_language: true
+    fail_on: error                       # exit status, like --fail-on
+    severity:                            # like --severity rule=level
+      MD013: "off"
+      spell-check: warning
+
+Teams with their own documentation platform can define project types next
+to the built-in ones (k8s, go, docker, hugo, mkdocs, ...) and select them
+with project or --project. An adapter extends a built-in one, adding
+dictionaries, ignore patterns and proper nouns; extensions replace the
+collected ones, and rules add extraction hints (ignore_shortcodes,
+ignore_admonitions, ignore_containers, ignore_roles):
+
+  quality:
+    project: acme
+    adapters:
+      - name: acme
+        extends: generic
+        dictionaries: [.mm/acme.txt]
+        ignore: ["generated/**"]
+        extensions: [.md, .adoc]
+        rules: {case_sensitive_terms: true}
+        proper_nouns: [AcmeCloud]`,
 }
 
 func init() {
 	// Add subcommands
 	QualityCmd.AddCommand(spellCmd)
-}
\ No newline at end of file
+	QualityCmd.AddCommand(markdownCmd)
+	QualityCmd.AddCommand(linksCmd)
+	QualityCmd.AddCommand(anchorsCmd)
+	QualityCmd.AddCommand(assetsCmd)
+	QualityCmd.AddCommand(shortcodesCmd)
+	QualityCmd.AddCommand(fencesCmd)
+	QualityCmd.AddCommand(structureCmd)
+	QualityCmd.AddCommand(grammarCmd)
+	QualityCmd.AddCommand(chineseCmd)
+	QualityCmd.AddCommand(zhVariantCmd)
+	QualityCmd.AddCommand(cjkLeakCmd)
+	QualityCmd.AddCommand(termsCmd)
+	QualityCmd.AddCommand(frontMatterCmd)
+	QualityCmd.AddCommand(casingCmd)
+	QualityCmd.AddCommand(dictCmd)
+	QualityCmd.AddCommand(checkCmd)
+	QualityCmd.AddCommand(badgeCmd)
+	QualityCmd.AddCommand(rulesCmd)
+}
@@ -0,0 +1,181 @@
+package quality
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd documents the rules of the built-in checkers
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List and describe the rule IDs of the checkers",
+	Long: `List the rules the built-in checkers report, with their IDs, default
+severities and examples, as registered by the checkers themselves.
+
+Rule IDs are used to suppress issues with inline comments and to change
+their severity:
+
+  <!-- mm-quality-disable-next-line MD013 -->
+  mm quality check --severity MD013=warning docs/
+
+Source code, data files and notebooks take the same directives in # and //
+comments as well, e.g. # mm-quality-disable-next-line spell.
+
+or in .mm.yaml:
+
+  quality:
+    severity:
+      MD013: "off"
+
+Examples:
+  mm quality rules                        # List every rule
+  mm quality rules list --checker markdown
+  mm quality rules list --format json
+  mm quality rules describe MD013         # Show a rule and how to configure it`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rulesListCmd.RunE(cmd, args)
+	},
+}
+
+// rulesListCmd lists the registered rules
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the rules of the checkers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checkerName, _ := cmd.Flags().GetString("checker")
+		format, _ := cmd.Flags().GetString("format")
+
+		rules := checker.AllRules()
+		if checkerName != "" {
+			names, err := checkerNames(checkerName)
+			if err != nil {
+				return err
+			}
+			var selected []checker.Rule
+			for _, rule := range rules {
+				if names[string(rule.Checker)] {
+					selected = append(selected, rule)
+				}
+			}
+			rules = selected
+		}
+
+		switch format {
+		case "console":
+			printRules(rules)
+			return nil
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if rules == nil {
+				rules = []checker.Rule{}
+			}
+			return encoder.Encode(rules)
+		default:
+			return fmt.Errorf("unsupported format: %s (use console or json)", format)
+		}
+	},
+}
+
+// rulesDescribeCmd describes a single rule
+var rulesDescribeCmd = &cobra.Command{
+	Use:   "describe <rule-id>",
+	Short: "Describe a rule and how to suppress or override it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rule, err := findRule(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n\n", rule.ID)
+		fmt.Printf("  Checker:   %s (mm quality %s)\n", rule.Checker, rule.Checker)
+		fmt.Printf("  Severity:  %s\n", rule.Severity)
+		fmt.Printf("  Checks:    %s\n", rule.Description)
+		if rule.Example != "" {
+			fmt.Printf("  Example:   %s\n", rule.Example)
+		}
+		fmt.Printf("\n  Suppress:  <!-- mm-quality-disable-next-line %s -->\n", rule.ID)
+		fmt.Printf("  Override:  --severity %s=warning, or in .mm.yaml:\n", rule.ID)
+		fmt.Printf("             quality: {severity: {%s: warning}}\n", rule.ID)
+		return nil
+	},
+}
+
+// printRules prints rules as a table grouped by checker
+func printRules(rules []checker.Rule) {
+	if len(rules) == 0 {
+		fmt.Println("No rules found")
+		return
+	}
+
+	var current checker.CheckerType
+	for _, rule := range rules {
+		if rule.Checker != current {
+			if current != "" {
+				fmt.Println()
+			}
+			current = rule.Checker
+			fmt.Printf("%s:\n", current)
+		}
+		fmt.Printf("  %-28s %-8s %s\n", rule.ID, rule.Severity, rule.Description)
+	}
+}
+
+// checkerNames returns the checker types selected by a checker name or
+// alias
+func checkerNames(name string) (map[string]bool, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, registration := range checker.Checkers() {
+		if registration.Name == name {
+			return map[string]bool{registration.Name: true}, nil
+		}
+		for _, alias := range registration.Aliases {
+			if alias == name {
+				return map[string]bool{registration.Name: true}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown checker: %s (see mm quality check --list)", name)
+}
+
+// findRule looks up a rule by ID, ignoring case, and suggests similar IDs
+// when there is none
+func findRule(id string) (checker.Rule, error) {
+	if rule, ok := checker.LookupRule(id); ok {
+		return rule, nil
+	}
+
+	var similar []string
+	for _, rule := range checker.AllRules() {
+		if strings.EqualFold(rule.ID, id) {
+			return rule, nil
+		}
+		if checker.EditDistance(strings.ToLower(rule.ID), strings.ToLower(id)) <= 2 {
+			similar = append(similar, rule.ID)
+		}
+	}
+	if len(similar) > 0 {
+		sort.Strings(similar)
+		return checker.Rule{}, fmt.Errorf("unknown rule: %s (did you mean %s?)", id, strings.Join(similar, ", "))
+	}
+	return checker.Rule{}, fmt.Errorf("unknown rule: %s (see mm quality rules list)", id)
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesDescribeCmd)
+
+	for _, cmd := range []*cobra.Command{rulesCmd, rulesListCmd} {
+		cmd.Flags().StringP("checker", "c", "", "Only list the rules of this checker")
+		cmd.Flags().StringP("format", "f", "console", "Output format (console, json)")
+	}
+}
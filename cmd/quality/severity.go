@@ -0,0 +1,116 @@
+package quality
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samzong/mm/cmd/cmdutil"
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// severityOff disables a rule in severity overrides
+const severityOff = "off"
+
+// failNever disables failing on issues with --fail-on
+const failNever = "never"
+
+// severityOverrides merges the severity overrides of the config with those
+// given by --severity, which take precedence
+func severityOverrides(cmd *cobra.Command, cfg *config.Config) (map[string]string, error) {
+	overrides := make(map[string]string)
+	if cfg != nil {
+		for id, severity := range cfg.Quality.Severity {
+			overrides[id] = severity
+		}
+	}
+
+	specs, _ := cmd.Flags().GetStringSlice("severity")
+	for _, spec := range specs {
+		id, level, ok := strings.Cut(spec, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid --severity %q, expected rule=level", spec)
+		}
+		level = strings.ToLower(level)
+		if level != severityOff {
+			if _, err := checker.ParseSeverity(level); err != nil {
+				return nil, fmt.Errorf("invalid --severity %q (use error, warning, info or off)", spec)
+			}
+		}
+		overrides[id] = level
+	}
+	return overrides, nil
+}
+
+// failThreshold returns the severity given by --fail-on, or by the config
+// when the flag is not set. It returns "" when checks never fail.
+func failThreshold(cmd *cobra.Command, cfg *config.Config) (checker.Severity, error) {
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	if !cmd.Flags().Changed("fail-on") && cfg != nil && cfg.Quality.FailOn != "" {
+		failOn = cfg.Quality.FailOn
+	}
+
+	if strings.EqualFold(failOn, failNever) {
+		return "", nil
+	}
+	threshold, err := checker.ParseSeverity(failOn)
+	if err != nil {
+		return "", fmt.Errorf("invalid --fail-on %q (use error, warning, info or never)", failOn)
+	}
+	return threshold, nil
+}
+
+// failOnIssues returns an error when the result has issues as severe as the
+// threshold or more
+func failOnIssues(cmd *cobra.Command, result *checker.CheckResult, threshold checker.Severity) error {
+	if threshold == "" {
+		return nil
+	}
+	if count := result.CountAtLeast(threshold); count > 0 {
+		cmdutil.SilenceFailure(cmd)
+		return fmt.Errorf("found %d issues with severity %s or above", count, threshold)
+	}
+	return nil
+}
+
+// applySeverityOverrides changes the severity of issues by rule and drops
+// the issues of rules turned off
+func applySeverityOverrides(overrides map[string]string, result *checker.CheckResult) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	// Keep rule metadata in reports consistent with the issues
+	for id, severity := range overrides {
+		if rule, ok := checker.LookupRule(id); ok && severity != severityOff {
+			rule.Severity = checker.Severity(severity)
+			checker.RegisterRule(rule)
+		}
+	}
+
+	var kept []checker.Issue
+	for _, issue := range result.Issues {
+		severity, ok := overrides[issue.RuleID]
+		if !ok {
+			kept = append(kept, issue)
+			continue
+		}
+		if severity == severityOff {
+			continue
+		}
+		issue.Severity = checker.Severity(severity)
+		kept = append(kept, issue)
+	}
+	result.SetIssues(kept)
+}
+
+// warnUnknownRules points out severity overrides for rules mm does not know
+func warnUnknownRules(overrides map[string]string) {
+	for id := range overrides {
+		if _, ok := checker.LookupRule(id); !ok {
+			fmt.Fprintf(os.Stderr, "Warning: Severity set for unknown rule %s\n", id)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package quality
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/samzong/mm/internal/config"
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+func TestFailThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		config  string
+		want    checker.Severity
+		wantErr bool
+	}{
+		{"default fails on errors", nil, "", checker.ErrorSeverity, false},
+		{"flag", []string{"--fail-on", "warning"}, "", checker.WarningSeverity, false},
+		{"never", []string{"--fail-on", "never"}, "", "", false},
+		{"config", nil, "info", checker.InfoSeverity, false},
+		{"flag over config", []string{"--fail-on", "never"}, "info", "", false},
+		{"invalid", []string{"--fail-on", "fatal"}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addCheckFlags(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{}
+			cfg.Quality.FailOn = tt.config
+			got, err := failThreshold(cmd, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("failThreshold() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("failThreshold() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteResultUnknownFormat(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeResult(&out, &checker.CheckResult{}, "xml", checker.ConsoleOptions{}); err == nil {
+		t.Errorf("writeResult() accepted the unknown format xml")
+	}
+	if out.Len() > 0 {
+		t.Errorf("writeResult() wrote %q for an unknown format", out.String())
+	}
+}
@@ -0,0 +1,57 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// shortcodesCmd represents the shortcode check command
+var shortcodesCmd = &cobra.Command{
+	Use:   "shortcodes [files/directories...]",
+	Short: "Check Hugo shortcode syntax and balance",
+	Long: `Check the Hugo shortcodes of markdown pages, so a broken shortcode is caught
+before it fails the site build.
+
+Shortcode names are checked against the templates in layouts/shortcodes of
+the site and its themes, plus the shortcodes built into Hugo. A template that
+uses .Inner must be closed ({{< tabs >}} ... {{< /tabs >}}); one that doesn't
+can't be. Name checks are skipped when the site has no shortcode templates on
+disk; list shortcodes provided by Hugo modules with --known.
+
+Rules:
+  shortcode-syntax         Shortcode is not terminated or its parameters are malformed
+  shortcode-unbalanced     Shortcode is not closed, or closed without being opened
+  shortcode-unknown        Shortcode has no template and is not built into Hugo
+  shortcode-missing-param  Shortcode lacks a required parameter
+
+Examples:
+  mm quality shortcodes content/en/docs/                     # Check directory recursively
+  mm quality shortcodes --require tab:name content/          # tab needs a name parameter
+  mm quality shortcodes --require glossary_tooltip:term_id --known feature-state content/`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		known, _ := cmd.Flags().GetStringSlice("known")
+		require, _ := cmd.Flags().GetStringSlice("require")
+
+		shortcodeChecker := checker.NewShortcodeChecker()
+		shortcodeChecker.Known = known
+		for _, requirement := range require {
+			name, param, ok := strings.Cut(requirement, ":")
+			if !ok || name == "" || param == "" {
+				return fmt.Errorf("invalid --require %q (use shortcode:parameter)", requirement)
+			}
+			shortcodeChecker.RequiredParams[name] = append(shortcodeChecker.RequiredParams[name], param)
+		}
+
+		return runChecker(cmd, shortcodeChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(shortcodesCmd)
+	shortcodesCmd.Flags().StringSlice("known", []string{}, "Shortcodes defined outside layouts/shortcodes, e.g. by Hugo modules")
+	shortcodesCmd.Flags().StringSlice("require", []string{}, "Required shortcode parameters as shortcode:parameter (a number for positional ones)")
+}
@@ -2,12 +2,8 @@ package quality
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/samzong/mm/internal/quality/checker"
-	"github.com/samzong/mm/internal/quality/detector"
 	"github.com/spf13/cobra"
 )
 
@@ -19,141 +15,73 @@ var spellCmd = &cobra.Command{
 Supports multiple file formats (markdown, text, etc.) and loads project-specific
 dictionaries automatically.
 
+The builtin engine needs no external tools. Use --engine=aspell or
+--engine=hunspell to check with an installed aspell or hunspell instead.
+Hunspell reads .dic/.aff dictionaries such as the ones shipped with
+LibreOffice and VS Code; pick one with --hunspell-dict.
+
+By default the language of each document is detected from its path
+(content/fr/, i18n/pt-br/, README.ja.md) and otherwise its script, so one run
+over a multilingual site checks every translation in its own language.
+Chinese, Japanese and Korean documents are checked for their English words;
+documents in languages without a dictionary are skipped with a warning. Use
+--lang to check all documents in one language (en, fr, de, es, pt, pt-br,
+...); the language is passed to aspell and selects the hunspell dictionary,
+and dictionaries/base-<lang>.txt replaces the English base dictionary.
+
+Source files (.go, .py, .js, .ts, .sh) are checked in their comments and in
+string literals that contain spaces. Name them directly, or use --code to
+collect them from directories too.
+
+MDX documents (Docusaurus) are checked in their Markdown text and the text
+inside JSX elements; imports, exports, JSX props and {expressions} are skipped.
+
+Jupyter notebooks (.ipynb) are checked in their markdown cells, and with
+--notebook-code also in the comments and strings of their code cells.
+
+YAML, JSON and TOML files (Hugo data files, Helm charts, OpenAPI specs) are
+checked in the string values of text keys such as title, description and
+message; --data-keys sets the key patterns. Name them directly, or use --data
+to collect them from directories too.
+
 Examples:
   mm quality spell README.md                    # Check single file
   mm quality spell docs/                        # Check directory recursively  
   mm quality spell content/en/docs/concepts/    # Check K8s docs (auto-detects project)
   mm quality spell --project=k8s docs/          # Explicitly use K8s dictionary
-  mm quality spell --format=json docs/ > report.json  # Output JSON format`,
+  mm quality spell --format=json docs/ > report.json  # Output JSON format
+  mm quality spell --engine=aspell docs/        # Use aspell instead of the builtin engine
+  mm quality spell --engine=hunspell --hunspell-dict=dicts/en_GB.dic docs/  # Use a hunspell dictionary
+  mm quality spell --engine=hunspell content/   # Check each translation in its language
+  mm quality spell --engine=hunspell --lang=fr content/fr/  # Check French docs
+  mm quality spell --code pkg/ docs/            # Also check comments and strings in source files
+  mm quality spell --data --data-keys 'description,*summary' api/  # Check descriptions in specs
+  git show HEAD:README.md | mm quality spell - --filename README.md  # Check content from stdin`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get flags
-		projectType, _ := cmd.Flags().GetString("project")
-		outputFormat, _ := cmd.Flags().GetString("format")
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		
 		// Initialize spell checker
 		spellChecker, err := checker.NewSpellChecker()
 		if err != nil {
 			return fmt.Errorf("failed to initialize spell checker: %w", err)
 		}
-		
-		// Auto-detect project if not specified
-		if projectType == "" {
-			detectedProject, err := detector.DetectProject(".")
-			if err != nil {
-				if verbose {
-					fmt.Printf("Warning: Could not detect project type: %v\n", err)
-				}
-				projectType = "generic"
-			} else {
-				projectType = detectedProject
-				if verbose {
-					fmt.Printf("Detected project type: %s\n", projectType)
-				}
-			}
-		}
-		
-		// Set project type for spell checker
-		if err := spellChecker.SetProject(projectType); err != nil {
-			return fmt.Errorf("failed to set project type: %w", err)
-		}
-		
-		// Collect files to check
-		var filesToCheck []string
-		for _, arg := range args {
-			files, err := collectFiles(arg)
-			if err != nil {
-				return fmt.Errorf("failed to collect files from %s: %w", arg, err)
-			}
-			filesToCheck = append(filesToCheck, files...)
-		}
-		
-		if len(filesToCheck) == 0 {
-			return fmt.Errorf("no files found to check")
-		}
-		
-		if verbose {
-			fmt.Printf("Checking %d files with %s dictionary\n", len(filesToCheck), projectType)
-		}
-		
-		// Run spell check
-		result, err := spellChecker.CheckFiles(filesToCheck)
-		if err != nil {
-			return fmt.Errorf("spell check failed: %w", err)
-		}
-		
-		// Output results
-		switch outputFormat {
-		case "json":
-			return result.OutputJSON(os.Stdout)
-		case "console":
-			fallthrough
-		default:
-			return result.OutputConsole(os.Stdout, verbose)
-		}
-	},
-}
+		spellChecker.EngineName, _ = cmd.Flags().GetString("engine")
+		spellChecker.HunspellDict, _ = cmd.Flags().GetString("hunspell-dict")
+		spellChecker.Lang, _ = cmd.Flags().GetString("lang")
+		spellChecker.DataKeys, _ = cmd.Flags().GetStringSlice("data-keys")
+		spellChecker.NotebookCode, _ = cmd.Flags().GetBool("notebook-code")
 
-// collectFiles recursively collects files to check based on supported extensions
-func collectFiles(path string) ([]string, error) {
-	var files []string
-	
-	// Supported file extensions
-	supportedExts := map[string]bool{
-		".md":   true,
-		".txt":  true,
-		".rst":  true,
-		".html": true,
-	}
-	
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, err
-	}
-	
-	if info.IsDir() {
-		// Walk directory
-		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			
-			// Skip hidden files and directories
-			if strings.HasPrefix(info.Name(), ".") {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
-			
-			// Check if file has supported extension
-			ext := strings.ToLower(filepath.Ext(filePath))
-			if supportedExts[ext] {
-				files = append(files, filePath)
-			}
-			
-			return nil
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		// Single file
-		ext := strings.ToLower(filepath.Ext(path))
-		if supportedExts[ext] {
-			files = append(files, path)
-		} else {
-			return nil, fmt.Errorf("unsupported file type: %s", ext)
-		}
-	}
-	
-	return files, nil
+		return runChecker(cmd, spellChecker, args)
+	},
 }
 
 func init() {
 	// Add flags for spell command
-	spellCmd.Flags().StringP("project", "p", "", "Project type (k8s, go, docker, generic)")
-	spellCmd.Flags().StringP("format", "f", "console", "Output format (console, json)")
-	spellCmd.Flags().BoolP("verbose", "v", false, "Verbose output")
-}
\ No newline at end of file
+	addCheckFlags(spellCmd)
+	spellCmd.Flags().String("engine", "builtin", "Spell engine (builtin, aspell, hunspell)")
+	spellCmd.Flags().String("hunspell-dict", "", "Hunspell dictionary name or .dic file (default from --lang)")
+	spellCmd.Flags().Bool("code", false, "Also collect source files from directories and check their comments and strings")
+	spellCmd.Flags().Bool("notebook-code", false, "Also check comments and strings in notebook code cells")
+	spellCmd.Flags().Bool("data", false, "Also collect YAML, JSON and TOML files from directories and check their text values")
+	spellCmd.Flags().StringSlice("data-keys", nil, "Glob patterns of the keys checked in data files (default title, description, summary, message, ...)")
+	spellCmd.Flags().String("lang", checker.LangAuto, "Document language (auto, en, fr, de, es, pt, ...)")
+}
@@ -0,0 +1,36 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// structureCmd represents the structure parity check command
+var structureCmd = &cobra.Command{
+	Use:   "structure [files/directories...]",
+	Short: "Compare the structure of translations with their English page",
+	Long: `Compare the sequence of headings, code blocks, shortcodes and tables of
+translated Hugo pages, such as content/zh-cn/docs/foo.md, with their English
+page content/en/docs/foo.md, a fast way to spot sections dropped or left
+over during translation.
+
+Headings match by level and code blocks by language, since their text is
+translated. HTML comments are skipped, so the English text k8s translations
+keep in them doesn't count. Pages without an English page are not checked.
+
+Rules:
+  structure-missing  Block of the English page is missing from the translation
+  structure-extra    Block of the translation is not in the English page
+
+Examples:
+  mm quality structure content/zh-cn/docs/concepts/
+  mm quality structure content/zh-cn/docs/tasks/tools/install-kubectl-linux.md`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChecker(cmd, checker.NewStructureChecker(), args)
+	},
+}
+
+func init() {
+	addCheckFlags(structureCmd)
+}
@@ -0,0 +1,41 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/spf13/cobra"
+)
+
+// termsCmd represents the terminology check command
+var termsCmd = &cobra.Command{
+	Use:   "terms [files/directories...]",
+	Short: "Check terminology against a glossary",
+	Long: `Check that documents use the approved terminology from a glossary.
+
+The glossary is a YAML file listing approved terms, forbidden variants and
+whether the approved term must keep its exact casing:
+
+  terms:
+    - source: container registry
+      approved: 容器镜像仓库
+      forbidden: [容器注册表, 镜像注册表]
+    - approved: Kubernetes
+      match_case: true
+      forbidden: [k8s]
+      note: spell out the project name in prose
+
+Examples:
+  mm quality terms content/zh-cn/docs/                        # Use .mm/glossary.yaml
+  mm quality terms --glossary glossary.yaml content/zh-cn/    # Use a specific glossary`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		terminologyChecker := checker.NewTerminologyChecker(nil)
+		terminologyChecker.GlossaryFile, _ = cmd.Flags().GetString("glossary")
+
+		return runChecker(cmd, terminologyChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(termsCmd)
+	termsCmd.Flags().StringP("glossary", "g", checker.DefaultGlossaryFile, "Glossary file (YAML)")
+}
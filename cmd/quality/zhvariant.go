@@ -0,0 +1,40 @@
+package quality
+
+import (
+	"github.com/samzong/mm/internal/quality/checker"
+	"github.com/samzong/mm/internal/quality/zhconv"
+	"github.com/spf13/cobra"
+)
+
+// zhVariantCmd represents the simplified/traditional Chinese check command
+var zhVariantCmd = &cobra.Command{
+	Use:   "zh-variant [files/directories...]",
+	Short: "Find traditional Chinese characters in simplified Chinese docs",
+	Long: `Find traditional Chinese characters in simplified Chinese (zh-cn) documents,
+such as fragments copied from zh-tw pages.
+
+Characters are looked up in a built-in table of traditional characters that
+are not used in simplified Chinese, and each finding suggests the
+character-by-character conversion. With --opencc the suggestions come from
+the opencc command instead, which also converts phrases and, with the tw2sp
+or hk2s configs, regional vocabulary. Files under zh-tw, zh-hk, zh-mo and
+zh-hant directories are skipped.
+
+Examples:
+  mm quality zh-variant content/zh-cn/docs/             # Check directory recursively
+  mm quality zh-variant --opencc content/zh-cn/         # Suggest with OpenCC (t2s)
+  mm quality zh-variant --opencc=tw2sp content/zh-cn/   # Also convert Taiwan vocabulary`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		variantChecker := checker.NewChineseVariantChecker()
+		variantChecker.OpenCCConfig, _ = cmd.Flags().GetString("opencc")
+
+		return runChecker(cmd, variantChecker, args)
+	},
+}
+
+func init() {
+	addCheckFlags(zhVariantCmd)
+	zhVariantCmd.Flags().String("opencc", "", "Suggest conversions with the opencc command and this config (t2s, tw2sp, hk2s)")
+	zhVariantCmd.Flags().Lookup("opencc").NoOptDefVal = zhconv.DefaultOpenCCConfig
+}
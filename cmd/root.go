@@ -40,6 +40,7 @@ func init() {
 	rootCmd.AddCommand(k8s.K8sCmd)
 	rootCmd.AddCommand(quality.QualityCmd)
 	rootCmd.AddCommand(formatCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
 
 func setupCommandGroups() {
@@ -61,5 +62,6 @@ func setupCommandGroups() {
 	k8s.K8sCmd.GroupID = "project"
 	quality.QualityCmd.GroupID = "tools"
 	formatCmd.GroupID = "tools"
+	cacheCmd.GroupID = "tools"
 	versionCmd.GroupID = "basic"
 }
@@ -0,0 +1,343 @@
+// Package cache stores the cached data of mm commands in namespaces under
+// ~/.cache/mm/entries, one JSON file per entry, with a time to live per
+// namespace that .mm.yaml can change
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samzong/mm/internal/config"
+)
+
+// Namespaces of the caches of mm
+const (
+	// Lsync holds the outdated pages lsync found, per localization
+	Lsync = "lsync"
+	// PullRequests holds the files of open pull requests, per
+	// localization
+	PullRequests = "prs"
+	// Links holds the status of external links
+	Links = "links"
+	// Results holds the issues of checked files, per checker
+	Results = "results"
+	// Glossary holds the glossary refreshed from the website, per
+	// localization
+	Glossary = "glossary"
+)
+
+// defaultTTLs are how long entries of a namespace stay fresh unless
+// cache.ttl of .mm.yaml says otherwise; 0 keeps them until cleared
+var defaultTTLs = map[string]time.Duration{
+	Lsync:        30 * time.Minute,
+	PullRequests: 15 * time.Minute,
+	Links:        24 * time.Hour,
+	Results:      0,
+	Glossary:     0,
+}
+
+// Locks older than staleLock are left over by a run that died, and locks
+// are waited for up to lockTimeout
+const (
+	staleLock   = 30 * time.Second
+	lockTimeout = 10 * time.Second
+)
+
+// configuredTTLs are the times to live of .mm.yaml, read once
+var (
+	configuredTTLs map[string]time.Duration
+	configOnce     sync.Once
+)
+
+// Entry describes a cached entry
+type Entry struct {
+	Namespace string    `json:"namespace"`
+	Key       string    `json:"key"`
+	Created   time.Time `json:"created"`
+	// Expires is zero for entries kept until cleared
+	Expires time.Time `json:"expires,omitempty"`
+	Size    int64     `json:"size"`
+}
+
+// Expired reports whether the entry is no longer fresh
+func (e Entry) Expired() bool {
+	return !e.Expires.IsZero() && time.Now().After(e.Expires)
+}
+
+// envelope is an entry as stored on disk
+type envelope struct {
+	Key     string          `json:"key"`
+	Created time.Time       `json:"created"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Store is the cache of a namespace
+type Store struct {
+	namespace string
+}
+
+// New returns the cache of a namespace
+func New(namespace string) *Store {
+	return &Store{namespace: namespace}
+}
+
+// Dir returns the directory of the caches
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "mm", "entries"), nil
+}
+
+// TTL returns how long the entries of a namespace stay fresh, 0 for ever
+func TTL(namespace string) time.Duration {
+	configOnce.Do(func() {
+		cfg, err := config.Discover(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; using the default cache TTLs\n", err)
+		} else if cfg != nil {
+			configuredTTLs = cfg.Cache.TTLs
+		}
+	})
+	if ttl, ok := configuredTTLs[namespace]; ok {
+		return ttl
+	}
+	return defaultTTLs[namespace]
+}
+
+// path returns the file of an entry
+func (s *Store) path(key string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, s.namespace, url.PathEscape(key)+".json"), nil
+}
+
+// Load reads an entry into v whether it is fresh or not, and describes it.
+// It returns nil without an error when there is no such entry, or when it
+// can't be decoded.
+func (s *Store) Load(key string, v any) (*Entry, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache %s/%s: %w", s.namespace, key, err)
+	}
+	var stored envelope
+	if json.Unmarshal(data, &stored) != nil || json.Unmarshal(stored.Data, v) != nil {
+		// A corrupt entry is simply rebuilt
+		return nil, nil
+	}
+	entry := s.entry(stored, int64(len(data)))
+	return &entry, nil
+}
+
+// Get reads an entry into v and reports whether it was found fresh
+func (s *Store) Get(key string, v any) bool {
+	entry, err := s.Load(key, v)
+	return err == nil && entry != nil && !entry.Expired()
+}
+
+// Put stores v as an entry, replacing the previous one. Concurrent runs
+// take turns, and readers see either entry whole.
+func (s *Store) Put(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache %s/%s: %w", s.namespace, key, err)
+	}
+	stored, err := json.Marshal(envelope{Key: key, Created: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache %s/%s: %w", s.namespace, key, err)
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, stored, 0644); err != nil {
+		return fmt.Errorf("failed to write cache %s/%s: %w", s.namespace, key, err)
+	}
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("failed to write cache %s/%s: %w", s.namespace, key, err)
+	}
+	return nil
+}
+
+// Delete removes an entry
+func (s *Store) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache %s/%s: %w", s.namespace, key, err)
+	}
+	return nil
+}
+
+// entry describes a stored entry
+func (s *Store) entry(stored envelope, size int64) Entry {
+	entry := Entry{Namespace: s.namespace, Key: stored.Key, Created: stored.Created, Size: size}
+	if ttl := TTL(s.namespace); ttl > 0 {
+		entry.Expires = stored.Created.Add(ttl)
+	}
+	return entry
+}
+
+// lock takes the lock of a cache file, waiting for other runs to release
+// it, and returns the function that releases it
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock cache: %w", err)
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("cache is locked by another run: %s", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Namespaces returns the namespaces with entries
+func Namespaces() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	var namespaces []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			namespaces = append(namespaces, entry.Name())
+		}
+	}
+	return namespaces, nil
+}
+
+// List describes the entries of a namespace, of every namespace when it is
+// empty, ordered by namespace and key
+func List(namespace string) ([]Entry, error) {
+	namespaces := []string{namespace}
+	if namespace == "" {
+		var err error
+		if namespaces, err = Namespaces(); err != nil {
+			return nil, err
+		}
+	}
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Entry
+	for _, name := range namespaces {
+		files, err := filepath.Glob(filepath.Join(dir, name, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		store := New(name)
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			var stored envelope
+			if json.Unmarshal(data, &stored) != nil {
+				continue
+			}
+			list = append(list, store.entry(stored, int64(len(data))))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list, nil
+}
+
+// Show returns the data of an entry as indented JSON
+func Show(namespace, key string) (*Entry, []byte, error) {
+	var data json.RawMessage
+	entry, err := New(namespace).Load(key, &data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry == nil {
+		return nil, nil, fmt.Errorf("no cache entry %s/%s", namespace, key)
+	}
+	var indented strings.Builder
+	encoder := json.NewEncoder(&indented)
+	encoder.SetIndent("", "  ")
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, nil, err
+	}
+	if err := encoder.Encode(value); err != nil {
+		return nil, nil, err
+	}
+	return entry, []byte(indented.String()), nil
+}
+
+// Clear removes the entries of a namespace, of every namespace when it is
+// empty
+func Clear(namespace string) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	target := dir
+	if namespace != "" {
+		if strings.ContainsAny(namespace, `/\`) || namespace == "." || namespace == ".." {
+			return fmt.Errorf("invalid cache namespace: %s", namespace)
+		}
+		target = filepath.Join(dir, namespace)
+	}
+	if err := os.RemoveAll(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
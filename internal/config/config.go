@@ -0,0 +1,351 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the project configuration file committed to a repository
+const FileName = ".mm.yaml"
+
+// Config is the project configuration read from .mm.yaml
+type Config struct {
+	Quality QualityConfig `yaml:"quality"`
+	K8s     K8sConfig     `yaml:"k8s"`
+	Cache   CacheConfig   `yaml:"cache"`
+
+	// Path is the file the configuration was read from
+	Path string `yaml:"-"`
+}
+
+// QualityConfig holds the settings of the quality commands. Command line
+// flags take precedence over these settings.
+type QualityConfig struct {
+	// Project is the project type (k8s, go, docker, hugo, docusaurus, mkdocs,
+	// sphinx, vitepress, generic)
+	Project string `yaml:"project"`
+	// Checkers are run by mm quality check
+	Checkers []string `yaml:"checkers"`
+	// Lang is the document language for spell checking, auto to detect it
+	// per file
+	Lang string `yaml:"lang"`
+	// Engine is the spell engine (builtin, aspell, hunspell)
+	Engine string `yaml:"engine"`
+	// Ignore lists glob patterns of files not to check, relative to the
+	// directory of the configuration file
+	Ignore []string `yaml:"ignore"`
+	// Severity overrides the severity of rules by ID; "off" disables a rule
+	Severity map[string]string `yaml:"severity"`
+	// FailOn is the lowest severity that makes a check fail (error,
+	// warning, info or never)
+	FailOn string `yaml:"fail_on"`
+	// Dictionaries are extra word lists for the spell checker
+	Dictionaries []string `yaml:"dictionaries"`
+	// DataKeys are glob patterns of the keys spell checked in data files
+	DataKeys []string `yaml:"data_keys"`
+	// Glossary is the terminology glossary
+	Glossary string `yaml:"glossary"`
+	// Schema is the front matter schema
+	Schema string `yaml:"schema"`
+	// Headings configures the heading style rules of the markdown checker
+	Headings HeadingsConfig `yaml:"headings"`
+	// Shortcodes configures the shortcode checker
+	Shortcodes ShortcodesConfig `yaml:"shortcodes"`
+	// Fences configures the code fence checker
+	Fences FencesConfig `yaml:"fences"`
+	// Adapters define project types in addition to the built-in ones,
+	// selected with project or --project
+	Adapters []AdapterConfig `yaml:"adapters"`
+}
+
+// AdapterConfig defines a project type
+type AdapterConfig struct {
+	// Name is the project type
+	Name string `yaml:"name"`
+	// Extends is the built-in project type the adapter builds on (default
+	// generic)
+	Extends string `yaml:"extends"`
+	// Dictionaries are loaded for the project type
+	Dictionaries []string `yaml:"dictionaries"`
+	// Ignore lists glob patterns of files not to check, relative to the
+	// directory of the configuration file
+	Ignore []string `yaml:"ignore"`
+	// Extensions are the extensions of the files collected from
+	// directories, replacing those of the extended adapter
+	Extensions []string `yaml:"extensions"`
+	// Rules override the custom rules of the extended adapter
+	Rules map[string]bool `yaml:"rules"`
+	// ProperNouns keep their casing in headings
+	ProperNouns []string `yaml:"proper_nouns"`
+}
+
+// K8sConfig holds the settings of the mm k8s docs commands
+type K8sConfig struct {
+	// Project is the localized site the commands work on, the Kubernetes
+	// website by default
+	Project ProjectConfig `yaml:"project"`
+	// Lang is the localization worked on (zh-cn, ja, ko, fr, ...)
+	Lang string `yaml:"lang"`
+	// ClaimsIssue is the kubernetes/website issue the localization team
+	// tracks who translates which page in
+	ClaimsIssue int `yaml:"claims_issue"`
+	// ClaimDays is how long a claim holds before it expires
+	ClaimDays int `yaml:"claim_days"`
+	// Priority tunes lsync --sort priority
+	Priority PriorityConfig `yaml:"priority"`
+	// SyncRef is how translations record the English commit they were
+	// made from: trailer (Upstream-Commit in the commit message) or
+	// front-matter (upstream_commit in the page); unset records nothing
+	SyncRef string `yaml:"sync_ref"`
+	// GitHubToken authenticates the GitHub API when neither GITHUB_TOKEN
+	// nor GH_TOKEN is set; without any, the token of gh auth login is used
+	GitHubToken string `yaml:"github_token"`
+	// Remote is the git remote of your fork that workflow and pr create
+	// push branches to; without it, the remote of a fork of the site is
+	// found among the remotes
+	Remote string `yaml:"remote"`
+	// Templates name the branches, commits and pull requests of workflow
+	// and pr create
+	Templates WorkflowTemplates `yaml:"templates"`
+	// Scopes name the parts of the site a contributor maintains, as paths
+	// like those of lsync --path, for lsync --scope
+	Scopes map[string][]string `yaml:"scopes"`
+}
+
+// ProjectConfig describes a localized site: a built-in type and the
+// settings of it that differ
+type ProjectConfig struct {
+	// Type is kubernetes (the default), hugo (content/en/ translated to
+	// content/<lang>/) or docusaurus (docs/ translated to
+	// i18n/<lang>/docusaurus-plugin-content-docs/current/)
+	Type string `yaml:"type"`
+	// Repository is the GitHub repository of the site, owner/name
+	Repository string `yaml:"repository"`
+	// SourceDir is the content directory of the source pages
+	SourceDir string `yaml:"source_dir"`
+	// TargetDir is the content directory of a localization, with {lang}
+	// standing for its code
+	TargetDir string `yaml:"target_dir"`
+	// Branch is the branch pull requests target
+	Branch string `yaml:"branch"`
+	// Label is the label of the pull requests of a localization, with
+	// {lang} standing for its code and {short_lang} for its language
+	Label string `yaml:"label"`
+	// Generated are the directories of generated pages, relative to
+	// SourceDir, left out of lsync by default
+	Generated []string `yaml:"generated"`
+}
+
+// WorkflowTemplates are Go templates of the branch, commit message and
+// pull request of a translation; empty ones keep the defaults
+type WorkflowTemplates struct {
+	Branch  string `yaml:"branch"`
+	Commit  string `yaml:"commit"`
+	PRTitle string `yaml:"pr_title"`
+	PRBody  string `yaml:"pr_body"`
+	// Langs override the templates for a localization
+	Langs map[string]WorkflowTemplates `yaml:"langs"`
+}
+
+// ForLang returns the templates of a localization, its own overriding the
+// shared ones
+func (t WorkflowTemplates) ForLang(lang string) WorkflowTemplates {
+	own := t.Langs[lang]
+	merged := WorkflowTemplates{Branch: t.Branch, Commit: t.Commit, PRTitle: t.PRTitle, PRBody: t.PRBody}
+	if own.Branch != "" {
+		merged.Branch = own.Branch
+	}
+	if own.Commit != "" {
+		merged.Commit = own.Commit
+	}
+	if own.PRTitle != "" {
+		merged.PRTitle = own.PRTitle
+	}
+	if own.PRBody != "" {
+		merged.PRBody = own.PRBody
+	}
+	return merged
+}
+
+// PriorityConfig holds the weights that rank outdated pages
+type PriorityConfig struct {
+	// Weights scale the importance of pages by path prefix under
+	// content/en/, e.g. docs/concepts/: 2; the longest prefix wins
+	Weights map[string]float64 `yaml:"weights"`
+	// Analytics is a CSV file of page views, with the URL path or content
+	// path of a page and its views per line
+	Analytics string `yaml:"analytics"`
+	// SizeWeight and StalenessWeight balance the size of the English
+	// changes against how long the translation has been outdated
+	SizeWeight      float64 `yaml:"size_weight"`
+	StalenessWeight float64 `yaml:"staleness_weight"`
+}
+
+// CacheConfig holds the settings of the caches of mm
+type CacheConfig struct {
+	// TTL overrides how long the entries of a cache namespace (lsync, prs,
+	// links, results, glossary) stay fresh, as durations like 10m, 2h or 7d; 0 keeps
+	// them until cleared
+	TTL map[string]string `yaml:"ttl"`
+	// TTLs are the parsed durations of TTL
+	TTLs map[string]time.Duration `yaml:"-"`
+}
+
+// HeadingsConfig holds the heading style of a project
+type HeadingsConfig struct {
+	// Case is sentence or title
+	Case string `yaml:"case"`
+	// MaxLength is the maximum heading length in characters
+	MaxLength int `yaml:"max_length"`
+	// Punctuation lists the characters headings must not end with
+	Punctuation string `yaml:"punctuation"`
+	// ProperNouns keep their casing in headings
+	ProperNouns []string `yaml:"proper_nouns"`
+}
+
+// ShortcodesConfig describes the Hugo shortcodes of a project
+type ShortcodesConfig struct {
+	// Known are shortcodes defined outside layouts/shortcodes, e.g. by Hugo
+	// modules
+	Known []string `yaml:"known"`
+	// Required lists the parameters each shortcode requires, by name or by
+	// position ("0", "1", ...)
+	Required map[string][]string `yaml:"required"`
+}
+
+// FencesConfig describes the code block languages of a project
+type FencesConfig struct {
+	// Languages are accepted in addition to those Hugo highlights
+	Languages []string `yaml:"languages"`
+	// RequireLanguage reports code blocks without a language
+	RequireLanguage bool `yaml:"require_language"`
+}
+
+// Load reads a configuration file. Relative paths in it are resolved
+// against the directory of the file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	cfg.Path = path
+
+	dir := filepath.Dir(path)
+	for i, dict := range cfg.Quality.Dictionaries {
+		cfg.Quality.Dictionaries[i] = resolve(dir, dict)
+	}
+	cfg.Quality.Glossary = resolve(dir, cfg.Quality.Glossary)
+	cfg.Quality.Schema = resolve(dir, cfg.Quality.Schema)
+	cfg.K8s.Priority.Analytics = resolve(dir, cfg.K8s.Priority.Analytics)
+	for i, adapter := range cfg.Quality.Adapters {
+		if strings.TrimSpace(adapter.Name) == "" {
+			return nil, fmt.Errorf("adapter %d in %s has no name", i+1, path)
+		}
+		for j, dict := range adapter.Dictionaries {
+			cfg.Quality.Adapters[i].Dictionaries[j] = resolve(dir, dict)
+		}
+	}
+
+	for rule, severity := range cfg.Quality.Severity {
+		switch strings.ToLower(severity) {
+		case "error", "warning", "info", "off":
+			cfg.Quality.Severity[rule] = strings.ToLower(severity)
+		default:
+			return nil, fmt.Errorf("invalid severity %q for rule %s in %s (use error, warning, info or off)", severity, rule, path)
+		}
+	}
+
+	switch strings.ToLower(cfg.Quality.FailOn) {
+	case "", "error", "warning", "info", "never":
+		cfg.Quality.FailOn = strings.ToLower(cfg.Quality.FailOn)
+	default:
+		return nil, fmt.Errorf("invalid fail_on %q in %s (use error, warning, info or never)", cfg.Quality.FailOn, path)
+	}
+
+	cfg.Cache.TTLs = make(map[string]time.Duration, len(cfg.Cache.TTL))
+	for namespace, value := range cfg.Cache.TTL {
+		ttl, err := parseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid cache ttl %q for %s in %s (use a duration like 30m, 2h or 7d)", value, namespace, path)
+		}
+		cfg.Cache.TTLs[namespace] = ttl
+	}
+
+	return &cfg, nil
+}
+
+// parseDuration parses a Go duration, or a number of days like 7d
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Discover walks up from start, a file or directory, to the first directory
+// containing .mm.yaml and loads it. It returns nil when there is none.
+func Discover(start string) (*Config, error) {
+	path, ok := Find(start)
+	if !ok {
+		return nil, nil
+	}
+	return Load(path)
+}
+
+// Find returns the path of the nearest .mm.yaml at or above start
+func Find(start string) (string, bool) {
+	return FindFile(start, FileName)
+}
+
+// FindFile returns the path of the nearest file with the given name at or
+// above start, a file or directory
+func FindFile(start, name string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	for {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Dir returns the directory of the configuration file
+func (c *Config) Dir() string {
+	return filepath.Dir(c.Path)
+}
+
+// resolve makes a relative path relative to dir
+func resolve(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
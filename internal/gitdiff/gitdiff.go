@@ -0,0 +1,165 @@
+package gitdiff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Changes records the lines added or modified per file, keyed by absolute
+// path. A nil line set means the whole file is new.
+type Changes map[string]map[int]bool
+
+// hunkHeader matches the old and new ranges of a unified diff hunk header
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Since returns the lines changed in the working tree since the merge base
+// of base and HEAD, so only the current branch's changes are included.
+// Untracked files count as changed entirely.
+func Since(base string) (Changes, error) {
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = absPath(strings.TrimSpace(root))
+
+	mergeBase, err := git("merge-base", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := git("diff", "--unified=0", "--no-color", "--no-ext-diff", "--no-renames", strings.TrimSpace(mergeBase))
+	if err != nil {
+		return nil, err
+	}
+	changes := parseDiff(root, diff)
+
+	untracked, err := git("ls-files", "-z", "--others", "--exclude-standard", "--full-name", ":/")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range strings.Split(untracked, "\x00") {
+		if file != "" {
+			changes[filepath.Join(root, filepath.FromSlash(file))] = nil
+		}
+	}
+
+	return changes, nil
+}
+
+// parseDiff collects the added lines of a unified diff with no context
+func parseDiff(root, diff string) Changes {
+	changes := make(Changes)
+	var current map[int]bool
+	// Lines of the hunk being read, counted down from its header, so that
+	// content lines like "+++ x" aren't taken for file headers
+	oldLeft, newLeft := 0, 0
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+				continue
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+				continue
+			case strings.HasPrefix(line, " "):
+				oldLeft--
+				newLeft--
+				continue
+			case strings.HasPrefix(line, "\\"):
+				// \ No newline at end of file
+				continue
+			}
+			// The hunk is shorter than its header says; read on from here
+			oldLeft, newLeft = 0, 0
+		}
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			current = nil
+			name := strings.TrimPrefix(line, "+++ ")
+			if name == "/dev/null" {
+				continue
+			}
+			// Names with control characters or quotes stay quoted even
+			// with core.quotepath off
+			if strings.HasPrefix(name, `"`) {
+				if unquoted, err := strconv.Unquote(name); err == nil {
+					name = unquoted
+				}
+			}
+			current = make(map[int]bool)
+			changes[filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "b/")))] = current
+		case strings.HasPrefix(line, "@@"):
+			match := hunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			oldLeft = 1
+			if match[1] != "" {
+				oldLeft, _ = strconv.Atoi(match[1])
+			}
+			start, _ := strconv.Atoi(match[2])
+			count := 1
+			if match[3] != "" {
+				count, _ = strconv.Atoi(match[3])
+			}
+			newLeft = count
+			for i := 0; current != nil && i < count; i++ {
+				current[start+i] = true
+			}
+		}
+	}
+	return changes
+}
+
+// Changed reports whether a file has any changes
+func (c Changes) Changed(filePath string) bool {
+	_, ok := c[absPath(filePath)]
+	return ok
+}
+
+// LineChanged reports whether a line of a file was added or modified. Line 0
+// stands for the file as a whole and counts as changed when the file is.
+func (c Changes) LineChanged(filePath string, line int) bool {
+	lines, ok := c[absPath(filePath)]
+	if !ok {
+		return false
+	}
+	return lines == nil || line <= 0 || lines[line]
+}
+
+// absPath resolves a path the way changes are keyed, following symlinks so
+// paths reported by git and paths given on the command line agree
+func absPath(filePath string) string {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return filePath
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
+
+// git runs a git command and returns its output, with paths unquoted so
+// non-ASCII names match the files on disk
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-c", "core.quotepath=off"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,159 @@
+package gitdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDiff(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	tests := []struct {
+		name string
+		diff string
+		want Changes
+	}{
+		{
+			name: "one line",
+			diff: "diff --git a/a.md b/a.md\n--- a/a.md\n+++ b/a.md\n@@ -3 +3 @@\n-old\n+new\n",
+			want: Changes{filepath.Join(root, "a.md"): {3: true}},
+		},
+		{
+			name: "ranges",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -1,0 +2,3 @@ heading\n+a\n+b\n+c\n@@ -10,2 +12 @@\n-x\n-y\n+z\n",
+			want: Changes{filepath.Join(root, "a.md"): {2: true, 3: true, 4: true, 12: true}},
+		},
+		{
+			name: "deleted lines only",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -5,2 +4,0 @@\n-x\n-y\n",
+			want: Changes{filepath.Join(root, "a.md"): {}},
+		},
+		{
+			name: "deleted file",
+			diff: "--- a/gone.md\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-x\n-y\n",
+			want: Changes{},
+		},
+		{
+			name: "nested path and two files",
+			diff: "--- a/docs/a.md\n+++ b/docs/a.md\n@@ -1 +1 @@\n-x\n+y\n--- /dev/null\n+++ b/docs/b.md\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			want: Changes{
+				filepath.Join(root, "docs", "a.md"): {1: true},
+				filepath.Join(root, "docs", "b.md"): {1: true, 2: true},
+			},
+		},
+		{
+			name: "added line that looks like a file header",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -1,0 +2,2 @@\n+++ b/other.md\n+x\n@@ -9 +11 @@\n-y\n+z\n",
+			want: Changes{filepath.Join(root, "a.md"): {2: true, 3: true, 11: true}},
+		},
+		{
+			name: "deleted line that looks like a file header",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -4 +3,0 @@\n--- a/other.md\n@@ -8 +7 @@\n-x\n+y\n",
+			want: Changes{filepath.Join(root, "a.md"): {7: true}},
+		},
+		{
+			name: "no newline at end of file",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -1 +1 @@\n-x\n\\ No newline at end of file\n+y\n\\ No newline at end of file\n--- a/b.md\n+++ b/b.md\n@@ -2 +2 @@\n-x\n+y\n",
+			want: Changes{
+				filepath.Join(root, "a.md"): {1: true},
+				filepath.Join(root, "b.md"): {2: true},
+			},
+		},
+		{
+			name: "quoted name",
+			diff: "--- \"a/tab\\there.md\"\n+++ \"b/tab\\there.md\"\n@@ -1 +1 @@\n-x\n+y\n",
+			want: Changes{filepath.Join(root, "tab\there.md"): {1: true}},
+		},
+		{
+			name: "malformed hunk header",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ oops @@\n+x\n",
+			want: Changes{filepath.Join(root, "a.md"): {}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDiff(root, tt.diff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineChanged(t *testing.T) {
+	dir := t.TempDir()
+	changed := filepath.Join(absPath(dir), "changed.md")
+	added := filepath.Join(absPath(dir), "added.md")
+	changes := Changes{changed: {2: true}, added: nil}
+
+	tests := []struct {
+		file string
+		line int
+		want bool
+	}{
+		{changed, 2, true},
+		{changed, 3, false},
+		{changed, 0, true},
+		{added, 40, true},
+		{filepath.Join(dir, "other.md"), 0, false},
+	}
+	for _, tt := range tests {
+		if got := changes.LineChanged(tt.file, tt.line); got != tt.want {
+			t.Errorf("LineChanged(%s, %d) = %v, want %v", filepath.Base(tt.file), tt.line, got, tt.want)
+		}
+	}
+	if !changes.Changed(added) || changes.Changed(filepath.Join(dir, "other.md")) {
+		t.Errorf("Changed() doesn't follow the changed files")
+	}
+}
+
+func TestSinceNonASCIIPath(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	runGit := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit("init", "-q", "-b", "main")
+	runGit("config", "user.name", "Test")
+	runGit("config", "user.email", "test@example.com")
+	write("文档.md", "one\ntwo\n")
+	runGit("add", "-A")
+	runGit("commit", "-q", "-m", "Add page")
+	write("文档.md", "one\n2\n")
+	write("新页面.md", "new\n")
+
+	changes, err := Since("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changes.LineChanged("文档.md", 2) || changes.LineChanged("文档.md", 1) {
+		t.Errorf("changed lines of 文档.md not found in %v", changes)
+	}
+	if !changes.LineChanged("新页面.md", 1) {
+		t.Errorf("untracked 新页面.md not found in %v", changes)
+	}
+}
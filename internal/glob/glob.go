@@ -0,0 +1,122 @@
+package glob
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[string]*regexp.Regexp)
+)
+
+// Match reports whether path matches the glob pattern.
+// In addition to filepath.Match syntax, "**" matches any number of path
+// segments. A pattern without a slash is also tried against each path
+// segment, and a pattern naming a directory matches everything below it.
+func Match(pattern, path string) bool {
+	pattern = filepath.ToSlash(strings.TrimSuffix(pattern, "/"))
+	path = filepath.ToSlash(filepath.Clean(path))
+	pattern = strings.TrimPrefix(pattern, "./")
+	path = strings.TrimPrefix(path, "./")
+
+	if pattern == "" {
+		return false
+	}
+
+	re := compile(pattern)
+	if re.MatchString(path) {
+		return true
+	}
+
+	// Directory pattern: match any prefix of the path
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if re.MatchString(strings.Join(segments[:i], "/")) {
+			return true
+		}
+	}
+
+	// Bare name pattern: match any single segment
+	if !strings.Contains(pattern, "/") {
+		for _, segment := range segments {
+			if re.MatchString(segment) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// MatchAny reports whether path matches any of the patterns
+func MatchAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// compile converts a glob pattern into an anchored regular expression
+func compile(pattern string) *regexp.Regexp {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if re, ok := cache[pattern]; ok {
+		return re
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" matches zero or more directories
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			// Copy a whole character, which may take several bytes
+			_, size := utf8.DecodeRuneInString(pattern[i:])
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+			i += size - 1
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		// Fall back to a literal match for malformed patterns
+		re = regexp.MustCompile("^" + regexp.QuoteMeta(pattern) + "$")
+	}
+	cache[pattern] = re
+	return re
+}
@@ -0,0 +1,54 @@
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.md", "docs/a.md", true},
+		{"*.md", "docs/a.txt", false},
+		{"docs/*.md", "docs/a.md", true},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"docs/**/*.md", "docs/a.md", true},
+		{"docs/**/*.md", "docs/sub/deep/a.md", true},
+		{"**/generated", "content/en/docs/generated/a.md", true},
+		{"docs/**", "docs/sub/a.md", true},
+		{"a?.md", "ab.md", true},
+		{"a?.md", "a/.md", false},
+		{"a?.md", "abc.md", false},
+		{"[ab].md", "b.md", true},
+		{"[ab].md", "c.md", false},
+		{"[!ab].md", "c.md", true},
+		{"[!ab].md", "a.md", false},
+		{"[a-c]x", "bx", true},
+		{"[oops", "[oops", true},
+		{"reference", "content/en/docs/reference/a.md", true},
+		{"content/en/docs/", "content/en/docs/a.md", true},
+		{"./docs/a.md", "docs/a.md", true},
+		{"", "docs/a.md", false},
+		{"概念", "content/zh-cn/docs/概念/a.md", true},
+		{"概念", "content/zh-cn/docs/概要/a.md", false},
+		{"docs/概?/*.md", "docs/概念/a.md", true},
+		{"docs/**/概念/*.md", "docs/zh/概念/a.md", true},
+		{"[概念]", "念", true},
+		{"*.md", "文档.md", true},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	patterns := []string{"*.yaml", "概念/"}
+	if !MatchAny(patterns, "概念/a.md") {
+		t.Errorf("MatchAny(%q, 概念/a.md) = false, want true", patterns)
+	}
+	if MatchAny(patterns, "docs/a.md") {
+		t.Errorf("MatchAny(%q, docs/a.md) = true, want false", patterns)
+	}
+}
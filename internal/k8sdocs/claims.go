@@ -0,0 +1,145 @@
+package k8sdocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Claims are comments on a tracking issue with a line per page:
+//
+//	mm-claim: content/zh-cn/docs/concepts/overview/_index.md
+//	mm-release: content/zh-cn/docs/concepts/overview/_index.md
+//
+// Lines don't start with / so that the Prow bot of kubernetes/website
+// doesn't take them for commands.
+const (
+	claimMarker   = "mm-claim:"
+	releaseMarker = "mm-release:"
+)
+
+// DefaultClaimDays is how long a claim holds unless configured otherwise
+const DefaultClaimDays = 14
+
+// Claim is a contributor's intent to translate a page
+type Claim struct {
+	// Path is the localized page
+	Path string `json:"path"`
+	// User is the GitHub login of the contributor
+	User string `json:"user"`
+	// Time is when the page was claimed
+	Time time.Time `json:"time"`
+	// URL links to the claim comment
+	URL string `json:"url"`
+}
+
+// Expires returns when the claim expires after ttl
+func (c Claim) Expires(ttl time.Duration) time.Time {
+	return c.Time.Add(ttl)
+}
+
+// issueComment is a comment of the tracking issue
+type issueComment struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	HTMLURL   string    `json:"html_url"`
+}
+
+// Claims returns the claims of the tracking issue that didn't expire after
+// ttl, keyed by localized path. A later claim of the same page by someone
+// else only counts once the earlier one expired or was released.
+func Claims(issue int, ttl time.Duration) (map[string]Claim, error) {
+	var comments []issueComment
+	err := githubPages(issueCommentsPath(issue)+"?per_page=100", func(data []byte) error {
+		var page []issueComment
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		comments = append(comments, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claims from issue #%d: %w", issue, err)
+	}
+
+	claims := make(map[string]Claim)
+	now := time.Now()
+	for _, comment := range comments {
+		for _, text := range strings.Split(comment.Body, "\n") {
+			text = strings.TrimSpace(text)
+			if path, ok := strings.CutPrefix(text, claimMarker); ok {
+				path = strings.TrimSpace(path)
+				current, claimed := claims[path]
+				if claimed && current.User != comment.User.Login && now.Before(current.Expires(ttl)) {
+					continue
+				}
+				claims[path] = Claim{Path: path, User: comment.User.Login, Time: comment.CreatedAt, URL: comment.HTMLURL}
+			} else if path, ok := strings.CutPrefix(text, releaseMarker); ok {
+				path = strings.TrimSpace(path)
+				if claims[path].User == comment.User.Login {
+					delete(claims, path)
+				}
+			}
+		}
+	}
+
+	for path, claim := range claims {
+		if !now.Before(claim.Expires(ttl)) {
+			delete(claims, path)
+		}
+	}
+	return claims, nil
+}
+
+// ClaimPages claims pages on the tracking issue and returns the URL of the
+// claim comment
+func ClaimPages(issue int, paths []string) (string, error) {
+	body := markerLines(claimMarker, paths) +
+		"\nClaimed with `mm k8s docs claim` so that nobody else starts translating these pages."
+	url, err := postComment(issue, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to claim pages: %w", err)
+	}
+	return url, nil
+}
+
+// ReleasePages releases claims on the tracking issue
+func ReleasePages(issue int, paths []string) (string, error) {
+	body := markerLines(releaseMarker, paths) +
+		"\nReleased with `mm k8s docs claim --release`; these pages are free to translate."
+	url, err := postComment(issue, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to release pages: %w", err)
+	}
+	return url, nil
+}
+
+// markerLines returns a marker line per path
+func markerLines(marker string, paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		b.WriteString(marker + " " + path + "\n")
+	}
+	return b.String()
+}
+
+// postComment comments on an issue of the website repository and returns
+// the URL of the comment
+func postComment(issue int, body string) (string, error) {
+	var comment issueComment
+	if err := githubJSON(http.MethodPost, issueCommentsPath(issue), map[string]string{"body": body}, &comment); err != nil {
+		return "", err
+	}
+	return comment.HTMLURL, nil
+}
+
+// issueCommentsPath returns the API path of the comments of an issue
+func issueCommentsPath(issue int) string {
+	return "repos/" + Repository + "/issues/" + strconv.Itoa(issue) + "/comments"
+}
@@ -0,0 +1,187 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BranchCommit is a commit of the branch being prepared for a pull request
+type BranchCommit struct {
+	Commit
+	// Body is the message after the subject
+	Body        string
+	AuthorName  string
+	AuthorEmail string
+	// Files are the files the commit adds, changes or removes
+	Files []string
+}
+
+// SignOffs returns the identities of the Signed-off-by lines of the
+// commit, like Jane Doe <jane@example.com>
+func (c BranchCommit) SignOffs() []string {
+	var signOffs []string
+	for _, line := range strings.Split(c.Body, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Signed-off-by:"); ok {
+			signOffs = append(signOffs, strings.TrimSpace(value))
+		}
+	}
+	return signOffs
+}
+
+// SignedOffByAuthor reports whether the author signed the commit off, as
+// the DCO check of the website requires
+func (c BranchCommit) SignedOffByAuthor() bool {
+	for _, signOff := range c.SignOffs() {
+		if strings.Contains(strings.ToLower(signOff), "<"+strings.ToLower(c.AuthorEmail)+">") {
+			return true
+		}
+	}
+	return false
+}
+
+// BranchCommits returns the commits of the current branch since it forked
+// from base, oldest first, leaving merges out
+func BranchCommits(base string) ([]BranchCommit, error) {
+	output, err := git("log", "--reverse", "--no-merges", "--name-only",
+		"--format=%x1e%H%x1f%an%x1f%ae%x1f%s%x1f%b%x1f", base+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the commits since %s: %w", base, err)
+	}
+	var commits []BranchCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(record, "\x1f")
+		if len(fields) != 6 {
+			continue
+		}
+		commit := BranchCommit{
+			Commit:      Commit{Hash: fields[0], Subject: fields[3]},
+			Body:        strings.TrimSpace(fields[4]),
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+		}
+		for _, file := range strings.Split(fields[5], "\n") {
+			if file = strings.TrimSpace(file); file != "" {
+				commit.Files = append(commit.Files, file)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// Kinds of the problems of commits
+const (
+	CommitPrefix  = "prefix"
+	CommitPath    = "path"
+	CommitSignOff = "signoff"
+)
+
+// CommitProblem is a convention of the localization a commit breaks
+type CommitProblem struct {
+	Kind    string
+	Message string
+	// Files are the files the problem is about, for path problems
+	Files []string
+	// Warning is set for problems that don't fail the check
+	Warning bool
+}
+
+// CommitRules are the conventions of the commits of a localization
+type CommitRules struct {
+	// Lang is the code of the localization
+	Lang string
+	// Prefix starts the subject of every commit, like [zh-cn]
+	Prefix string
+	// Base is the ref the branch forked from, where the English pages of
+	// the translations are looked for
+	Base string
+}
+
+// Check returns the problems of a commit: a subject without the prefix of
+// the localization, changes to the English pages or to other
+// localizations, translations without an English page, and no sign-off
+// of the author
+func (r CommitRules) Check(commit BranchCommit) []CommitProblem {
+	var problems []CommitProblem
+	if !strings.HasPrefix(commit.Subject, r.Prefix+" ") {
+		problems = append(problems, CommitProblem{
+			Kind:    CommitPrefix,
+			Message: fmt.Sprintf("the subject doesn't start with %s", r.Prefix),
+		})
+	}
+
+	var english, others, orphans []string
+	for _, file := range commit.Files {
+		switch lang := LocalizationOf(file); {
+		case strings.HasPrefix(file, UpstreamDir):
+			english = append(english, file)
+		case lang != "" && lang != r.Lang:
+			others = append(others, file)
+		case lang == r.Lang && IsPage(file):
+			upstream := Upstream(file, r.Lang)
+			if !existsAt(r.Base, upstream) && !fileExists(upstream) && fileExists(file) {
+				orphans = append(orphans, file)
+			}
+		}
+	}
+	if len(english) > 0 {
+		problems = append(problems, CommitProblem{
+			Kind:    CommitPath,
+			Message: "changes the English pages, which need a pull request of their own",
+			Files:   english,
+		})
+	}
+	if len(others) > 0 {
+		problems = append(problems, CommitProblem{
+			Kind:    CommitPath,
+			Message: "changes other localizations than " + r.Lang,
+			Files:   others,
+		})
+	}
+	if len(orphans) > 0 {
+		problems = append(problems, CommitProblem{
+			Kind:    CommitPath,
+			Message: "translates pages without an English page at the same path",
+			Files:   orphans,
+			Warning: true,
+		})
+	}
+
+	if !commit.SignedOffByAuthor() {
+		message := fmt.Sprintf("no Signed-off-by of the author %s <%s>", commit.AuthorName, commit.AuthorEmail)
+		if signOffs := commit.SignOffs(); len(signOffs) > 0 {
+			message += ", only of " + strings.Join(signOffs, ", ")
+		}
+		problems = append(problems, CommitProblem{Kind: CommitSignOff, Message: message})
+	}
+	return problems
+}
+
+// subjectTags matches the tags subjects start with, like [zh] or
+// [dev-1.32]
+var subjectTags = regexp.MustCompile(`^(\[(?:[a-z]{2,3}(?:[-_][A-Za-z0-9]{2,4})?|dev-\d+\.\d+)\]\s*)+`)
+
+// FixSubject returns a subject with the prefix, in place of the language
+// and release tags it starts with
+func (r CommitRules) FixSubject(subject string) string {
+	return r.Prefix + " " + subjectTags.ReplaceAllString(subject, "")
+}
+
+// LocalizationOf returns the code of the localization whose content
+// directory holds path, "" for the English pages and other files
+func LocalizationOf(path string) string {
+	if strings.HasPrefix(path, UpstreamDir) {
+		return ""
+	}
+	prefix, _, _ := strings.Cut(targetDir, "{lang}")
+	rest, ok := strings.CutPrefix(path, prefix)
+	if !ok {
+		return ""
+	}
+	lang, _, _ := strings.Cut(rest, "/")
+	if lang == "" || !strings.HasPrefix(path, LocalizedDir(lang)) {
+		return ""
+	}
+	return lang
+}
@@ -0,0 +1,45 @@
+package k8sdocs
+
+import (
+	"strings"
+)
+
+// Commit is a commit of the website repository
+type Commit struct {
+	Hash    string
+	Subject string
+}
+
+// Short returns the abbreviated hash of the commit
+func (c Commit) Short() string {
+	if len(c.Hash) > 8 {
+		return c.Hash[:8]
+	}
+	return c.Hash
+}
+
+// URL returns the page of the commit on GitHub
+func (c Commit) URL() string {
+	return "https://github.com/" + Repository + "/commit/" + c.Hash
+}
+
+// UpstreamCommits returns the commits that changed the English page of a
+// page since SyncCommit, newest first, following the renames of the page
+func UpstreamCommits(page Page) ([]Commit, error) {
+	if page.SyncCommit == "" {
+		return nil, nil
+	}
+	output, err := git("log", "--follow", "--format=%H%x09%s", page.SyncCommit+"..HEAD", "--", page.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		hash, subject, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
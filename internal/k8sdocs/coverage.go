@@ -0,0 +1,97 @@
+package k8sdocs
+
+import (
+	"sort"
+	"strings"
+)
+
+// SectionCoverage counts the pages of a part of the site by the state of
+// their translation
+type SectionCoverage struct {
+	// Section is the part of the site, see GroupOf
+	Section string `json:"section"`
+	// Total counts the English pages
+	Total int `json:"total"`
+	// Translated counts the English pages with a translation, UpToDate and
+	// Stale split them by whether the English page changed since
+	Translated int `json:"translated"`
+	UpToDate   int `json:"up_to_date"`
+	Stale      int `json:"stale"`
+	Missing    int `json:"missing"`
+}
+
+// add adds the counts of another section
+func (c *SectionCoverage) add(other SectionCoverage) {
+	c.Total += other.Total
+	c.Translated += other.Translated
+	c.UpToDate += other.UpToDate
+	c.Stale += other.Stale
+	c.Missing += other.Missing
+}
+
+// Coverage counts the pages under target, a directory of the content/<lang>
+// tree, per part of the site, and returns them ordered by section with the
+// sum of all of them. Translations never committed count as up to date, and
+// translations without an English page aren't counted. Generated reference
+// pages are skipped with excludeGenerated.
+func Coverage(target, lang string, excludeGenerated bool) ([]SectionCoverage, SectionCoverage, error) {
+	result, err := Lsync(target, lang)
+	if err != nil {
+		return nil, SectionCoverage{}, err
+	}
+	missing, err := Missing(target, lang)
+	if err != nil {
+		return nil, SectionCoverage{}, err
+	}
+	localized, err := localizedPages(slashPath(target))
+	if err != nil {
+		return nil, SectionCoverage{}, err
+	}
+
+	sections := make(map[string]*SectionCoverage)
+	sectionOf := func(upstream string) *SectionCoverage {
+		name := GroupOf(strings.TrimPrefix(upstream, UpstreamDir))
+		if sections[name] == nil {
+			sections[name] = &SectionCoverage{Section: name}
+		}
+		return sections[name]
+	}
+	stale := make(map[string]bool, len(result.Outdated))
+	for _, page := range result.Outdated {
+		stale[page.Path] = true
+	}
+
+	for _, page := range localized {
+		upstream := Upstream(page, lang)
+		if !fileExists(upstream) || (excludeGenerated && IsGenerated(upstream)) {
+			continue
+		}
+		section := sectionOf(upstream)
+		section.Total++
+		section.Translated++
+		if stale[page] {
+			section.Stale++
+		} else {
+			section.UpToDate++
+		}
+	}
+	for _, page := range missing {
+		if excludeGenerated && IsGenerated(page.Upstream) {
+			continue
+		}
+		section := sectionOf(page.Upstream)
+		section.Total++
+		section.Missing++
+	}
+
+	coverage := make([]SectionCoverage, 0, len(sections))
+	total := SectionCoverage{Section: "Total"}
+	for _, section := range sections {
+		coverage = append(coverage, *section)
+		total.add(*section)
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Section < coverage[j].Section
+	})
+	return coverage, total, nil
+}
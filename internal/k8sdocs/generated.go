@@ -0,0 +1,113 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// kubernetesGenerated are the English directories, under the content
+// directory, whose pages are generated from the Kubernetes sources, by the
+// reference docs tooling of the website
+var kubernetesGenerated = []string{
+	"docs/reference/kubernetes-api/",
+	"docs/reference/config-api/",
+	"docs/reference/kubectl/generated/",
+	"docs/reference/setup-tools/kubeadm/generated/",
+	"docs/reference/command-line-tools-reference/kube-",
+}
+
+// generatedDirs are the directories of generated pages of the project
+var generatedDirs = kubernetesGenerated
+
+// generatedMarkers are the notes, lowercased, that generated pages start
+// with in an HTML comment, like the "The file is auto-generated from the Go
+// source code" of the command line tools reference
+var generatedMarkers = []string{"auto-generated", "autogenerated", "do not edit"}
+
+// generatedMarkerLines is how many lines after the front matter are searched for a
+// generated marker
+const generatedMarkerLines = 30
+
+// IsGenerated reports whether an English page is generated: it is in one of
+// the generated reference directories, its front matter says
+// auto_generated: true, or a comment at its top says it is generated
+func IsGenerated(upstream string) bool {
+	for _, dir := range generatedDirs {
+		if strings.HasPrefix(upstream, UpstreamDir+dir) {
+			return true
+		}
+	}
+	return frontMatterGenerated(upstream) || hasGeneratedMarker(upstream)
+}
+
+// hasGeneratedMarker reports whether an HTML comment near the top of a page
+// says it is generated
+func hasGeneratedMarker(page string) bool {
+	file, err := os.Open(filepath.FromSlash(page))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	inFrontMatter, inComment := false, false
+	for lines := 0; scanner.Scan() && lines < generatedMarkerLines; {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch {
+		case line == "---" && lines == 0:
+			// The front matter doesn't count toward the lines searched
+			inFrontMatter = !inFrontMatter
+			continue
+		case inFrontMatter:
+			continue
+		}
+		lines++
+		if strings.HasPrefix(line, "<!--") {
+			inComment = true
+		}
+		if inComment {
+			for _, marker := range generatedMarkers {
+				if strings.Contains(line, marker) {
+					return true
+				}
+			}
+		}
+		if strings.Contains(line, "-->") {
+			inComment = false
+		}
+	}
+	return false
+}
+
+// frontMatterGenerated reports whether the front matter of a page has
+// auto_generated: true
+func frontMatterGenerated(page string) bool {
+	return frontMatterValue(page, "auto_generated") == "true"
+}
+
+// frontMatterValue returns a top-level value of the YAML front matter of a
+// page, unquoted; empty when the page or key doesn't exist
+func frontMatterValue(page, key string) string {
+	file, err := os.Open(filepath.FromSlash(page))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return ""
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			return ""
+		}
+		if value, ok := strings.CutPrefix(line, key+":"); ok {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,287 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// git runs a git command and returns its output
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-c", "core.quotepath=off"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// lastCommits returns the last commit that changed each of the files, found
+// in a single walk of the history of the paths. The walk stops once every
+// file is found; files never committed are missing from the result.
+func lastCommits(paths []string, files map[string]bool) (map[string]string, error) {
+	args := []string{"-c", "core.quotepath=off", "log", "--no-renames", "--format=commit %H", "--name-only", "--"}
+	cmd := exec.Command("git", append(args, paths...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+
+	commits := make(map[string]string, len(files))
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	current := ""
+	for scanner.Scan() && len(commits) < len(files) {
+		line := scanner.Text()
+		if hash, ok := strings.CutPrefix(line, "commit "); ok {
+			current = hash
+			continue
+		}
+		if line == "" || !files[line] {
+			continue
+		}
+		if _, seen := commits[line]; !seen {
+			commits[line] = current
+		}
+	}
+
+	// Stop git once every file is found
+	if len(commits) == len(files) {
+		_ = cmd.Process.Kill()
+		_, _ = io.Copy(io.Discard, stdout)
+		_ = cmd.Wait()
+		return commits, nil
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return commits, scanner.Err()
+}
+
+// numstat is the line counts of a file in a diff
+type numstat struct {
+	added   int
+	deleted int
+	binary  bool
+}
+
+// diffNumstat returns the added and deleted lines of the files changed
+// between a commit and HEAD, keyed by path
+func diffNumstat(commit string, paths []string) (map[string]numstat, error) {
+	args := append([]string{"diff", "--no-renames", "--numstat", "-z", commit, "HEAD", "--"}, paths...)
+	output, err := git(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are "added\tdeleted\tpath\x00"; binary files count "-"
+	stats := make(map[string]numstat)
+	for _, record := range strings.Split(output, "\x00") {
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		var stat numstat
+		if fields[0] == "-" {
+			stat.binary = true
+		} else {
+			fmt.Sscan(fields[0], &stat.added)
+			fmt.Sscan(fields[1], &stat.deleted)
+		}
+		stats[fields[2]] = stat
+	}
+	return stats, nil
+}
+
+// diffRenamed counts the lines changed between a file at commit and the
+// file it was renamed to at HEAD
+func diffRenamed(commit, old, current string) (numstat, error) {
+	output, err := git("diff", "--numstat", commit+":"+old, "HEAD:"+current)
+	if err != nil {
+		return numstat{}, err
+	}
+	// The record is "added\tdeleted\told => current"
+	var stat numstat
+	fields := strings.SplitN(strings.TrimSpace(output), "\t", 3)
+	switch {
+	case len(fields) < 2:
+	case fields[0] == "-":
+		stat.binary = true
+	default:
+		fmt.Sscan(fields[0], &stat.added)
+		fmt.Sscan(fields[1], &stat.deleted)
+	}
+	return stat, nil
+}
+
+// upstreamAt returns the path an English page had at commit, following its
+// renames since, or "" when it didn't exist then
+func upstreamAt(commit, upstream string) (string, error) {
+	if existsAt(commit, upstream) {
+		return upstream, nil
+	}
+	output, err := git("log", "--follow", "-M", "--diff-filter=R", "--name-status", "--format=", commit+"..HEAD", "--", upstream)
+	if err != nil {
+		return "", err
+	}
+	// Renames are listed newest first, so the last one names the page at
+	// commit: R<score>\told\tnew
+	at := ""
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Split(line, "\t"); len(fields) == 3 && strings.HasPrefix(fields[0], "R") {
+			at = fields[1]
+		}
+	}
+	if at == "" || !existsAt(commit, at) {
+		return "", nil
+	}
+	return at, nil
+}
+
+// existsAt reports whether a file exists at commit
+func existsAt(commit, p string) bool {
+	_, err := git("cat-file", "-e", commit+":"+p)
+	return err == nil
+}
+
+// CurrentBranch returns the branch checked out in the working directory
+func CurrentBranch() (string, error) {
+	output, err := git("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GitDir returns the git directory of the repository in the working
+// directory
+func GitDir() (string, error) {
+	output, err := git("rev-parse", "--git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// BranchExists reports whether a local branch exists
+func BranchExists(branch string) bool {
+	_, err := git("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// HasStagedChanges reports whether the index has changes to commit
+func HasStagedChanges() bool {
+	cmd := exec.Command("git", "diff", "--cached", "--quiet")
+	return cmd.Run() != nil
+}
+
+// RemoteURL returns the URL of a git remote
+func RemoteURL(remote string) (string, error) {
+	output, err := git("remote", "get-url", remote)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// Remotes returns the names of the git remotes of the repository
+func Remotes() ([]string, error) {
+	output, err := git("remote")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// CommitTimes returns the commit time of each commit
+func CommitTimes(hashes []string) (map[string]time.Time, error) {
+	times := make(map[string]time.Time, len(hashes))
+	if len(hashes) == 0 {
+		return times, nil
+	}
+	output, err := git(append([]string{"show", "-s", "--format=%H %ct"}, hashes...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		hash, seconds, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		unix, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			continue
+		}
+		times[hash] = time.Unix(unix, 0)
+	}
+	return times, nil
+}
+
+// GitConfig returns a git configuration value, empty when it isn't set
+func GitConfig(key string) string {
+	output, err := git("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// UncommittedFiles returns the files under a directory with uncommitted
+// changes, untracked files included and deleted files left out
+func UncommittedFiles(dir string) ([]string, error) {
+	output, err := git("status", "--porcelain", "--untracked-files=all", "--", dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 4 || strings.Contains(line[:2], "D") {
+			continue
+		}
+		file := line[3:]
+		if _, renamed, ok := strings.Cut(file, " -> "); ok {
+			file = renamed
+		}
+		files = append(files, strings.Trim(file, `"`))
+	}
+	return files, nil
+}
+
+// BranchFiles returns the files under a directory changed on the current
+// branch since it forked from base, with the uncommitted ones, leaving
+// deleted files out
+func BranchFiles(dir, base string) ([]string, error) {
+	output, err := git("diff", "--name-only", "--diff-filter=d", base+"...HEAD", "--", dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff the branch against %s: %w", base, err)
+	}
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	uncommitted, err := UncommittedFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range uncommitted {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
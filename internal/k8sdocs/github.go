@@ -0,0 +1,303 @@
+package k8sdocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultAPIURL is the GitHub API, unless GITHUB_API_URL names another one
+const defaultAPIURL = "https://api.github.com"
+
+// githubClient sends the requests to the GitHub API
+var githubClient = &http.Client{Timeout: 30 * time.Second}
+
+// Rate limits of the GitHub API up to maxRateLimitWait are waited out, up
+// to maxRateLimitRetries times per request
+const (
+	maxRateLimitWait    = time.Minute
+	maxRateLimitRetries = 3
+)
+
+// configuredToken is the GitHub token of the configuration
+var configuredToken string
+
+// token caches the GitHub token and where it came from once found
+var token, tokenSource string
+
+// nextLink matches the link to the next page in a Link header
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// SetGitHubToken sets the GitHub token of the configuration, used when
+// GITHUB_TOKEN and GH_TOKEN aren't set
+func SetGitHubToken(value string) {
+	configuredToken = value
+}
+
+// githubToken returns the token to call the GitHub API with and where it
+// came from: the environment, the configuration, or the GitHub CLI
+func githubToken() (string, string, error) {
+	if token != "" {
+		return token, tokenSource, nil
+	}
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			token, tokenSource = value, name
+			return token, tokenSource, nil
+		}
+	}
+	if configuredToken != "" {
+		token, tokenSource = configuredToken, "k8s.github_token"
+		return token, tokenSource, nil
+	}
+	if output, err := exec.Command("gh", "auth", "token").Output(); err == nil {
+		if value := strings.TrimSpace(string(output)); value != "" {
+			token, tokenSource = value, "gh auth token"
+			return token, tokenSource, nil
+		}
+	}
+	return "", "", fmt.Errorf("no GitHub token found; set GITHUB_TOKEN, set k8s.github_token in .mm.yaml, or log in with gh auth login")
+}
+
+// APIError is an error response of the GitHub API
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RateLimitReset is when the rate limit resets, zero unless the
+	// request was rate limited
+	RateLimitReset time.Time
+}
+
+func (e *APIError) Error() string {
+	if !e.RateLimitReset.IsZero() {
+		return fmt.Sprintf("GitHub API rate limit exceeded, resets at %s", e.RateLimitReset.Format("15:04:05"))
+	}
+	return fmt.Sprintf("GitHub API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// PullRequestOptions describes a pull request to open
+type PullRequestOptions struct {
+	// Head is the branch with the changes, as owner:branch for a fork
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft"`
+}
+
+// PullRequest is a pull request of the website on GitHub
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreatePullRequest opens a pull request on the website repository through
+// the GitHub API
+func CreatePullRequest(opts PullRequestOptions) (*PullRequest, error) {
+	var pr PullRequest
+	if err := githubJSON(http.MethodPost, "repos/"+Repository+"/pulls", opts, &pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// CurrentUser returns the login of the user the GitHub token belongs to
+func CurrentUser() (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := githubJSON(http.MethodGet, "user", nil, &user); err != nil {
+		return "", fmt.Errorf("failed to get the GitHub user: %w", err)
+	}
+	return user.Login, nil
+}
+
+// githubJSON sends a request to the GitHub API with a JSON body, unless
+// body is nil, and decodes the response into result
+func githubJSON(method, endpoint string, body, result any) error {
+	data, _, err := githubRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+	return nil
+}
+
+// githubPages gets every page of a list of the GitHub API, following the
+// Link headers, and hands each one to page
+func githubPages(endpoint string, page func(data []byte) error) error {
+	for endpoint != "" {
+		data, header, err := githubRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		if err := page(data); err != nil {
+			return fmt.Errorf("failed to parse GitHub response: %w", err)
+		}
+		endpoint = ""
+		if match := nextLink.FindStringSubmatch(header.Get("Link")); match != nil {
+			endpoint = match[1]
+		}
+	}
+	return nil
+}
+
+// githubGraphQL runs a GraphQL query and decodes its data into result
+func githubGraphQL(query string, variables map[string]any, result any) error {
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	body := map[string]any{"query": query, "variables": variables}
+	if err := githubJSON(http.MethodPost, graphQLURL(), body, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		var messages []string
+		for _, e := range response.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("GitHub GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+	return nil
+}
+
+// githubRequest sends a request to the GitHub API, to an endpoint relative
+// to the API or a full URL, and returns the body and headers of the
+// response
+func githubRequest(method, endpoint string, body any) ([]byte, http.Header, error) {
+	if offline {
+		return nil, nil, ErrOffline
+	}
+	auth, source, err := githubToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target := endpoint
+	if !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "http://") {
+		target = apiURL() + "/" + strings.TrimPrefix(endpoint, "/")
+	}
+	var payload []byte
+	if body != nil {
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequest(method, target, reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+auth)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		req.Header.Set("User-Agent", "mm")
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := githubClient.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reach GitHub: %w", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read GitHub response: %w", err)
+		}
+		if resp.StatusCode < 300 {
+			return data, resp.Header, nil
+		}
+
+		apiErr := responseError(resp, data)
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, nil, fmt.Errorf("GitHub rejected the token from %s: %w", source, apiErr)
+		}
+		// Short rate limits, like the secondary ones for many requests in
+		// a row, are waited out; longer ones fail
+		wait := time.Until(apiErr.RateLimitReset)
+		if apiErr.RateLimitReset.IsZero() || wait > maxRateLimitWait || attempt == maxRateLimitRetries {
+			return nil, nil, apiErr
+		}
+		wait = max(wait, time.Second)
+		fmt.Fprintf(os.Stderr, "Warning: GitHub API rate limit hit, retrying in %s\n", wait.Round(time.Second))
+		time.Sleep(wait)
+	}
+}
+
+// apiURL returns the base URL of the REST API, without a trailing slash
+func apiURL() string {
+	base := os.Getenv("GITHUB_API_URL")
+	if base == "" {
+		base = defaultAPIURL
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// graphQLURL returns the GraphQL endpoint: GITHUB_GRAPHQL_URL, as set in
+// GitHub Actions, or the one next to the REST API. GitHub Enterprise
+// Server serves REST under /api/v3 and GraphQL at /api/graphql.
+func graphQLURL() string {
+	if endpoint := os.Getenv("GITHUB_GRAPHQL_URL"); endpoint != "" {
+		return endpoint
+	}
+	return strings.TrimSuffix(apiURL(), "/v3") + "/graphql"
+}
+
+// responseError builds the error of a failed response from its message,
+// its validation errors and its rate limit headers
+func responseError(resp *http.Response, data []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	var body struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(data, &body) == nil && body.Message != "" {
+		apiErr.Message = body.Message
+		for _, e := range body.Errors {
+			if e.Message != "" {
+				apiErr.Message += "; " + e.Message
+			}
+		}
+	}
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		// The primary limit says when it resets, secondary limits how long
+		// to wait
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			apiErr.RateLimitReset = time.Now().Add(time.Duration(seconds) * time.Second)
+		} else if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				apiErr.RateLimitReset = time.Unix(reset, 0)
+			}
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,136 @@
+package k8sdocs
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		api, graphql string
+		want         string
+	}{
+		{"", "", "https://api.github.com/graphql"},
+		{"https://api.github.com/", "", "https://api.github.com/graphql"},
+		{"https://github.example.com/api/v3", "", "https://github.example.com/api/graphql"},
+		{"https://github.example.com/api/v3/", "", "https://github.example.com/api/graphql"},
+		{"https://github.example.com/api/v3", "https://gql.example.com/graphql", "https://gql.example.com/graphql"},
+	}
+	for _, tt := range tests {
+		t.Setenv("GITHUB_API_URL", tt.api)
+		t.Setenv("GITHUB_GRAPHQL_URL", tt.graphql)
+		if got := graphQLURL(); got != tt.want {
+			t.Errorf("graphQLURL() with GITHUB_API_URL=%q = %q, want %q", tt.api, got, tt.want)
+		}
+	}
+}
+
+// fakeGitHub serves a GitHub Enterprise Server API for the test and sets
+// the environment to call it
+func fakeGitHub(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("GITHUB_API_URL", server.URL+"/api/v3")
+	t.Setenv("GITHUB_GRAPHQL_URL", "")
+	t.Setenv("GITHUB_TOKEN", "secret")
+	token, tokenSource = "", ""
+	t.Cleanup(func() { token, tokenSource = "", "" })
+}
+
+func TestGitHubGraphQL(t *testing.T) {
+	fakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Query string `json:"query"`
+		}
+		_ = json.Unmarshal(body, &request)
+		if strings.Contains(request.Query, "broken") {
+			_, _ = io.WriteString(w, `{"errors": [{"message": "Field 'broken' doesn't exist"}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data": {"viewer": {"login": "octocat"}}}`)
+	})
+
+	var result struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	if err := githubGraphQL("{ viewer { login } }", nil, &result); err != nil {
+		t.Fatalf("githubGraphQL() error = %v", err)
+	}
+	if result.Viewer.Login != "octocat" {
+		t.Errorf("githubGraphQL() login = %q, want octocat", result.Viewer.Login)
+	}
+
+	err := githubGraphQL("{ broken }", nil, &result)
+	if err == nil || !strings.Contains(err.Error(), "Field 'broken' doesn't exist") {
+		t.Errorf("githubGraphQL() error = %v, want the GraphQL error", err)
+	}
+}
+
+func TestGitHubRequestErrors(t *testing.T) {
+	fakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/user":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"message": "Bad credentials"}`)
+		case "/api/v3/repos/kubernetes/website/pulls":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"message": "Validation Failed", "errors": [{"message": "A pull request already exists"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	if _, err := CurrentUser(); err == nil || !strings.Contains(err.Error(), "GitHub rejected the token from GITHUB_TOKEN") {
+		t.Errorf("CurrentUser() error = %v, want the rejected token", err)
+	}
+
+	_, err := CreatePullRequest(PullRequestOptions{Head: "me:branch", Base: "main", Title: "t"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity ||
+		apiErr.Message != "Validation Failed; A pull request already exists" {
+		t.Errorf("CreatePullRequest() error = %v, want the validation errors", err)
+	}
+}
+
+func TestGitHubPages(t *testing.T) {
+	var serverURL string
+	fakeGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+serverURL+`/api/v3/items?page=2>; rel="next", <`+serverURL+`/api/v3/items?page=2>; rel="last"`)
+			_, _ = io.WriteString(w, `[1, 2]`)
+			return
+		}
+		_, _ = io.WriteString(w, `[3]`)
+	})
+	serverURL = strings.TrimSuffix(apiURL(), "/api/v3")
+
+	var items []int
+	err := githubPages("items", func(data []byte) error {
+		var page []int
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil || len(items) != 3 {
+		t.Errorf("githubPages() = %v, %v, want the items of both pages", items, err)
+	}
+}
@@ -0,0 +1,130 @@
+package k8sdocs
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/checker"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed glossary/*.yaml
+var embeddedGlossaries embed.FS
+
+// GlossaryDir is where the website defines its glossary terms, one page per
+// term with the same file name in every localization
+const GlossaryDir = "docs/reference/glossary/"
+
+// EmbeddedGlossary returns the glossary of a localization shipped with mm,
+// nil when there is none
+func EmbeddedGlossary(lang string) *checker.Glossary {
+	data, err := embeddedGlossaries.ReadFile("glossary/" + lang + ".yaml")
+	if err != nil {
+		return nil
+	}
+	var glossary checker.Glossary
+	if err := yaml.Unmarshal(data, &glossary); err != nil {
+		return nil
+	}
+	return &glossary
+}
+
+// GlossaryFromRepo builds a glossary from the glossary pages of the website
+// checked out in the working directory, pairing the title of each English
+// term with the title of its translation
+func GlossaryFromRepo(lang string) (*checker.Glossary, error) {
+	localizedDir := LocalizedDir(lang) + GlossaryDir
+	entries, err := os.ReadDir(filepath.FromSlash(localizedDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", localizedDir, err)
+	}
+
+	glossary := &checker.Glossary{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") || entry.Name() == "_index.md" {
+			continue
+		}
+		source := frontMatterValue(UpstreamDir+GlossaryDir+entry.Name(), "title")
+		translated := stripOriginal(frontMatterValue(localizedDir+entry.Name(), "title"))
+		if source == "" || translated == "" {
+			continue
+		}
+		glossary.Terms = append(glossary.Terms, checker.GlossaryTerm{Source: source, Approved: translated})
+	}
+	return glossary, nil
+}
+
+// MergeGlossaries returns the terms of base with those of override added,
+// replacing the terms with the same source; forbidden variants of replaced
+// terms are kept
+func MergeGlossaries(base, override *checker.Glossary) *checker.Glossary {
+	merged := &checker.Glossary{}
+	index := make(map[string]int)
+	for _, glossary := range []*checker.Glossary{base, override} {
+		if glossary == nil {
+			continue
+		}
+		for _, term := range glossary.Terms {
+			key := strings.ToLower(term.Source)
+			i, exists := index[key]
+			if !exists || term.Source == "" {
+				index[key] = len(merged.Terms)
+				merged.Terms = append(merged.Terms, term)
+				continue
+			}
+			previous := merged.Terms[i]
+			if len(term.Forbidden) == 0 {
+				term.Forbidden = previous.Forbidden
+			}
+			if term.Note == "" {
+				term.Note = previous.Note
+			}
+			merged.Terms[i] = term
+		}
+	}
+	return merged
+}
+
+// LookupTerm returns the terms whose source, translation or forbidden
+// variants contain query, ignoring case; exact matches come first
+func LookupTerm(glossary *checker.Glossary, query string) []checker.GlossaryTerm {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var exact, partial []checker.GlossaryTerm
+	for _, term := range glossary.Terms {
+		forms := append([]string{term.Source, term.Approved}, term.Forbidden...)
+		matched, isExact := false, false
+		for _, form := range forms {
+			form = strings.ToLower(form)
+			if form == query {
+				isExact = true
+			}
+			if strings.Contains(form, query) {
+				matched = true
+			}
+		}
+		switch {
+		case isExact:
+			exact = append(exact, term)
+		case matched:
+			partial = append(partial, term)
+		}
+	}
+	sort.SliceStable(partial, func(i, j int) bool {
+		return len(partial[i].Source) < len(partial[j].Source)
+	})
+	return append(exact, partial...)
+}
+
+// originalPattern matches the original term some translations add to their
+// title, e.g. 容器运行时（Container Runtime）
+var originalPattern = regexp.MustCompile(`\s*[（(][^）)]*[）)]\s*$`)
+
+// stripOriginal removes the original term from a translated title
+func stripOriginal(title string) string {
+	return strings.TrimSpace(originalPattern.ReplaceAllString(title, ""))
+}
@@ -0,0 +1,166 @@
+package k8sdocs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hugoConfigs are the site configurations the website has used, newest
+// first
+var hugoConfigs = []string{"hugo.toml", "config.toml"}
+
+// maxHugoErrorLines limits the Hugo output a failed build reports
+const maxHugoErrorLines = 20
+
+// HasHugo reports whether Hugo is installed
+func HasHugo() bool {
+	_, err := exec.LookPath("hugo")
+	return err == nil
+}
+
+// BuildSite builds the website with hugo --minify into a temporary
+// directory, in English and one localization only to keep it fast, and
+// returns the localized docs pages that didn't render. Drafts and pages
+// that set their own URL aren't looked for, nor blog posts, whose URLs
+// come from their dates.
+func BuildSite(lang string, pages []string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "mm-hugo-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	configs, err := scopedConfig(dir, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := filepath.Join(dir, "public")
+	cmd := exec.Command("hugo", "--minify", "--config", configs, "--destination", destination)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if len(lines) > maxHugoErrorLines {
+			lines = lines[len(lines)-maxHugoErrorLines:]
+		}
+		return nil, fmt.Errorf("hugo --minify failed: %w\n%s", err, strings.Join(lines, "\n"))
+	}
+
+	var missing []string
+	for _, page := range pages {
+		rest, ok := strings.CutPrefix(page, LocalizedDir(lang)+"docs/")
+		if !ok || !strings.HasSuffix(rest, ".md") {
+			continue
+		}
+		if frontMatterValue(page, "draft") == "true" || frontMatterValue(page, "url") != "" || frontMatterValue(page, "slug") != "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(destination, lang, filepath.FromSlash(pageDir("docs/"+rest)), "index.html")); err != nil {
+			missing = append(missing, page)
+		}
+	}
+	return missing, nil
+}
+
+// scopedConfig writes a Hugo configuration into dir that disables every
+// localization but English and lang, and returns the --config value that
+// layers it on top of the site configuration
+func scopedConfig(dir, lang string) (string, error) {
+	siteConfig := ""
+	for _, name := range hugoConfigs {
+		if _, err := os.Stat(name); err == nil {
+			siteConfig = name
+			break
+		}
+	}
+	if siteConfig == "" {
+		return "", fmt.Errorf("no Hugo configuration found (%s)", strings.Join(hugoConfigs, ", "))
+	}
+
+	entries, err := os.ReadDir("content")
+	if err != nil {
+		return "", fmt.Errorf("failed to read content: %w", err)
+	}
+	var disabled []string
+	for _, entry := range entries {
+		if name := entry.Name(); entry.IsDir() && name != "en" && name != lang {
+			disabled = append(disabled, fmt.Sprintf("%q", name))
+		}
+	}
+	override := filepath.Join(dir, "mm.toml")
+	content := "disableLanguages = [" + strings.Join(disabled, ", ") + "]\n"
+	if err := os.WriteFile(override, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write Hugo configuration: %w", err)
+	}
+	return siteConfig + "," + override, nil
+}
+
+// pageDir returns the directory a page of a content directory renders to,
+// e.g. docs/concepts/ for docs/concepts/_index.md
+func pageDir(rest string) string {
+	dir, name := path.Split(strings.TrimSuffix(rest, path.Ext(rest)))
+	if name != "_index" && name != "index" {
+		dir += name + "/"
+	}
+	return dir
+}
+
+// LocalizedPageURL returns the URL path the site serves a localized page
+// at. Pages that set their own url are served there; blog posts need a date
+// and a slug for their dated URL, and fall back to the blog otherwise.
+func LocalizedPageURL(page, lang string) string {
+	if url := frontMatterValue(page, "url"); url != "" {
+		return urlPath(url)
+	}
+	rest := strings.TrimPrefix(page, LocalizedDir(lang))
+	slug := frontMatterValue(page, "slug")
+	if SectionOf(rest) == SectionBlog {
+		date := frontMatterValue(page, "date")
+		if len(date) < len("2006-01-02") || slug == "" {
+			return urlPath(lang + "/blog")
+		}
+		return urlPath(lang + "/blog/" + strings.ReplaceAll(date[:10], "-", "/") + "/" + slug)
+	}
+	dir := pageDir(rest)
+	if slug != "" && path.Base(strings.TrimSuffix(page, path.Ext(page))) != "_index" {
+		dir = path.Dir(strings.TrimSuffix(dir, "/")) + "/" + slug
+	}
+	return urlPath(lang + "/" + dir)
+}
+
+// StartPreview starts hugo server for English and one localization only,
+// which rebuilds the site and reloads the browser whenever a page is
+// saved. stop waits for the server to exit and removes its configuration.
+func StartPreview(lang, bind string, port int) (stop func() error, err error) {
+	dir, err := os.MkdirTemp("", "mm-hugo-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview directory: %w", err)
+	}
+	configs, err := scopedConfig(dir, lang)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	cmd := exec.Command("hugo", "server", "--config", configs, "--bind", bind, "--port", strconv.Itoa(port), "--navigateToChanged")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to start hugo server: %w", err)
+	}
+	return func() error {
+		defer os.RemoveAll(dir)
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("hugo server failed: %w", err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,319 @@
+package k8sdocs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HunkKind is how a change of an English page can reach its translation
+type HunkKind int
+
+// Kinds of hunks
+const (
+	// HunkProse changes text, which needs translating by hand
+	HunkProse HunkKind = iota
+	// HunkCode changes a fenced code block, such as a YAML manifest, which
+	// translations keep as in English
+	HunkCode
+	// HunkLinks changes the targets of links and nothing else
+	HunkLinks
+)
+
+// String names a kind of hunk
+func (k HunkKind) String() string {
+	switch k {
+	case HunkCode:
+		return "code block"
+	case HunkLinks:
+		return "link targets"
+	}
+	return "prose"
+}
+
+// Hunk is a block of an English page that changed, with how it can be
+// brought to the translation
+type Hunk struct {
+	ParagraphChange
+	Kind HunkKind
+	// Links are the link targets a HunkLinks changes
+	Links []LinkChange
+	// After is the code block before an added block in the English page,
+	// which the block is added after in the translation; empty when the
+	// block before it is prose
+	After string
+}
+
+// LinkChange is a link target that changed
+type LinkChange struct {
+	Old string
+	New string
+}
+
+// ErrProseHunk is returned when applying a hunk that needs translating
+var ErrProseHunk = errors.New("prose changes need translating by hand")
+
+// linkTarget matches the target of an inline markdown link or image, and
+// of a link reference definition
+var linkTarget = regexp.MustCompile(`\]\(\s*<?([^()\s<>]+)>?(?:\s+"[^"]*")?\s*\)|(?m)^[ \t]*\[[^\]]+\]:[ \t]*<?([^\s<>]+)>?`)
+
+// UpstreamHunks returns the blocks of the English original of a localized
+// page that changed since the page was last updated, paragraphs with the
+// fenced code blocks kept whole, with the kind of each
+func UpstreamHunks(localized, lang string) (Page, []Hunk, error) {
+	page, versions, err := upstreamVersions(localized, lang)
+	if err != nil || versions == nil {
+		return page, nil, err
+	}
+	current := Blocks(versions.after)
+	changes := diffBlocks(Blocks(versions.before), current)
+	versions.findTranslations(changes)
+
+	hunks := make([]Hunk, 0, len(changes))
+	for _, change := range changes {
+		hunk := Hunk{ParagraphChange: change, Kind: HunkProse}
+		switch {
+		case isCodeBlock(change.Old.Text) || isCodeBlock(change.New.Text):
+			// A block that became or stopped being code changes prose too
+			if (change.Old.Text == "" || isCodeBlock(change.Old.Text)) &&
+				(change.New.Text == "" || isCodeBlock(change.New.Text)) {
+				hunk.Kind = HunkCode
+			}
+		case change.Old.Text != "" && change.New.Text != "":
+			if links := changedLinks(change.Old.Text, change.New.Text); len(links) > 0 {
+				hunk.Kind = HunkLinks
+				hunk.Links = links
+			}
+		}
+		if hunk.Kind == HunkCode && change.Old.Text == "" {
+			for _, block := range current {
+				if block.Line >= change.New.Line {
+					break
+				}
+				hunk.After = block.Text
+			}
+			if !isCodeBlock(hunk.After) {
+				hunk.After = ""
+			}
+		}
+		hunks = append(hunks, hunk)
+	}
+	return page, hunks, nil
+}
+
+// Blocks splits a page into paragraphs like Paragraphs, but keeps each
+// fenced code block whole, blank lines and all
+func Blocks(text string) []Paragraph {
+	var blocks []Paragraph
+	var block []string
+	start := 0
+	fence := ""
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" && trimmed == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, Paragraph{Text: strings.Join(block, "\n"), Line: start + 1})
+				block = nil
+			}
+			continue
+		}
+		if len(block) == 0 {
+			start = n
+		}
+		block = append(block, line)
+		switch {
+		case fence == "":
+			fence = openingFence(trimmed)
+		case strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "":
+			fence = ""
+		}
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, Paragraph{Text: strings.Join(block, "\n"), Line: start + 1})
+	}
+	return blocks
+}
+
+// openingFence returns the fence a line opens a code block with, ``` or
+// ~~~ or longer, empty when it opens none
+func openingFence(line string) string {
+	for _, mark := range []string{"`", "~"} {
+		fence := line[:len(line)-len(strings.TrimLeft(line, mark))]
+		// Backticks in the info string make an inline code span instead
+		if len(fence) >= 3 && !(mark == "`" && strings.Contains(line[len(fence):], mark)) {
+			return fence
+		}
+	}
+	return ""
+}
+
+// isCodeBlock reports whether a block is a fenced code block and nothing
+// else
+func isCodeBlock(block string) bool {
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+	if len(lines) < 2 {
+		return false
+	}
+	fence := openingFence(strings.TrimSpace(lines[0]))
+	last := strings.TrimSpace(lines[len(lines)-1])
+	return fence != "" && strings.HasPrefix(last, fence) && strings.Trim(last, fence[:1]) == ""
+}
+
+// splitLinks returns a text with its link targets left out, and the
+// targets
+func splitLinks(text string) (string, []string) {
+	var rest strings.Builder
+	var targets []string
+	last := 0
+	for _, match := range linkTarget.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		if start < 0 {
+			start, end = match[4], match[5]
+		}
+		rest.WriteString(text[last:start])
+		targets = append(targets, text[start:end])
+		last = end
+	}
+	rest.WriteString(text[last:])
+	return rest.String(), targets
+}
+
+// changedLinks returns the link targets that changed between two versions
+// of a paragraph, none when anything else changed too
+func changedLinks(before, after string) []LinkChange {
+	oldRest, oldTargets := splitLinks(before)
+	newRest, newTargets := splitLinks(after)
+	if oldRest != newRest || len(oldTargets) != len(newTargets) {
+		return nil
+	}
+	var links []LinkChange
+	for i := range oldTargets {
+		if oldTargets[i] != newTargets[i] {
+			links = append(links, LinkChange{Old: oldTargets[i], New: newTargets[i]})
+		}
+	}
+	return links
+}
+
+// ApplyHunk makes the change of a code block or link targets hunk to the
+// translation of a page in a localization. It returns ErrProseHunk for
+// prose, and why it skips the hunk when the translation doesn't have what
+// changed as the English page had it.
+func ApplyHunk(translated string, hunk Hunk, lang string) (string, error) {
+	switch hunk.Kind {
+	case HunkCode:
+		return applyCode(translated, hunk)
+	case HunkLinks:
+		return applyLinks(translated, hunk, lang)
+	}
+	return "", ErrProseHunk
+}
+
+// applyCode changes, adds or removes a code block the translation has as
+// in English
+func applyCode(translated string, hunk Hunk) (string, error) {
+	if hunk.Old.Text == "" {
+		if hunk.After == "" {
+			return "", fmt.Errorf("the block before the new code block is prose; add it by hand")
+		}
+		at, err := findOnce(translated, hunk.After, "the code block before the new one")
+		if err != nil {
+			return "", err
+		}
+		at += len(hunk.After)
+		return translated[:at] + "\n\n" + hunk.New.Text + translated[at:], nil
+	}
+
+	at, err := findOnce(translated, hunk.Old.Text, "the old code block")
+	if err != nil {
+		return "", err
+	}
+	end := at + len(hunk.Old.Text)
+	if hunk.New.Text != "" {
+		return translated[:at] + hunk.New.Text + translated[end:], nil
+	}
+	// Drop the blank lines after the removed block along with it
+	rest := strings.TrimLeft(translated[end:], "\n")
+	if rest == "" {
+		return strings.TrimRight(translated[:at], "\n") + "\n", nil
+	}
+	return translated[:at] + rest, nil
+}
+
+// applyLinks changes the link targets of the translated paragraph, and of
+// the English paragraph it keeps in a comment. Without the paragraph, each
+// old target is changed where the page links to it, when it does only once.
+func applyLinks(translated string, hunk Hunk, lang string) (string, error) {
+	if strings.Count(translated, hunk.Old.Text) == 1 {
+		translated = strings.Replace(translated, hunk.Old.Text, hunk.New.Text, 1)
+	}
+	if hunk.Translation != "" && strings.Count(translated, hunk.Translation) == 1 {
+		at := strings.Index(translated, hunk.Translation)
+		end := at + len(hunk.Translation)
+		if retargeted, changed := retarget(hunk.Translation, hunk.Links, lang); changed > 0 {
+			return translated[:at] + retargeted + translated[end:], nil
+		}
+	}
+
+	for _, link := range hunk.Links {
+		_, count := retarget(translated, []LinkChange{link}, lang)
+		switch {
+		case count == 0:
+			return "", fmt.Errorf("the translation doesn't link to %s", link.Old)
+		case count > 1:
+			return "", fmt.Errorf("the translation links to %s %d times", link.Old, count)
+		}
+	}
+	retargeted, _ := retarget(translated, hunk.Links, lang)
+	return retargeted, nil
+}
+
+// retarget changes the link targets of a text, as they are in English or
+// with the site-relative paths localized, and returns how many changed
+func retarget(text string, links []LinkChange, lang string) (string, int) {
+	var result strings.Builder
+	changed, last := 0, 0
+	for _, match := range linkTarget.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		if start < 0 {
+			start, end = match[4], match[5]
+		}
+		target := text[start:end]
+		for _, link := range links {
+			replacement := ""
+			switch {
+			case target == link.Old:
+				replacement = link.New
+			case strings.HasPrefix(link.Old, "/") && target == "/"+lang+link.Old:
+				replacement = link.New
+				if strings.HasPrefix(link.New, "/") {
+					replacement = "/" + lang + link.New
+				}
+			default:
+				continue
+			}
+			result.WriteString(text[last:start])
+			result.WriteString(replacement)
+			last = end
+			changed++
+			break
+		}
+	}
+	result.WriteString(text[last:])
+	return result.String(), changed
+}
+
+// findOnce returns where text is in a page, or an error when it isn't
+// there exactly once
+func findOnce(page, text, what string) (int, error) {
+	switch count := strings.Count(page, text); count {
+	case 0:
+		return 0, fmt.Errorf("%s isn't in the translation as in English", what)
+	case 1:
+		return strings.Index(page, text), nil
+	default:
+		return 0, fmt.Errorf("%s is in the translation %d times", what, count)
+	}
+}
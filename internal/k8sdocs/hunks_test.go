@@ -0,0 +1,188 @@
+package k8sdocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBlocks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Paragraph
+	}{
+		{"paragraphs", "one\ntwo\n\nthree\n", []Paragraph{{"one\ntwo", 1}, {"three", 4}}},
+		{"code block with blank lines", "text\n\n```yaml\na: 1\n\nb: 2\n```\n\nafter", []Paragraph{{"text", 1}, {"```yaml\na: 1\n\nb: 2\n```", 3}, {"after", 9}}},
+		{"tilde fence", "~~~\nx\n\ny\n~~~", []Paragraph{{"~~~\nx\n\ny\n~~~", 1}}},
+		{"longer closing fence", "````\n```\n\n````\nnext", []Paragraph{{"````\n```\n\n````\nnext", 1}}},
+		{"inline code span", "```inline``` text\n\nnext", []Paragraph{{"```inline``` text", 1}, {"next", 3}}},
+		{"indented fence", "1. Run:\n\n   ```shell\n   ls\n\n   ```", []Paragraph{{"1. Run:", 1}, {"   ```shell\n   ls\n\n   ```", 3}}},
+		{"windows line endings", "a\r\n\r\nb", []Paragraph{{"a", 1}, {"b", 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Blocks(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Blocks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCodeBlock(t *testing.T) {
+	tests := []struct {
+		block string
+		want  bool
+	}{
+		{"```yaml\na: 1\n```", true},
+		{"   ```\nls\n   ```", true},
+		{"~~~~\nx\n~~~~", true},
+		{"```\nunclosed", false},
+		{"Run:\n```\nls\n```", false},
+		{"```inline```", false},
+		{"text", false},
+	}
+	for _, tt := range tests {
+		if got := isCodeBlock(tt.block); got != tt.want {
+			t.Errorf("isCodeBlock(%q) = %v, want %v", tt.block, got, tt.want)
+		}
+	}
+}
+
+func TestChangedLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []LinkChange
+	}{
+		{"inline link", "See [a](/docs/a/) now.", "See [a](/docs/b/) now.", []LinkChange{{"/docs/a/", "/docs/b/"}}},
+		{"image with title", `![x](a.png "t")`, `![x](b.png "t")`, []LinkChange{{"a.png", "b.png"}}},
+		{"reference definition", "[ref]: https://a.io/", "[ref]: https://b.io/", []LinkChange{{"https://a.io/", "https://b.io/"}}},
+		{"one of two", "[a](/x/) and [b](/y/)", "[a](/x/) and [b](/z/)", []LinkChange{{"/y/", "/z/"}}},
+		{"text changed too", "See [a](/docs/a/).", "Read [a](/docs/b/).", nil},
+		{"link added", "See [a](/a/).", "See [a](/a/) [b](/b/).", nil},
+		{"nothing changed", "See [a](/a/).", "See [a](/a/).", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changedLinks(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changedLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyHunk(t *testing.T) {
+	const code = "```yaml\nname: old\n```"
+	const newCode = "```yaml\nname: new\n```"
+	const shell = "```shell\nls\n```"
+	linkHunk := func(translation string) Hunk {
+		return Hunk{
+			ParagraphChange: ParagraphChange{
+				Old:         Paragraph{Text: "Read the [guide](/docs/old/)."},
+				New:         Paragraph{Text: "Read the [guide](/docs/new/)."},
+				Translation: translation,
+			},
+			Kind:  HunkLinks,
+			Links: []LinkChange{{"/docs/old/", "/docs/new/"}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		translated string
+		hunk       Hunk
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "changed code block",
+			translated: "说明\n\n" + code + "\n\n结束\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: code}, New: Paragraph{Text: newCode}}, Kind: HunkCode},
+			want:       "说明\n\n" + newCode + "\n\n结束\n",
+		},
+		{
+			name:       "code block translated",
+			translated: "说明\n\n```yaml\nname: old # 名称\n```\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: code}, New: Paragraph{Text: newCode}}, Kind: HunkCode},
+			wantErr:    true,
+		},
+		{
+			name:       "code block twice",
+			translated: code + "\n\n" + code + "\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: code}, New: Paragraph{Text: newCode}}, Kind: HunkCode},
+			wantErr:    true,
+		},
+		{
+			name:       "removed code block",
+			translated: "说明\n\n" + code + "\n\n结束\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: code}}, Kind: HunkCode},
+			want:       "说明\n\n结束\n",
+		},
+		{
+			name:       "removed last code block",
+			translated: "说明\n\n" + code + "\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: code}}, Kind: HunkCode},
+			want:       "说明\n",
+		},
+		{
+			name:       "added after a code block",
+			translated: shell + "\n\n结束\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{New: Paragraph{Text: code}}, Kind: HunkCode, After: shell},
+			want:       shell + "\n\n" + code + "\n\n结束\n",
+		},
+		{
+			name:       "added after prose",
+			translated: "说明\n",
+			hunk:       Hunk{ParagraphChange: ParagraphChange{New: Paragraph{Text: code}}, Kind: HunkCode},
+			wantErr:    true,
+		},
+		{
+			name:       "links of the commented paragraph",
+			translated: "<!--\nRead the [guide](/docs/old/).\n-->\n阅读[指南](/zh-cn/docs/old/)。\n",
+			hunk:       linkHunk("阅读[指南](/zh-cn/docs/old/)。"),
+			want:       "<!--\nRead the [guide](/docs/new/).\n-->\n阅读[指南](/zh-cn/docs/new/)。\n",
+		},
+		{
+			name:       "link found once without the paragraph",
+			translated: "阅读[指南](/zh-cn/docs/old/)。\n\n参阅[其他](/zh-cn/docs/other/)。\n",
+			hunk:       linkHunk(""),
+			want:       "阅读[指南](/zh-cn/docs/new/)。\n\n参阅[其他](/zh-cn/docs/other/)。\n",
+		},
+		{
+			name:       "link found twice without the paragraph",
+			translated: "阅读[指南](/docs/old/)。\n\n再读[指南](/docs/old/)。\n",
+			hunk:       linkHunk(""),
+			wantErr:    true,
+		},
+		{
+			name:       "link not found",
+			translated: "阅读指南。\n",
+			hunk:       linkHunk(""),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyHunk(tt.translated, tt.hunk, "zh-cn")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ApplyHunk() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyHunk() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyHunk() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	prose := Hunk{ParagraphChange: ParagraphChange{Old: Paragraph{Text: "a"}, New: Paragraph{Text: "b"}}}
+	if _, err := ApplyHunk("a", prose, "zh-cn"); !errors.Is(err, ErrProseHunk) {
+		t.Errorf("ApplyHunk(prose) error = %v, want ErrProseHunk", err)
+	}
+}
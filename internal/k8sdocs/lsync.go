@@ -0,0 +1,358 @@
+// Package k8sdocs implements the localization tooling of the Kubernetes
+// website (kubernetes/website) in Go, so it runs on every platform without
+// the shell scripts of the repository.
+package k8sdocs
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// diffBatch is how many files are passed to a single git diff, keeping the
+// command line short on every platform
+const diffBatch = 100
+
+// Page is a localized page whose English original changed since the page
+// was last updated
+type Page struct {
+	// Path is the localized page, e.g. content/zh-cn/docs/home/_index.md
+	Path string
+	// Upstream is the English page, e.g. content/en/docs/home/_index.md
+	Upstream string
+	// SyncCommit is the English version the page was translated from: the
+	// commit recorded for it (see syncCommits), or its last commit
+	SyncCommit string
+	// Added and Deleted count the lines changed in the English page since
+	// SyncCommit
+	Added   int
+	Deleted int
+	// RenamedFrom is the path the English page had at SyncCommit when it
+	// was renamed since. The translation may still be at the old path,
+	// when Path isn't the localized path of Upstream.
+	RenamedFrom string
+}
+
+// syncedUpstream returns the path of the English page at SyncCommit
+func (p Page) syncedUpstream() string {
+	if p.RenamedFrom != "" {
+		return p.RenamedFrom
+	}
+	return p.Upstream
+}
+
+// LsyncResult is the outcome of comparing localized pages with their
+// English originals, like scripts/lsync.sh of kubernetes/website
+type LsyncResult struct {
+	// Outdated are the pages whose English original changed
+	Outdated []Page
+	// Orphaned are localized pages without an English page
+	Orphaned []string
+	// Untracked are localized pages never committed, which have no
+	// recorded English version
+	Untracked []string
+	// Checked counts the localized pages compared
+	Checked int
+}
+
+// Localize converts an English content path to the path of a localization
+func Localize(upstream, lang string) string {
+	if rest, ok := strings.CutPrefix(upstream, UpstreamDir); ok {
+		return LocalizedDir(lang) + rest
+	}
+	return upstream
+}
+
+// Upstream converts a localized content path to the English path
+func Upstream(localized, lang string) string {
+	if rest, ok := strings.CutPrefix(localized, LocalizedDir(lang)); ok {
+		return UpstreamDir + rest
+	}
+	return localized
+}
+
+// Lsync compares the localized pages under target, a file or directory of
+// the content/<lang> tree, with their English originals. It must run in the
+// root of the website repository.
+func Lsync(target, lang string) (*LsyncResult, error) {
+	target = slashPath(target)
+	localized, err := localizedPages(target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LsyncResult{Checked: len(localized)}
+	if len(localized) == 0 {
+		return result, nil
+	}
+
+	files := make(map[string]bool, len(localized))
+	for _, page := range localized {
+		files[page] = true
+	}
+	last, err := lastCommits([]string{target}, files)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := syncCommits(localized, last)
+	if err != nil {
+		return nil, err
+	}
+
+	// Diff the English pages of all localized pages synced at the same
+	// commit at once
+	byCommit := make(map[string][]string)
+	var removed []Orphan
+	for _, page := range localized {
+		upstream := Upstream(page, lang)
+		switch {
+		case !fileExists(upstream) && commits[page] != "":
+			removed = append(removed, Orphan{Path: page, Upstream: upstream})
+		case !fileExists(upstream):
+			result.Orphaned = append(result.Orphaned, page)
+		case commits[page] == "":
+			result.Untracked = append(result.Untracked, page)
+		default:
+			byCommit[commits[page]] = append(byCommit[commits[page]], page)
+		}
+	}
+
+	for commit, pages := range byCommit {
+		for start := 0; start < len(pages); start += diffBatch {
+			batch := pages[start:min(start+diffBatch, len(pages))]
+			upstreams := make([]string, len(batch))
+			for i, page := range batch {
+				upstreams[i] = Upstream(page, lang)
+			}
+
+			stats, err := diffNumstat(commit, upstreams)
+			if err != nil {
+				return nil, err
+			}
+			for i, page := range batch {
+				stat, changed := stats[upstreams[i]]
+				if !changed {
+					continue
+				}
+				renamedFrom := ""
+				if stat.deleted == 0 && !stat.binary && stat.added == lineCount(upstreams[i]) {
+					// Every line is new, as when the English page was
+					// renamed after the translation moved along
+					at, err := upstreamAt(commit, upstreams[i])
+					if err != nil {
+						return nil, err
+					}
+					if at != "" && at != upstreams[i] {
+						if stat, err = diffRenamed(commit, at, upstreams[i]); err != nil {
+							return nil, err
+						}
+						if stat.added == 0 && stat.deleted == 0 {
+							continue
+						}
+						renamedFrom = at
+					}
+				}
+				result.Outdated = append(result.Outdated, Page{
+					Path:        page,
+					Upstream:    upstreams[i],
+					SyncCommit:  commit,
+					Added:       stat.added,
+					Deleted:     stat.deleted,
+					RenamedFrom: renamedFrom,
+				})
+			}
+		}
+	}
+
+	// Translations whose English page was renamed are compared with the
+	// page at its new path, to be moved along
+	if len(removed) > 0 {
+		if err := traceOrphans(removed); err != nil {
+			return nil, err
+		}
+		for _, orphan := range removed {
+			page, ok, err := renamedPage(orphan, commits[orphan.Path])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				result.Orphaned = append(result.Orphaned, orphan.Path)
+				continue
+			}
+			result.Outdated = append(result.Outdated, page)
+		}
+		sort.Strings(result.Orphaned)
+	}
+
+	sort.Slice(result.Outdated, func(i, j int) bool {
+		return result.Outdated[i].Path < result.Outdated[j].Path
+	})
+	return result, nil
+}
+
+// renamedPage compares a translation whose English page was renamed with
+// the page at its new path. It reports false when the English
+// page was deleted, or its path at commit isn't found.
+func renamedPage(orphan Orphan, commit string) (Page, bool, error) {
+	if !orphan.Moved() {
+		return Page{}, false, nil
+	}
+	at, err := upstreamAt(commit, orphan.MovedTo)
+	if err != nil {
+		return Page{}, false, err
+	}
+	// Renames that change much of the page escape git log --follow
+	if at == "" && existsAt(commit, orphan.Upstream) {
+		at = orphan.Upstream
+	}
+	if at == "" {
+		return Page{}, false, nil
+	}
+	stat, err := diffRenamed(commit, at, orphan.MovedTo)
+	if err != nil {
+		return Page{}, false, err
+	}
+	page := Page{
+		Path:       orphan.Path,
+		Upstream:   orphan.MovedTo,
+		SyncCommit: commit,
+		Added:      stat.added,
+		Deleted:    stat.deleted,
+	}
+	// The English page may have been renamed before the translation was
+	// last synced, and only the translation is left to move
+	if at != orphan.MovedTo {
+		page.RenamedFrom = at
+	}
+	return page, true, nil
+}
+
+// UpstreamDiff returns the changes of the English original of a localized
+// page since the page was last updated, as a unified diff. The diff is
+// empty when the page is up to date.
+func UpstreamDiff(localized, lang string) (Page, string, error) {
+	localized = slashPath(localized)
+	page := Page{Path: localized, Upstream: Upstream(localized, lang)}
+	previous := ""
+	if !fileExists(page.Upstream) {
+		previous = page.Upstream
+		orphans := []Orphan{{Path: localized, Upstream: page.Upstream}}
+		if err := traceOrphans(orphans); err != nil {
+			return page, "", err
+		}
+		if !orphans[0].Moved() {
+			return page, "", fmt.Errorf("%s has no English page (%s was deleted)", localized, page.Upstream)
+		}
+		page.Upstream = orphans[0].MovedTo
+	}
+
+	last, err := lastCommits([]string{localized}, map[string]bool{localized: true})
+	if err != nil {
+		return page, "", err
+	}
+	commits, err := syncCommits([]string{localized}, last)
+	if err != nil {
+		return page, "", err
+	}
+	page.SyncCommit = commits[localized]
+	if page.SyncCommit == "" {
+		return page, "", fmt.Errorf("%s is not committed yet", localized)
+	}
+
+	at, err := upstreamAt(page.SyncCommit, page.Upstream)
+	if err != nil {
+		return page, "", err
+	}
+	if at == "" && previous != "" && existsAt(page.SyncCommit, previous) {
+		at = previous
+	}
+	if at != "" && at != page.Upstream {
+		// A translation left at the old path is outdated by the rename
+		// alone, and the diff shows it
+		page.RenamedFrom = at
+		stat, err := diffRenamed(page.SyncCommit, at, page.Upstream)
+		if err != nil {
+			return page, "", err
+		}
+		if stat.added == 0 && stat.deleted == 0 && page.Path == Localize(page.Upstream, lang) {
+			return page, "", nil
+		}
+		page.Added, page.Deleted = stat.added, stat.deleted
+		diff, err := git("diff", "-M", page.SyncCommit, "HEAD", "--", at, page.Upstream)
+		if err != nil {
+			return page, "", err
+		}
+		return page, diff, nil
+	}
+
+	stats, err := diffNumstat(page.SyncCommit, []string{page.Upstream})
+	if err != nil {
+		return page, "", err
+	}
+	stat, changed := stats[page.Upstream]
+	if !changed {
+		return page, "", nil
+	}
+	page.Added, page.Deleted = stat.added, stat.deleted
+
+	diff, err := git("diff", "--no-renames", page.SyncCommit, "HEAD", "--", page.Upstream)
+	if err != nil {
+		return page, "", err
+	}
+	return page, diff, nil
+}
+
+// localizedPages returns the Markdown and HTML pages at or under target
+func localizedPages(target string) ([]string, error) {
+	info, err := os.Stat(filepath.FromSlash(target))
+	if err != nil {
+		return nil, fmt.Errorf("path not found: %s", target)
+	}
+	if !info.IsDir() {
+		return []string{target}, nil
+	}
+
+	var pages []string
+	err = filepath.WalkDir(filepath.FromSlash(target), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && IsPage(d.Name()) {
+			pages = append(pages, slashPath(filePath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", target, err)
+	}
+	return pages, nil
+}
+
+// slashPath cleans a relative path and uses forward slashes, as git does
+func slashPath(p string) string {
+	return path.Clean(filepath.ToSlash(p))
+}
+
+// lineCount counts the lines of a file as git does, 0 when it can't be read
+func lineCount(p string) int {
+	data, err := os.ReadFile(filepath.FromSlash(p))
+	if err != nil {
+		return 0
+	}
+	count := bytes.Count(data, []byte("\n"))
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		count++
+	}
+	return count
+}
+
+// fileExists reports whether a file exists at a slash-separated path
+func fileExists(p string) bool {
+	info, err := os.Stat(filepath.FromSlash(p))
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,142 @@
+package k8sdocs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// gitRepo creates a git repository for the test and makes it the working
+// directory, as the commands run in the root of the website
+func gitRepo(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	runGit(t, "init", "-q", "-b", "main")
+	runGit(t, "config", "user.name", "Test")
+	runGit(t, "config", "user.email", "test@example.com")
+}
+
+// runGit runs a git command in the working directory
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+// writePages writes files given by slash-separated paths
+func writePages(t *testing.T, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.FromSlash(name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// commitAll commits every change of the working tree
+func commitAll(t *testing.T, message string) {
+	t.Helper()
+	runGit(t, "add", "-A")
+	runGit(t, "commit", "-q", "-m", message)
+}
+
+func TestLsync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	gitRepo(t)
+
+	guide := "# Guide\n\none\ntwo\nthree\nfour\nfive\nsix\n"
+	writePages(t, map[string]string{
+		"content/en/docs/outdated.md":    "# Title\n\none\ntwo\n",
+		"content/en/docs/current.md":     "# Current\n",
+		"content/en/docs/guide.md":       guide,
+		"content/en/docs/untracked.md":   "# New\n",
+		"content/en/docs/deleted.md":     "# Deleted\n",
+		"content/zh-cn/docs/outdated.md": "# 标题\n",
+		"content/zh-cn/docs/current.md":  "# 当前\n",
+		"content/zh-cn/docs/guide.md":    "# 指南\n",
+		"content/zh-cn/docs/deleted.md":  "# 已删除\n",
+		"content/zh-cn/docs/orphan.md":   "# 孤立\n",
+	})
+	commitAll(t, "Add pages")
+
+	writePages(t, map[string]string{
+		"content/en/docs/outdated.md":     "# Title\n\none\n2\nthree\n",
+		"content/zh-cn/docs/untracked.md": "# 新\n",
+	})
+	runGit(t, "mv", "content/en/docs/guide.md", "content/en/docs/tasks.md")
+	writePages(t, map[string]string{"content/en/docs/tasks.md": guide + "seven\n"})
+	runGit(t, "rm", "-q", "content/en/docs/deleted.md")
+	runGit(t, "add", "content/en")
+	runGit(t, "commit", "-q", "-m", "Update English pages")
+
+	result, err := Lsync("content/zh-cn/docs", "zh-cn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outdated []string
+	for _, page := range result.Outdated {
+		outdated = append(outdated, strings.Join([]string{page.Path, page.Upstream, strconv.Itoa(page.Added), strconv.Itoa(page.Deleted)}, " "))
+	}
+	wantOutdated := []string{
+		"content/zh-cn/docs/guide.md content/en/docs/tasks.md 1 0",
+		"content/zh-cn/docs/outdated.md content/en/docs/outdated.md 2 1",
+	}
+	if !reflect.DeepEqual(outdated, wantOutdated) {
+		t.Errorf("Outdated = %v, want %v", outdated, wantOutdated)
+	}
+	if want := []string{"content/zh-cn/docs/deleted.md", "content/zh-cn/docs/orphan.md"}; !reflect.DeepEqual(result.Orphaned, want) {
+		t.Errorf("Orphaned = %v, want %v", result.Orphaned, want)
+	}
+	if want := []string{"content/zh-cn/docs/untracked.md"}; !reflect.DeepEqual(result.Untracked, want) {
+		t.Errorf("Untracked = %v, want %v", result.Untracked, want)
+	}
+	if result.Checked != 6 {
+		t.Errorf("Checked = %d, want 6", result.Checked)
+	}
+
+	page, diff, err := UpstreamDiff("content/zh-cn/docs/outdated.md", "zh-cn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Added != 2 || page.Deleted != 1 || !strings.Contains(diff, "-two\n+2\n+three\n") {
+		t.Errorf("UpstreamDiff() = %+v\n%s", page, diff)
+	}
+	if _, diff, err := UpstreamDiff("content/zh-cn/docs/current.md", "zh-cn"); err != nil || diff != "" {
+		t.Errorf("UpstreamDiff() of an up to date page = %q, %v", diff, err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	if got := Localize("content/en/docs/home/_index.md", "zh-cn"); got != "content/zh-cn/docs/home/_index.md" {
+		t.Errorf("Localize() = %s", got)
+	}
+	if got := Upstream("content/zh-cn/docs/home/_index.md", "zh-cn"); got != "content/en/docs/home/_index.md" {
+		t.Errorf("Upstream() = %s", got)
+	}
+	if got := Upstream("static/x.png", "zh-cn"); got != "static/x.png" {
+		t.Errorf("Upstream() of another path = %s", got)
+	}
+}
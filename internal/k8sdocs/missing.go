@@ -0,0 +1,88 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MissingPage is an English page that has no translation yet
+type MissingPage struct {
+	// Upstream is the English page, e.g. content/en/docs/home/_index.md
+	Upstream string
+	// Group is the part of the site the page belongs to, see GroupOf
+	Group string
+	// Lines and Words estimate the size of the translation
+	Lines int
+	Words int
+}
+
+// Missing returns the English pages at or under target, a file or directory
+// of the content/<lang> tree, that the localization doesn't have, ordered
+// by group and path
+func Missing(target, lang string) ([]MissingPage, error) {
+	// The trailing slash lets the content directory itself convert too
+	upstreamTarget := Upstream(slashPath(target)+"/", lang)
+	if !strings.HasPrefix(upstreamTarget, UpstreamDir) {
+		return nil, fmt.Errorf("%s is not in content/%s/", target, lang)
+	}
+	upstreamTarget = strings.TrimSuffix(upstreamTarget, "/")
+	if _, err := os.Stat(filepath.FromSlash(upstreamTarget)); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var pages []MissingPage
+	err := filepath.WalkDir(filepath.FromSlash(upstreamTarget), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		upstream := slashPath(filePath)
+		if d.IsDir() || !IsPage(upstream) || fileExists(Localize(upstream, lang)) {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, MissingPage{
+			Upstream: upstream,
+			Group:    GroupOf(strings.TrimPrefix(upstream, UpstreamDir)),
+			Lines:    strings.Count(string(data), "\n"),
+			Words:    len(strings.Fields(string(data))),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", upstreamTarget, err)
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Group != pages[j].Group {
+			return pages[i].Group < pages[j].Group
+		}
+		return pages[i].Upstream < pages[j].Upstream
+	})
+	return pages, nil
+}
+
+// GroupOf returns the part of the site a page belongs to, given relative to
+// its content directory: the top-level directory of the docs, such as
+// docs/concepts, or the section of other pages, such as blog
+func GroupOf(rel string) string {
+	section := SectionOf(rel)
+	if section != SectionDocs {
+		return string(section)
+	}
+	parts := strings.SplitN(rel, "/", 3)
+	if len(parts) == 3 && parts[0] == "docs" {
+		return "docs/" + parts[1]
+	}
+	if len(parts) > 1 {
+		return parts[0]
+	}
+	// Pages at the top of the content directory, like the home page
+	return "."
+}
@@ -0,0 +1,47 @@
+package k8sdocs
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrOffline is returned instead of calling GitHub or fetching a remote in
+// offline mode
+var ErrOffline = errors.New("GitHub and remotes aren't reached offline; run again without --offline")
+
+// offline is set when only local git and cached data may be used
+var offline bool
+
+// SetOffline turns offline mode on or off. In offline mode GitHub isn't
+// called and remotes aren't fetched; those calls fail with ErrOffline.
+func SetOffline(value bool) {
+	offline = value
+}
+
+// Offline reports whether offline mode is on
+func Offline() bool {
+	return offline
+}
+
+// LastFetch returns when a remote was last fetched into the repository,
+// zero when it never was
+func LastFetch() time.Time {
+	output, err := git("rev-parse", "--git-path", "FETCH_HEAD")
+	if err != nil {
+		return time.Time{}
+	}
+	info, err := os.Stat(strings.TrimSpace(output))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// IsRemoteRef reports whether ref is a remote-tracking branch, like
+// upstream/main
+func IsRemoteRef(ref string) bool {
+	output, err := git("rev-parse", "--symbolic-full-name", ref)
+	return err == nil && strings.HasPrefix(strings.TrimSpace(output), "refs/remotes/")
+}
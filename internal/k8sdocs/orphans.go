@@ -0,0 +1,165 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRenames bounds how many renames of an English page are followed
+const maxRenames = 10
+
+// Orphan is a localized page whose English page no longer exists
+type Orphan struct {
+	// Path is the localized page
+	Path string
+	// Upstream is the English page it was translated from
+	Upstream string
+	// MovedTo is where the English page lives now after one or more
+	// renames, empty when it was deleted
+	MovedTo string
+	// Commit removed or renamed the English page, at Time; empty when
+	// the English page is not in the history
+	Commit string
+	Time   time.Time
+}
+
+// Moved reports whether the English page was renamed rather than deleted
+func (o Orphan) Moved() bool {
+	return o.MovedTo != ""
+}
+
+// pathEvent is the latest deletion or rename of a path
+type pathEvent struct {
+	commit  string
+	time    time.Time
+	renamed string
+}
+
+// Orphans returns the localized pages at or under target whose English page
+// was deleted or renamed, following renames to the current English page
+func Orphans(target, lang string) ([]Orphan, error) {
+	target = slashPath(target)
+	localized, err := localizedPages(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	for _, page := range localized {
+		upstream := Upstream(page, lang)
+		if !fileExists(upstream) {
+			orphans = append(orphans, Orphan{Path: page, Upstream: upstream})
+		}
+	}
+	if len(orphans) == 0 {
+		return nil, nil
+	}
+	if err := traceOrphans(orphans); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Path < orphans[j].Path
+	})
+	return orphans, nil
+}
+
+// traceOrphans finds when the English page of each orphan was removed, and
+// where it lives now when it was renamed
+func traceOrphans(orphans []Orphan) error {
+	wanted := make(map[string]bool, len(orphans))
+	for _, orphan := range orphans {
+		wanted[orphan.Upstream] = true
+	}
+	events, err := removals(wanted)
+	if err != nil {
+		return err
+	}
+	for i := range orphans {
+		orphan := &orphans[i]
+		event, ok := events[orphan.Upstream]
+		if !ok {
+			continue
+		}
+		orphan.Commit, orphan.Time = event.commit, event.time
+
+		// Follow the renames to the page that exists now
+		current := event.renamed
+		for hops := 0; current != "" && !fileExists(current) && hops < maxRenames; hops++ {
+			current = events[current].renamed
+		}
+		if current != "" && fileExists(current) {
+			orphan.MovedTo = current
+		}
+	}
+	return nil
+}
+
+// removals walks the history of the English pages with rename detection
+// and returns the latest deletion or rename of every path it sees, until
+// the wanted paths are all found. Renames of a renamed page are newer, so
+// they are found first.
+func removals(wanted map[string]bool) (map[string]pathEvent, error) {
+	cmd := exec.Command("git", "-c", "core.quotepath=off", "log", "-M", "--diff-filter=DR",
+		"--name-status", "--format=commit %H %ct", "--", UpstreamDir)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+
+	events := make(map[string]pathEvent)
+	found := 0
+	var current pathEvent
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() && found < len(wanted) {
+		line := scanner.Text()
+		if header, ok := strings.CutPrefix(line, "commit "); ok {
+			hash, seconds, _ := strings.Cut(header, " ")
+			unix, _ := strconv.ParseInt(seconds, 10, 64)
+			current = pathEvent{commit: hash, time: time.Unix(unix, 0)}
+			continue
+		}
+
+		// D<TAB>path or R<score><TAB>old<TAB>new
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		event := current
+		if strings.HasPrefix(fields[0], "R") && len(fields) == 3 {
+			event.renamed = fields[2]
+		}
+		if _, seen := events[fields[1]]; seen {
+			continue
+		}
+		events[fields[1]] = event
+		if wanted[fields[1]] {
+			found++
+		}
+	}
+
+	// Stop git once every wanted path is found
+	if found == len(wanted) {
+		_ = cmd.Process.Kill()
+		_, _ = io.Copy(io.Discard, stdout)
+		_ = cmd.Wait()
+		return events, nil
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return events, scanner.Err()
+}
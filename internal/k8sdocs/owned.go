@@ -0,0 +1,147 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mergeSubject matches the subject of the merge commits GitHub makes for
+// pull requests
+var mergeSubject = regexp.MustCompile(`^Merge pull request #(\d+)`)
+
+// StalePage is a translated page whose English page changed on the base
+// branch since the translation was merged
+type StalePage struct {
+	Page     string `json:"page"`
+	Upstream string `json:"upstream"`
+	// Commit is the last commit of the author that changed the page
+	Commit string `json:"commit"`
+	// PullRequest is the pull request that merged Commit, found from its
+	// merge commit; 0 when it wasn't merged with one
+	PullRequest int       `json:"pull_request,omitempty"`
+	MergedAt    time.Time `json:"merged_at"`
+	// Commits, Added and Deleted measure the English changes since the
+	// page was last changed, by the author or anyone after them
+	Commits int `json:"commits"`
+	Added   int `json:"lines_added"`
+	Deleted int `json:"lines_deleted"`
+}
+
+// StaleAgain returns the pages of a localization an author, matched like
+// git log --author, translated on base since a time whose English pages
+// changed on base after, the most changed first. It only needs git: run
+// it after fetching base.
+func StaleAgain(author, lang, base string, since time.Time) ([]StalePage, error) {
+	args := []string{"log", base, "--no-merges", "--no-renames", "--name-only",
+		"--author=" + author, "--format=%x1e%H"}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	output, err := git(append(args, "--", LocalizedDir(lang))...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The history is newest first, so the first commit of a page is the
+	// last one of the author
+	owned := make(map[string]string)
+	var pages []string
+	for _, record := range strings.Split(output, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		for _, page := range lines[1:] {
+			page = strings.TrimSpace(page)
+			if _, ok := owned[page]; ok || !IsPage(page) {
+				continue
+			}
+			owned[page] = lines[0]
+			pages = append(pages, page)
+		}
+	}
+
+	merges := make(map[string]pullRequestMerge)
+	var stale []StalePage
+	for _, page := range pages {
+		upstream := Upstream(page, lang)
+		if !existsAt(base, page) || !existsAt(base, upstream) {
+			continue
+		}
+		last, err := git("log", "-1", "--format=%H", base, "--", page)
+		if err != nil {
+			return nil, err
+		}
+		synced := strings.TrimSpace(last)
+		count, err := git("rev-list", "--count", "--no-merges", synced+".."+base, "--", upstream)
+		if err != nil {
+			return nil, err
+		}
+		commits, _ := strconv.Atoi(strings.TrimSpace(count))
+		if commits == 0 {
+			continue
+		}
+
+		commit := owned[page]
+		merge, ok := merges[commit]
+		if !ok {
+			if merge, err = mergeOf(commit, base); err != nil {
+				return nil, err
+			}
+			merges[commit] = merge
+		}
+		entry := StalePage{Page: page, Upstream: upstream, Commit: commit,
+			PullRequest: merge.number, MergedAt: merge.at, Commits: commits}
+		numstat, err := git("diff", "--numstat", "--no-renames", synced, base, "--", upstream)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(strings.TrimSpace(numstat), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(fields[0])
+			deleted, _ := strconv.Atoi(fields[1])
+			entry.Added += added
+			entry.Deleted += deleted
+		}
+		stale = append(stale, entry)
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].Added+stale[i].Deleted > stale[j].Added+stale[j].Deleted
+	})
+	return stale, nil
+}
+
+// pullRequestMerge is the merge of a commit into the base branch
+type pullRequestMerge struct {
+	number int
+	at     time.Time
+}
+
+// mergeOf returns the pull request that merged a commit into base and when,
+// from the first pull request merge commit after it. Commits merged
+// without one get the time of the commit.
+func mergeOf(commit, base string) (pullRequestMerge, error) {
+	output, err := git("log", "--ancestry-path", "--merges", "--reverse", "--format=%ct%x09%s", commit+".."+base)
+	if err != nil {
+		return pullRequestMerge{}, fmt.Errorf("failed to find the merge of %s: %w", commit, err)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		seconds, subject, _ := strings.Cut(line, "\t")
+		if match := mergeSubject.FindStringSubmatch(subject); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			unix, _ := strconv.ParseInt(seconds, 10, 64)
+			return pullRequestMerge{number: number, at: time.Unix(unix, 0)}, nil
+		}
+	}
+	output, err = git("show", "-s", "--format=%ct", commit)
+	if err != nil {
+		return pullRequestMerge{}, err
+	}
+	unix, _ := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	return pullRequestMerge{at: time.Unix(unix, 0)}, nil
+}
@@ -0,0 +1,154 @@
+package k8sdocs
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default weights of the priority score
+const (
+	DefaultSizeWeight      = 0.6
+	DefaultStalenessWeight = 0.4
+)
+
+// Changes and staleness at or above these count fully in the score
+const (
+	fullSizeLines = 500
+	fullStaleness = 365 * 24 * time.Hour
+)
+
+// Priority ranks outdated pages by a weighted combination of the size of
+// the English changes, how long the translation has been outdated and the
+// importance of the page
+type Priority struct {
+	// Weights scale the importance of pages by path prefix under
+	// content/en/; the longest prefix wins and pages default to 1
+	Weights map[string]float64
+	// Views are page views keyed by URL path, e.g. /docs/concepts/
+	Views map[string]float64
+	// SizeWeight and StalenessWeight balance the two parts of the score
+	SizeWeight      float64
+	StalenessWeight float64
+
+	maxViews float64
+}
+
+// NewPriority returns a Priority with the default weights where weights
+// are not set
+func NewPriority(weights map[string]float64, views map[string]float64, sizeWeight, stalenessWeight float64) *Priority {
+	if sizeWeight <= 0 && stalenessWeight <= 0 {
+		sizeWeight, stalenessWeight = DefaultSizeWeight, DefaultStalenessWeight
+	}
+	p := &Priority{Weights: weights, Views: views, SizeWeight: sizeWeight, StalenessWeight: stalenessWeight}
+	for _, v := range views {
+		p.maxViews = math.Max(p.maxViews, v)
+	}
+	return p
+}
+
+// Score returns the priority of an outdated page from 0 to 100, given its
+// English path, the lines changed in English and how long ago the
+// translation was last updated
+func (p *Priority) Score(upstream string, changed int, stale time.Duration) float64 {
+	// Both parts grow fast at first and level off, so that a page with a
+	// thousand changed lines doesn't hide everything else
+	size := math.Min(math.Log1p(float64(changed))/math.Log1p(fullSizeLines), 1)
+	staleness := math.Min(math.Max(stale.Hours(), 0)/fullStaleness.Hours(), 1)
+	urgency := (p.SizeWeight*size + p.StalenessWeight*staleness) / (p.SizeWeight + p.StalenessWeight)
+	return 100 * urgency * p.importance(upstream)
+}
+
+// importance returns the weight of a page from its path and page views.
+// Without analytics it is the directory weight; with them, pages range
+// from half to one and a half times that weight by their views.
+func (p *Priority) importance(upstream string) float64 {
+	page := strings.TrimPrefix(upstream, UpstreamDir)
+	weight, longest := 1.0, -1
+	for prefix, w := range p.Weights {
+		if strings.HasPrefix(page, strings.TrimPrefix(prefix, "/")) && len(prefix) > longest {
+			weight, longest = w, len(prefix)
+		}
+	}
+
+	if p.maxViews <= 0 {
+		return weight
+	}
+	views := p.Views[PageURL(upstream)]
+	return weight * (0.5 + math.Log1p(views)/math.Log1p(p.maxViews))
+}
+
+// PageURL returns the URL path of an English page on the website, e.g.
+// /docs/concepts/overview/ for content/en/docs/concepts/overview/_index.md
+func PageURL(upstream string) string {
+	page := strings.TrimSuffix(strings.TrimPrefix(upstream, UpstreamDir), ".md")
+	if base := path.Base(page); base == "_index" || base == "index" {
+		page = path.Dir(page)
+	}
+	return urlPath(page)
+}
+
+// urlPath returns a path with a slash at both ends
+func urlPath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" || p == "." {
+		return "/"
+	}
+	return "/" + p + "/"
+}
+
+// LoadPageViews reads a CSV file of page views, as exported from web
+// analytics: the first column is the URL or content path of a page and the
+// second its views. Rows without a number of views, like the header, are
+// skipped, and views of the same page in several rows add up.
+func LoadPageViews(file string) (map[string]float64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	views := make(map[string]float64)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read analytics file %s: %w", file, err)
+		}
+		if len(record) < 2 {
+			continue
+		}
+		count, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(record[1]), ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		views[normalizeURL(record[0])] += count
+	}
+	return views, nil
+}
+
+// normalizeURL turns the page column of an analytics export into the URL
+// path PageURL returns
+func normalizeURL(page string) string {
+	page = strings.TrimSpace(page)
+	if strings.HasSuffix(page, ".md") {
+		return PageURL(page)
+	}
+	if _, rest, ok := strings.Cut(page, "://"); ok {
+		// Drop the host of full URLs
+		_, page, _ = strings.Cut(rest, "/")
+	}
+	page, _, _ = strings.Cut(page, "?")
+	page, _, _ = strings.Cut(page, "#")
+	return urlPath(page)
+}
@@ -0,0 +1,158 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Project describes a localized site the docs commands work on: where its
+// source and localized pages live, and the GitHub repository its pull
+// requests are opened on
+type Project struct {
+	// Repository is the GitHub repository, owner/name
+	Repository string
+	// SourceDir is the content directory of the source pages, e.g.
+	// content/en/
+	SourceDir string
+	// TargetDir is the content directory of a localization, with {lang}
+	// standing for its code, e.g. content/{lang}/
+	TargetDir string
+	// Branch is the branch pull requests target
+	Branch string
+	// Label is the label of the pull requests of a localization, with
+	// {lang} standing for its code and {short_lang} for its language, e.g.
+	// language/{short_lang}; empty when pull requests aren't labeled
+	Label string
+	// Generated are the directories of generated pages, relative to
+	// SourceDir, or the starts of their paths
+	Generated []string
+}
+
+// Projects are the built-in project types. Kubernetes is the website of
+// kubernetes/website; Hugo and Docusaurus sites name their repository in
+// .mm.yaml.
+var Projects = map[string]Project{
+	"kubernetes": {
+		Repository: "kubernetes/website",
+		SourceDir:  "content/en/",
+		TargetDir:  "content/{lang}/",
+		Branch:     "main",
+		Label:      "language/{short_lang}",
+		Generated:  kubernetesGenerated,
+	},
+	"hugo": {
+		SourceDir: "content/en/",
+		TargetDir: "content/{lang}/",
+		Branch:    "main",
+	},
+	"docusaurus": {
+		SourceDir: "docs/",
+		TargetDir: "i18n/{lang}/docusaurus-plugin-content-docs/current/",
+		Branch:    "main",
+	},
+}
+
+// DefaultProject is the project type used unless .mm.yaml selects another
+const DefaultProject = "kubernetes"
+
+// The layout of the project the package works on, set by UseProject
+var (
+	// UpstreamDir is the content directory of the source pages
+	UpstreamDir = "content/en/"
+	// Repository is the GitHub repository of the site
+	Repository = "kubernetes/website"
+	// DefaultBranch is the branch pull requests of the site target
+	DefaultBranch = "main"
+
+	targetDir     = "content/{lang}/"
+	languageLabel = "language/{short_lang}"
+)
+
+// ProjectTypes returns the names of the built-in project types
+func ProjectTypes() []string {
+	names := make([]string, 0, len(Projects))
+	for name := range Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProject returns a built-in project type, kubernetes when it is empty,
+// with the settings of override that are set replacing its own
+func NewProject(projectType string, override Project) (Project, error) {
+	if projectType == "" {
+		projectType = DefaultProject
+	}
+	project, ok := Projects[projectType]
+	if !ok {
+		return Project{}, fmt.Errorf("unknown project type %q (use %s)", projectType, strings.Join(ProjectTypes(), ", "))
+	}
+	if override.Repository != "" {
+		project.Repository = override.Repository
+	}
+	if override.SourceDir != "" {
+		project.SourceDir = override.SourceDir
+	}
+	if override.TargetDir != "" {
+		project.TargetDir = override.TargetDir
+	}
+	if override.Branch != "" {
+		project.Branch = override.Branch
+	}
+	if override.Label != "" {
+		project.Label = override.Label
+	}
+	if len(override.Generated) > 0 {
+		project.Generated = override.Generated
+	}
+
+	project.SourceDir = dirPath(project.SourceDir)
+	project.TargetDir = dirPath(project.TargetDir)
+	switch {
+	case project.Repository == "" || strings.Count(project.Repository, "/") != 1:
+		return Project{}, fmt.Errorf("the %s project needs its GitHub repository as owner/name", projectType)
+	case !strings.Contains(project.TargetDir, "{lang}"):
+		return Project{}, fmt.Errorf("the target directory %s has no {lang}", project.TargetDir)
+	}
+	return project, nil
+}
+
+// UseProject makes the package work on a project
+func UseProject(project Project) {
+	UpstreamDir = project.SourceDir
+	Repository = project.Repository
+	DefaultBranch = project.Branch
+	targetDir = project.TargetDir
+	languageLabel = project.Label
+	generatedDirs = project.Generated
+}
+
+// LocalizedDir returns the content directory of a localization, with a
+// trailing slash
+func LocalizedDir(lang string) string {
+	return strings.ReplaceAll(targetDir, "{lang}", lang)
+}
+
+// LanguageLabel returns the label of the pull requests of a localization,
+// empty when the project doesn't label them
+func LanguageLabel(lang string) string {
+	short, _, _ := strings.Cut(lang, "-")
+	return strings.NewReplacer("{lang}", lang, "{short_lang}", strings.ToLower(short)).Replace(languageLabel)
+}
+
+// labelFilter returns the search qualifier of a label, with a leading
+// space, or "" to search pull requests with any label
+func labelFilter(label string) string {
+	if label == "" {
+		return ""
+	}
+	return " label:" + label
+}
+
+// dirPath cleans a directory relative to the root of the repository and
+// adds a trailing slash
+func dirPath(dir string) string {
+	return strings.Trim(slashPath(strings.TrimSpace(dir)), "/") + "/"
+}
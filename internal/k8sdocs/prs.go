@@ -0,0 +1,318 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Labels Prow sets on pull requests of the website
+const (
+	LabelLGTM        = "lgtm"
+	LabelApproved    = "approved"
+	LabelNeedsRebase = "needs-rebase"
+	LabelHold        = "do-not-merge/hold"
+)
+
+// OpenPullRequest is an open pull request of the website with the state of
+// its checks and reviews
+type OpenPullRequest struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Branch    string    `json:"branch"`
+	Draft     bool      `json:"draft"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Checks is the combined state of the checks of the last commit:
+	// SUCCESS, FAILURE, ERROR, PENDING or EXPECTED; empty without checks
+	Checks string `json:"checks"`
+	// Review is the review decision: APPROVED, CHANGES_REQUESTED or
+	// REVIEW_REQUIRED; empty when no review is required
+	Review string `json:"review"`
+	// ChangesRequestedBy are the reviewers whose last review requested
+	// changes
+	ChangesRequestedBy []string `json:"changes_requested_by"`
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN while GitHub computes
+	// it
+	Mergeable string   `json:"mergeable"`
+	Labels    []string `json:"labels"`
+}
+
+// HasLabel reports whether the pull request has a label
+func (pr OpenPullRequest) HasLabel(name string) bool {
+	for _, label := range pr.Labels {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsRebase reports whether the pull request conflicts with its base
+func (pr OpenPullRequest) NeedsRebase() bool {
+	return pr.Mergeable == "CONFLICTING" || pr.HasLabel(LabelNeedsRebase)
+}
+
+// openPullRequestsQuery searches pull requests with what the dashboard
+// shows, in a single request
+const openPullRequestsQuery = `query($search: String!) {
+  search(query: $search, type: ISSUE, first: 100) {
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        url
+        headRefName
+        isDraft
+        createdAt
+        updatedAt
+        mergeable
+        reviewDecision
+        labels(first: 30) { nodes { name } }
+        latestReviews(first: 30) { nodes { state author { login } } }
+        commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+      }
+    }
+  }
+}`
+
+// OpenPullRequests returns the open pull requests of a GitHub user on the
+// website, only those with a label such as language/zh unless the label is
+// empty, newest first
+func OpenPullRequests(login, label string) ([]OpenPullRequest, error) {
+	search := fmt.Sprintf("repo:%s is:pr is:open author:%s sort:created-desc", Repository, login)
+	if label != "" {
+		search += " label:" + label
+	}
+	var response struct {
+		Search struct {
+			Nodes []struct {
+				Number         int       `json:"number"`
+				Title          string    `json:"title"`
+				URL            string    `json:"url"`
+				HeadRefName    string    `json:"headRefName"`
+				IsDraft        bool      `json:"isDraft"`
+				CreatedAt      time.Time `json:"createdAt"`
+				UpdatedAt      time.Time `json:"updatedAt"`
+				Mergeable      string    `json:"mergeable"`
+				ReviewDecision string    `json:"reviewDecision"`
+				Labels         struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				LatestReviews struct {
+					Nodes []struct {
+						State  string `json:"state"`
+						Author struct {
+							Login string `json:"login"`
+						} `json:"author"`
+					} `json:"nodes"`
+				} `json:"latestReviews"`
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+	if err := githubGraphQL(openPullRequestsQuery, map[string]any{"search": search}, &response); err != nil {
+		return nil, fmt.Errorf("failed to search open pull requests: %w", err)
+	}
+
+	prs := []OpenPullRequest{}
+	for _, node := range response.Search.Nodes {
+		if node.Number == 0 {
+			continue
+		}
+		pr := OpenPullRequest{
+			Number:             node.Number,
+			Title:              node.Title,
+			URL:                node.URL,
+			Branch:             node.HeadRefName,
+			Draft:              node.IsDraft,
+			CreatedAt:          node.CreatedAt,
+			UpdatedAt:          node.UpdatedAt,
+			Review:             node.ReviewDecision,
+			Mergeable:          node.Mergeable,
+			ChangesRequestedBy: []string{},
+			Labels:             []string{},
+		}
+		for _, label := range node.Labels.Nodes {
+			pr.Labels = append(pr.Labels, label.Name)
+		}
+		for _, review := range node.LatestReviews.Nodes {
+			if review.State == "CHANGES_REQUESTED" {
+				pr.ChangesRequestedBy = append(pr.ChangesRequestedBy, review.Author.Login)
+			}
+		}
+		if commits := node.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+			pr.Checks = commits[0].Commit.StatusCheckRollup.State
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// maxParallelRequests limits the requests sent to GitHub at once, to stay
+// clear of its secondary rate limits
+const maxParallelRequests = 4
+
+// pullRequestFilesQuery lists open pull requests with their files, a page
+// of each at a time
+const pullRequestFilesQuery = `query($search: String!, $cursor: String) {
+  search(query: $search, type: ISSUE, first: 50, after: $cursor) {
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        url
+        files(first: 100) { pageInfo { hasNextPage endCursor } nodes { path } }
+      }
+    }
+  }
+}`
+
+// moreFilesQuery lists the next files of a pull request
+const moreFilesQuery = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      files(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { path } }
+    }
+  }
+}`
+
+// fileConnection is a page of the files of a pull request
+type fileConnection struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []struct {
+		Path string `json:"path"`
+	} `json:"nodes"`
+}
+
+// PullRequestFiles returns the open pull requests of the website with a
+// label such as language/zh, keyed by the files they change. It takes a
+// request per 50 pull requests, plus one per further 100 files of the
+// pull requests that change more, sent in parallel.
+func PullRequestFiles(label string) (map[string][]PullRequest, error) {
+	search := fmt.Sprintf("repo:%s is:pr is:open%s", Repository, labelFilter(label))
+	files := make(map[string][]PullRequest)
+	type pending struct {
+		pr     PullRequest
+		cursor string
+	}
+	var more []pending
+
+	cursor := ""
+	for {
+		var response struct {
+			Search struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Number int            `json:"number"`
+					Title  string         `json:"title"`
+					URL    string         `json:"url"`
+					Files  fileConnection `json:"files"`
+				} `json:"nodes"`
+			} `json:"search"`
+		}
+		variables := map[string]any{"search": search}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+		if err := githubGraphQL(pullRequestFilesQuery, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to list the files of open pull requests: %w", err)
+		}
+		for _, node := range response.Search.Nodes {
+			if node.Number == 0 {
+				continue
+			}
+			pr := PullRequest{Number: node.Number, Title: node.Title, HTMLURL: node.URL}
+			for _, file := range node.Files.Nodes {
+				files[file.Path] = append(files[file.Path], pr)
+			}
+			if node.Files.PageInfo.HasNextPage {
+				more = append(more, pending{pr, node.Files.PageInfo.EndCursor})
+			}
+		}
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor = response.Search.PageInfo.EndCursor
+	}
+
+	// Large pull requests page through their files one after another, but
+	// several of them at once
+	owner, name, _ := strings.Cut(Repository, "/")
+	var mu sync.Mutex
+	var firstErr error
+	jobs := make(chan pending)
+	var wg sync.WaitGroup
+	for i := 0; i < min(maxParallelRequests, len(more)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				prFiles, err := morePullRequestFiles(owner, name, p.pr.Number, p.cursor)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("failed to list the files of #%d: %w", p.pr.Number, err)
+				}
+				for _, file := range prFiles {
+					files[file] = append(files[file], p.pr)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, p := range more {
+		jobs <- p
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return files, nil
+}
+
+// morePullRequestFiles returns the files of a pull request after a cursor
+func morePullRequestFiles(owner, name string, number int, cursor string) ([]string, error) {
+	var files []string
+	for cursor != "" {
+		var response struct {
+			Repository struct {
+				PullRequest struct {
+					Files fileConnection `json:"files"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		variables := map[string]any{"owner": owner, "name": name, "number": number, "cursor": cursor}
+		if err := githubGraphQL(moreFilesQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		connection := response.Repository.PullRequest.Files
+		for _, file := range connection.Nodes {
+			files = append(files, file.Path)
+		}
+		cursor = ""
+		if connection.PageInfo.HasNextPage {
+			cursor = connection.PageInfo.EndCursor
+		}
+	}
+	return files, nil
+}
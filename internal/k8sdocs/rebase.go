@@ -0,0 +1,155 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ErrRebaseConflicts is returned when a rebase stops at conflicts to
+// resolve
+var ErrRebaseConflicts = errors.New("the rebase stopped at conflicts")
+
+// Rebase rebases a branch onto a ref, checking the branch out. It returns
+// ErrRebaseConflicts when it stops at conflicts.
+func Rebase(onto, branch string) error {
+	return rebase("rebase", onto, branch)
+}
+
+// ContinueRebase continues a rebase stopped at resolved conflicts, keeping
+// the messages of the commits. It returns ErrRebaseConflicts when a later
+// commit conflicts.
+func ContinueRebase() error {
+	return rebase("rebase", "--continue")
+}
+
+// rebase runs a git rebase without opening an editor
+func rebase(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if files, _ := ConflictedFiles(); RebaseInProgress() && len(files) > 0 {
+			return ErrRebaseConflicts
+		}
+		return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// RebaseInProgress reports whether a rebase stopped and waits to be
+// continued or aborted
+func RebaseInProgress() bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		output, err := git("rev-parse", "--git-path", dir)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(strings.TrimSpace(output)); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// RebaseHead returns the commit a stopped rebase was applying
+func RebaseHead() (Commit, error) {
+	output, err := git("show", "-s", "--format=%H%x1f%s", "REBASE_HEAD")
+	if err != nil {
+		return Commit{}, err
+	}
+	hash, subject, _ := strings.Cut(strings.TrimSpace(output), "\x1f")
+	return Commit{Hash: hash, Subject: subject}, nil
+}
+
+// CommitsBehind returns the number of commits of ref a branch doesn't
+// have
+func CommitsBehind(branch, ref string) (int, error) {
+	output, err := git("rev-list", "--count", branch+".."+ref)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+// ConflictedFiles returns the files with unresolved conflicts
+func ConflictedFiles() ([]string, error) {
+	output, err := git("diff", "--name-only", "-z", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// HasTrackedChanges reports whether tracked files have uncommitted
+// changes, which keep a rebase from starting
+func HasTrackedChanges() (bool, error) {
+	output, err := git("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+// ConflictHunk is a conflict left in a file by a rebase, between the
+// version of the branch rebased onto and the commit being rebased
+type ConflictHunk struct {
+	// Start and End are the lines of the conflict markers
+	Start int
+	End   int
+	// Upstream and Yours are the numbers of lines of each side
+	Upstream int
+	Yours    int
+}
+
+// ConflictHunks returns the conflicts marked in a file, none once they are
+// resolved
+func ConflictHunks(path string) ([]ConflictHunk, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var hunks []ConflictHunk
+	var hunk *ConflictHunk
+	yours := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "<<<<<<<"):
+			hunk = &ConflictHunk{Start: line}
+			yours = false
+		case hunk == nil:
+		case strings.HasPrefix(text, "|||||||"):
+			// The common ancestor of diff3 conflicts belongs to neither side
+			yours = true
+			hunk.Yours = -1
+		case strings.HasPrefix(text, "======="):
+			yours = true
+			hunk.Yours = 0
+		case strings.HasPrefix(text, ">>>>>>>"):
+			hunk.End = line
+			hunks = append(hunks, *hunk)
+			hunk = nil
+		case yours && hunk.Yours >= 0:
+			hunk.Yours++
+		case !yours:
+			hunk.Upstream++
+		}
+	}
+	return hunks, scanner.Err()
+}
@@ -0,0 +1,38 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// releaseBranchPattern matches the branches kubernetes/website prepares the
+// documentation of upcoming releases on, like dev-1.32
+var releaseBranchPattern = regexp.MustCompile(`^dev-(\d+\.\d+)$`)
+
+// ReleaseOf returns the Kubernetes version of a release branch, e.g. 1.32
+// for dev-1.32, or "" for other branches
+func ReleaseOf(branch string) string {
+	if match := releaseBranchPattern.FindStringSubmatch(branch); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// BranchRef returns the ref of a branch of kubernetes/website: its
+// remote-tracking branch of the upstream or origin remote, or the local
+// branch when neither remote has it
+func BranchRef(branch string) (string, error) {
+	for _, ref := range []string{"upstream/" + branch, "origin/" + branch, branch} {
+		if _, err := RevParse(ref); err == nil {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("branch %s not found; fetch it first: git fetch upstream %s", branch, branch)
+}
+
+// OnBranch reports whether the commit checked out contains ref, as when
+// the branch of ref, or a branch made from it, is checked out
+func OnBranch(ref string) bool {
+	_, err := git("merge-base", "--is-ancestor", ref, "HEAD")
+	return err == nil
+}
@@ -0,0 +1,183 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ownersFile is an OWNERS file of the website, as Prow reads it
+type ownersFile struct {
+	Reviewers []string `yaml:"reviewers"`
+	Approvers []string `yaml:"approvers"`
+	Options   struct {
+		NoParentOwners bool `yaml:"no_parent_owners"`
+	} `yaml:"options"`
+}
+
+// ownersAliases is the OWNERS_ALIASES file at the root of the website,
+// naming groups like sig-docs-zh-reviews
+type ownersAliases struct {
+	Aliases map[string][]string `yaml:"aliases"`
+}
+
+// Reviewer is a reviewer suggested for a pull request
+type Reviewer struct {
+	Login string `json:"login"`
+	// Owner is set for the reviewers and approvers of the OWNERS files of
+	// the page
+	Owner bool `json:"owner"`
+	// Reviews counts the recent pull requests of the localization they
+	// reviewed
+	Reviews int `json:"reviews"`
+}
+
+// PageOwners returns the reviewers and approvers of the OWNERS files from
+// the directory of a localized page up to the content directory of its
+// localization, nearest first, with the aliases of OWNERS_ALIASES resolved
+func PageOwners(page, lang string) ([]string, error) {
+	var aliases ownersAliases
+	if data, err := os.ReadFile("OWNERS_ALIASES"); err == nil {
+		if err := yaml.Unmarshal(data, &aliases); err != nil {
+			return nil, fmt.Errorf("failed to parse OWNERS_ALIASES: %w", err)
+		}
+	}
+
+	root := strings.TrimSuffix(LocalizedDir(lang), "/")
+	seen := make(map[string]bool)
+	var owners []string
+	for dir := path.Dir(slashPath(page)); ; dir = path.Dir(dir) {
+		data, err := os.ReadFile(filepath.Join(filepath.FromSlash(dir), "OWNERS"))
+		if err == nil {
+			var file ownersFile
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return nil, fmt.Errorf("failed to parse %s/OWNERS: %w", dir, err)
+			}
+			for _, name := range append(file.Reviewers, file.Approvers...) {
+				logins, ok := aliases.Aliases[name]
+				if !ok {
+					logins = []string{name}
+				}
+				for _, login := range logins {
+					if key := strings.ToLower(login); !seen[key] {
+						seen[key] = true
+						owners = append(owners, login)
+					}
+				}
+			}
+			if file.Options.NoParentOwners {
+				break
+			}
+		}
+		if dir == root || dir == "." || dir == "/" {
+			break
+		}
+	}
+	return owners, nil
+}
+
+// recentReviewersQuery lists the reviews of recently merged pull requests
+const recentReviewersQuery = `query($search: String!) {
+  search(query: $search, type: ISSUE, first: 50) {
+    nodes {
+      ... on PullRequest {
+        author { login }
+        reviews(first: 50) { nodes { author { login } } }
+      }
+    }
+  }
+}`
+
+// RecentReviewers counts the recently merged pull requests with a label
+// such as language/zh each user reviewed, their own left out
+func RecentReviewers(label string) (map[string]int, error) {
+	search := fmt.Sprintf("repo:%s is:pr is:merged%s sort:updated-desc", Repository, labelFilter(label))
+	type login struct {
+		Login string `json:"login"`
+	}
+	var response struct {
+		Search struct {
+			Nodes []struct {
+				Author  login `json:"author"`
+				Reviews struct {
+					Nodes []struct {
+						Author login `json:"author"`
+					} `json:"nodes"`
+				} `json:"reviews"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+	if err := githubGraphQL(recentReviewersQuery, map[string]any{"search": search}, &response); err != nil {
+		return nil, fmt.Errorf("failed to search recent reviews: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, pr := range response.Search.Nodes {
+		reviewed := make(map[string]bool)
+		for _, review := range pr.Reviews.Nodes {
+			name := review.Author.Login
+			if name == "" || strings.EqualFold(name, pr.Author.Login) || reviewed[strings.ToLower(name)] {
+				continue
+			}
+			reviewed[strings.ToLower(name)] = true
+			counts[name]++
+		}
+	}
+	return counts, nil
+}
+
+// SuggestReviewers ranks the owners of a page and the recent reviewers of
+// the localization: the most active first, owners first among equals. The
+// author is left out, and reviews may be nil to rank owners only.
+func SuggestReviewers(owners []string, reviews map[string]int, author string, limit int) []Reviewer {
+	candidates := make(map[string]*Reviewer)
+	var order []string
+	add := func(login string) *Reviewer {
+		key := strings.ToLower(login)
+		if candidates[key] == nil {
+			candidates[key] = &Reviewer{Login: login}
+			order = append(order, key)
+		}
+		return candidates[key]
+	}
+	for _, login := range owners {
+		add(login).Owner = true
+	}
+	logins := make([]string, 0, len(reviews))
+	for login := range reviews {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	for _, login := range logins {
+		add(login).Reviews += reviews[login]
+	}
+
+	var reviewers []Reviewer
+	for _, key := range order {
+		if key == strings.ToLower(author) || strings.HasSuffix(key, "[bot]") {
+			continue
+		}
+		reviewers = append(reviewers, *candidates[key])
+	}
+	sort.SliceStable(reviewers, func(i, j int) bool {
+		if reviewers[i].Reviews != reviewers[j].Reviews {
+			return reviewers[i].Reviews > reviewers[j].Reviews
+		}
+		return reviewers[i].Owner && !reviewers[j].Owner
+	})
+	if limit > 0 && len(reviewers) > limit {
+		reviewers = reviewers[:limit]
+	}
+	return reviewers
+}
+
+// CommentOnPullRequest comments on a pull request of the website, e.g. with
+// Prow commands like /cc, and returns the URL of the comment
+func CommentOnPullRequest(number int, body string) (string, error) {
+	return postComment(number, body)
+}
@@ -0,0 +1,74 @@
+package k8sdocs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samzong/mm/internal/config"
+)
+
+// rootMarkers are the files the root of a site has besides its source
+// pages, one of which tells it from a directory of the content that
+// happens to hold the same layout
+var rootMarkers = []string{".git", config.FileName}
+
+// FindRoot returns the root of the site a path is in: the nearest directory
+// at or above it with the source pages (UpstreamDir) and a git directory or
+// .mm.yaml
+func FindRoot(path string) (string, bool) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		if IsRoot(dir) {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// IsRoot reports whether a directory is the root of the site
+func IsRoot(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(UpstreamDir)))
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	for _, marker := range rootMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RootRelative returns a path given relative to dir as relative to the root
+// of the site, slash-separated with a trailing slash kept, when it exists
+// under the root. Other paths, like the page paths under UpstreamDir the
+// commands accept, are returned as they are.
+func RootRelative(root, dir, path string) string {
+	abs := path
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(dir, path)
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	rel = filepath.ToSlash(rel)
+	if strings.HasSuffix(path, "/") && rel != "." {
+		rel += "/"
+	}
+	return rel
+}
@@ -0,0 +1,121 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Section is a part of the website with its own localization conventions
+type Section string
+
+// Sections of the website
+const (
+	SectionDocs        Section = "docs"
+	SectionBlog        Section = "blog"
+	SectionCaseStudies Section = "case-studies"
+)
+
+// Sections are the sections lsync and the workflow commands support
+var Sections = []Section{SectionDocs, SectionBlog, SectionCaseStudies}
+
+// ParseSection returns the section with a name
+func ParseSection(name string) (Section, error) {
+	for _, section := range Sections {
+		if string(section) == name {
+			return section, nil
+		}
+	}
+	return "", fmt.Errorf("unknown section: %s (use docs, blog or case-studies)", name)
+}
+
+// SectionOf returns the section of a page, given relative to its content
+// directory, e.g. blog/_posts/2024-01-01-post.md. Other pages belong to the
+// docs.
+func SectionOf(rel string) Section {
+	for _, section := range Sections {
+		if strings.HasPrefix(rel, string(section)+"/") {
+			return section
+		}
+	}
+	return SectionDocs
+}
+
+// IsPage reports whether a file is a content page; case studies and landing
+// pages are HTML
+func IsPage(name string) bool {
+	return strings.HasSuffix(name, ".md") || strings.HasSuffix(name, ".html")
+}
+
+// isBundleIndex reports whether a page is the index of a page bundle, a
+// directory with the page and its images
+func isBundleIndex(page string) bool {
+	base := path.Base(page)
+	return base == "index.md" || base == "index.html"
+}
+
+// PostName returns the name of a blog post or case study: the name of its
+// bundle directory, or of its file without extension. Blog post names start
+// with their date, e.g. 2024-01-01-post.
+func PostName(page string) string {
+	if isBundleIndex(page) {
+		return path.Base(path.Dir(page))
+	}
+	return strings.TrimSuffix(path.Base(page), path.Ext(page))
+}
+
+// BundleDir returns the bundle directory of a page, empty when the page is
+// not the index of a bundle
+func BundleDir(page string) string {
+	if !isBundleIndex(page) {
+		return ""
+	}
+	return path.Dir(page) + "/"
+}
+
+// MissingAssets returns the files of the bundle of an English page, such as
+// featured images, that the localized bundle doesn't have yet
+func MissingAssets(upstream, lang string) ([]string, error) {
+	dir := BundleDir(upstream)
+	if dir == "" {
+		return nil, nil
+	}
+
+	var assets []string
+	err := filepath.WalkDir(filepath.FromSlash(dir), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		asset := filepath.ToSlash(filePath)
+		if d.IsDir() || IsPage(asset) {
+			return nil
+		}
+		if _, err := os.Stat(filepath.FromSlash(Localize(asset, lang))); os.IsNotExist(err) {
+			assets = append(assets, asset)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle %s: %w", dir, err)
+	}
+	return assets, nil
+}
+
+// CopyAsset copies an English asset to the localized bundle
+func CopyAsset(asset, lang string) error {
+	data, err := os.ReadFile(filepath.FromSlash(asset))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", asset, err)
+	}
+	target := filepath.FromSlash(Localize(asset, lang))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StaleChange is a change of an English page on the base branch since its
+// translation was started
+type StaleChange struct {
+	// Upstream is the English page
+	Upstream string
+	// Since is the commit the translation was started from, Base the
+	// commit of the base branch it is compared with
+	Since string
+	Base  string
+	// Added and Deleted count the lines changed, Diff shows them
+	Added   int
+	Deleted int
+	Diff    string
+}
+
+// StaleSince returns the changes of an English page between since and the
+// branch base, or nil when it didn't change. Without since, the translation
+// counts as started where the current branch forked from base.
+func StaleSince(upstream, since, base string) (*StaleChange, error) {
+	baseCommit, err := RevParse(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", base, err)
+	}
+	if since == "" {
+		output, err := git("merge-base", "HEAD", baseCommit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find where the branch forked from %s: %w", base, err)
+		}
+		since = strings.TrimSpace(output)
+	}
+	if since == baseCommit {
+		return nil, nil
+	}
+
+	output, err := git("diff", "--no-renames", "--numstat", since, baseCommit, "--", upstream)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) < 2 {
+		return nil, nil
+	}
+	change := &StaleChange{Upstream: upstream, Since: since, Base: baseCommit}
+	fmt.Sscan(fields[0], &change.Added)
+	fmt.Sscan(fields[1], &change.Deleted)
+	if change.Diff, err = git("diff", "--no-renames", since, baseCommit, "--", upstream); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// HeadCommit returns the commit checked out in the working directory
+func HeadCommit() (string, error) {
+	return RevParse("HEAD")
+}
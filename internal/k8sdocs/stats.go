@@ -0,0 +1,227 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Release is a minor release of Kubernetes. Localization work between two
+// releases counts towards the release cycle of the later one.
+type Release struct {
+	Version string
+	Date    time.Time
+}
+
+// knownReleases are the dates of past minor releases, used where the
+// website's data/releases/schedule.yaml no longer lists them
+var knownReleases = []Release{
+	{"1.25", date(2022, 8, 23)},
+	{"1.26", date(2022, 12, 8)},
+	{"1.27", date(2023, 4, 11)},
+	{"1.28", date(2023, 8, 15)},
+	{"1.29", date(2023, 12, 13)},
+	{"1.30", date(2024, 4, 17)},
+	{"1.31", date(2024, 8, 13)},
+	{"1.32", date(2024, 12, 11)},
+	{"1.33", date(2025, 4, 23)},
+	{"1.34", date(2025, 8, 27)},
+}
+
+// scheduleFile lists the supported releases of Kubernetes in the website
+const scheduleFile = "data/releases/schedule.yaml"
+
+// date returns midnight UTC of a day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Releases returns the minor releases of Kubernetes by date, from the
+// release schedule of the website checked out in the working directory and
+// the releases known to mm
+func Releases() []Release {
+	byVersion := make(map[string]time.Time)
+	for _, release := range knownReleases {
+		byVersion[release.Version] = release.Date
+	}
+	for _, release := range scheduledReleases(scheduleFile) {
+		byVersion[release.Version] = release.Date
+	}
+
+	releases := make([]Release, 0, len(byVersion))
+	for version, releaseDate := range byVersion {
+		releases = append(releases, Release{Version: version, Date: releaseDate})
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Date.Before(releases[j].Date)
+	})
+	return releases
+}
+
+// scheduledReleases reads the release dates of the schedule file: the
+// releaseDate of each release, or the target date of its .0 patch
+func scheduledReleases(file string) []Release {
+	data, err := os.ReadFile(filepath.FromSlash(file))
+	if err != nil {
+		return nil
+	}
+	var schedule struct {
+		Schedules []struct {
+			Release         string `yaml:"release"`
+			ReleaseDate     string `yaml:"releaseDate"`
+			PreviousPatches []struct {
+				Release    string `yaml:"release"`
+				TargetDate string `yaml:"targetDate"`
+			} `yaml:"previousPatches"`
+		} `yaml:"schedules"`
+	}
+	if yaml.Unmarshal(data, &schedule) != nil {
+		return nil
+	}
+
+	var releases []Release
+	for _, entry := range schedule.Schedules {
+		day := entry.ReleaseDate
+		for _, patch := range entry.PreviousPatches {
+			if day == "" && patch.Release == entry.Release+".0" {
+				day = patch.TargetDate
+			}
+		}
+		if releaseDate, err := time.Parse("2006-01-02", day); err == nil && entry.Release != "" {
+			releases = append(releases, Release{Version: entry.Release, Date: releaseDate})
+		}
+	}
+	return releases
+}
+
+// CycleOf returns the release cycle of a time, named by the release that
+// ends it, e.g. v1.34 for work after the 1.33 release. Work after the last
+// known release counts towards the next minor version.
+func CycleOf(t time.Time, releases []Release) string {
+	for _, release := range releases {
+		if t.Before(release.Date) {
+			return "v" + release.Version
+		}
+	}
+	if len(releases) == 0 {
+		return "unknown"
+	}
+	return "v" + nextMinor(releases[len(releases)-1].Version)
+}
+
+// nextMinor returns the minor version after a version such as 1.34
+func nextMinor(version string) string {
+	major, minor, _ := strings.Cut(version, ".")
+	n, err := strconv.Atoi(minor)
+	if err != nil {
+		return version + "+"
+	}
+	return major + "." + strconv.Itoa(n+1)
+}
+
+// Contribution is a commit changing localized pages
+type Contribution struct {
+	Commit  string
+	Time    time.Time
+	Files   []string
+	Added   int
+	Deleted int
+}
+
+// Contributions returns the commits of an author, matched like git log
+// --author, that changed the pages of a localization since a time
+func Contributions(author, lang string, since time.Time) ([]Contribution, error) {
+	args := []string{"-c", "core.quotepath=off", "log", "--no-merges", "--no-renames",
+		"--author=" + author, "--format=commit %H %ct", "--numstat"}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	args = append(args, "--", LocalizedDir(lang))
+
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	var contributions []Contribution
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if header, ok := strings.CutPrefix(line, "commit "); ok {
+			hash, seconds, _ := strings.Cut(header, " ")
+			unix, _ := strconv.ParseInt(seconds, 10, 64)
+			contributions = append(contributions, Contribution{Commit: hash, Time: time.Unix(unix, 0)})
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || len(contributions) == 0 {
+			continue
+		}
+		current := &contributions[len(contributions)-1]
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		current.Added += added
+		current.Deleted += deleted
+		current.Files = append(current.Files, fields[2])
+	}
+	return contributions, scanner.Err()
+}
+
+// MergedPullRequest is a merged pull request of the website
+type MergedPullRequest struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	HTMLURL  string    `json:"html_url"`
+	MergedAt time.Time `json:"merged_at"`
+}
+
+// MergedPullRequests returns the pull requests of a GitHub user merged into
+// the website since a time with a label such as language/zh
+func MergedPullRequests(login, label string, since time.Time) ([]MergedPullRequest, error) {
+	query := fmt.Sprintf("repo:%s is:pr is:merged author:%s%s", Repository, login, labelFilter(label))
+	if !since.IsZero() {
+		query += " merged:>=" + since.Format("2006-01-02")
+	}
+	var prs []MergedPullRequest
+	endpoint := "search/issues?" + url.Values{"q": {query}, "per_page": {"100"}}.Encode()
+	err := githubPages(endpoint, func(data []byte) error {
+		var page struct {
+			Items []struct {
+				Number      int    `json:"number"`
+				Title       string `json:"title"`
+				HTMLURL     string `json:"html_url"`
+				PullRequest struct {
+					MergedAt time.Time `json:"merged_at"`
+				} `json:"pull_request"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			if !item.PullRequest.MergedAt.IsZero() {
+				prs = append(prs, MergedPullRequest{Number: item.Number, Title: item.Title, HTMLURL: item.HTMLURL, MergedAt: item.PullRequest.MergedAt})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search merged pull requests: %w", err)
+	}
+	return prs, nil
+}
@@ -0,0 +1,201 @@
+package k8sdocs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Ways to record the English commit a translation was made from, for when
+// the last commit of the localized page doesn't tell, e.g. after a typo fix
+// or a translation of a newer English version than the branch started from
+const (
+	// SyncRefNone records nothing, leaving the last commit of the page
+	SyncRefNone = ""
+	// SyncRefTrailer records it in the commit message of the translation
+	SyncRefTrailer = "trailer"
+	// SyncRefFrontMatter records it in the front matter of the page
+	SyncRefFrontMatter = "front-matter"
+)
+
+// Names of the recorded English commit
+const (
+	// SyncRefField is the front matter field
+	SyncRefField = "upstream_commit"
+	// SyncRefTrailerKey is the commit message trailer
+	SyncRefTrailerKey = "Upstream-Commit"
+)
+
+// ParseSyncRefStyle checks a way to record the English commit; empty
+// means none
+func ParseSyncRefStyle(style string) (string, error) {
+	switch style {
+	case SyncRefNone, SyncRefTrailer, SyncRefFrontMatter:
+		return style, nil
+	}
+	return "", fmt.Errorf("unknown sync_ref: %s (use trailer or front-matter)", style)
+}
+
+// LatestUpstreamCommit returns the last commit that changed an English page
+func LatestUpstreamCommit(upstream string) (string, error) {
+	output, err := git("log", "-1", "--format=%H", "--", upstream)
+	if err != nil {
+		return "", err
+	}
+	commit := strings.TrimSpace(output)
+	if commit == "" {
+		return "", fmt.Errorf("%s is not committed", upstream)
+	}
+	return commit, nil
+}
+
+// RecordedRef returns the English commit recorded in the front matter of a
+// localized page, empty when there is none
+func RecordedRef(localized string) string {
+	return frontMatterValue(localized, SyncRefField)
+}
+
+// SetRecordedRef records an English commit in the front matter of a
+// localized page, replacing the one recorded before
+func SetRecordedRef(localized, commit string) error {
+	data, err := os.ReadFile(filepath.FromSlash(localized))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", localized, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return fmt.Errorf("%s has no front matter", localized)
+	}
+
+	field := SyncRefField + ": " + commit
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(line) == "---" {
+			// Add the field at the end of the front matter
+			lines = append(lines[:i], append([]string{field}, lines[i:]...)...)
+			break
+		}
+		if strings.HasPrefix(line, SyncRefField+":") {
+			lines[i] = field
+			break
+		}
+		if i == len(lines)-1 {
+			return fmt.Errorf("%s has no end of front matter", localized)
+		}
+	}
+	if err := os.WriteFile(filepath.FromSlash(localized), []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", localized, err)
+	}
+	return nil
+}
+
+// syncCommits returns the English commit each localized page was translated
+// from: the commit recorded in its front matter, else the one in the
+// Upstream-Commit trailer of its last commit, else its last commit.
+// Recorded commits missing from the repository are ignored.
+func syncCommits(pages []string, lastCommits map[string]string) (map[string]string, error) {
+	recorded := make(map[string]string)
+	for _, page := range pages {
+		if ref := RecordedRef(page); ref != "" {
+			recorded[page] = ref
+		}
+	}
+
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, page := range pages {
+		if commit := lastCommits[page]; commit != "" && recorded[page] == "" && !seen[commit] {
+			seen[commit] = true
+			hashes = append(hashes, commit)
+		}
+	}
+	trailers, err := commitTrailers(hashes, SyncRefTrailerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	for _, page := range pages {
+		if recorded[page] == "" {
+			if trailer := trailers[lastCommits[page]]; trailer != "" {
+				recorded[page] = trailer
+			}
+		}
+		if recorded[page] != "" {
+			refs = append(refs, recorded[page])
+		}
+	}
+	resolved, err := resolveCommits(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string]string, len(pages))
+	for _, page := range pages {
+		if full := resolved[recorded[page]]; full != "" {
+			commits[page] = full
+		} else if lastCommits[page] != "" {
+			commits[page] = lastCommits[page]
+		}
+	}
+	return commits, nil
+}
+
+// commitTrailers returns the first value of a trailer in the message of
+// each commit that has it
+func commitTrailers(hashes []string, key string) (map[string]string, error) {
+	trailers := make(map[string]string)
+	if len(hashes) == 0 {
+		return trailers, nil
+	}
+	format := "--format=commit %H%n%(trailers:key=" + key + ",valueonly)"
+	for start := 0; start < len(hashes); start += diffBatch {
+		batch := hashes[start:min(start+diffBatch, len(hashes))]
+		output, err := git(append([]string{"show", "-s", format}, batch...)...)
+		if err != nil {
+			return nil, err
+		}
+		current := ""
+		for _, line := range strings.Split(output, "\n") {
+			if hash, ok := strings.CutPrefix(line, "commit "); ok {
+				current = hash
+				continue
+			}
+			if value := strings.TrimSpace(line); value != "" && trailers[current] == "" {
+				trailers[current] = value
+			}
+		}
+	}
+	return trailers, nil
+}
+
+// resolveCommits returns the full hash of each ref that names a commit of
+// the repository
+func resolveCommits(refs []string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	if len(refs) == 0 {
+		return resolved, nil
+	}
+	cmd := exec.Command("git", "cat-file", "--batch-check=%(objectname) %(objecttype)")
+	cmd.Stdin = strings.NewReader(strings.Join(refs, "\n") + "\n")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	// One line per ref, in order: the object or "<ref> missing"
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for i := 0; scanner.Scan() && i < len(refs); i++ {
+		hash, kind, _ := strings.Cut(scanner.Text(), " ")
+		if kind == "commit" {
+			resolved[refs[i]] = hash
+		}
+	}
+	return resolved, scanner.Err()
+}
@@ -0,0 +1,22 @@
+package k8sdocs
+
+import "testing"
+
+func TestParseSyncRefStyle(t *testing.T) {
+	tests := []struct {
+		style   string
+		want    string
+		wantErr bool
+	}{
+		{"", SyncRefNone, false},
+		{"trailer", SyncRefTrailer, false},
+		{"front-matter", SyncRefFrontMatter, false},
+		{"frontmatter", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSyncRefStyle(tt.style)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSyncRefStyle(%q) = %q, %v, want %q", tt.style, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package k8sdocs
+
+import (
+	"strings"
+)
+
+// Fetch fetches a branch of a remote
+func Fetch(remote, branch string) error {
+	if offline {
+		return ErrOffline
+	}
+	_, err := git("fetch", "--quiet", remote, branch)
+	return err
+}
+
+// RevParse returns the commit a revision points to
+func RevParse(rev string) (string, error) {
+	output, err := git("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// RemoteExists reports whether the repository has a remote
+func RemoteExists(remote string) bool {
+	_, err := git("remote", "get-url", remote)
+	return err == nil
+}
+
+// ChangedUpstream returns the English pages changed between two commits
+func ChangedUpstream(from, to string) ([]string, error) {
+	output, err := git("diff", "--no-renames", "--name-only", "-z", from, to, "--", UpstreamDir)
+	if err != nil {
+		return nil, err
+	}
+	var pages []string
+	for _, page := range strings.Split(output, "\x00") {
+		if IsPage(page) {
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
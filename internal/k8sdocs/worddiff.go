@@ -0,0 +1,454 @@
+package k8sdocs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EditOp is the kind of a word edit
+type EditOp int
+
+// Word edits
+const (
+	EditEqual EditOp = iota
+	EditDelete
+	EditInsert
+)
+
+// WordEdit is a run of words kept, deleted or inserted
+type WordEdit struct {
+	Op   EditOp
+	Text string
+}
+
+// Paragraph is a block of a page separated by blank lines
+type Paragraph struct {
+	Text string
+	// Line is the line of the page the paragraph starts at, from 1
+	Line int
+}
+
+// ParagraphChange is a paragraph of an English page that changed
+type ParagraphChange struct {
+	// Old is the paragraph the translation was made from, empty when the
+	// paragraph is new; New is the current paragraph, empty when it was
+	// removed
+	Old Paragraph
+	New Paragraph
+	// Edits turn Old into New word by word
+	Edits []WordEdit
+	// Translation is the paragraph of the localized page translating Old,
+	// empty when it isn't found
+	Translation string
+}
+
+// similarParagraphs is how much of their words two paragraphs share at
+// least to count as versions of each other
+const similarParagraphs = 0.5
+
+// UpstreamChanges returns the paragraphs of the English original of a
+// localized page that changed since the page was last updated, with the
+// words that changed and the current translation of each
+func UpstreamChanges(localized, lang string) (Page, []ParagraphChange, error) {
+	page, versions, err := upstreamVersions(localized, lang)
+	if err != nil || versions == nil {
+		return page, nil, err
+	}
+	changes := DiffParagraphs(versions.before, versions.after)
+	versions.findTranslations(changes)
+	return page, changes, nil
+}
+
+// pageVersions are the versions of a page a diff of its English changes
+// compares: the English page the translation was made from, the current
+// one and the translation
+type pageVersions struct {
+	before, after, translated string
+}
+
+// upstreamVersions returns the versions of the English original of a
+// localized page since the page was last updated, nil when it didn't
+// change
+func upstreamVersions(localized, lang string) (Page, *pageVersions, error) {
+	page, diff, err := UpstreamDiff(localized, lang)
+	if err != nil || diff == "" {
+		return page, nil, err
+	}
+	// The whole page is new when it didn't exist at the commit
+	before := ""
+	if existsAt(page.SyncCommit, page.syncedUpstream()) {
+		if before, err = git("show", page.SyncCommit+":"+page.syncedUpstream()); err != nil {
+			return page, nil, err
+		}
+	}
+	after, err := git("show", "HEAD:"+page.Upstream)
+	if err != nil {
+		return page, nil, err
+	}
+	// The working copy of the translation may have updates already
+	translated, err := os.ReadFile(filepath.FromSlash(page.Path))
+	if err != nil {
+		return page, nil, fmt.Errorf("failed to read %s: %w", page.Path, err)
+	}
+	return page, &pageVersions{before: before, after: after, translated: string(translated)}, nil
+}
+
+// findTranslations sets the current translation of the changed paragraphs
+func (v *pageVersions) findTranslations(changes []ParagraphChange) {
+	translations := commentedTranslations(v.translated)
+	for i := range changes {
+		if changes[i].Old.Text != "" {
+			changes[i].Translation = findTranslation(translations, changes[i].Old.Text)
+		}
+	}
+}
+
+// DiffParagraphs returns the paragraphs that differ between two versions
+// of a page. Removed and added paragraphs that are similar enough are
+// paired as a change with word edits.
+func DiffParagraphs(before, after string) []ParagraphChange {
+	return diffBlocks(Paragraphs(before), Paragraphs(after))
+}
+
+// diffBlocks returns the changes between two versions of a page split
+// into blocks
+func diffBlocks(old, current []Paragraph) []ParagraphChange {
+	oldTexts, currentTexts := make([]string, len(old)), make([]string, len(current))
+	for i, p := range old {
+		oldTexts[i] = p.Text
+	}
+	for i, p := range current {
+		currentTexts[i] = p.Text
+	}
+
+	var changes []ParagraphChange
+	var removed, added []Paragraph
+	flush := func() {
+		changes = append(changes, pairParagraphs(removed, added)...)
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for _, op := range diffSequences(oldTexts, currentTexts) {
+		switch op {
+		case EditEqual:
+			flush()
+			i++
+			j++
+		case EditDelete:
+			removed = append(removed, old[i])
+			i++
+		case EditInsert:
+			added = append(added, current[j])
+			j++
+		}
+	}
+	flush()
+	return changes
+}
+
+// pairParagraphs pairs removed paragraphs with the added paragraphs they
+// became, keeping their order; the others stay removed or added
+func pairParagraphs(removed, added []Paragraph) []ParagraphChange {
+	var changes []ParagraphChange
+	next := 0
+	for _, old := range removed {
+		match := -1
+		for k := next; k < len(added); k++ {
+			if similarity(old.Text, added[k].Text) >= similarParagraphs {
+				match = k
+				break
+			}
+		}
+		if match < 0 {
+			changes = append(changes, ParagraphChange{Old: old, Edits: []WordEdit{{EditDelete, old.Text}}})
+			continue
+		}
+		for ; next < match; next++ {
+			changes = append(changes, ParagraphChange{New: added[next], Edits: []WordEdit{{EditInsert, added[next].Text}}})
+		}
+		changes = append(changes, ParagraphChange{Old: old, New: added[match], Edits: WordDiff(old.Text, added[match].Text)})
+		next = match + 1
+	}
+	for ; next < len(added); next++ {
+		changes = append(changes, ParagraphChange{New: added[next], Edits: []WordEdit{{EditInsert, added[next].Text}}})
+	}
+	return changes
+}
+
+// Paragraphs splits a page into blocks separated by blank lines
+func Paragraphs(text string) []Paragraph {
+	var paragraphs []Paragraph
+	var block []string
+	start := 0
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for n, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				paragraphs = append(paragraphs, Paragraph{Text: strings.Join(block, "\n"), Line: start + 1})
+				block = nil
+			}
+			continue
+		}
+		if len(block) == 0 {
+			start = n
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		paragraphs = append(paragraphs, Paragraph{Text: strings.Join(block, "\n"), Line: start + 1})
+	}
+	return paragraphs
+}
+
+// WordDiff returns the word edits that turn one text into another, with
+// runs of the same edit merged
+func WordDiff(before, after string) []WordEdit {
+	a, b := tokenize(before), tokenize(after)
+	var edits []WordEdit
+	i, j := 0, 0
+	for _, op := range diffSequences(a, b) {
+		var text string
+		switch op {
+		case EditEqual, EditDelete:
+			text = a[i]
+			i++
+			if op == EditEqual {
+				j++
+			}
+		case EditInsert:
+			text = b[j]
+			j++
+		}
+		if n := len(edits); n > 0 && edits[n-1].Op == op {
+			edits[n-1].Text += text
+		} else {
+			edits = append(edits, WordEdit{op, text})
+		}
+	}
+	return groupChanges(edits)
+}
+
+// groupChanges merges the changes separated only by spaces into one
+// deletion and one insertion, so that "a b" replaced by "c d" reads as one
+// replacement rather than two
+func groupChanges(edits []WordEdit) []WordEdit {
+	var grouped []WordEdit
+	for k := 0; k < len(edits); {
+		if edits[k].Op == EditEqual {
+			grouped = append(grouped, edits[k])
+			k++
+			continue
+		}
+		var deleted, inserted strings.Builder
+	run:
+		for ; k < len(edits); k++ {
+			edit := edits[k]
+			switch edit.Op {
+			case EditDelete:
+				deleted.WriteString(edit.Text)
+			case EditInsert:
+				inserted.WriteString(edit.Text)
+			default:
+				if strings.TrimSpace(edit.Text) != "" || k+1 == len(edits) || edits[k+1].Op == EditEqual {
+					break run
+				}
+				deleted.WriteString(edit.Text)
+				inserted.WriteString(edit.Text)
+			}
+		}
+		if deleted.Len() > 0 {
+			grouped = append(grouped, WordEdit{EditDelete, deleted.String()})
+		}
+		if inserted.Len() > 0 {
+			grouped = append(grouped, WordEdit{EditInsert, inserted.String()})
+		}
+	}
+	return grouped
+}
+
+// tokenize splits text into words, runs of spaces and single other
+// characters. Each CJK character is a word, since CJK text has no spaces.
+func tokenize(text string) []string {
+	var tokens []string
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		end := size
+		switch {
+		case isWordRune(r) && !isCJK(r):
+			for end < len(text) {
+				next, n := utf8.DecodeRuneInString(text[end:])
+				if !isWordRune(next) || isCJK(next) {
+					break
+				}
+				end += n
+			}
+		case unicode.IsSpace(r):
+			for end < len(text) {
+				next, n := utf8.DecodeRuneInString(text[end:])
+				if !unicode.IsSpace(next) {
+					break
+				}
+				end += n
+			}
+		}
+		tokens = append(tokens, text[:end])
+		text = text[end:]
+	}
+	return tokens
+}
+
+// isWordRune reports whether a rune is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '\''
+}
+
+// isCJK reports whether a rune is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// similarity returns the share of the words of two texts they have in
+// common, from 0 to 1
+func similarity(a, b string) float64 {
+	wordsA, wordsB := strings.Fields(a), strings.Fields(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(wordsA))
+	for _, word := range wordsA {
+		counts[word]++
+	}
+	common := 0
+	for _, word := range wordsB {
+		if counts[word] > 0 {
+			counts[word]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(wordsA)+len(wordsB))
+}
+
+// diffSequences returns the edits turning a into b along a longest common
+// subsequence. The common prefix and suffix are skipped, which keeps the
+// table small for the usual few changes in a long page.
+func diffSequences(a, b []string) []EditOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// midA[i:] and midB[j:]
+	lcs := make([][]int32, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]EditOp, 0, len(a)+len(b))
+	for k := 0; k < prefix; k++ {
+		ops = append(ops, EditEqual)
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, EditEqual)
+			i++
+			j++
+		case j < len(midB) && (i == len(midA) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, EditInsert)
+			j++
+		default:
+			ops = append(ops, EditDelete)
+			i++
+		}
+	}
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, EditEqual)
+	}
+	return ops
+}
+
+// commentedTranslations maps the English paragraphs that translations keep
+// in HTML comments, as the Chinese localization does, to the translated
+// text following the comment
+func commentedTranslations(page string) map[string]string {
+	translations := make(map[string]string)
+	var english, translated []string
+	flush := func() {
+		translation := strings.TrimSpace(strings.Join(translated, "\n"))
+		if translation != "" {
+			for _, p := range Paragraphs(strings.Join(english, "\n")) {
+				translations[normalizeSpace(p.Text)] = translation
+			}
+		}
+		english, translated = nil, nil
+	}
+
+	inComment := false
+	for _, line := range strings.Split(strings.ReplaceAll(page, "\r\n", "\n"), "\n") {
+		if !inComment {
+			if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "<!--"); ok {
+				flush()
+				inComment = true
+				line = rest
+			}
+		}
+		if !inComment {
+			translated = append(translated, line)
+			continue
+		}
+		// The translation may follow the end of the comment on its line
+		comment, rest, closed := strings.Cut(line, "-->")
+		english = append(english, comment)
+		if closed {
+			inComment = false
+			if strings.TrimSpace(rest) != "" {
+				translated = append(translated, rest)
+			}
+		}
+	}
+	flush()
+	return translations
+}
+
+// findTranslation returns the translation of an English paragraph, or of
+// the most similar commented paragraph when it changed a little since
+func findTranslation(translations map[string]string, english string) string {
+	key := normalizeSpace(english)
+	if translation, ok := translations[key]; ok {
+		return translation
+	}
+	best, bestKey, bestScore := "", "", similarParagraphs
+	for commented, translation := range translations {
+		score := similarity(commented, key)
+		// Break ties by text, since maps have no order
+		if score > bestScore || (score == bestScore && best != "" && commented < bestKey) {
+			best, bestKey, bestScore = translation, commented, score
+		}
+	}
+	return best
+}
+
+// normalizeSpace collapses runs of white space into single spaces
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,93 @@
+package k8sdocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"The kubelet runs", []string{"The", " ", "kubelet", " ", "runs"}},
+		{"Pod's  spec.", []string{"Pod's", "  ", "spec", "."}},
+		{"容器运行时", []string{"容", "器", "运", "行", "时"}},
+		{"运行kubectl命令", []string{"运", "行", "kubectl", "命", "令"}},
+		{"v1.32\n", []string{"v1", ".", "32", "\n"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []WordEdit
+	}{
+		{"same", "a b", "a b", []WordEdit{{EditEqual, "a b"}}},
+		{"word replaced", "the old value", "the new value", []WordEdit{{EditEqual, "the "}, {EditDelete, "old"}, {EditInsert, "new"}, {EditEqual, " value"}}},
+		{"words replaced as one", "use a b now", "use c d now", []WordEdit{{EditEqual, "use "}, {EditDelete, "a b"}, {EditInsert, "c d"}, {EditEqual, " now"}}},
+		{"word added", "run it", "run it twice", []WordEdit{{EditEqual, "run it"}, {EditInsert, " twice"}}},
+		{"CJK character", "运行容器", "停止容器", []WordEdit{{EditDelete, "运行"}, {EditInsert, "停止"}, {EditEqual, "容器"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WordDiff(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WordDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	got := Paragraphs("\none\ntwo\n  \nthree\r\n\r\n\r\nfour")
+	want := []Paragraph{{"one\ntwo", 2}, {"three", 5}, {"four", 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Paragraphs() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffParagraphs(t *testing.T) {
+	before := "Intro text here.\n\nThe kubelet runs pods on every node.\n\nRemoved paragraph entirely.\n\nEnd."
+	after := "Intro text here.\n\nThe kubelet runs containers on every node.\n\nA brand new paragraph.\n\nEnd."
+	got := DiffParagraphs(before, after)
+	if len(got) != 3 {
+		t.Fatalf("DiffParagraphs() = %d changes, want 3: %v", len(got), got)
+	}
+	if got[0].Old.Line != 3 || got[0].New.Line != 3 || got[0].Old.Text == "" || got[0].New.Text == "" {
+		t.Errorf("changed paragraph = %+v, want a pair at line 3", got[0])
+	}
+	if got[1].Old.Text != "Removed paragraph entirely." || got[1].New.Text != "" {
+		t.Errorf("removed paragraph = %+v", got[1])
+	}
+	if got[2].New.Text != "A brand new paragraph." || got[2].Old.Text != "" {
+		t.Errorf("added paragraph = %+v", got[2])
+	}
+}
+
+func TestFindTranslation(t *testing.T) {
+	page := "<!--\nThe kubelet runs pods\non every node.\n-->\nkubelet 在每个节点上运行 Pod。\n\n<!-- Short note. -->\n简短说明。\n\n<!--\nFirst.\n\nSecond.\n-->\n第一。第二。\n"
+	translations := commentedTranslations(page)
+	tests := []struct {
+		english string
+		want    string
+	}{
+		{"The kubelet runs pods on every node.", "kubelet 在每个节点上运行 Pod。"},
+		{"The kubelet runs pods on each node.", "kubelet 在每个节点上运行 Pod。"},
+		{"Short note.", "简短说明。"},
+		{"Second.", "第一。第二。"},
+		{"Something else entirely.", ""},
+	}
+	for _, tt := range tests {
+		if got := findTranslation(translations, tt.english); got != tt.want {
+			t.Errorf("findTranslation(%q) = %q, want %q", tt.english, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,29 @@ type ProjectAdapter interface {
 	GetIgnorePatterns() []string
 	GetFileExtensions() []string
 	GetCustomRules() map[string]bool
+	GetProperNouns() []string
+}
+
+// commonProperNouns are product names every project writes the same way
+var commonProperNouns = []string{
+	"GitHub",
+	"GitLab",
+	"Kubernetes",
+	"Docker",
+	"Linux",
+	"JavaScript",
+	"TypeScript",
+	"macOS",
+	"iOS",
+	"PostgreSQL",
+	"MySQL",
+	"YAML",
+	"JSON",
+}
+
+// properNouns returns the common proper nouns followed by extra ones
+func properNouns(extra ...string) []string {
+	return append(append([]string{}, commonProperNouns...), extra...)
 }
 
 // K8sAdapter provides configuration for Kubernetes projects
@@ -38,8 +61,6 @@ func (a *K8sAdapter) GetIgnorePatterns() []string {
 		"resources/**",
 		"static/images/**",
 		"layouts/**/*.html",
-		"data/**/*.yaml",
-		"data/**/*.yml",
 	}
 }
 
@@ -54,9 +75,31 @@ func (a *K8sAdapter) GetCustomRules() map[string]bool {
 		"ignore_urls":          true,
 		"ignore_yaml_headers":  true,
 		"case_sensitive_terms": false,
+		RuleIgnoreShortcodes:   true,
 	}
 }
 
+func (a *K8sAdapter) GetProperNouns() []string {
+	return properNouns(
+		"kubectl",
+		"kubeadm",
+		"kubelet",
+		"minikube",
+		"DaemonSet",
+		"StatefulSet",
+		"ReplicaSet",
+		"ConfigMap",
+		"CronJob",
+		"PersistentVolume",
+		"PersistentVolumeClaim",
+		"StorageClass",
+		"CustomResourceDefinition",
+		"etcd",
+		"containerd",
+		"Helm",
+	)
+}
+
 // GoAdapter provides configuration for Go projects
 type GoAdapter struct{}
 
@@ -75,12 +118,12 @@ func (a *GoAdapter) GetIgnorePatterns() []string {
 	return []string{
 		"vendor/**",
 		".git/**",
-		"*.go", // Skip Go code files, focus on docs
+		"*.pb.go", // Generated code
 	}
 }
 
 func (a *GoAdapter) GetFileExtensions() []string {
-	return []string{".md", ".txt", ".rst"}
+	return []string{".md", ".txt", ".rst", ".go"}
 }
 
 func (a *GoAdapter) GetCustomRules() map[string]bool {
@@ -92,6 +135,14 @@ func (a *GoAdapter) GetCustomRules() map[string]bool {
 	}
 }
 
+func (a *GoAdapter) GetProperNouns() []string {
+	return properNouns(
+		"gRPC",
+		"gofmt",
+		"GoLand",
+	)
+}
+
 // DockerAdapter provides configuration for Docker projects
 type DockerAdapter struct{}
 
@@ -128,6 +179,13 @@ func (a *DockerAdapter) GetCustomRules() map[string]bool {
 	}
 }
 
+func (a *DockerAdapter) GetProperNouns() []string {
+	return properNouns(
+		"Dockerfile",
+		"containerd",
+	)
+}
+
 // GenericAdapter provides basic configuration for generic projects
 type GenericAdapter struct{}
 
@@ -164,8 +222,21 @@ func (a *GenericAdapter) GetCustomRules() map[string]bool {
 	}
 }
 
-// GetAdapter returns the appropriate adapter for the given project type
+func (a *GenericAdapter) GetProperNouns() []string {
+	return properNouns()
+}
+
+// GetAdapter returns the appropriate adapter for the given project type,
+// preferring adapters registered from configuration
 func GetAdapter(projectType string) (ProjectAdapter, error) {
+	if a, ok := customAdapter(projectType); ok {
+		return a, nil
+	}
+	return builtinAdapter(projectType)
+}
+
+// builtinAdapter returns the built-in adapter of a project type
+func builtinAdapter(projectType string) (ProjectAdapter, error) {
 	switch strings.ToLower(projectType) {
 	case "k8s", "kubernetes":
 		return &K8sAdapter{}, nil
@@ -173,6 +244,16 @@ func GetAdapter(projectType string) (ProjectAdapter, error) {
 		return &GoAdapter{}, nil
 	case "docker":
 		return &DockerAdapter{}, nil
+	case "hugo":
+		return &HugoAdapter{}, nil
+	case "docusaurus":
+		return &DocusaurusAdapter{}, nil
+	case "mkdocs":
+		return &MkDocsAdapter{}, nil
+	case "sphinx":
+		return &SphinxAdapter{}, nil
+	case "vitepress":
+		return &VitePressAdapter{}, nil
 	case "generic", "":
 		return &GenericAdapter{}, nil
 	default:
@@ -180,14 +261,26 @@ func GetAdapter(projectType string) (ProjectAdapter, error) {
 	}
 }
 
-// GetAllAdapters returns all available adapters
+// GetAllAdapters returns all available adapters, the built-in ones followed
+// by those registered from configuration
 func GetAllAdapters() []ProjectAdapter {
-	return []ProjectAdapter{
+	var adapters []ProjectAdapter
+	for _, a := range []ProjectAdapter{
 		&K8sAdapter{},
 		&GoAdapter{},
 		&DockerAdapter{},
+		&HugoAdapter{},
+		&DocusaurusAdapter{},
+		&MkDocsAdapter{},
+		&SphinxAdapter{},
+		&VitePressAdapter{},
 		&GenericAdapter{},
+	} {
+		if _, ok := customAdapter(a.Name()); !ok {
+			adapters = append(adapters, a)
+		}
 	}
+	return append(adapters, customAdapters()...)
 }
 
 // ShouldIgnoreFile checks if a file should be ignored based on patterns
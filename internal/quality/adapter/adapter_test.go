@@ -0,0 +1,102 @@
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samzong/mm/internal/quality/dictionary"
+)
+
+func TestGetAdapter(t *testing.T) {
+	tests := map[string]string{
+		"k8s":        "k8s",
+		"Kubernetes": "k8s",
+		"golang":     "go",
+		"hugo":       "hugo",
+		"docusaurus": "docusaurus",
+		"mkdocs":     "mkdocs",
+		"sphinx":     "sphinx",
+		"vitepress":  "vitepress",
+		"":           "generic",
+	}
+	for projectType, want := range tests {
+		a, err := GetAdapter(projectType)
+		if err != nil {
+			t.Errorf("GetAdapter(%q) error: %v", projectType, err)
+			continue
+		}
+		if a.Name() != want {
+			t.Errorf("GetAdapter(%q) = %s, want %s", projectType, a.Name(), want)
+		}
+	}
+	if _, err := GetAdapter("jekyll"); err == nil {
+		t.Errorf("GetAdapter(jekyll) found an adapter")
+	}
+}
+
+func TestFrameworkDictionaries(t *testing.T) {
+	// Keep dictionaries in the user cache out of the lookup
+	t.Setenv("HOME", t.TempDir())
+	for _, a := range []ProjectAdapter{&HugoAdapter{}, &DocusaurusAdapter{}, &MkDocsAdapter{}, &SphinxAdapter{}, &VitePressAdapter{}} {
+		for _, dict := range a.GetDictionaries() {
+			// The base dictionary stands for the language of the documents
+			if strings.HasPrefix(dict, "dictionaries/base-") {
+				continue
+			}
+			if _, source, err := dictionary.ResolveDictionary(dict); err != nil || source != "embedded" {
+				t.Errorf("%s dictionary %s = %s, %v, want it built in", a.Name(), dict, source, err)
+			}
+		}
+	}
+}
+
+func TestCustomAdapter(t *testing.T) {
+	a, err := NewCustomAdapter("Handbook", "mkdocs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Dictionaries = []string{"handbook.txt"}
+	a.Rules = map[string]bool{RuleIgnoreAdmonitions: false}
+	Register(a)
+	t.Cleanup(func() {
+		customMu.Lock()
+		delete(custom, "handbook")
+		customMu.Unlock()
+	})
+
+	got, err := GetAdapter("handbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"dictionaries/base-en.txt", "dictionaries/mkdocs.txt", "handbook.txt"}; !reflect.DeepEqual(got.GetDictionaries(), want) {
+		t.Errorf("GetDictionaries() = %v, want %v", got.GetDictionaries(), want)
+	}
+	if rules := got.GetCustomRules(); rules[RuleIgnoreAdmonitions] || !rules["ignore_code_blocks"] {
+		t.Errorf("GetCustomRules() = %v, want admonitions checked", rules)
+	}
+	if !reflect.DeepEqual(got.GetFileExtensions(), []string{".md"}) {
+		t.Errorf("GetFileExtensions() = %v, want those of mkdocs", got.GetFileExtensions())
+	}
+	if _, err := NewCustomAdapter("other", "jekyll"); err == nil {
+		t.Errorf("NewCustomAdapter() extended an unknown adapter")
+	}
+}
+
+func TestShouldIgnoreFile(t *testing.T) {
+	patterns := (&HugoAdapter{}).GetIgnorePatterns()
+	tests := map[string]bool{
+		"public/index.html":            true,
+		"themes/docsy/README.md":       true,
+		"layouts/partials/head.html":   true,
+		"layouts/shortcodes/note.md":   false,
+		"content/en/docs/_index.md":    false,
+		"node_modules/pkg/README.md":   true,
+		"content/en/public/article.md": false,
+	}
+	for file, want := range tests {
+		if got := ShouldIgnoreFile(file, patterns); got != want {
+			t.Errorf("ShouldIgnoreFile(%s) = %v, want %v", file, got, want)
+		}
+	}
+}
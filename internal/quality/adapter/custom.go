@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	customMu sync.RWMutex
+	custom   = make(map[string]ProjectAdapter)
+)
+
+// CustomAdapter is a project adapter defined in configuration. It extends a
+// built-in adapter: its dictionaries, ignore patterns and proper nouns are
+// added to those of the base, its extensions replace them when given, and
+// its rules override theirs.
+type CustomAdapter struct {
+	ProjectName    string
+	Base           ProjectAdapter
+	Dictionaries   []string
+	IgnorePatterns []string
+	Extensions     []string
+	Rules          map[string]bool
+	ProperNouns    []string
+}
+
+func (a *CustomAdapter) Name() string {
+	return a.ProjectName
+}
+
+func (a *CustomAdapter) GetDictionaries() []string {
+	return append(append([]string{}, a.Base.GetDictionaries()...), a.Dictionaries...)
+}
+
+func (a *CustomAdapter) GetIgnorePatterns() []string {
+	return append(append([]string{}, a.Base.GetIgnorePatterns()...), a.IgnorePatterns...)
+}
+
+func (a *CustomAdapter) GetFileExtensions() []string {
+	if len(a.Extensions) > 0 {
+		return a.Extensions
+	}
+	return a.Base.GetFileExtensions()
+}
+
+func (a *CustomAdapter) GetCustomRules() map[string]bool {
+	rules := a.Base.GetCustomRules()
+	for rule, enabled := range a.Rules {
+		rules[rule] = enabled
+	}
+	return rules
+}
+
+func (a *CustomAdapter) GetProperNouns() []string {
+	return append(a.Base.GetProperNouns(), a.ProperNouns...)
+}
+
+// NewCustomAdapter creates an adapter named name that extends the built-in
+// adapter base (generic when empty)
+func NewCustomAdapter(name, base string) (*CustomAdapter, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("adapter name is empty")
+	}
+	baseAdapter, err := builtinAdapter(base)
+	if err != nil {
+		return nil, fmt.Errorf("adapter %s extends %s: %w", name, base, err)
+	}
+	return &CustomAdapter{ProjectName: strings.ToLower(name), Base: baseAdapter}, nil
+}
+
+// Register makes an adapter available to GetAdapter under its name,
+// replacing a built-in or previously registered adapter of that name
+func Register(a ProjectAdapter) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	custom[strings.ToLower(a.Name())] = a
+}
+
+// customAdapter returns the registered adapter of a project type
+func customAdapter(projectType string) (ProjectAdapter, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	a, ok := custom[strings.ToLower(projectType)]
+	return a, ok
+}
+
+// customAdapters returns the registered adapters sorted by name
+func customAdapters() []ProjectAdapter {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	adapters := make([]ProjectAdapter, 0, len(custom))
+	for _, a := range custom {
+		adapters = append(adapters, a)
+	}
+	sort.Slice(adapters, func(i, j int) bool {
+		return adapters[i].Name() < adapters[j].Name()
+	})
+	return adapters
+}
@@ -0,0 +1,211 @@
+package adapter
+
+// Extraction hints in the custom rules of an adapter. They name the syntax
+// of documentation frameworks that is not prose.
+const (
+	// RuleIgnoreShortcodes skips Hugo shortcode tags
+	RuleIgnoreShortcodes = "ignore_shortcodes"
+	// RuleIgnoreAdmonitions skips MkDocs admonition markers (!!! note)
+	RuleIgnoreAdmonitions = "ignore_admonitions"
+	// RuleIgnoreContainers skips Docusaurus and VitePress containers (:::tip)
+	RuleIgnoreContainers = "ignore_containers"
+	// RuleIgnoreRoles skips Sphinx and MyST roles and directive options
+	RuleIgnoreRoles = "ignore_roles"
+)
+
+// docsRules returns the custom rules shared by documentation sites with
+// the given extraction hints enabled
+func docsRules(hints ...string) map[string]bool {
+	rules := map[string]bool{
+		"ignore_code_blocks":   true,
+		"ignore_inline_code":   true,
+		"ignore_urls":          true,
+		"ignore_yaml_headers":  true,
+		"case_sensitive_terms": false,
+	}
+	for _, hint := range hints {
+		rules[hint] = true
+	}
+	return rules
+}
+
+// HugoAdapter provides configuration for Hugo sites
+type HugoAdapter struct{}
+
+func (a *HugoAdapter) Name() string {
+	return "hugo"
+}
+
+func (a *HugoAdapter) GetDictionaries() []string {
+	return []string{
+		"dictionaries/base-en.txt",
+		"dictionaries/hugo.txt",
+	}
+}
+
+func (a *HugoAdapter) GetIgnorePatterns() []string {
+	return []string{
+		".git/**",
+		"node_modules/**",
+		"public/**",
+		"resources/**",
+		"themes/**",
+		"layouts/**/*.html",
+	}
+}
+
+func (a *HugoAdapter) GetFileExtensions() []string {
+	return []string{".md", ".markdown", ".txt"}
+}
+
+func (a *HugoAdapter) GetCustomRules() map[string]bool {
+	return docsRules(RuleIgnoreShortcodes)
+}
+
+func (a *HugoAdapter) GetProperNouns() []string {
+	return properNouns("Hugo", "Goldmark", "Markdown")
+}
+
+// DocusaurusAdapter provides configuration for Docusaurus sites
+type DocusaurusAdapter struct{}
+
+func (a *DocusaurusAdapter) Name() string {
+	return "docusaurus"
+}
+
+func (a *DocusaurusAdapter) GetDictionaries() []string {
+	return []string{
+		"dictionaries/base-en.txt",
+		"dictionaries/docusaurus.txt",
+	}
+}
+
+func (a *DocusaurusAdapter) GetIgnorePatterns() []string {
+	return []string{
+		".git/**",
+		"node_modules/**",
+		"build/**",
+		".docusaurus/**",
+		"static/**",
+	}
+}
+
+func (a *DocusaurusAdapter) GetFileExtensions() []string {
+	return []string{".md", ".mdx"}
+}
+
+func (a *DocusaurusAdapter) GetCustomRules() map[string]bool {
+	return docsRules(RuleIgnoreContainers)
+}
+
+func (a *DocusaurusAdapter) GetProperNouns() []string {
+	return properNouns("Docusaurus", "React", "MDX", "npm")
+}
+
+// MkDocsAdapter provides configuration for MkDocs sites
+type MkDocsAdapter struct{}
+
+func (a *MkDocsAdapter) Name() string {
+	return "mkdocs"
+}
+
+func (a *MkDocsAdapter) GetDictionaries() []string {
+	return []string{
+		"dictionaries/base-en.txt",
+		"dictionaries/mkdocs.txt",
+	}
+}
+
+func (a *MkDocsAdapter) GetIgnorePatterns() []string {
+	return []string{
+		".git/**",
+		"site/**",
+		"overrides/**/*.html",
+	}
+}
+
+func (a *MkDocsAdapter) GetFileExtensions() []string {
+	return []string{".md"}
+}
+
+func (a *MkDocsAdapter) GetCustomRules() map[string]bool {
+	return docsRules(RuleIgnoreAdmonitions)
+}
+
+func (a *MkDocsAdapter) GetProperNouns() []string {
+	return properNouns("MkDocs", "Python")
+}
+
+// SphinxAdapter provides configuration for Sphinx projects
+type SphinxAdapter struct{}
+
+func (a *SphinxAdapter) Name() string {
+	return "sphinx"
+}
+
+func (a *SphinxAdapter) GetDictionaries() []string {
+	return []string{
+		"dictionaries/base-en.txt",
+		"dictionaries/sphinx.txt",
+		"dictionaries/programming.txt",
+	}
+}
+
+func (a *SphinxAdapter) GetIgnorePatterns() []string {
+	return []string{
+		".git/**",
+		"_build/**",
+		"build/**",
+		"_static/**",
+		"_templates/**",
+		".tox/**",
+	}
+}
+
+func (a *SphinxAdapter) GetFileExtensions() []string {
+	return []string{".rst", ".md", ".txt"}
+}
+
+func (a *SphinxAdapter) GetCustomRules() map[string]bool {
+	return docsRules(RuleIgnoreRoles)
+}
+
+func (a *SphinxAdapter) GetProperNouns() []string {
+	return properNouns("Sphinx", "reStructuredText", "Python", "MyST")
+}
+
+// VitePressAdapter provides configuration for VitePress sites
+type VitePressAdapter struct{}
+
+func (a *VitePressAdapter) Name() string {
+	return "vitepress"
+}
+
+func (a *VitePressAdapter) GetDictionaries() []string {
+	return []string{
+		"dictionaries/base-en.txt",
+		"dictionaries/vitepress.txt",
+	}
+}
+
+func (a *VitePressAdapter) GetIgnorePatterns() []string {
+	return []string{
+		".git/**",
+		"node_modules/**",
+		".vitepress/cache/**",
+		".vitepress/dist/**",
+		"public/**",
+	}
+}
+
+func (a *VitePressAdapter) GetFileExtensions() []string {
+	return []string{".md"}
+}
+
+func (a *VitePressAdapter) GetCustomRules() map[string]bool {
+	return docsRules(RuleIgnoreContainers)
+}
+
+func (a *VitePressAdapter) GetProperNouns() []string {
+	return properNouns("VitePress", "Vue", "Vite")
+}
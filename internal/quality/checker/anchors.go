@@ -0,0 +1,269 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Anchor rule IDs
+const (
+	RuleAnchorDuplicate = "anchor-duplicate"
+	RuleAnchorCollision = "anchor-collision"
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(AnchorsCheckerType),
+		Description: "Duplicate headings and colliding anchors within a page",
+		Factory: func() (Checker, error) {
+			return NewAnchorChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleAnchorDuplicate,
+		Checker:     AnchorsCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Heading repeats an earlier heading and gets a numbered anchor",
+		Example:     "## Example ... ## Example → #example-1",
+	})
+	RegisterRule(Rule{
+		ID:          RuleAnchorCollision,
+		Checker:     AnchorsCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Anchor is defined twice or taken by an explicit {#id}",
+		Example:     "## Setup {#install} ... ## Install",
+	})
+}
+
+// pageAnchor is an anchor of a rendered page
+type pageAnchor struct {
+	// id is the anchor as rendered
+	id string
+	// want is the anchor the heading asked for: its explicit id or its slug
+	want     string
+	line     int
+	column   int
+	text     string
+	explicit bool
+	heading  bool
+	// parent is the anchor of the enclosing heading
+	parent string
+}
+
+// pageAnchors computes the anchors of a markdown page the way Hugo renders
+// them: explicit {#id} and HTML ids are used as written, and a heading slug
+// already in use gets the first free -1, -2, ... suffix
+func pageAnchors(content string) []pageAnchor {
+	var anchors []pageAnchor
+	used := make(map[string]bool)
+	var parents []pageAnchor // enclosing headings by level
+	levels := []int{}
+
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	inComment := false
+	fence := ""
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		line := lines[i]
+		if inCodeBlock {
+			if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, fence) {
+				inCodeBlock = false
+			}
+			continue
+		}
+
+		// Localized pages keep the English source in HTML comments, whose
+		// headings render no anchors
+		uncommented, stillInComment := maskHTMLComments(line, inComment)
+		inComment = stillInComment
+		if trimmed := strings.TrimSpace(uncommented); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = true
+			fence = trimmed[:3]
+			continue
+		}
+
+		masked := inlineCodeSpan.ReplaceAllStringFunc(uncommented, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+		for _, match := range htmlIDPattern.FindAllStringSubmatchIndex(masked, -1) {
+			id := strings.ToLower(line[match[2]:match[3]])
+			anchors = append(anchors, pageAnchor{id: id, want: id, line: i + 1, column: match[2] + 1, explicit: true})
+			used[id] = true
+		}
+
+		heading := atxHeadingPattern.FindStringSubmatchIndex(uncommented)
+		if heading == nil || heading[4] < 0 {
+			continue
+		}
+		level := heading[3] - heading[2]
+		text := strings.TrimSpace(line[heading[4]:heading[5]])
+		anchor := pageAnchor{line: i + 1, column: heading[4] + 1, heading: true}
+
+		for len(levels) > 0 && levels[len(levels)-1] >= level {
+			levels = levels[:len(levels)-1]
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) > 0 {
+			anchor.parent = parents[len(parents)-1].id
+		}
+
+		if match := explicitAnchor.FindStringSubmatchIndex(text); match != nil {
+			anchor.explicit = true
+			anchor.id = strings.ToLower(text[match[2]:match[3]])
+			anchor.want = anchor.id
+			anchor.text = strings.TrimSpace(text[:match[0]])
+		} else {
+			anchor.text = text
+			anchor.want = slugify(text)
+			anchor.id = anchor.want
+			for n := 1; used[anchor.id]; n++ {
+				anchor.id = fmt.Sprintf("%s-%d", anchor.want, n)
+			}
+		}
+		used[anchor.id] = true
+
+		anchors = append(anchors, anchor)
+		levels = append(levels, level)
+		parents = append(parents, anchor)
+	}
+	return anchors
+}
+
+// AnchorChecker implements the Checker interface for anchors that break deep
+// links: repeated headings whose numbered anchors shift when the page
+// changes, and anchors defined twice
+type AnchorChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewAnchorChecker creates a new anchor checker
+func NewAnchorChecker() *AnchorChecker {
+	return &AnchorChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (a *AnchorChecker) Name() string {
+	return "Anchor Checker"
+}
+
+// Type returns the type of this checker
+func (a *AnchorChecker) Type() CheckerType {
+	return AnchorsCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (a *AnchorChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	a.projectType = projectType
+	a.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks the anchors of a single markdown file
+func (a *AnchorChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if a.adapter != nil && adapter.ShouldIgnoreFile(filePath, a.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return a.lint(filePath, string(content)), nil
+}
+
+// CheckFiles checks the anchors of multiple markdown files
+func (a *AnchorChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(a, a.projectType, filePaths)
+}
+
+// lint reports repeated and colliding anchors
+func (a *AnchorChecker) lint(filePath, content string) []Issue {
+	anchors := pageAnchors(content)
+
+	taken := make(map[string]bool, len(anchors))
+	for _, anchor := range anchors {
+		taken[anchor.id] = true
+		taken[anchor.want] = true
+	}
+
+	var issues []Issue
+	defined := make(map[string]pageAnchor) // first anchor rendered with an id
+	for _, anchor := range anchors {
+		holder, collides := defined[anchor.want]
+		if _, ok := defined[anchor.id]; !ok {
+			defined[anchor.id] = anchor
+		}
+
+		var issue Issue
+		switch {
+		case !collides:
+			continue
+		case anchor.explicit:
+			issue = Issue{
+				RuleID:  RuleAnchorCollision,
+				Message: fmt.Sprintf("Anchor '#%s' is already defined on line %d", anchor.id, holder.line),
+			}
+		case holder.explicit || holder.want != anchor.want:
+			issue = Issue{
+				RuleID: RuleAnchorCollision,
+				Message: fmt.Sprintf("Anchor '#%s' of this heading is taken by the anchor on line %d; Hugo renders it as '#%s'",
+					anchor.want, holder.line, anchor.id),
+			}
+		default:
+			issue = Issue{
+				RuleID: RuleAnchorDuplicate,
+				Message: fmt.Sprintf("Heading '%s' repeats the heading on line %d; Hugo renders its anchor as '#%s', which changes when headings are added or removed",
+					anchor.text, holder.line, anchor.id),
+			}
+		}
+
+		unique := uniqueAnchor(anchor, taken)
+		taken[unique] = true
+		issue.Type = AnchorsCheckerType
+		issue.File = filePath
+		issue.Line = anchor.line
+		issue.Column = anchor.column
+		issue.Severity = WarningSeverity
+		if rule, ok := LookupRule(issue.RuleID); ok {
+			issue.Severity = rule.Severity
+		}
+		if anchor.heading && !anchor.explicit {
+			// Pin the heading to an anchor of its own
+			issue.Word = anchor.text
+			issue.Suggestions = []string{anchor.text + " {#" + unique + "}"}
+		} else {
+			issue.Message += fmt.Sprintf(" (use a unique anchor such as '#%s')", unique)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// uniqueAnchor suggests an anchor that no other anchor of the page uses,
+// preferring one qualified by the enclosing heading
+func uniqueAnchor(anchor pageAnchor, taken map[string]bool) string {
+	if anchor.parent != "" {
+		if id := anchor.parent + "-" + anchor.want; !taken[id] {
+			return id
+		}
+	}
+	for n := 2; ; n++ {
+		if id := fmt.Sprintf("%s-%d", anchor.want, n); !taken[id] {
+			return id
+		}
+	}
+}
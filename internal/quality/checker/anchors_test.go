@@ -0,0 +1,85 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+// zhPage is a localized page that keeps the English source in comments
+const zhPage = `---
+title: 概述
+---
+<!--
+## Before you begin
+-->
+## 准备开始 {#before-you-begin}
+
+<!--
+## Steps
+-->
+## 步骤 {#steps}
+
+<!-- ## Steps -->
+### 步骤
+`
+
+func TestPageAnchors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"slugs", "# Title\n## Install kubectl\n", []string{"title", "install-kubectl"}},
+		{"repeated heading", "## Example\n## Example\n## Example\n", []string{"example", "example-1", "example-2"}},
+		{"explicit anchor", "## 安装 {#install}\n", []string{"install"}},
+		{"HTML id", `<a id="top"></a>` + "\n## Top\n", []string{"top", "top-1"}},
+		{"fenced code", "```\n# not a heading\n```\n## After\n", []string{"after"}},
+		{"inline code in heading", "## Run `kubectl`\n", []string{"run-kubectl"}},
+		{"commented English source", zhPage, []string{"before-you-begin", "steps", "步骤"}},
+		{"fence in a comment", "<!--\n```\n-->\n## After\n", []string{"after"}},
+		{"comment on the heading line", "<!-- old --> text\n## Kept\n", []string{"kept"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, anchor := range pageAnchors(tt.content) {
+				got = append(got, anchor.id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pageAnchors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnchorLint(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // rule IDs of the issues
+	}{
+		{"unique headings", "## A\n## B\n", nil},
+		{"duplicate heading", "## Example\n## Example\n", []string{RuleAnchorDuplicate}},
+		{"explicit anchor defined twice", "## A {#x}\n## B {#x}\n", []string{RuleAnchorCollision}},
+		{"slug taken by an explicit anchor", "## Setup {#install}\n## Install\n", []string{RuleAnchorCollision}},
+		{"localized page", zhPage, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range NewAnchorChecker().lint("a.md", tt.content) {
+				got = append(got, issue.RuleID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractAnchorsSkipsComments(t *testing.T) {
+	anchors := extractAnchors("<!--\n## Overview\n-->\n## 概述 {#summary}\n")
+	if anchors["overview"] || !anchors["summary"] {
+		t.Errorf("extractAnchors() = %v, want only summary", anchors)
+	}
+}
@@ -0,0 +1,561 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Asset rule IDs
+const (
+	RuleAssetMissing         = "asset-missing"
+	RuleAssetRemovedUpstream = "asset-removed-upstream"
+	RuleAssetReplaced        = "asset-replaced-upstream"
+	RuleAssetUnusedUpstream  = "asset-unused-upstream"
+	RuleAssetAddedUpstream   = "asset-added-upstream"
+	RuleAssetBundleOutdated  = "asset-bundle-outdated"
+)
+
+// defaultContentLanguage is the language localized Hugo pages are
+// translated from, and whose page bundles they share resources with
+const defaultContentLanguage = "en"
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(AssetsCheckerType),
+		Description: "Images and other assets referenced by pages exist",
+		Factory: func() (Checker, error) {
+			return NewAssetChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleAssetMissing,
+		Checker:     AssetsCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Referenced image or asset does not exist",
+		Example:     `{{< figure src="/images/docs/arch.svg" >}}`,
+	})
+	RegisterRule(Rule{
+		ID:          RuleAssetRemovedUpstream,
+		Checker:     AssetsCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Localized page references an asset the English page no longer uses",
+		Example:     "![](/images/docs/old-diagram.png) in content/zh-cn/docs/...",
+	})
+	RegisterRule(Rule{
+		ID:          RuleAssetReplaced,
+		Checker:     AssetsCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Localized page references an asset the English page replaced with another",
+		Example:     "arch.png in content/zh-cn/docs/..., arch-v2.svg in content/en/docs/...",
+	})
+	RegisterRule(Rule{
+		ID:          RuleAssetUnusedUpstream,
+		Checker:     AssetsCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Localized page references an existing asset the English page no longer uses",
+		Example:     "![](/images/docs/old-diagram.png) in content/zh-cn/docs/...",
+	})
+	RegisterRule(Rule{
+		ID:          RuleAssetAddedUpstream,
+		Checker:     AssetsCheckerType,
+		Severity:    WarningSeverity,
+		Description: "English page references an asset its translation doesn't",
+		Example:     "![](/images/docs/new-diagram.svg) in content/en/docs/... only",
+	})
+	RegisterRule(Rule{
+		ID:          RuleAssetBundleOutdated,
+		Checker:     AssetsCheckerType,
+		Severity:    WarningSeverity,
+		Description: "File of a localized page bundle differs from the English bundle's",
+		Example:     "content/zh-cn/docs/.../diagram.svg",
+	})
+}
+
+var (
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	htmlAssetPattern     = regexp.MustCompile(`<(?i:img|source|video|audio|embed|track)\b[^>]*?\s(?i:src|poster)\s*=\s*["']([^"']+)["']`)
+	shortcodePattern     = regexp.MustCompile(`\{\{[<%].*?[%>]\}\}`)
+	shortcodeAssetParam  = regexp.MustCompile(`\s(?:src|image|poster)\s*=\s*["']([^"']+)["']`)
+)
+
+// AssetChecker implements the Checker interface for images, figures and
+// other assets referenced by markdown pages
+type AssetChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewAssetChecker creates a new asset checker
+func NewAssetChecker() *AssetChecker {
+	return &AssetChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (a *AssetChecker) Name() string {
+	return "Asset Checker"
+}
+
+// Type returns the type of this checker
+func (a *AssetChecker) Type() CheckerType {
+	return AssetsCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (a *AssetChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	a.projectType = projectType
+	a.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks the assets referenced by a single markdown file
+func (a *AssetChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if a.adapter != nil && adapter.ShouldIgnoreFile(filePath, a.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var issues []Issue
+	var upstream []markdownLink // references of the English page, read on demand
+	upstreamRead := false
+	refs := assetReferences(string(content))
+	for _, ref := range refs {
+		assetPath, ok := localAssetPath(ref.target)
+		if !ok || assetExists(filePath, assetPath) {
+			continue
+		}
+
+		issue := Issue{
+			Type:     AssetsCheckerType,
+			Severity: ErrorSeverity,
+			File:     filePath,
+			Line:     ref.line,
+			Column:   ref.column,
+			Word:     ref.target,
+			RuleID:   RuleAssetMissing,
+			Message:  fmt.Sprintf("Asset '%s' does not exist", assetPath),
+		}
+
+		source := upstreamPage(filePath)
+		if source != "" {
+			if !upstreamRead {
+				upstream = readAssetReferences(source)
+				upstreamRead = true
+			}
+			issue.RuleID = RuleAssetRemovedUpstream
+			issue.Message = fmt.Sprintf("Asset '%s' does not exist and %s no longer references it", assetPath, source)
+			for _, theirs := range upstream {
+				if theirs.target == ref.target {
+					// Missing upstream too, not a stale translation
+					issue.RuleID = RuleAssetMissing
+					issue.Message = fmt.Sprintf("Asset '%s' does not exist", assetPath)
+					break
+				}
+				// The asset may have been moved or renamed upstream
+				if path.Base(theirs.target) == path.Base(ref.target) && issue.Suggestions == nil {
+					issue.Message = fmt.Sprintf("Asset '%s' does not exist; %s references it as '%s'", assetPath, source, theirs.target)
+					issue.Suggestions = []string{theirs.target}
+				}
+			}
+			if issue.RuleID == RuleAssetMissing {
+				issue.Suggestions = nil
+			}
+		}
+
+		if rule, ok := LookupRule(issue.RuleID); ok {
+			issue.Severity = rule.Severity
+		}
+		issues = append(issues, issue)
+	}
+
+	if source := upstreamPage(filePath); source != "" {
+		issues = append(issues, compareUpstreamAssets(filePath, source, refs)...)
+		issues = append(issues, outdatedBundleFiles(filePath, source, refs)...)
+	}
+	return issues, nil
+}
+
+// compareUpstreamAssets compares the existing assets a localized page
+// references with those of its English page, by file name so that assets
+// moved upstream still match. When as many assets are only referenced by
+// the translation as only by the English page, they are paired in order as
+// replaced upstream; otherwise they are reported as no longer used and as
+// added upstream.
+func compareUpstreamAssets(filePath, source string, refs []markdownLink) []Issue {
+	theirs := readAssetReferences(source)
+	lang := filepath.Base(hugoContentRoot(filePath))
+
+	theirNames := make(map[string]bool)
+	for _, ref := range theirs {
+		if assetPath, ok := localAssetPath(ref.target); ok {
+			theirNames[path.Base(assetPath)] = true
+		}
+	}
+	ourNames := make(map[string]bool)
+	var stale []markdownLink
+	var staleNames []string
+	for _, ref := range refs {
+		assetPath, ok := localAssetPath(ref.target)
+		if !ok {
+			continue
+		}
+		name := path.Base(assetPath)
+		ourNames[name] = true
+		// Missing assets are reported already, and translated ones are the
+		// translation's own
+		if theirNames[name] || !assetExists(filePath, assetPath) || localizedAsset(filePath, source, assetPath, lang) {
+			continue
+		}
+		if !containsString(staleNames, name) {
+			staleNames = append(staleNames, name)
+		}
+		stale = append(stale, ref)
+	}
+	var added []markdownLink
+	var addedNames []string
+	for _, ref := range theirs {
+		assetPath, ok := localAssetPath(ref.target)
+		if !ok {
+			continue
+		}
+		if name := path.Base(assetPath); !ourNames[name] && !containsString(addedNames, name) {
+			addedNames = append(addedNames, name)
+			added = append(added, ref)
+		}
+	}
+
+	replacements := make(map[string]markdownLink)
+	if len(staleNames) == len(added) {
+		for i, name := range staleNames {
+			replacements[name] = added[i]
+		}
+		added = nil
+	}
+	var issues []Issue
+	for _, ref := range stale {
+		assetPath, _ := localAssetPath(ref.target)
+		if replacement, ok := replacements[path.Base(assetPath)]; ok {
+			issue := newAssetIssue(filePath, ref, RuleAssetReplaced,
+				fmt.Sprintf("Asset '%s' was replaced upstream: %s:%d references '%s'", assetPath, source, replacement.line, replacement.target))
+			issue.Suggestions = []string{replacement.target}
+			issues = append(issues, issue)
+			continue
+		}
+		issues = append(issues, newAssetIssue(filePath, ref, RuleAssetUnusedUpstream,
+			fmt.Sprintf("Asset '%s' is no longer used by %s", assetPath, source)))
+	}
+	for _, ref := range added {
+		issue := newAssetIssue(filePath, markdownLink{line: 1, column: 1}, RuleAssetAddedUpstream,
+			fmt.Sprintf("%s:%d references '%s', which the translation doesn't", source, ref.line, ref.target))
+		issue.Word = ""
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// localizedAsset reports whether an asset belongs to the translation
+// rather than the English page: a path with a segment naming the language,
+// like /images/docs/zh-cn/arch.svg, or a file of the localized page bundle
+// the English bundle doesn't have
+func localizedAsset(filePath, source, assetPath, lang string) bool {
+	for _, segment := range strings.Split(assetPath, "/") {
+		if segment == lang {
+			return true
+		}
+	}
+	if strings.HasPrefix(assetPath, "/") {
+		return false
+	}
+	return fileExistsOrDir(filepath.Join(filepath.Dir(filePath), assetPath)) &&
+		!fileExistsOrDir(filepath.Join(filepath.Dir(source), assetPath))
+}
+
+// outdatedBundleFiles reports the files of the page bundle of a localized
+// page that differ from the files of the same name in the English bundle,
+// left behind when an image was replaced upstream under the same name.
+// Branch bundles (_index.md) only hold the files next to the page.
+func outdatedBundleFiles(filePath, source string, refs []markdownLink) []Issue {
+	base := filepath.Base(filePath)
+	if base != "index.md" && base != "_index.md" {
+		return nil
+	}
+	dir, sourceDir := filepath.Dir(filePath), filepath.Dir(source)
+
+	var issues []Issue
+	_ = filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if file != dir && base == "_index.md" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isMarkdownFile(file) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return nil
+		}
+		ours, err := os.ReadFile(file)
+		if err != nil {
+			return nil
+		}
+		theirs, err := os.ReadFile(filepath.Join(sourceDir, rel))
+		if err != nil || bytes.Equal(ours, theirs) {
+			return nil
+		}
+
+		// Point at the reference of the file when the page has one
+		at := markdownLink{line: 1, column: 1}
+		for _, ref := range refs {
+			if assetPath, ok := localAssetPath(ref.target); ok && path.Clean(assetPath) == filepath.ToSlash(rel) {
+				at = ref
+				break
+			}
+		}
+		issue := newAssetIssue(filePath, at, RuleAssetBundleOutdated,
+			fmt.Sprintf("Bundle file '%s' differs from %s; copy it again if it was replaced upstream", rel, filepath.Join(sourceDir, rel)))
+		issue.Word = ""
+		issues = append(issues, issue)
+		return nil
+	})
+	return issues
+}
+
+// newAssetIssue returns an issue of an asset rule at a reference, with the
+// severity of the rule
+func newAssetIssue(filePath string, ref markdownLink, ruleID, message string) Issue {
+	issue := Issue{
+		Type:     AssetsCheckerType,
+		Severity: WarningSeverity,
+		File:     filePath,
+		Line:     ref.line,
+		Column:   ref.column,
+		Word:     ref.target,
+		RuleID:   ruleID,
+		Message:  message,
+	}
+	if rule, ok := LookupRule(ruleID); ok {
+		issue.Severity = rule.Severity
+	}
+	return issue
+}
+
+// crossFile marks the results as depending on the assets on disk
+func (a *AssetChecker) crossFile() {}
+
+// CheckFiles checks the assets referenced by multiple markdown files
+func (a *AssetChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(a, a.projectType, filePaths)
+}
+
+// assetReferences finds images, HTML media sources and the src, image and
+// poster parameters of Hugo shortcodes outside of code and HTML comments,
+// where translations keep the English text
+func assetReferences(content string) []markdownLink {
+	var refs []markdownLink
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	inComment := false
+	fence := ""
+
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			marker := trimmed[:3]
+			if !inCodeBlock {
+				inCodeBlock = true
+				fence = marker
+			} else if marker == fence {
+				inCodeBlock = false
+			}
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		var masked string
+		masked, inComment = maskHTMLComments(line, inComment)
+		masked = inlineCodeSpan.ReplaceAllStringFunc(masked, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+
+		var matches [][]int
+		matches = append(matches, markdownImagePattern.FindAllStringSubmatchIndex(masked, -1)...)
+		matches = append(matches, htmlAssetPattern.FindAllStringSubmatchIndex(masked, -1)...)
+		for _, shortcode := range shortcodePattern.FindAllStringIndex(masked, -1) {
+			for _, match := range shortcodeAssetParam.FindAllStringSubmatchIndex(masked[shortcode[0]:shortcode[1]], -1) {
+				matches = append(matches, []int{match[0] + shortcode[0], match[1] + shortcode[0], match[2] + shortcode[0], match[3] + shortcode[0]})
+			}
+		}
+
+		for _, match := range matches {
+			target := masked[match[2]:match[3]]
+			if target == "" || strings.Contains(target, "{{") {
+				continue
+			}
+			refs = append(refs, markdownLink{target: target, line: i + 1, column: match[2] + 1})
+		}
+	}
+	return refs
+}
+
+// maskHTMLComments blanks out the HTML comments of a line, keeping the
+// columns of the rest, and reports whether a comment is still open at its
+// end
+func maskHTMLComments(line string, inComment bool) (string, bool) {
+	masked := []byte(line)
+	for i := 0; i < len(line); {
+		if inComment {
+			end := strings.Index(line[i:], "-->")
+			stop := len(line)
+			if end >= 0 {
+				stop = i + end + 3
+				inComment = false
+			}
+			for j := i; j < stop; j++ {
+				masked[j] = ' '
+			}
+			i = stop
+			continue
+		}
+		start := strings.Index(line[i:], "<!--")
+		if start < 0 {
+			break
+		}
+		inComment = true
+		i += start
+	}
+	return string(masked), inComment
+}
+
+// readAssetReferences returns the asset references of a file, or nil when
+// it can't be read
+func readAssetReferences(filePath string) []markdownLink {
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil
+	}
+	return assetReferences(string(content))
+}
+
+// localAssetPath returns the decoded path of a reference to a local asset
+func localAssetPath(target string) (string, bool) {
+	if strings.HasPrefix(target, "//") || strings.HasPrefix(target, "#") || !isLocalLink(target) {
+		return "", false
+	}
+	target, _, _ = strings.Cut(target, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if decoded, err := url.PathUnescape(target); err == nil {
+		target = decoded
+	}
+	return target, target != ""
+}
+
+// assetExists resolves an asset path the way Hugo publishes it: relative to
+// the page or its bundle, site-absolute under static/ or the content root,
+// and for localized pages falling back to the bundle of the default language
+func assetExists(filePath, assetPath string) bool {
+	contentRoot := hugoContentRoot(filePath)
+
+	var candidates []string
+	if strings.HasPrefix(assetPath, "/") {
+		if contentRoot == "" {
+			// Site-absolute paths can't be mapped outside a Hugo site
+			return true
+		}
+		siteRoot := filepath.Dir(filepath.Dir(contentRoot))
+		trimmed := strings.TrimPrefix(assetPath, "/")
+		candidates = append(candidates, filepath.Join(siteRoot, "static", trimmed))
+		// Drop a leading language prefix such as /zh-cn/docs/...
+		if parts := strings.SplitN(trimmed, "/", 2); len(parts) == 2 && filepath.Base(contentRoot) == parts[0] {
+			trimmed = parts[1]
+		}
+		candidates = append(candidates, filepath.Join(contentRoot, trimmed))
+	} else {
+		candidates = append(candidates, filepath.Join(filepath.Dir(filePath), assetPath))
+		// Pretty URLs make paths relative to the page, not its directory
+		if base := filepath.Base(filePath); base != "index.md" && base != "_index.md" {
+			pageDir := strings.TrimSuffix(filePath, filepath.Ext(filePath))
+			candidates = append(candidates, filepath.Join(pageDir, assetPath))
+		}
+	}
+
+	// Translations share the resources of the default language's bundle
+	if contentRoot != "" && filepath.Base(contentRoot) != defaultContentLanguage {
+		defaultRoot := filepath.Join(filepath.Dir(contentRoot), defaultContentLanguage)
+		for _, candidate := range candidates {
+			absCandidate, err := filepath.Abs(candidate)
+			if err != nil {
+				continue
+			}
+			if rel, err := filepath.Rel(contentRoot, absCandidate); err == nil && !strings.HasPrefix(rel, "..") {
+				candidates = append(candidates, filepath.Join(defaultRoot, rel))
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if fileExistsOrDir(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// upstreamPage returns the default-language page a localized Hugo page is
+// translated from, or "" when the page is not localized or has no source
+func upstreamPage(filePath string) string {
+	contentRoot := hugoContentRoot(filePath)
+	if contentRoot == "" || filepath.Base(contentRoot) == defaultContentLanguage {
+		return ""
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(contentRoot, absPath)
+	if err != nil {
+		return ""
+	}
+	source := filepath.Join(filepath.Dir(contentRoot), defaultContentLanguage, rel)
+	if !fileExistsOrDir(source) {
+		return ""
+	}
+	// Report the page the way the localized file was given
+	if !filepath.IsAbs(filePath) {
+		if wd, err := os.Getwd(); err == nil {
+			if relSource, err := filepath.Rel(wd, source); err == nil {
+				return relSource
+			}
+		}
+	}
+	return source
+}
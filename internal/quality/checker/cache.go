@@ -0,0 +1,171 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/samzong/mm/internal/cache"
+)
+
+// cacheVersion is bumped whenever cached results of an older mm must not be
+// reused, in addition to the build revision
+const cacheVersion = "1"
+
+// Fingerprinter is implemented by checkers whose results depend on
+// configuration beyond the project type, such as loaded dictionaries. The
+// fingerprint changes whenever that configuration does.
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// crossFileChecker is implemented by checkers whose results depend on files
+// other than the one checked, which the result cache can't track
+type crossFileChecker interface {
+	crossFile()
+}
+
+// ResultCache stores the issues of checked files keyed by file content and
+// checker configuration, so unchanged files are not checked again
+type ResultCache struct {
+	store   *cache.Store
+	key     string
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	dirty   bool
+}
+
+// cacheEntry is the cached result of one file
+type cacheEntry struct {
+	Key    string  `json:"key"`
+	Issues []Issue `json:"issues"`
+}
+
+// resultCache is the cache used by checkFiles, nil when caching is off
+var resultCache *ResultCache
+
+// EnableCache makes checkers reuse and store results in cache. Passing nil
+// disables caching.
+func EnableCache(cache *ResultCache) {
+	resultCache = cache
+}
+
+// LoadResultCache opens the result cache of a checker type, kept in the
+// results namespace of the mm cache. A missing, expired or unreadable cache
+// starts out empty.
+func LoadResultCache(checkerType CheckerType) (*ResultCache, error) {
+	rc := &ResultCache{
+		store:   cache.New(cache.Results),
+		key:     string(checkerType),
+		entries: make(map[string]cacheEntry),
+	}
+
+	entry, err := rc.store.Load(rc.key, &rc.entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result cache: %w", err)
+	}
+	if entry == nil || entry.Expired() || rc.entries == nil {
+		rc.entries = make(map[string]cacheEntry)
+	}
+	return rc, nil
+}
+
+// Get returns the cached issues of a file if its key still matches
+func (rc *ResultCache) Get(filePath, key string) ([]Issue, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[cachePathKey(filePath)]
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+
+	// Report the path the file was given as this time
+	issues := make([]Issue, len(entry.Issues))
+	for i, issue := range entry.Issues {
+		issue.File = filePath
+		issues[i] = issue
+	}
+	return issues, true
+}
+
+// Put stores the issues of a file
+func (rc *ResultCache) Put(filePath, key string, issues []Issue) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[cachePathKey(filePath)] = cacheEntry{Key: key, Issues: issues}
+	rc.dirty = true
+}
+
+// Save writes the cache back to disk if it changed
+func (rc *ResultCache) Save() error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if !rc.dirty {
+		return nil
+	}
+	if err := rc.store.Put(rc.key, rc.entries); err != nil {
+		return fmt.Errorf("failed to write result cache: %w", err)
+	}
+	rc.dirty = false
+	return nil
+}
+
+// cachePathKey identifies a file independently of how its path was given
+func cachePathKey(filePath string) string {
+	if abs, err := filepath.Abs(filePath); err == nil {
+		return abs
+	}
+	return filePath
+}
+
+// checkerFingerprint describes everything besides the file content that a
+// checker's results depend on
+func checkerFingerprint(c Checker, projectType string) string {
+	fingerprint := cacheVersion + "\x00" + buildRevision() + "\x00" + string(c.Type()) + "\x00" + projectType
+	if f, ok := c.(Fingerprinter); ok {
+		fingerprint += "\x00" + f.Fingerprint()
+	}
+	return fingerprint
+}
+
+// cacheKey combines a checker fingerprint with the content of a file
+func cacheKey(fingerprint string, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(fingerprint))
+	h.Write([]byte{0})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// buildRevision identifies the mm build so results of other versions are
+// not reused
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	revision := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" || setting.Key == "vcs.time" {
+			revision += " " + setting.Value
+		}
+	}
+	return revision
+}
+
+// fingerprintOf hashes values into a fingerprint
+func fingerprintOf(values ...interface{}) string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,93 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countingChecker reports one issue per file and counts the files it
+// actually checked
+type countingChecker struct {
+	checked int
+	setting string
+}
+
+func (c *countingChecker) Name() string            { return "Counting Checker" }
+func (c *countingChecker) Type() CheckerType       { return MarkdownCheckerType }
+func (c *countingChecker) SetProject(string) error { return nil }
+func (c *countingChecker) Fingerprint() string     { return c.setting }
+func (c *countingChecker) CheckFiles(paths []string) (*CheckResult, error) {
+	return checkFiles(c, "generic", paths)
+}
+
+func (c *countingChecker) CheckFile(filePath string) ([]Issue, error) {
+	c.checked++
+	return []Issue{{Type: MarkdownCheckerType, Severity: WarningSeverity, File: filePath, Line: 1, Message: "checked"}}, nil
+}
+
+func TestResultCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.md")
+	if err := os.WriteFile(file, []byte("one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// run checks the file with a freshly loaded cache, as each mm run does
+	run := func(c *countingChecker, path string) *CheckResult {
+		t.Helper()
+		cache, err := LoadResultCache(c.Type())
+		if err != nil {
+			t.Fatal(err)
+		}
+		EnableCache(cache)
+		defer EnableCache(nil)
+		result, err := c.CheckFiles([]string{path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	c := &countingChecker{setting: "a"}
+	run(c, file)
+	result := run(c, file)
+	if c.checked != 1 {
+		t.Errorf("unchanged file checked %d times, want once", c.checked)
+	}
+	if result.TotalIssues != 1 || result.Issues[0].File != file {
+		t.Errorf("cached result = %+v, want the issue of %s", result.Issues, file)
+	}
+
+	// The same file given by another path reports that path
+	relative, err := filepath.Rel(mustGetwd(t), file)
+	if err == nil {
+		if result := run(c, relative); c.checked != 1 || result.Issues[0].File != relative {
+			t.Errorf("cached result by relative path = %+v after %d checks", result.Issues, c.checked)
+		}
+	}
+
+	if err := os.WriteFile(file, []byte("two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run(c, file)
+	if c.checked != 2 {
+		t.Errorf("changed file checked %d times in all, want twice", c.checked)
+	}
+
+	c.setting = "b"
+	run(c, file)
+	if c.checked != 3 {
+		t.Errorf("file checked %d times in all after a configuration change, want 3", c.checked)
+	}
+}
+
+func mustGetwd(t *testing.T) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wd
+}
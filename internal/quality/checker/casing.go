@@ -0,0 +1,249 @@
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/dictionary"
+	"github.com/samzong/mm/internal/quality/extract"
+)
+
+// RuleProperNounCasing is reported for known terms written with the wrong casing
+const RuleProperNounCasing = "proper-noun-casing"
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(CasingCheckerType),
+		Description: "Casing of product names and terms from the project dictionaries",
+		Factory: func() (Checker, error) {
+			return NewCasingChecker()
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleProperNounCasing,
+		Checker:     CasingCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Known term is not written with its canonical casing",
+		Example:     "Github → GitHub",
+	})
+}
+
+// CasingChecker implements the Checker interface for the casing of proper
+// nouns. Terms come from the project adapter and from the mixed-case words
+// of the loaded dictionaries.
+type CasingChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	dictManager *dictionary.Manager
+	// terms maps lowercase terms to their canonical casing
+	terms map[string]string
+	// Dictionaries are loaded in addition to the project's dictionaries
+	Dictionaries []string
+	// DataKeys are glob patterns of the keys whose values are checked in
+	// YAML, JSON and TOML files (default extract.DefaultDataKeys)
+	DataKeys []string
+}
+
+// NewCasingChecker creates a new casing checker instance
+func NewCasingChecker() (*CasingChecker, error) {
+	dictManager, err := dictionary.NewManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dictionary manager: %w", err)
+	}
+
+	return &CasingChecker{
+		projectType: "generic",
+		dictManager: dictManager,
+	}, nil
+}
+
+// Name returns the name of this checker
+func (c *CasingChecker) Name() string {
+	return "Casing Checker"
+}
+
+// Type returns the type of this checker
+func (c *CasingChecker) Type() CheckerType {
+	return CasingCheckerType
+}
+
+// SetProject sets the project type and loads the terms of its dictionaries
+func (c *CasingChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+
+	dictionaries := append(projectAdapter.GetDictionaries(), c.Dictionaries...)
+	if err := c.dictManager.LoadDictionaries(dictionaries); err != nil {
+		return err
+	}
+
+	c.terms = make(map[string]string)
+	ambiguous := make(map[string]bool)
+	for _, dict := range c.dictManager.Dictionaries() {
+		for _, word := range dict.Words {
+			lower := strings.ToLower(word)
+			if canonical, ok := c.terms[lower]; ok && canonical != word {
+				// Words listed with several casings have no canonical one
+				ambiguous[lower] = true
+				continue
+			}
+			c.terms[lower] = word
+		}
+	}
+	for lower, word := range c.terms {
+		if ambiguous[lower] || !isMixedCase(word) {
+			delete(c.terms, lower)
+		}
+	}
+
+	// The adapter's own terms win over the dictionaries
+	for _, term := range projectAdapter.GetProperNouns() {
+		c.terms[strings.ToLower(term)] = term
+	}
+	return nil
+}
+
+// isMixedCase reports whether a word has both upper and lower case letters
+func isMixedCase(word string) bool {
+	return word != strings.ToLower(word) && word != strings.ToUpper(word)
+}
+
+// CheckFile checks a single file for miscased terms
+func (c *CasingChecker) CheckFile(filePath string) ([]Issue, error) {
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	text, err := extract.Prose(string(content), filepath.Ext(filePath), extract.Options{
+		DataKeys: c.DataKeys,
+		Markup:   adapterMarkup(c.adapter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract text from %s: %w", filePath, err)
+	}
+
+	if c.terms == nil {
+		if err := c.SetProject(c.projectType); err != nil {
+			return nil, err
+		}
+	}
+
+	var issues []Issue
+	for i, line := range strings.Split(text, "\n") {
+		issues = append(issues, c.checkLine(filePath, i+1, line)...)
+	}
+	return issues, nil
+}
+
+// checkLine reports the words of a line that are known terms written with
+// another casing
+func (c *CasingChecker) checkLine(filePath string, lineNum int, line string) []Issue {
+	var issues []Issue
+	for start := 0; start < len(line); {
+		r, size := utf8.DecodeRuneInString(line[start:])
+		if !isWordRune(r) {
+			start += size
+			continue
+		}
+		end := start
+		for end < len(line) {
+			r, size := utf8.DecodeRuneInString(line[end:])
+			if !isWordRune(r) {
+				break
+			}
+			end += size
+		}
+
+		word := line[start:end]
+		if canonical, ok := c.terms[strings.ToLower(word)]; ok && miscased(word, canonical, sentenceStart(line, start)) && !partOfName(line, start, end) {
+			issues = append(issues, Issue{
+				Type:        CasingCheckerType,
+				Severity:    WarningSeverity,
+				File:        filePath,
+				Line:        lineNum,
+				Column:      start + 1,
+				Word:        word,
+				Message:     fmt.Sprintf("Incorrect casing: '%s' should be '%s'", word, canonical),
+				Suggestions: []string{canonical},
+				RuleID:      RuleProperNounCasing,
+			})
+		}
+		start = end
+	}
+	return issues
+}
+
+// miscased reports whether word is written differently from canonical.
+// Words in capitals are left alone, as is a lowercase term capitalized at
+// the start of a sentence or heading.
+func miscased(word, canonical string, atSentenceStart bool) bool {
+	if word == canonical {
+		return false
+	}
+	if utf8.RuneCountInString(word) > 1 && word == strings.ToUpper(word) {
+		return false
+	}
+	if atSentenceStart && canonical == strings.ToLower(canonical) {
+		first, size := utf8.DecodeRuneInString(canonical)
+		if word == string(unicode.ToUpper(first))+canonical[size:] {
+			return false
+		}
+	}
+	return true
+}
+
+// sentenceStart reports whether line[start:] starts a line or a sentence
+func sentenceStart(line string, start int) bool {
+	before := strings.TrimRightFunc(line[:start], unicode.IsSpace)
+	return before == "" || strings.ContainsAny(before[len(before)-1:], ".!?:")
+}
+
+// partOfName reports whether line[start:end] belongs to a host name, path,
+// file name, handle or hyphenated identifier rather than prose
+func partOfName(line string, start, end int) bool {
+	if start > 0 && strings.IndexByte("./@-_#$", line[start-1]) >= 0 {
+		return true
+	}
+	if end < len(line) {
+		switch line[end] {
+		case '/', '@', '-', '_':
+			return true
+		case '.', ':':
+			// A sentence may end after the term, a domain or port may not
+			next, _ := utf8.DecodeRuneInString(line[end+1:])
+			return end+1 < len(line) && isWordRune(next)
+		}
+	}
+	return false
+}
+
+// Fingerprint identifies the terms in use
+func (c *CasingChecker) Fingerprint() string {
+	terms := make([]string, 0, len(c.terms))
+	for _, term := range c.terms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	return fingerprintOf(terms, c.DataKeys)
+}
+
+// CheckFiles checks multiple files for miscased terms
+func (c *CasingChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
@@ -0,0 +1,89 @@
+package checker
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// checkstyleVersion is the checkstyle report version consumers expect
+const checkstyleVersion = "8.0"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// OutputCheckstyle outputs the check result as checkstyle XML, as consumed
+// by reviewdog (-f=checkstyle) and the Jenkins warnings plugin
+func (r *CheckResult) OutputCheckstyle(w io.Writer) error {
+	report := checkstyleReport{Version: checkstyleVersion}
+	fileIndex := make(map[string]int)
+
+	for _, issue := range r.Issues {
+		index, ok := fileIndex[issue.File]
+		if !ok {
+			index = len(report.Files)
+			fileIndex[issue.File] = index
+			report.Files = append(report.Files, checkstyleFile{Name: issue.File})
+		}
+
+		message := issue.Message
+		if len(issue.Suggestions) > 0 {
+			message += " (suggestions: " + joinStrings(issue.Suggestions, ", ") + ")"
+		}
+
+		report.Files[index].Errors = append(report.Files[index].Errors, checkstyleError{
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Severity: checkstyleSeverity(issue.Severity),
+			Message:  message,
+			Source:   checkstyleSource(issue),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// checkstyleSeverity maps a severity to a checkstyle severity
+func checkstyleSeverity(severity Severity) string {
+	switch severity {
+	case ErrorSeverity:
+		return "error"
+	case WarningSeverity:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// checkstyleSource builds a dotted source name such as mm.markdown.MD013
+func checkstyleSource(issue Issue) string {
+	source := "mm." + string(issue.Type)
+	if issue.RuleID != "" {
+		source += "." + issue.RuleID
+	}
+	return source
+}
@@ -0,0 +1,49 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestOutputCheckstyle(t *testing.T) {
+	result := &CheckResult{Issues: []Issue{
+		{Type: SpellCheckerType, Severity: WarningSeverity, File: "a.md", Line: 2, Column: 8, Message: "Unknown word", Suggestions: []string{"the"}, RuleID: "spell-check"},
+		{Type: LinksCheckerType, Severity: ErrorSeverity, File: "a.md", Line: 5, Message: "Broken link"},
+		{Type: MarkdownCheckerType, Severity: InfoSeverity, File: "b.md", Line: 1, Column: 1, Message: "Long line", RuleID: "MD013"},
+	}}
+	var out bytes.Buffer
+	if err := result.OutputCheckstyle(&out); err != nil {
+		t.Fatal(err)
+	}
+	var report checkstyleReport
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out.String())
+	}
+
+	want := []checkstyleFile{
+		{Name: "a.md", Errors: []checkstyleError{
+			{Line: 2, Column: 8, Severity: "warning", Message: "Unknown word (suggestions: the)", Source: "mm.spell.spell-check"},
+			{Line: 5, Severity: "error", Message: "Broken link", Source: "mm.links"},
+		}},
+		{Name: "b.md", Errors: []checkstyleError{
+			{Line: 1, Column: 1, Severity: "info", Message: "Long line", Source: "mm.markdown.MD013"},
+		}},
+	}
+	if len(report.Files) != len(want) {
+		t.Fatalf("files = %+v, want %+v", report.Files, want)
+	}
+	for i := range want {
+		if report.Files[i].Name != want[i].Name || len(report.Files[i].Errors) != len(want[i].Errors) {
+			t.Fatalf("file %d = %+v, want %+v", i, report.Files[i], want[i])
+		}
+		for j, e := range want[i].Errors {
+			if report.Files[i].Errors[j] != e {
+				t.Errorf("error %d of %s = %+v, want %+v", j, want[i].Name, report.Files[i].Errors[j], e)
+			}
+		}
+	}
+	if bytes.Contains(out.Bytes(), []byte(`column="0"`)) {
+		t.Errorf("issues without a column have column=\"0\":\n%s", out.String())
+	}
+}
@@ -0,0 +1,342 @@
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/locale"
+)
+
+// Chinese style rule IDs
+const (
+	RuleZhSpacing          = "zh-spacing"
+	RuleZhPunctuationWidth = "zh-punctuation-width"
+	RuleZhDeDiDe           = "zh-de-di-de"
+	RuleZhRepeatedPunct    = "zh-repeated-punctuation"
+	RuleZhFullWidthAlnum   = "zh-fullwidth-alnum"
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(ChineseCheckerType),
+		Description: "Chinese copywriting style",
+		Factory: func() (Checker, error) {
+			return NewChineseChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleZhSpacing,
+		Checker:     ChineseCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Missing space between Chinese and English words or numbers",
+		Example:     "使用kubectl命令 → 使用 kubectl 命令",
+	})
+	RegisterRule(Rule{
+		ID:          RuleZhPunctuationWidth,
+		Checker:     ChineseCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Half-width punctuation used in Chinese text",
+		Example:     "创建Pod,然后 → 创建 Pod，然后",
+	})
+	RegisterRule(Rule{
+		ID:          RuleZhDeDiDe,
+		Checker:     ChineseCheckerType,
+		Severity:    InfoSeverity,
+		Description: "Possible confusion between 的, 地 and 得",
+		Example:     "自动的创建 → 自动地创建",
+	})
+	RegisterRule(Rule{
+		ID:          RuleZhRepeatedPunct,
+		Checker:     ChineseCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Repeated punctuation marks",
+		Example:     "注意！！ → 注意！",
+	})
+	RegisterRule(Rule{
+		ID:          RuleZhFullWidthAlnum,
+		Checker:     ChineseCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Full-width digits or letters should be half-width",
+		Example:     "版本１．２８ → 版本 1.28",
+	})
+}
+
+// hanRange matches the same characters as the format command's rules
+const hanRange = `一-龯`
+
+var (
+	hanPresence        = regexp.MustCompile(`[` + hanRange + `]`)
+	hanThenLatin       = regexp.MustCompile(`[` + hanRange + `][a-zA-Z0-9]`)
+	latinThenHan       = regexp.MustCompile(`[a-zA-Z0-9][` + hanRange + `]`)
+	halfWidthPunct     = regexp.MustCompile(`[,;:!?]`)
+	repeatedPunct      = regexp.MustCompile(`([，。！？；：、])[，。！？；：、]+`)
+	fullWidthAlnum     = regexp.MustCompile(`[０-９Ａ-Ｚａ-ｚ]+`)
+	maskedSpanPattern  = regexp.MustCompile("`[^`]*`" + `|https?://\S+|\]\([^)]*\)|\{\{[^}]*\}\}|<[^>]+>`)
+	adverbBeforeVerb   = regexp.MustCompile(`(自动|手动|动态|快速|认真|仔细|轻松|简单|有效|逐步|安全|正确|顺利|成功|持续|定期|显式|隐式)的(创建|部署|运行|处理|完成|管理|删除|配置|更新|扩展|扩缩|启动|实现|访问|调度|检查|加载|生成|重启|终止)`)
+	verbBeforeComplete = regexp.MustCompile(`(运行|处理|执行|工作|变|做|写|跑|扩展|增长|完成)[的地](很|非常|更|太|比较|足够|越来越)`)
+	halfToFullPunct    = map[byte]string{',': "，", ';': "；", ':': "：", '!': "！", '?': "？"}
+)
+
+// ChineseChecker implements the Checker interface with zhlint-style rules
+type ChineseChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewChineseChecker creates a new Chinese style checker
+func NewChineseChecker() *ChineseChecker {
+	return &ChineseChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (c *ChineseChecker) Name() string {
+	return "Chinese Style Checker"
+}
+
+// Type returns the type of this checker
+func (c *ChineseChecker) Type() CheckerType {
+	return ChineseCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (c *ChineseChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks a single file for Chinese style problems
+func (c *ChineseChecker) CheckFile(filePath string) ([]Issue, error) {
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	// Nothing to check in files without Chinese text, or in translations
+	// into other languages, such as Japanese
+	if !hanPresence.Match(content) {
+		return nil, nil
+	}
+	if lang := locale.FromPath(filePath); lang != "" && !locale.IsChinese(lang) {
+		return nil, nil
+	}
+
+	return c.lint(filePath, string(content)), nil
+}
+
+// CheckFiles checks multiple files for Chinese style problems
+func (c *ChineseChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
+
+// lint runs every Chinese style rule over prose lines
+func (c *ChineseChecker) lint(filePath, content string) []Issue {
+	var issues []Issue
+	add := func(ruleID string, line, column int, word, message string, suggestion string) {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		issue := Issue{
+			Type:     ChineseCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Word:     word,
+			Message:  message,
+			RuleID:   ruleID,
+		}
+		if suggestion != "" {
+			issue.Suggestions = []string{suggestion}
+		}
+		issues = append(issues, issue)
+	}
+
+	forEachProseLine(content, func(lineNum int, line string) {
+		// Blank out code, URLs, link targets and shortcodes, keeping offsets
+		masked := maskSpans(line)
+		if !hanPresence.MatchString(masked) {
+			return
+		}
+
+		for _, pattern := range []*regexp.Regexp{hanThenLatin, latinThenHan} {
+			for _, loc := range findAllOverlapping(pattern, masked) {
+				word := masked[loc[0]:loc[1]]
+				first, size := firstRune(word)
+				add(RuleZhSpacing, lineNum, loc[0]+1, word,
+					"Missing space between Chinese and English text",
+					first+" "+word[size:])
+			}
+		}
+
+		for _, loc := range halfWidthPunct.FindAllStringIndex(masked, -1) {
+			if !besideHan(masked, loc[0], loc[1]) {
+				continue
+			}
+			punct := masked[loc[0]]
+			// Same exceptions as the format command: URLs, times and image syntax
+			if next := nextByte(masked, loc[1]); (punct == ':' && (next == '/' || isDigit(next))) || (punct == '!' && next == '[') {
+				continue
+			}
+			add(RuleZhPunctuationWidth, lineNum, loc[0]+1, string(punct),
+				fmt.Sprintf("Use full-width '%s' instead of '%c' in Chinese text", halfToFullPunct[punct], punct),
+				halfToFullPunct[punct])
+		}
+
+		for _, loc := range repeatedPunct.FindAllStringIndex(masked, -1) {
+			word := masked[loc[0]:loc[1]]
+			first, _ := firstRune(word)
+			add(RuleZhRepeatedPunct, lineNum, loc[0]+1, word,
+				fmt.Sprintf("Repeated punctuation '%s'", word), first)
+		}
+
+		for _, loc := range fullWidthAlnum.FindAllStringIndex(masked, -1) {
+			word := masked[loc[0]:loc[1]]
+			add(RuleZhFullWidthAlnum, lineNum, loc[0]+1, word,
+				fmt.Sprintf("Full-width characters '%s' should be half-width", word), toHalfWidth(word))
+		}
+
+		for _, match := range adverbBeforeVerb.FindAllStringSubmatchIndex(masked, -1) {
+			word := masked[match[0]:match[1]]
+			suggestion := masked[match[2]:match[3]] + "地" + masked[match[4]:match[5]]
+			add(RuleZhDeDiDe, lineNum, match[0]+1, word,
+				"Use '地' between an adverbial modifier and a verb", suggestion)
+		}
+		for _, match := range verbBeforeComplete.FindAllStringSubmatchIndex(masked, -1) {
+			word := masked[match[0]:match[1]]
+			suggestion := masked[match[2]:match[3]] + "得" + masked[match[4]:match[5]]
+			add(RuleZhDeDiDe, lineNum, match[0]+1, word,
+				"Use '得' between a verb and its complement", suggestion)
+		}
+	})
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues
+}
+
+// forEachProseLine calls fn for every line outside front matter, fenced code
+// blocks and HTML comments (which hold the English source in k8s docs)
+func forEachProseLine(content string, fn func(lineNum int, line string)) {
+	lines := strings.Split(content, "\n")
+	inCodeBlock := false
+	inComment := false
+
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		if inComment {
+			if strings.Contains(line, "-->") {
+				inComment = false
+			}
+			continue
+		}
+		if start := strings.Index(line, "<!--"); start >= 0 {
+			if !strings.Contains(line[start:], "-->") {
+				inComment = true
+			}
+			line = line[:start]
+		}
+
+		fn(i+1, line)
+	}
+}
+
+// maskSpans replaces non-prose spans with spaces of the same byte length
+func maskSpans(line string) string {
+	return maskedSpanPattern.ReplaceAllStringFunc(line, func(span string) string {
+		return strings.Repeat(" ", len(span))
+	})
+}
+
+// findAllOverlapping finds two-character matches that may share characters,
+// such as both boundaries in "使用kubectl命令"
+func findAllOverlapping(pattern *regexp.Regexp, s string) [][]int {
+	var locs [][]int
+	for offset := 0; offset < len(s); {
+		loc := pattern.FindStringIndex(s[offset:])
+		if loc == nil {
+			break
+		}
+		locs = append(locs, []int{offset + loc[0], offset + loc[1]})
+		_, size := firstRune(s[offset+loc[0]:])
+		offset += loc[0] + size
+	}
+	return locs
+}
+
+// firstRune returns the first character of s and its byte size
+func firstRune(s string) (string, int) {
+	for _, r := range s {
+		return string(r), len(string(r))
+	}
+	return "", 0
+}
+
+// besideHan reports whether the text around s[start:end], ignoring spaces,
+// is a Chinese character
+func besideHan(s string, start, end int) bool {
+	before := strings.TrimRight(s[:start], " ")
+	after := strings.TrimLeft(s[end:], " ")
+	prev, _ := utf8.DecodeLastRuneInString(before)
+	next, _ := utf8.DecodeRuneInString(after)
+	return isHan(prev) || isHan(next)
+}
+
+// isHan matches the same range as hanRange
+func isHan(r rune) bool {
+	return r >= '一' && r <= '龯'
+}
+
+// nextByte returns the byte at index i, or 0 past the end of s
+func nextByte(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
+
+// isDigit checks for an ASCII digit
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// toHalfWidth converts full-width ASCII variants to their half-width form
+func toHalfWidth(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 0xFF01 && r <= 0xFF5E {
+			r -= 0xFEE0
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,255 @@
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// CJK leak rule IDs
+const (
+	RuleEnFullWidthPunct = "en-fullwidth-punctuation"
+	RuleEnFullWidthSpace = "en-fullwidth-space"
+	RuleEnCJKCharacter   = "en-cjk-character"
+)
+
+// cjkPunctToASCII maps CJK punctuation to its English counterpart
+var cjkPunctToASCII = map[rune]string{
+	'、': ",",
+	'。': ".",
+	'「': `"`,
+	'」': `"`,
+	'『': `"`,
+	'』': `"`,
+	'《': `"`,
+	'》': `"`,
+	'〈': `"`,
+	'〉': `"`,
+	'【': "[",
+	'】': "]",
+	'〔': "(",
+	'〕': ")",
+}
+
+// cjkLocales are content directories written in Chinese, Japanese or Korean
+var cjkLocales = []string{"zh", "ja", "ko"}
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(CJKLeakCheckerType),
+		Description: "Chinese punctuation and characters leaked into English docs",
+		Factory: func() (Checker, error) {
+			return NewCJKLeakChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleEnFullWidthPunct,
+		Checker:     CJKLeakCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Full-width or CJK punctuation in English text",
+		Example:     "Create a Pod，then → Create a Pod, then",
+	})
+	RegisterRule(Rule{
+		ID:          RuleEnFullWidthSpace,
+		Checker:     CJKLeakCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Full-width space in English text",
+		Example:     "the　Pod → the Pod",
+	})
+	RegisterRule(Rule{
+		ID:          RuleEnCJKCharacter,
+		Checker:     CJKLeakCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Chinese, Japanese or Korean characters in English text",
+		Example:     "Create the 节点 first",
+	})
+}
+
+// CJKLeakChecker implements the Checker interface for the inverse of the
+// Chinese punctuation rules: text from localized pages that leaks back into
+// English documents
+type CJKLeakChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewCJKLeakChecker creates a new CJK leak checker
+func NewCJKLeakChecker() *CJKLeakChecker {
+	return &CJKLeakChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (c *CJKLeakChecker) Name() string {
+	return "CJK Leak Checker"
+}
+
+// Type returns the type of this checker
+func (c *CJKLeakChecker) Type() CheckerType {
+	return CJKLeakCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (c *CJKLeakChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks a single English file for leaked CJK text
+func (c *CJKLeakChecker) CheckFile(filePath string) ([]Issue, error) {
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+	if inCJKLocale(filePath) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return c.lint(filePath, string(content)), nil
+}
+
+// CheckFiles checks multiple files for leaked CJK text
+func (c *CJKLeakChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
+
+// lint reports full-width punctuation, full-width spaces and CJK characters
+// on prose lines
+func (c *CJKLeakChecker) lint(filePath, content string) []Issue {
+	var issues []Issue
+	add := func(ruleID string, line, column int, word, message string, suggestion string) {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		issue := Issue{
+			Type:     CJKLeakCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Word:     word,
+			Message:  message,
+			RuleID:   ruleID,
+		}
+		if suggestion != "" {
+			issue.Suggestions = []string{suggestion}
+		}
+		issues = append(issues, issue)
+	}
+
+	forEachProseLine(content, func(lineNum int, line string) {
+		masked := maskSpans(line)
+		for i := 0; i < len(masked); {
+			r, size := utf8.DecodeRuneInString(masked[i:])
+			switch {
+			case r == '　':
+				add(RuleEnFullWidthSpace, lineNum, i+1, string(r),
+					"Full-width space in English text", " ")
+			case isFullWidthASCII(r):
+				// Runs of letters and digits such as "１０" are reported once
+				end := i + size
+				for end < len(masked) && isFullWidthAlnum(r) {
+					next, nextSize := utf8.DecodeRuneInString(masked[end:])
+					if !isFullWidthAlnum(next) {
+						break
+					}
+					end += nextSize
+				}
+				word := masked[i:end]
+				suggestion := toHalfWidth(word)
+				if spaceAfter(suggestion, masked[end:]) {
+					suggestion += " "
+				}
+				add(RuleEnFullWidthPunct, lineNum, i+1, word,
+					fmt.Sprintf("Full-width '%s' in English text", word), suggestion)
+				size = end - i
+			case isCJKPunct(r):
+				word := string(r)
+				suggestion := cjkPunctToASCII[r]
+				if spaceAfter(suggestion, masked[i+size:]) {
+					suggestion += " "
+				}
+				add(RuleEnFullWidthPunct, lineNum, i+1, word,
+					fmt.Sprintf("Full-width '%s' in English text", word), suggestion)
+			case isCJK(r):
+				end := i + size
+				for end < len(masked) {
+					next, nextSize := utf8.DecodeRuneInString(masked[end:])
+					if !isCJK(next) {
+						break
+					}
+					end += nextSize
+				}
+				word := masked[i:end]
+				add(RuleEnCJKCharacter, lineNum, i+1, word,
+					fmt.Sprintf("CJK characters '%s' in English text", word), "")
+				size = end - i
+			}
+			i += size
+		}
+	})
+	return issues
+}
+
+// isFullWidthASCII reports whether r is a full-width form of an ASCII character
+func isFullWidthASCII(r rune) bool {
+	return r >= 0xFF01 && r <= 0xFF5E
+}
+
+// isFullWidthAlnum reports whether r is a full-width letter or digit
+func isFullWidthAlnum(r rune) bool {
+	return isFullWidthASCII(r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// isCJKPunct reports whether r is CJK punctuation
+func isCJKPunct(r rune) bool {
+	return r > '　' && r <= '〿'
+}
+
+// spaceAfter reports whether English punctuation replacing a full-width
+// mark needs a space before the following text, as full-width punctuation
+// carries its own spacing. Decimal points and separators in numbers do not.
+func spaceAfter(replacement, rest string) bool {
+	if rest == "" || !strings.ContainsAny(replacement[len(replacement)-1:], ",.;:!?") {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(rest)
+	if unicode.IsDigit(next) && strings.ContainsAny(replacement, ".,") {
+		return false
+	}
+	return !unicode.IsSpace(next) && !unicode.IsPunct(next) && !isCJKPunct(next)
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// inCJKLocale reports whether a file lives under a Chinese, Japanese or
+// Korean content directory such as content/zh-cn
+func inCJKLocale(filePath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(filePath), "/") {
+		part = strings.ToLower(part)
+		for _, locale := range cjkLocales {
+			if part == locale || strings.HasPrefix(part, locale+"-") || strings.HasPrefix(part, locale+"_") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// externalCheckerPrefix is the executable name prefix of checker plugins
+const externalCheckerPrefix = "mm-quality-"
+
+// externalProtocolVersion is the version of the plugin protocol
+const externalProtocolVersion = 1
+
+// ExternalRequest is written as JSON to a plugin's stdin
+type ExternalRequest struct {
+	Version     int      `json:"version"`
+	ProjectType string   `json:"project_type"`
+	Files       []string `json:"files"`
+}
+
+// ExternalResponse is read as JSON from a plugin's stdout. Issues without a
+// type are attributed to the plugin and issues without a severity use the
+// severity of their rule, or warning.
+type ExternalResponse struct {
+	Rules  []Rule  `json:"rules,omitempty"`
+	Issues []Issue `json:"issues"`
+}
+
+// ExternalChecker runs a checker plugin: an executable named
+// mm-quality-<name> that reads an ExternalRequest from stdin and writes an
+// ExternalResponse to stdout
+type ExternalChecker struct {
+	name        string
+	path        string
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewExternalChecker finds the plugin for name in PATH
+func NewExternalChecker(name string) (*ExternalChecker, error) {
+	path, err := exec.LookPath(externalCheckerPrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("checker plugin %s%s not found in PATH", externalCheckerPrefix, name)
+	}
+	return &ExternalChecker{
+		name:        name,
+		path:        path,
+		projectType: "generic",
+	}, nil
+}
+
+// Name returns the name of this checker
+func (e *ExternalChecker) Name() string {
+	return "External Checker " + e.name
+}
+
+// Type returns the type of this checker
+func (e *ExternalChecker) Type() CheckerType {
+	return CheckerType(e.name)
+}
+
+// SetProject sets the project type passed to the plugin
+func (e *ExternalChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	e.projectType = projectType
+	e.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile runs the plugin on a single file
+func (e *ExternalChecker) CheckFile(filePath string) ([]Issue, error) {
+	if e.adapter != nil && adapter.ShouldIgnoreFile(filePath, e.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+	return e.run([]string{filePath})
+}
+
+// CheckFiles runs the plugin once over all files
+func (e *ExternalChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	var files []string
+	for _, filePath := range filePaths {
+		if e.adapter == nil || !adapter.ShouldIgnoreFile(filePath, e.adapter.GetIgnorePatterns()) {
+			files = append(files, filePath)
+		}
+	}
+
+	result := &CheckResult{
+		TotalFiles:   len(filePaths),
+		CheckedFiles: len(filePaths),
+		Issues:       []Issue{},
+		ProjectType:  e.projectType,
+		CheckerType:  e.Type(),
+	}
+	if len(files) > 0 {
+		issues, err := e.run(files)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			result.AddIssue(issue)
+		}
+	}
+
+	applySuppressions(result)
+	return result, nil
+}
+
+// run sends a request to the plugin and normalizes the issues it reports
+func (e *ExternalChecker) run(files []string) ([]Issue, error) {
+	request, err := json.Marshal(ExternalRequest{
+		Version:     externalProtocolVersion,
+		ProjectType: e.projectType,
+		Files:       files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	cmd := exec.Command(e.path)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("checker plugin %s failed: %w", e.path, err)
+	}
+
+	var response ExternalResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("checker plugin %s returned invalid JSON: %w", e.path, err)
+	}
+
+	for _, rule := range response.Rules {
+		if rule.Checker == "" {
+			rule.Checker = e.Type()
+		}
+		if rule.ID != "" {
+			RegisterRule(rule)
+		}
+	}
+
+	issues := response.Issues
+	for i := range issues {
+		if issues[i].Type == "" {
+			issues[i].Type = e.Type()
+		}
+		if issues[i].Severity == "" {
+			issues[i].Severity = WarningSeverity
+			if rule, ok := LookupRule(issues[i].RuleID); ok {
+				issues[i].Severity = rule.Severity
+			}
+		}
+		issues[i].Severity = Severity(strings.ToLower(string(issues[i].Severity)))
+	}
+	return issues, nil
+}
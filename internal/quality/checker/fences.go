@@ -0,0 +1,234 @@
+package checker
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Code fence rule IDs. MD040 follows markdownlint.
+const (
+	RuleFenceLanguageUnknown = "fence-language-unknown"
+	RuleFenceLanguageMissing = "MD040"
+)
+
+//go:embed languages.txt
+var languagesFile string
+
+// fenceLanguages are the languages known to Hugo, lowercase
+var fenceLanguages = func() map[string]bool {
+	languages := make(map[string]bool)
+	for _, line := range strings.Split(languagesFile, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			languages[line] = true
+		}
+	}
+	return languages
+}()
+
+var fenceOpenPattern = regexp.MustCompile("^(\\s*)(`{3,}|~{3,})\\s*(.*)$")
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(CodeFenceCheckerType),
+		Description: "Code fence languages known to Hugo",
+		Factory: func() (Checker, error) {
+			return NewCodeFenceChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleFenceLanguageUnknown,
+		Checker:     CodeFenceCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Code fence language is not known to Chroma or Hugo",
+		Example:     "```ymal",
+	})
+	RegisterRule(Rule{
+		ID:          RuleFenceLanguageMissing,
+		Checker:     CodeFenceCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Fenced code blocks should have a language specified",
+		Example:     "``` without a language",
+	})
+}
+
+// CodeFenceChecker implements the Checker interface for the languages of
+// fenced code blocks, which Hugo silently renders unhighlighted when it
+// doesn't know them
+type CodeFenceChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	// Languages are accepted in addition to those Hugo knows, e.g. the
+	// languages of code block render hooks
+	Languages []string
+	// RequireLanguage reports fences without a language
+	RequireLanguage bool
+}
+
+// NewCodeFenceChecker creates a new code fence checker
+func NewCodeFenceChecker() *CodeFenceChecker {
+	return &CodeFenceChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (c *CodeFenceChecker) Name() string {
+	return "Code Fence Checker"
+}
+
+// Type returns the type of this checker
+func (c *CodeFenceChecker) Type() CheckerType {
+	return CodeFenceCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (c *CodeFenceChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks the code fences of a single markdown file
+func (c *CodeFenceChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return c.lint(filePath, string(content)), nil
+}
+
+// Fingerprint identifies the accepted languages
+func (c *CodeFenceChecker) Fingerprint() string {
+	return fingerprintOf(c.Languages, c.RequireLanguage)
+}
+
+// CheckFiles checks the code fences of multiple markdown files
+func (c *CodeFenceChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
+
+// lint reports unknown and missing languages of the opening fences
+func (c *CodeFenceChecker) lint(filePath, content string) []Issue {
+	var issues []Issue
+
+	known := make(map[string]bool, len(fenceLanguages)+len(c.Languages))
+	for language := range fenceLanguages {
+		known[language] = true
+	}
+	for _, language := range c.Languages {
+		known[strings.ToLower(language)] = true
+	}
+	candidates := make([]string, 0, len(known))
+	for language := range known {
+		candidates = append(candidates, language)
+	}
+	sort.Strings(candidates)
+
+	newIssue := func(ruleID string, line, column int, message string) Issue {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		return Issue{
+			Type:     CodeFenceCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Message:  message,
+			RuleID:   ruleID,
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	fence := "" // the opening fence of the current block
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		match := fenceOpenPattern.FindStringSubmatchIndex(lines[i])
+		if match == nil {
+			continue
+		}
+		line := lines[i]
+		marker := line[match[4]:match[5]]
+		info := strings.TrimSpace(line[match[6]:match[7]])
+
+		if fence != "" {
+			// A closing fence repeats the character at least as often
+			if marker[0] == fence[0] && len(marker) >= len(fence) && info == "" {
+				fence = ""
+			}
+			continue
+		}
+		if marker[0] == '`' && strings.Contains(info, "`") {
+			// An inline code span, not a fence
+			continue
+		}
+		fence = marker
+
+		language, column := fenceLanguage(info)
+		column += match[6] + 1
+		switch {
+		case language == "":
+			if c.RequireLanguage {
+				issues = append(issues, newIssue(RuleFenceLanguageMissing, i+1, match[4]+1,
+					"Code block has no language"))
+			}
+		case !known[strings.ToLower(language)]:
+			issue := newIssue(RuleFenceLanguageUnknown, i+1, column,
+				fmt.Sprintf("Unknown code block language '%s'", language))
+			maxDistance := 2
+			if len(language) <= 4 {
+				maxDistance = 1
+			}
+			if suggestions := closestNames(strings.ToLower(language), candidates, maxDistance); len(suggestions) > 0 {
+				if len(suggestions) > 3 {
+					suggestions = suggestions[:3]
+				}
+				issue.Word = language
+				issue.Suggestions = suggestions
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// fenceLanguage returns the language of a fence info string and its byte
+// offset, accepting Hugo attributes (go {linenos=true}) and Pandoc classes
+// ({.go})
+func fenceLanguage(info string) (string, int) {
+	offset := 0
+	if strings.HasPrefix(info, "{") {
+		offset = strings.IndexFunc(info, func(r rune) bool { return r != '{' && r != '.' && r != ' ' })
+		if offset < 0 {
+			return "", 0
+		}
+	}
+	language := info[offset:]
+	if end := strings.IndexAny(language, " \t{},"); end >= 0 {
+		language = language[:end]
+	}
+	if strings.Contains(language, "=") {
+		// Attributes only
+		return "", 0
+	}
+	return language, offset
+}
@@ -0,0 +1,522 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"gopkg.in/yaml.v3"
+)
+
+// Front matter rule IDs
+const (
+	RuleFrontMatterSyntax   = "fm-syntax"
+	RuleFrontMatterMissing  = "fm-missing"
+	RuleFrontMatterRequired = "fm-required"
+	RuleFrontMatterType     = "fm-type"
+	RuleFrontMatterAllowed  = "fm-allowed-value"
+	RuleFrontMatterRange    = "fm-range"
+	RuleFrontMatterDate     = "fm-date-format"
+	RuleFrontMatterPattern  = "fm-pattern"
+	RuleFrontMatterUnknown  = "fm-unknown-field"
+	RuleFrontMatterDrift    = "fm-drift"
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(FrontMatterCheckerType),
+		Description: "Front matter against the project schema",
+		Factory: func() (Checker, error) {
+			return NewFrontMatterChecker(), nil
+		},
+	})
+
+	for _, rule := range []Rule{
+		{ID: RuleFrontMatterSyntax, Severity: ErrorSeverity, Description: "Front matter is not valid YAML"},
+		{ID: RuleFrontMatterMissing, Severity: ErrorSeverity, Description: "Document has no front matter but the schema requires fields"},
+		{ID: RuleFrontMatterRequired, Severity: ErrorSeverity, Description: "Required front matter field is missing", Example: "title"},
+		{ID: RuleFrontMatterType, Severity: ErrorSeverity, Description: "Front matter field has the wrong type", Example: "weight: ten"},
+		{ID: RuleFrontMatterAllowed, Severity: ErrorSeverity, Description: "Front matter field value is not one of the allowed values", Example: "content_type: guide"},
+		{ID: RuleFrontMatterRange, Severity: WarningSeverity, Description: "Numeric front matter field is out of range", Example: "weight: -10"},
+		{ID: RuleFrontMatterDate, Severity: ErrorSeverity, Description: "Date field does not match an accepted format", Example: "date: 2024/01/02"},
+		{ID: RuleFrontMatterPattern, Severity: WarningSeverity, Description: "Front matter field does not match the required pattern"},
+		{ID: RuleFrontMatterUnknown, Severity: InfoSeverity, Description: "Field is not defined in a strict schema"},
+		{ID: RuleFrontMatterDrift, Severity: WarningSeverity, Description: "Localized page and its English page disagree on a field that must match", Example: "weight: 20 where the English page has weight: 10"},
+	} {
+		rule.Checker = FrontMatterCheckerType
+		RegisterRule(rule)
+	}
+}
+
+// defaultDateFormats are accepted when a date field lists no formats
+var defaultDateFormats = []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05"}
+
+// FieldSchema describes the constraints for one front matter field
+type FieldSchema struct {
+	Required bool     `yaml:"required,omitempty"`
+	Type     string   `yaml:"type,omitempty"` // string, int, number, bool, date, list or map
+	Allowed  []string `yaml:"allowed,omitempty"`
+	Min      *float64 `yaml:"min,omitempty"`
+	Max      *float64 `yaml:"max,omitempty"`
+	Formats  []string `yaml:"formats,omitempty"` // Go time layouts for date fields
+	Pattern  string   `yaml:"pattern,omitempty"`
+}
+
+// FrontMatterSchema describes the expected front matter of a document
+type FrontMatterSchema struct {
+	Fields map[string]FieldSchema `yaml:"fields"`
+	// Strict reports fields that are not listed in Fields
+	Strict bool `yaml:"strict,omitempty"`
+	// Parity lists the fields localized pages must keep as their English
+	// page has them
+	Parity []string `yaml:"parity,omitempty"`
+}
+
+// LoadFrontMatterSchema reads a YAML schema file
+func LoadFrontMatterSchema(path string) (*FrontMatterSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+
+	var schema FrontMatterSchema
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", path, err)
+	}
+
+	for name, field := range schema.Fields {
+		switch field.Type {
+		case "", "string", "int", "number", "bool", "date", "list", "map":
+		default:
+			return nil, fmt.Errorf("schema %s: field %s has unknown type %q", path, name, field.Type)
+		}
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return nil, fmt.Errorf("schema %s: field %s has invalid pattern: %w", path, name, err)
+			}
+		}
+	}
+	return &schema, nil
+}
+
+// floatPtr returns a pointer to v for schema bounds
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// defaultFrontMatterSchemas are the built-in schemas keyed by adapter name
+var defaultFrontMatterSchemas = map[string]*FrontMatterSchema{
+	"k8s": {
+		Fields: map[string]FieldSchema{
+			"title":        {Required: true, Type: "string"},
+			"linkTitle":    {Type: "string"},
+			"description":  {Type: "string"},
+			"content_type": {Type: "string", Allowed: []string{"concept", "task", "tutorial", "reference", "tool-reference", "api_reference"}},
+			"weight":       {Type: "int", Min: floatPtr(0), Max: floatPtr(10000)},
+			"date":         {Type: "date"},
+			"reviewers":    {Type: "list"},
+			"draft":        {Type: "bool"},
+			"slug":         {Type: "string", Pattern: `^[a-z0-9][a-z0-9-]*$`},
+		},
+		// A weight that differs from the English page silently reorders the
+		// navigation of the localization
+		Parity: []string{"weight", "content_type", "min-kubernetes-server-version", "aliases"},
+	},
+}
+
+// frontMatterField is a top-level front matter entry with its position
+type frontMatterField struct {
+	node *yaml.Node
+	line int
+}
+
+// FrontMatterChecker implements the Checker interface by validating Hugo
+// front matter against a schema
+type FrontMatterChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	schema      *FrontMatterSchema
+	patterns    map[string]*regexp.Regexp
+	// Schema overrides the project's built-in schema when set
+	Schema *FrontMatterSchema
+	// SchemaFile is loaded into Schema by SetProject when Schema is not set
+	SchemaFile string
+}
+
+// NewFrontMatterChecker creates a new front matter checker
+func NewFrontMatterChecker() *FrontMatterChecker {
+	return &FrontMatterChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (f *FrontMatterChecker) Name() string {
+	return "Front Matter Checker"
+}
+
+// Type returns the type of this checker
+func (f *FrontMatterChecker) Type() CheckerType {
+	return FrontMatterCheckerType
+}
+
+// SetProject sets the project type and selects the schema to validate against
+func (f *FrontMatterChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	f.projectType = projectType
+	f.adapter = projectAdapter
+
+	if f.Schema == nil && f.SchemaFile != "" {
+		schema, err := LoadFrontMatterSchema(f.SchemaFile)
+		if err != nil {
+			return err
+		}
+		f.Schema = schema
+	}
+
+	f.schema = f.Schema
+	if f.schema == nil {
+		f.schema = defaultFrontMatterSchemas[projectAdapter.Name()]
+	}
+	if f.schema == nil {
+		return fmt.Errorf("no front matter schema for %s projects; pass one with --schema", projectAdapter.Name())
+	}
+
+	f.patterns = make(map[string]*regexp.Regexp)
+	for name, field := range f.schema.Fields {
+		if field.Pattern != "" {
+			f.patterns[name] = regexp.MustCompile(field.Pattern)
+		}
+	}
+	return nil
+}
+
+// CheckFile validates the front matter of a single markdown file
+func (f *FrontMatterChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if f.adapter != nil && adapter.ShouldIgnoreFile(filePath, f.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return f.validate(filePath, string(content)), nil
+}
+
+// Fingerprint identifies the schema in use
+func (f *FrontMatterChecker) Fingerprint() string {
+	return fingerprintOf(f.schema)
+}
+
+// CheckFiles validates the front matter of multiple files
+func (f *FrontMatterChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	if f.schema == nil {
+		if err := f.SetProject(f.projectType); err != nil {
+			return nil, err
+		}
+	}
+	return checkFiles(f, f.projectType, filePaths)
+}
+
+// validate checks the front matter of a document against the schema
+func (f *FrontMatterChecker) validate(filePath, content string) []Issue {
+	var issues []Issue
+	add := func(ruleID string, line int, word, message string) {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		issues = append(issues, Issue{
+			Type:     FrontMatterCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   1,
+			Word:     word,
+			Message:  message,
+			RuleID:   ruleID,
+		})
+	}
+
+	lines := strings.Split(content, "\n")
+	end := frontMatterEnd(lines)
+	if end == 0 {
+		if f.hasRequiredFields() {
+			add(RuleFrontMatterMissing, 1, "", "Document has no front matter")
+		}
+		return issues
+	}
+	// TOML front matter is not validated
+	if strings.TrimSpace(lines[0]) != "---" {
+		return issues
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end-1], "\n")), &doc); err != nil {
+		add(RuleFrontMatterSyntax, 1, "", fmt.Sprintf("Invalid front matter: %v", err))
+		return issues
+	}
+
+	fields := mappingFields(&doc)
+
+	names := make([]string, 0, len(f.schema.Fields))
+	for name := range f.schema.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := f.schema.Fields[name]
+		value, ok := fields[name]
+		if !ok {
+			if field.Required {
+				add(RuleFrontMatterRequired, 1, name, fmt.Sprintf("Missing required field '%s'", name))
+			}
+			continue
+		}
+		for _, problem := range f.validateField(name, field, value.node) {
+			add(problem.ruleID, value.line, name, problem.message)
+		}
+	}
+
+	if f.schema.Strict {
+		for name, value := range fields {
+			if _, ok := f.schema.Fields[name]; !ok {
+				add(RuleFrontMatterUnknown, value.line, name, fmt.Sprintf("Unknown field '%s'", name))
+			}
+		}
+	}
+
+	if source := upstreamPage(filePath); len(f.schema.Parity) > 0 && source != "" {
+		if upstream, ok := readFrontMatterFields(source); ok {
+			lang := filepath.Base(hugoContentRoot(filePath))
+			for _, name := range f.schema.Parity {
+				ours, inOurs := fields[name]
+				theirs, inTheirs := upstream[name]
+				switch {
+				case !inOurs && inTheirs:
+					add(RuleFrontMatterDrift, 1, name, fmt.Sprintf("Missing field '%s', which %s sets to %s",
+						name, source, parityValue(theirs.node, "")))
+				case inOurs && !inTheirs:
+					add(RuleFrontMatterDrift, ours.line, name, fmt.Sprintf("Field '%s' is not set in %s", name, source))
+				case inOurs && parityValue(ours.node, lang) != parityValue(theirs.node, ""):
+					add(RuleFrontMatterDrift, ours.line, name, fmt.Sprintf("Field '%s' is %s, but %s in %s",
+						name, parityValue(ours.node, lang), parityValue(theirs.node, ""), source))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+
+	return issues
+}
+
+// mappingFields returns the top-level fields of a parsed front matter
+func mappingFields(doc *yaml.Node) map[string]frontMatterField {
+	fields := make(map[string]frontMatterField)
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		mapping := doc.Content[0]
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			key := mapping.Content[i]
+			// Node lines are relative to the YAML body, which starts on line 2
+			fields[key.Value] = frontMatterField{node: mapping.Content[i+1], line: key.Line + 1}
+		}
+	}
+	return fields
+}
+
+// readFrontMatterFields reads the top-level fields of the YAML front matter
+// of a file, reporting false when it has none or it doesn't parse
+func readFrontMatterFields(filePath string) (map[string]frontMatterField, bool) {
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, false
+	}
+	lines := strings.Split(string(content), "\n")
+	end := frontMatterEnd(lines)
+	if end == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, false
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end-1], "\n")), &doc); err != nil {
+		return nil, false
+	}
+	return mappingFields(&doc), true
+}
+
+// parityValue returns a field value in a form comparable between a page and
+// its English page: lists are sorted, and the /<lang>/ prefix localized
+// aliases carry is dropped
+func parityValue(node *yaml.Node, lang string) string {
+	normalize := func(value string) string {
+		if lang != "" {
+			if rest, ok := strings.CutPrefix(value, "/"+lang+"/"); ok {
+				return "/" + rest
+			}
+		}
+		return value
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return normalize(node.Value)
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			values = append(values, parityValue(item, lang))
+		}
+		sort.Strings(values)
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// crossFile marks the results as depending on the English pages
+func (f *FrontMatterChecker) crossFile() {}
+
+// fieldProblem is a schema violation found in a single field
+type fieldProblem struct {
+	ruleID  string
+	message string
+}
+
+// validateField checks one value against its field schema
+func (f *FrontMatterChecker) validateField(name string, field FieldSchema, node *yaml.Node) []fieldProblem {
+	var problems []fieldProblem
+	report := func(ruleID, format string, args ...interface{}) {
+		problems = append(problems, fieldProblem{ruleID: ruleID, message: fmt.Sprintf(format, args...)})
+	}
+
+	if field.Type != "" && !matchesType(field.Type, node) {
+		report(RuleFrontMatterType, "Field '%s' should be of type %s", name, field.Type)
+		return problems
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return problems
+	}
+	value := node.Value
+
+	if len(field.Allowed) > 0 && !containsString(field.Allowed, value) {
+		report(RuleFrontMatterAllowed, "Field '%s' has value '%s', expected one of: %s",
+			name, value, strings.Join(field.Allowed, ", "))
+	}
+
+	if field.Min != nil || field.Max != nil {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			min, max := math.Inf(-1), math.Inf(1)
+			if field.Min != nil {
+				min = *field.Min
+			}
+			if field.Max != nil {
+				max = *field.Max
+			}
+			if number < min || number > max {
+				report(RuleFrontMatterRange, "Field '%s' is %s, expected a value between %s and %s",
+					name, value, formatBound(min), formatBound(max))
+			}
+		}
+	}
+
+	if field.Type == "date" {
+		formats := field.Formats
+		if len(formats) == 0 {
+			formats = defaultDateFormats
+		}
+		if !parsesAsDate(value, formats) {
+			report(RuleFrontMatterDate, "Field '%s' has date '%s', expected a format like %s",
+				name, value, strings.Join(formats, " or "))
+		}
+	}
+
+	if pattern := f.patterns[name]; pattern != nil && !pattern.MatchString(value) {
+		report(RuleFrontMatterPattern, "Field '%s' does not match pattern %s", name, pattern)
+	}
+
+	return problems
+}
+
+// hasRequiredFields reports whether the schema requires any field
+func (f *FrontMatterChecker) hasRequiredFields() bool {
+	for _, field := range f.schema.Fields {
+		if field.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesType checks a YAML node against a schema type
+func matchesType(fieldType string, node *yaml.Node) bool {
+	switch fieldType {
+	case "list":
+		return node.Kind == yaml.SequenceNode
+	case "map":
+		return node.Kind == yaml.MappingNode
+	}
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+
+	switch fieldType {
+	case "string":
+		return node.ShortTag() == "!!str"
+	case "int":
+		return node.ShortTag() == "!!int"
+	case "number":
+		return node.ShortTag() == "!!int" || node.ShortTag() == "!!float"
+	case "bool":
+		return node.ShortTag() == "!!bool"
+	case "date":
+		// Dates may be unquoted timestamps or quoted strings
+		return node.ShortTag() == "!!timestamp" || node.ShortTag() == "!!str"
+	}
+	return true
+}
+
+// parsesAsDate checks a value against a list of time layouts
+func parsesAsDate(value string, formats []string) bool {
+	for _, format := range formats {
+		if _, err := time.Parse(format, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatBound prints a range bound without a trailing ".0"
+func formatBound(v float64) string {
+	if math.IsInf(v, 0) {
+		return "∞"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// containsString checks whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+const (
+	// DefaultLanguageToolServer is a locally running LanguageTool server
+	DefaultLanguageToolServer = "http://localhost:8081"
+
+	// languageToolChunkSize keeps requests under the public API size limit
+	languageToolChunkSize = 15000
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(GrammarCheckerType),
+		Description: "Grammar and style through LanguageTool (MM_LANGUAGETOOL_URL)",
+		Factory: func() (Checker, error) {
+			grammarChecker := NewGrammarChecker(os.Getenv("MM_LANGUAGETOOL_URL"))
+			grammarChecker.DisabledCategories = []string{"TYPOS"}
+			return grammarChecker, nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          "grammar",
+		Checker:     GrammarCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Grammar or style problem reported by LanguageTool; issues carry the LanguageTool rule ID",
+		Example:     "This are a example.",
+	})
+}
+
+// markupPattern matches inline markdown that LanguageTool should not read as prose
+var markupPattern = regexp.MustCompile("`[^`]*`" + `|\]\([^)]*\)|<[^>]+>|\{\{[^}]*\}\}|^\s*(?:#{1,6}|[-*+]|\d+\.|>)\s+|[*_]{1,2}|[\[\]!]`)
+
+// ltAnnotation is one segment of LanguageTool annotated input
+type ltAnnotation struct {
+	Text   string `json:"text,omitempty"`
+	Markup string `json:"markup,omitempty"`
+}
+
+// ltResponse is the relevant part of the LanguageTool /v2/check response
+type ltResponse struct {
+	Matches []struct {
+		Message      string `json:"message"`
+		Offset       int    `json:"offset"`
+		Length       int    `json:"length"`
+		Replacements []struct {
+			Value string `json:"value"`
+		} `json:"replacements"`
+		Rule struct {
+			ID        string `json:"id"`
+			IssueType string `json:"issueType"`
+			Category  struct {
+				ID string `json:"id"`
+			} `json:"category"`
+		} `json:"rule"`
+	} `json:"matches"`
+}
+
+// GrammarChecker implements the Checker interface using a LanguageTool server
+type GrammarChecker struct {
+	projectType        string
+	adapter            adapter.ProjectAdapter
+	client             *http.Client
+	ServerURL          string
+	Language           string
+	DisabledRules      []string
+	DisabledCategories []string
+}
+
+// NewGrammarChecker creates a grammar checker talking to the given server
+func NewGrammarChecker(serverURL string) *GrammarChecker {
+	if serverURL == "" {
+		serverURL = DefaultLanguageToolServer
+	}
+	return &GrammarChecker{
+		projectType: "generic",
+		client:      &http.Client{Timeout: 60 * time.Second},
+		ServerURL:   strings.TrimSuffix(serverURL, "/"),
+		Language:    "en-US",
+	}
+}
+
+// Name returns the name of this checker
+func (g *GrammarChecker) Name() string {
+	return "Grammar Checker"
+}
+
+// Type returns the type of this checker
+func (g *GrammarChecker) Type() CheckerType {
+	return GrammarCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (g *GrammarChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	g.projectType = projectType
+	g.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks a single file for grammar problems
+func (g *GrammarChecker) CheckFile(filePath string) ([]Issue, error) {
+	if g.adapter != nil && adapter.ShouldIgnoreFile(filePath, g.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var segments []ltAnnotation
+	if isMarkdownFile(filePath) {
+		segments = annotateMarkdown(string(content))
+	} else {
+		segments = []ltAnnotation{{Text: string(content)}}
+	}
+
+	lineStarts := computeLineStarts(string(content))
+	var issues []Issue
+
+	for _, chunk := range chunkAnnotations(segments, languageToolChunkSize) {
+		resp, err := g.check(chunk.segments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range resp.Matches {
+			start := chunk.offset + utf16ToByteOffset(chunk.text, match.Offset)
+			end := chunk.offset + utf16ToByteOffset(chunk.text, match.Offset+match.Length)
+			line, column := offsetToPosition(lineStarts, start)
+
+			var suggestions []string
+			for _, replacement := range match.Replacements {
+				suggestions = append(suggestions, replacement.Value)
+				if len(suggestions) == 5 {
+					break
+				}
+			}
+
+			issues = append(issues, Issue{
+				Type:        GrammarCheckerType,
+				Severity:    languageToolSeverity(match.Rule.IssueType),
+				File:        filePath,
+				Line:        line,
+				Column:      column,
+				Word:        string(content[start:end]),
+				Message:     match.Message,
+				Suggestions: suggestions,
+				RuleID:      match.Rule.ID,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// Fingerprint identifies the server, language and disabled rules
+func (g *GrammarChecker) Fingerprint() string {
+	return fingerprintOf(g.ServerURL, g.Language, g.DisabledRules, g.DisabledCategories)
+}
+
+// CheckFiles checks multiple files for grammar problems
+func (g *GrammarChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(g, g.projectType, filePaths)
+}
+
+// check sends annotated text to the LanguageTool server
+func (g *GrammarChecker) check(segments []ltAnnotation) (*ltResponse, error) {
+	data, err := json.Marshal(map[string][]ltAnnotation{"annotation": segments})
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("data", string(data))
+	form.Set("language", g.Language)
+	if len(g.DisabledRules) > 0 {
+		form.Set("disabledRules", strings.Join(g.DisabledRules, ","))
+	}
+	if len(g.DisabledCategories) > 0 {
+		form.Set("disabledCategories", strings.Join(g.DisabledCategories, ","))
+	}
+	// Premium accounts on the hosted API
+	if username := os.Getenv("MM_LANGUAGETOOL_USERNAME"); username != "" {
+		form.Set("username", username)
+		form.Set("apiKey", os.Getenv("MM_LANGUAGETOOL_API_KEY"))
+	}
+
+	resp, err := g.client.PostForm(g.ServerURL+"/v2/check", form)
+	if err != nil {
+		return nil, fmt.Errorf("LanguageTool server not reachable at %s: %w", g.ServerURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LanguageTool server returned HTTP %d", resp.StatusCode)
+	}
+
+	var result ltResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode LanguageTool response: %w", err)
+	}
+	return &result, nil
+}
+
+// languageToolSeverity maps LanguageTool issue types to severities
+func languageToolSeverity(issueType string) Severity {
+	switch issueType {
+	case "misspelling", "grammar":
+		return WarningSeverity
+	default:
+		return InfoSeverity
+	}
+}
+
+// annotateMarkdown splits markdown into prose and markup segments whose
+// concatenation is exactly the original content
+func annotateMarkdown(content string) []ltAnnotation {
+	var segments []ltAnnotation
+	add := func(text string, markup bool) {
+		if text == "" {
+			return
+		}
+		if n := len(segments); n > 0 && (segments[n-1].Markup != "") == markup {
+			if markup {
+				segments[n-1].Markup += text
+			} else {
+				segments[n-1].Text += text
+			}
+			return
+		}
+		if markup {
+			segments = append(segments, ltAnnotation{Markup: text})
+		} else {
+			segments = append(segments, ltAnnotation{Text: text})
+		}
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	frontMatter := frontMatterEnd(strings.Split(content, "\n"))
+	inCodeBlock := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i < frontMatter {
+			add(line, true)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			add(line, true)
+			continue
+		}
+		if inCodeBlock || strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, "<!--") {
+			add(line, true)
+			continue
+		}
+
+		body := strings.TrimSuffix(line, "\n")
+		last := 0
+		for _, loc := range markupPattern.FindAllStringIndex(body, -1) {
+			add(body[last:loc[0]], false)
+			add(body[loc[0]:loc[1]], true)
+			last = loc[1]
+		}
+		add(body[last:], false)
+		if strings.HasSuffix(line, "\n") {
+			add("\n", false)
+		}
+	}
+
+	return segments
+}
+
+// annotationChunk is a group of segments sent in one request
+type annotationChunk struct {
+	segments []ltAnnotation
+	text     string // concatenated segment content
+	offset   int    // byte offset of the chunk in the original content
+}
+
+// chunkAnnotations groups segments into requests of roughly maxSize bytes,
+// splitting only at segment boundaries
+func chunkAnnotations(segments []ltAnnotation, maxSize int) []annotationChunk {
+	var chunks []annotationChunk
+	current := annotationChunk{}
+	var text strings.Builder
+
+	for _, segment := range segments {
+		value := segment.Text + segment.Markup
+		if text.Len() > 0 && text.Len()+len(value) > maxSize {
+			current.text = text.String()
+			chunks = append(chunks, current)
+			current = annotationChunk{offset: current.offset + len(current.text)}
+			text.Reset()
+		}
+		current.segments = append(current.segments, segment)
+		text.WriteString(value)
+	}
+	if text.Len() > 0 {
+		current.text = text.String()
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// utf16ToByteOffset converts a UTF-16 code unit offset (as used by
+// LanguageTool) into a byte offset in s
+func utf16ToByteOffset(s string, offset int) int {
+	units := 0
+	for i, r := range s {
+		if units >= offset {
+			return i
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return len(s)
+}
+
+// computeLineStarts returns the byte offset of the start of each line
+func computeLineStarts(content string) []int {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// offsetToPosition converts a byte offset into 1-based line and column
+func offsetToPosition(lineStarts []int, offset int) (int, int) {
+	line := 0
+	for line+1 < len(lineStarts) && lineStarts[line+1] <= offset {
+		line++
+	}
+	return line + 1, offset - lineStarts[line] + 1
+}
@@ -0,0 +1,209 @@
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Heading style rule IDs. MD026 and MD036 follow markdownlint; markdownlint
+// has no rules for heading case and length.
+const (
+	RuleHeadingPunctuation = "MD026"
+	RuleEmphasisAsHeading  = "MD036"
+	RuleHeadingCase        = "heading-case"
+	RuleHeadingLength      = "heading-length"
+)
+
+// Heading cases accepted by MarkdownChecker.HeadingCase
+const (
+	HeadingCaseSentence = "sentence"
+	HeadingCaseTitle    = "title"
+)
+
+// DefaultHeadingPunctuation is the trailing punctuation reported by MD026,
+// as in markdownlint
+const DefaultHeadingPunctuation = ".,;:!。，；：！"
+
+// titleMinorWords stay lowercase in title case unless they start or end
+// the heading
+var titleMinorWords = map[string]bool{
+	"a": true, "an": true, "the": true,
+	"and": true, "but": true, "or": true, "nor": true, "for": true, "so": true, "yet": true,
+	"as": true, "at": true, "by": true, "in": true, "of": true, "off": true, "on": true,
+	"per": true, "to": true, "up": true, "via": true, "vs": true, "with": true, "from": true, "into": true,
+}
+
+var (
+	headingWordPattern  = regexp.MustCompile(`\p{L}[\p{L}\p{N}'’]*`)
+	headingMaskPattern  = regexp.MustCompile("`[^`]*`" + `|\]\([^)]*\)|\{\{[^}]*\}\}|<[^>]+>|https?://\S+`)
+	emphasisLinePattern = regexp.MustCompile(`^\s*(\*\*|__|\*|_)([^*_]+)(\*\*|__|\*|_)\s*$`)
+)
+
+func init() {
+	RegisterRule(Rule{
+		ID:          RuleHeadingPunctuation,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Trailing punctuation in heading",
+		Example:     "## Prerequisites:",
+	})
+	RegisterRule(Rule{
+		ID:          RuleEmphasisAsHeading,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Emphasis used instead of a heading",
+		Example:     "**Before you begin**",
+	})
+	RegisterRule(Rule{
+		ID:          RuleHeadingCase,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Heading does not use the configured sentence or title case",
+		Example:     "## Configure The Service → ## Configure the service",
+	})
+	RegisterRule(Rule{
+		ID:          RuleHeadingLength,
+		Checker:     MarkdownCheckerType,
+		Severity:    InfoSeverity,
+		Description: "Heading exceeds the maximum heading length",
+	})
+}
+
+// validateHeadingCase checks the configured heading case
+func validateHeadingCase(headingCase string) error {
+	switch headingCase {
+	case "", HeadingCaseSentence, HeadingCaseTitle:
+		return nil
+	}
+	return fmt.Errorf("invalid heading case %q (use %s or %s)", headingCase, HeadingCaseSentence, HeadingCaseTitle)
+}
+
+// headingStyle reports the style problems of the heading text found at
+// byte offset column-1 of a line
+func (m *MarkdownChecker) headingStyle(newIssue func(ruleID string, line, column int, message string) Issue, lineNum, column int, text string) []Issue {
+	var issues []Issue
+	if text == "" {
+		return nil
+	}
+
+	if m.MaxHeadingLength > 0 {
+		if length := utf8.RuneCountInString(text); length > m.MaxHeadingLength {
+			issues = append(issues, newIssue(RuleHeadingLength, lineNum, column,
+				fmt.Sprintf("Heading length %d exceeds %d characters", length, m.MaxHeadingLength)))
+		}
+	}
+
+	if m.HeadingCase != "" {
+		if fixed := m.applyHeadingCase(text); fixed != text {
+			issue := newIssue(RuleHeadingCase, lineNum, column,
+				fmt.Sprintf("Heading should be in %s case: '%s'", m.HeadingCase, fixed))
+			issue.Word = text
+			issue.Suggestions = []string{fixed}
+			issues = append(issues, issue)
+		}
+	}
+
+	if last, size := utf8.DecodeLastRuneInString(text); strings.ContainsRune(m.HeadingPunctuation, last) {
+		issues = append(issues, newIssue(RuleHeadingPunctuation, lineNum, column+len(text)-size,
+			fmt.Sprintf("Trailing punctuation '%c' in heading", last)))
+	}
+	return issues
+}
+
+// applyHeadingCase rewrites the words of a heading in the configured case.
+// Code, links and shortcodes are left alone, as are words that carry their
+// own casing: acronyms, camelCase names, words with digits and proper nouns.
+func (m *MarkdownChecker) applyHeadingCase(text string) string {
+	masked := headingMaskPattern.ReplaceAllStringFunc(text, func(span string) string {
+		return strings.Repeat(" ", len(span))
+	})
+	words := headingWordPattern.FindAllStringIndex(masked, -1)
+
+	properNouns := make(map[string]bool)
+	if m.adapter != nil {
+		for _, noun := range m.adapter.GetProperNouns() {
+			properNouns[noun] = true
+		}
+	}
+	for _, noun := range m.HeadingProperNouns {
+		properNouns[noun] = true
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, loc := range words {
+		word := text[loc[0]:loc[1]]
+		b.WriteString(text[last:loc[0]])
+		last = loc[1]
+
+		if properNouns[word] || !caseableWord(word) {
+			b.WriteString(word)
+			continue
+		}
+
+		// Style guides disagree on the word after a colon, so it is kept
+		if strings.HasSuffix(strings.TrimSpace(masked[:loc[0]]), ":") {
+			b.WriteString(word)
+			continue
+		}
+
+		first := i == 0
+		switch {
+		case m.HeadingCase == HeadingCaseSentence && !first:
+			b.WriteString(strings.ToLower(word))
+		case m.HeadingCase == HeadingCaseTitle && !first && i < len(words)-1 && titleMinorWords[strings.ToLower(word)]:
+			b.WriteString(strings.ToLower(word))
+		default:
+			b.WriteString(capitalize(word))
+		}
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// caseableWord reports whether a word may be recased: it is either all
+// lowercase or capitalized, and is not the pronoun I
+func caseableWord(word string) bool {
+	if word == "I" || strings.HasPrefix(word, "I'") || strings.HasPrefix(word, "I’") {
+		return false
+	}
+	for i, r := range word {
+		if unicode.IsDigit(r) || (i > 0 && unicode.IsUpper(r)) {
+			return false
+		}
+	}
+	first, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(first) || unicode.IsLower(first)
+}
+
+// capitalize upper-cases the first letter of a word
+func capitalize(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + word[size:]
+}
+
+// emphasisHeading reports a paragraph made of a single emphasized phrase,
+// which reads as a heading but is not one. Phrases ending with punctuation
+// are emphasized sentences and allowed.
+func (m *MarkdownChecker) emphasisHeading(lines []string, i int) (string, bool) {
+	match := emphasisLinePattern.FindStringSubmatch(lines[i])
+	if match == nil || match[1] != match[3] {
+		return "", false
+	}
+	if i > 0 && strings.TrimSpace(lines[i-1]) != "" {
+		return "", false
+	}
+	if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+		return "", false
+	}
+
+	text := strings.TrimSpace(match[2])
+	last, _ := utf8.DecodeLastRuneInString(text)
+	if strings.ContainsRune(DefaultHeadingPunctuation+"?？", last) {
+		return "", false
+	}
+	return text, true
+}
@@ -0,0 +1,172 @@
+package checker
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+// htmlContextLines is the number of lines shown around each issue
+const htmlContextLines = 2
+
+// htmlSnippetLine is a line of source shown with an issue
+type htmlSnippetLine struct {
+	Number  int
+	Text    string
+	Current bool
+}
+
+// htmlIssue is an issue with its source context
+type htmlIssue struct {
+	Issue
+	Snippet []htmlSnippetLine
+}
+
+// htmlFile groups the issues of one file
+type htmlFile struct {
+	Name   string
+	Issues []htmlIssue
+}
+
+// htmlReport is the data passed to the report template
+type htmlReport struct {
+	Result    *CheckResult
+	Files     []htmlFile
+	Counts    map[string]int // keyed by severity
+	Generated string
+}
+
+// OutputHTML outputs the check result as a self-contained HTML report with
+// per-file issue tables, severity filters and source snippets
+func (r *CheckResult) OutputHTML(w io.Writer) error {
+	report := htmlReport{
+		Result:    r,
+		Counts:    make(map[string]int),
+		Generated: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	lines := newLineCache()
+	fileIndex := make(map[string]int)
+
+	for _, issue := range r.Issues {
+		index, ok := fileIndex[issue.File]
+		if !ok {
+			index = len(report.Files)
+			fileIndex[issue.File] = index
+			report.Files = append(report.Files, htmlFile{Name: issue.File})
+		}
+
+		entry := htmlIssue{Issue: issue}
+		if issue.Line > 0 {
+			for n := issue.Line - htmlContextLines; n <= issue.Line+htmlContextLines; n++ {
+				if n < 1 {
+					continue
+				}
+				text, ok := lines.lookup(issue.File, n)
+				if !ok {
+					break
+				}
+				entry.Snippet = append(entry.Snippet, htmlSnippetLine{Number: n, Text: text, Current: n == issue.Line})
+			}
+		}
+
+		report.Files[index].Issues = append(report.Files[index].Issues, entry)
+		report.Counts[string(issue.Severity)]++
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Name < report.Files[j].Name })
+	for _, file := range report.Files {
+		sort.SliceStable(file.Issues, func(i, j int) bool {
+			if file.Issues[i].Line != file.Issues[j].Line {
+				return file.Issues[i].Line < file.Issues[j].Line
+			}
+			return file.Issues[i].Column < file.Issues[j].Column
+		})
+	}
+
+	return htmlReportTemplate.Execute(w, report)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>mm quality report – {{.Result.CheckerType}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.1em; margin-top: 2em; font-family: monospace; }
+.summary span { margin-right: 1.5em; }
+.filters { margin: 1em 0; padding: .5em 1em; background: #f6f8fa; border-radius: 6px; }
+.filters label { margin-right: 1em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: .4em .6em; border-bottom: 1px solid #d0d7de; }
+th { background: #f6f8fa; }
+.sev { font-weight: bold; text-transform: uppercase; font-size: .8em; }
+.sev-error { color: #cf222e; }
+.sev-warning { color: #9a6700; }
+.sev-info { color: #0969da; }
+pre { margin: .4em 0 0; padding: .4em; background: #f6f8fa; font-size: .85em; overflow-x: auto; }
+pre .current { background: #fff8c5; display: block; }
+.lineno { color: #8c959f; user-select: none; }
+code { background: #eff1f3; padding: 0 .2em; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>mm quality report – {{.Result.CheckerType}}</h1>
+<p class="summary">
+<span>Project: <b>{{.Result.ProjectType}}</b></span>
+<span>Files checked: <b>{{.Result.CheckedFiles}}</b></span>
+<span>Issues: <b>{{.Result.TotalIssues}}</b>{{if .Result.Suppressed}} ({{.Result.Suppressed}} suppressed){{end}}</span>
+<span class="sev-error">Errors: {{index .Counts "error"}}</span>
+<span class="sev-warning">Warnings: {{index .Counts "warning"}}</span>
+<span class="sev-info">Info: {{index .Counts "info"}}</span>
+<span>Generated: {{.Generated}}</span>
+</p>
+<div class="filters">
+Show:
+<label><input type="checkbox" data-severity="error" checked> errors</label>
+<label><input type="checkbox" data-severity="warning" checked> warnings</label>
+<label><input type="checkbox" data-severity="info" checked> info</label>
+</div>
+{{if not .Files}}<p>✅ No issues found.</p>{{end}}
+{{range .Files}}
+<section class="file">
+<h2>{{.Name}} ({{len .Issues}} issues)</h2>
+<table>
+<thead><tr><th>Line</th><th>Severity</th><th>Rule</th><th>Issue</th></tr></thead>
+<tbody>
+{{range .Issues}}
+<tr class="issue" data-severity="{{.Severity}}">
+<td>{{if gt .Line 0}}{{.Line}}:{{.Column}}{{end}}</td>
+<td class="sev sev-{{.Severity}}">{{.Severity}}</td>
+<td><code>{{.RuleID}}</code></td>
+<td>{{.Message}}{{if .Word}} (<code>{{.Word}}</code>){{end}}
+{{if .Suggestions}}<br>Suggestions: {{range $i, $s := .Suggestions}}{{if $i}}, {{end}}<code>{{$s}}</code>{{end}}{{end}}
+{{if .Snippet}}<pre>{{range .Snippet}}<span{{if .Current}} class="current"{{end}}><span class="lineno">{{printf "%4d" .Number}}  </span>{{.Text}}
+</span>{{end}}</pre>{{end}}
+</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+</section>
+{{end}}
+<script>
+document.querySelectorAll('.filters input').forEach(function (box) {
+  box.addEventListener('change', function () {
+    var shown = {};
+    document.querySelectorAll('.filters input').forEach(function (b) { shown[b.dataset.severity] = b.checked; });
+    document.querySelectorAll('tr.issue').forEach(function (row) {
+      row.classList.toggle('hidden', !shown[row.dataset.severity]);
+    });
+    document.querySelectorAll('section.file').forEach(function (section) {
+      section.classList.toggle('hidden', !section.querySelector('tr.issue:not(.hidden)'));
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -4,16 +4,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 )
 
 // CheckerType represents the type of quality checker
 type CheckerType string
 
 const (
-	SpellCheckerType    CheckerType = "spell"
-	GrammarCheckerType  CheckerType = "grammar"
-	MarkdownCheckerType CheckerType = "markdown"
-	ChineseCheckerType  CheckerType = "chinese"
+	SpellCheckerType          CheckerType = "spell"
+	GrammarCheckerType        CheckerType = "grammar"
+	MarkdownCheckerType       CheckerType = "markdown"
+	ChineseCheckerType        CheckerType = "chinese"
+	LinksCheckerType          CheckerType = "links"
+	TerminologyCheckerType    CheckerType = "terminology"
+	FrontMatterCheckerType    CheckerType = "frontmatter"
+	CasingCheckerType         CheckerType = "casing"
+	ChineseVariantCheckerType CheckerType = "zh-variant"
+	CJKLeakCheckerType        CheckerType = "cjk-leak"
+	AnchorsCheckerType        CheckerType = "anchors"
+	AssetsCheckerType         CheckerType = "assets"
+	ShortcodesCheckerType     CheckerType = "shortcodes"
+	CodeFenceCheckerType      CheckerType = "fences"
+	StructureCheckerType      CheckerType = "structure"
+	// AllCheckerType marks a result merged from several checkers
+	AllCheckerType CheckerType = "all"
 )
 
 // Severity represents the severity level of an issue
@@ -25,6 +40,33 @@ const (
 	InfoSeverity    Severity = "info"
 )
 
+// ParseSeverity parses a severity level, ignoring case
+func ParseSeverity(s string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(strings.TrimSpace(s))); severity {
+	case ErrorSeverity, WarningSeverity, InfoSeverity:
+		return severity, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (use error, warning or info)", s)
+}
+
+// rank orders severities from info (1) to error (3)
+func (s Severity) rank() int {
+	switch s {
+	case ErrorSeverity:
+		return 3
+	case WarningSeverity:
+		return 2
+	case InfoSeverity:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast reports whether s is as severe as threshold or more
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() >= threshold.rank()
+}
+
 // Issue represents a single quality issue found in a file
 type Issue struct {
 	Type        CheckerType `json:"type"`
@@ -40,55 +82,195 @@ type Issue struct {
 
 // CheckResult represents the result of a quality check operation
 type CheckResult struct {
-	TotalFiles   int     `json:"total_files"`
-	CheckedFiles int     `json:"checked_files"`
-	TotalIssues  int     `json:"total_issues"`
-	Issues       []Issue `json:"issues"`
-	ProjectType  string  `json:"project_type"`
+	TotalFiles   int         `json:"total_files"`
+	CheckedFiles int         `json:"checked_files"`
+	TotalIssues  int         `json:"total_issues"`
+	Suppressed   int         `json:"suppressed"`
+	Issues       []Issue     `json:"issues"`
+	ProjectType  string      `json:"project_type"`
 	CheckerType  CheckerType `json:"checker_type"`
+	// Sections summarizes each checker of a merged result
+	Sections []Section `json:"sections,omitempty"`
 }
 
+// Section summarizes the part of a merged result produced by one checker
+type Section struct {
+	CheckerType  CheckerType `json:"checker_type"`
+	CheckedFiles int         `json:"checked_files"`
+	TotalIssues  int         `json:"total_issues"`
+	Suppressed   int         `json:"suppressed"`
+}
+
+// ConsoleOptions controls the console output. Zero limits mean no limit.
+type ConsoleOptions struct {
+	Verbose bool
+	// MaxIssues limits the number of issues written in total
+	MaxIssues int
+	// MaxIssuesPerFile limits the number of issues written for each file
+	MaxIssuesPerFile int
+	// Suggest fills in the suggestions of the issues written, when set
+	Suggest func(*Issue)
+}
+
+// maxListedLocations limits the locations written for a repeated issue
+const maxListedLocations = 5
+
 // OutputConsole outputs the check result to console format
-func (r *CheckResult) OutputConsole(w io.Writer, verbose bool) error {
+func (r *CheckResult) OutputConsole(w io.Writer, opts ConsoleOptions) error {
+	suppressed := ""
+	if r.Suppressed > 0 {
+		suppressed = fmt.Sprintf(" (%d suppressed)", r.Suppressed)
+	}
+
 	if r.TotalIssues == 0 {
-		fmt.Fprintf(w, "✅ No issues found in %d files\n", r.CheckedFiles)
+		fmt.Fprintf(w, "✅ No issues found in %d files%s\n", r.CheckedFiles, suppressed)
 		return nil
 	}
-	
-	fmt.Fprintf(w, "Found %d issues in %d files:\n\n", r.TotalIssues, r.CheckedFiles)
-	
-	// Group issues by file
+
+	fmt.Fprintf(w, "Found %d issues in %d files%s:\n\n", r.TotalIssues, r.CheckedFiles, suppressed)
+
+	shown, hidden := 0, 0
+	if len(r.Sections) == 0 {
+		hidden = writeIssuesByFile(w, r.Issues, opts, &shown)
+	}
+
+	// Merged results list the issues of each checker in its own section
+	for _, section := range r.Sections {
+		fmt.Fprintf(w, "== %s: %d issues in %d files ==\n\n", section.CheckerType, section.TotalIssues, section.CheckedFiles)
+		var issues []Issue
+		for _, issue := range r.Issues {
+			if issue.Type == section.CheckerType {
+				issues = append(issues, issue)
+			}
+		}
+		hidden += writeIssuesByFile(w, issues, opts, &shown)
+	}
+
+	if hidden > 0 {
+		fmt.Fprintf(w, "... %d more issues not shown\n", hidden)
+	}
+	return nil
+}
+
+// issueGroup is an issue repeated at several locations of a file
+type issueGroup struct {
+	issues []Issue
+}
+
+// groupRepeated groups the issues about the same word with the same message,
+// in order of first appearance. Other issues stay on their own.
+func groupRepeated(issues []Issue) []issueGroup {
+	var groups []issueGroup
+	index := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Word == "" {
+			groups = append(groups, issueGroup{issues: []Issue{issue}})
+			continue
+		}
+		key := issue.RuleID + "\x00" + issue.Word + "\x00" + issue.Message
+		if i, ok := index[key]; ok {
+			groups[i].issues = append(groups[i].issues, issue)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, issueGroup{issues: []Issue{issue}})
+	}
+	return groups
+}
+
+// writeIssuesByFile writes issues grouped by file, writing repeated issues
+// once with all their locations. shown counts the issues written so far
+// across calls, each location of a repeated issue counting as one; it
+// returns the number of issues left out by the limits.
+func writeIssuesByFile(w io.Writer, issues []Issue, opts ConsoleOptions, shown *int) int {
+	// Group issues by file, in order of first appearance
+	var files []string
 	fileIssues := make(map[string][]Issue)
-	for _, issue := range r.Issues {
+	for _, issue := range issues {
+		if _, ok := fileIssues[issue.File]; !ok {
+			files = append(files, issue.File)
+		}
 		fileIssues[issue.File] = append(fileIssues[issue.File], issue)
 	}
-	
-	for file, issues := range fileIssues {
-		fmt.Fprintf(w, "📁 %s (%d issues):\n", file, len(issues))
-		
-		for _, issue := range issues {
-			severityIcon := getSeverityIcon(issue.Severity)
-			
-			if issue.Line > 0 {
-				fmt.Fprintf(w, "  %s Line %d:%d - %s", severityIcon, issue.Line, issue.Column, issue.Message)
-			} else {
-				fmt.Fprintf(w, "  %s %s", severityIcon, issue.Message)
+
+	hidden := 0
+	for _, file := range files {
+		if opts.MaxIssues > 0 && *shown >= opts.MaxIssues {
+			hidden += len(fileIssues[file])
+			continue
+		}
+
+		fmt.Fprintf(w, "📁 %s (%d issues):\n", file, len(fileIssues[file]))
+
+		groups := groupRepeated(fileIssues[file])
+		written := 0
+		for i, group := range groups {
+			// A repeated issue is cut to the locations left within the limits
+			limit := len(group.issues)
+			if opts.MaxIssuesPerFile > 0 {
+				limit = min(limit, opts.MaxIssuesPerFile-written)
 			}
-			
-			if issue.Word != "" {
-				fmt.Fprintf(w, " ('%s')", issue.Word)
+			if opts.MaxIssues > 0 {
+				limit = min(limit, opts.MaxIssues-*shown)
 			}
-			
-			if len(issue.Suggestions) > 0 {
-				fmt.Fprintf(w, " → Suggestions: %s", joinStrings(issue.Suggestions, ", "))
+			if limit > 0 {
+				if opts.Suggest != nil {
+					opts.Suggest(&group.issues[0])
+				}
+				writeIssueGroup(w, issueGroup{issues: group.issues[:limit]})
+				written += limit
+				*shown += limit
+			}
+			if limit < len(group.issues) {
+				rest := len(group.issues) - max(limit, 0)
+				for _, g := range groups[i+1:] {
+					rest += len(g.issues)
+				}
+				fmt.Fprintf(w, "  ... %d more issues in this file\n", rest)
+				hidden += rest
+				break
 			}
-			
-			fmt.Fprintln(w)
 		}
 		fmt.Fprintln(w)
 	}
-	
-	return nil
+	return hidden
+}
+
+// writeIssueGroup writes an issue with the locations it repeats at
+func writeIssueGroup(w io.Writer, group issueGroup) {
+	issue := group.issues[0]
+	severityIcon := getSeverityIcon(issue.Severity)
+
+	switch {
+	case len(group.issues) > 1:
+		var locations []string
+		for i, repeated := range group.issues {
+			if i == maxListedLocations {
+				locations = append(locations, "...")
+				break
+			}
+			locations = append(locations, fmt.Sprintf("%d:%d", repeated.Line, repeated.Column))
+		}
+		fmt.Fprintf(w, "  %s Lines %s - %s", severityIcon, joinStrings(locations, ", "), issue.Message)
+	case issue.Line > 0:
+		fmt.Fprintf(w, "  %s Line %d:%d - %s", severityIcon, issue.Line, issue.Column, issue.Message)
+	default:
+		fmt.Fprintf(w, "  %s %s", severityIcon, issue.Message)
+	}
+
+	if issue.Word != "" {
+		fmt.Fprintf(w, " ('%s')", issue.Word)
+	}
+
+	if len(issue.Suggestions) > 0 {
+		fmt.Fprintf(w, " → Suggestions: %s", joinStrings(issue.Suggestions, ", "))
+	}
+
+	if len(group.issues) > 1 {
+		fmt.Fprintf(w, " (%d times)", len(group.issues))
+	}
+
+	fmt.Fprintln(w)
 }
 
 // OutputJSON outputs the check result in JSON format
@@ -104,6 +286,56 @@ func (r *CheckResult) AddIssue(issue Issue) {
 	r.TotalIssues++
 }
 
+// SetIssues replaces the issues of the result and updates the counts
+func (r *CheckResult) SetIssues(issues []Issue) {
+	r.Issues = issues
+	r.TotalIssues = len(issues)
+	for i := range r.Sections {
+		r.Sections[i].TotalIssues = 0
+		for _, issue := range issues {
+			if issue.Type == r.Sections[i].CheckerType {
+				r.Sections[i].TotalIssues++
+			}
+		}
+	}
+}
+
+// CountAtLeast returns the number of issues as severe as threshold or more
+func (r *CheckResult) CountAtLeast(threshold Severity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity.AtLeast(threshold) {
+			count++
+		}
+	}
+	return count
+}
+
+// MergeResults combines the results of several checkers run over the same
+// files into one result with a section per checker
+func MergeResults(projectType string, results []*CheckResult) *CheckResult {
+	merged := &CheckResult{
+		Issues:      []Issue{},
+		ProjectType: projectType,
+		CheckerType: AllCheckerType,
+	}
+
+	for _, result := range results {
+		merged.TotalFiles = max(merged.TotalFiles, result.TotalFiles)
+		merged.CheckedFiles = max(merged.CheckedFiles, result.CheckedFiles)
+		merged.Suppressed += result.Suppressed
+		merged.Issues = append(merged.Issues, result.Issues...)
+		merged.Sections = append(merged.Sections, Section{
+			CheckerType:  result.CheckerType,
+			CheckedFiles: result.CheckedFiles,
+			TotalIssues:  result.TotalIssues,
+			Suppressed:   result.Suppressed,
+		})
+	}
+	merged.TotalIssues = len(merged.Issues)
+	return merged
+}
+
 // Checker interface defines the contract for all quality checkers
 type Checker interface {
 	Name() string
@@ -113,6 +345,106 @@ type Checker interface {
 	SetProject(projectType string) error
 }
 
+// Suggester is a checker that leaves the suggestions of its issues out while
+// checking, since they are costly to find, for Suggest to fill in for the
+// issues written or fixed only
+type Suggester interface {
+	// Suggest fills in the suggestions of an issue the checker found
+	Suggest(issue *Issue)
+}
+
+// SuggestFunc returns a function filling in the suggestions of the issues
+// of the checkers that leave them out while checking
+func SuggestFunc(checkers []Checker) func(*Issue) {
+	suggesters := make(map[CheckerType]Suggester)
+	for _, c := range checkers {
+		if suggester, ok := c.(Suggester); ok {
+			suggesters[c.Type()] = suggester
+		}
+	}
+	return func(issue *Issue) {
+		if suggester, ok := suggesters[issue.Type]; ok {
+			suggester.Suggest(issue)
+		}
+	}
+}
+
+// FillSuggestions fills in the suggestions of every issue of the result
+func (r *CheckResult) FillSuggestions(suggest func(*Issue)) {
+	if suggest == nil {
+		return
+	}
+	for i := range r.Issues {
+		suggest(&r.Issues[i])
+	}
+}
+
+// checkFiles runs a checker over every file and collects the issues into a
+// single result. Files that fail to check are reported and skipped.
+func checkFiles(c Checker, projectType string, filePaths []string) (*CheckResult, error) {
+	result := &CheckResult{
+		TotalFiles:   len(filePaths),
+		CheckedFiles: 0,
+		TotalIssues:  0,
+		Issues:       []Issue{},
+		ProjectType:  projectType,
+		CheckerType:  c.Type(),
+	}
+
+	cache := resultCache
+	if _, ok := c.(crossFileChecker); ok {
+		cache = nil
+	}
+	fingerprint := checkerFingerprint(c, projectType)
+
+	for _, filePath := range filePaths {
+		issues, err := checkFileCached(c, cache, fingerprint, filePath)
+		if err != nil {
+			// Log error but continue with other files
+			fmt.Fprintf(os.Stderr, "Warning: Failed to check %s: %v\n", filePath, err)
+			continue
+		}
+
+		result.CheckedFiles++
+		for _, issue := range issues {
+			result.AddIssue(issue)
+		}
+	}
+
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	applySuppressions(result)
+	return result, nil
+}
+
+// checkFileCached checks a file, reusing the cached issues when neither the
+// file nor the checker configuration changed since the last run
+func checkFileCached(c Checker, cache *ResultCache, fingerprint, filePath string) ([]Issue, error) {
+	if cache == nil {
+		return c.CheckFile(filePath)
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return c.CheckFile(filePath)
+	}
+	key := cacheKey(fingerprint, content)
+	if issues, ok := cache.Get(filePath, key); ok {
+		return issues, nil
+	}
+
+	issues, err := c.CheckFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(filePath, key, issues)
+	return issues, nil
+}
+
 // getSeverityIcon returns an icon for the given severity level
 func getSeverityIcon(severity Severity) string {
 	switch severity {
@@ -135,10 +467,10 @@ func joinStrings(strs []string, sep string) string {
 	if len(strs) == 1 {
 		return strs[0]
 	}
-	
+
 	result := strs[0]
 	for i := 1; i < len(strs); i++ {
 		result += sep + strs[i]
 	}
 	return result
-}
\ No newline at end of file
+}
@@ -0,0 +1,48 @@
+package checker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOutputConsoleLimits(t *testing.T) {
+	var issues []Issue
+	for line := 1; line <= 6; line++ {
+		issues = append(issues, Issue{Type: SpellCheckerType, Severity: WarningSeverity, File: "a.md", Line: line, Column: 1, Word: "teh", Message: "Unknown word", RuleID: "spell-check"})
+	}
+	issues = append(issues,
+		Issue{Type: SpellCheckerType, Severity: WarningSeverity, File: "a.md", Line: 7, Column: 1, Word: "recieve", Message: "Unknown word", RuleID: "spell-check"},
+		Issue{Type: SpellCheckerType, Severity: WarningSeverity, File: "b.md", Line: 1, Column: 1, Word: "teh", Message: "Unknown word", RuleID: "spell-check"},
+	)
+
+	tests := []struct {
+		name  string
+		opts  ConsoleOptions
+		lines []string // expected in the output
+		shown int      // issue lines written
+	}{
+		{"no limits", ConsoleOptions{}, []string{"Lines 1:1, 2:1, 3:1, 4:1, 5:1, ... - Unknown word ('teh') (6 times)", "Line 7:1", "📁 b.md"}, 3},
+		{"total limit cuts a repeated issue", ConsoleOptions{MaxIssues: 3}, []string{"Lines 1:1, 2:1, 3:1 - Unknown word ('teh') (3 times)", "... 4 more issues in this file", "... 5 more issues not shown"}, 1},
+		{"total limit across files", ConsoleOptions{MaxIssues: 7}, []string{"(6 times)", "Line 7:1", "... 1 more issues not shown"}, 2},
+		{"per-file limit", ConsoleOptions{MaxIssuesPerFile: 2}, []string{"Lines 1:1, 2:1 - Unknown word ('teh') (2 times)", "... 5 more issues in this file", "📁 b.md"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &CheckResult{CheckedFiles: 2}
+			result.SetIssues(issues)
+			var out bytes.Buffer
+			if err := result.OutputConsole(&out, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range tt.lines {
+				if !strings.Contains(out.String(), line) {
+					t.Errorf("output lacks %q:\n%s", line, out.String())
+				}
+			}
+			if got := strings.Count(out.String(), "⚠️"); got != tt.shown {
+				t.Errorf("wrote %d issue lines, want %d:\n%s", got, tt.shown, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,625 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/samzong/mm/internal/cache"
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Link checker rule IDs
+const (
+	RuleDeadExternalLink = "link-external"
+	RuleBrokenRelative   = "link-relative"
+	RuleBrokenAnchor     = "link-anchor"
+)
+
+const (
+	linkCheckerUserAgent  = "mm-link-checker (+https://github.com/samzong/mm)"
+	linkCacheFailureTTL   = time.Hour
+	defaultLinkTimeout    = 10 * time.Second
+	defaultLinkRetries    = 2
+	defaultLinkConcurrent = 8
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(LinksCheckerType),
+		Description: "Broken relative links, anchors and external URLs",
+		Factory: func() (Checker, error) {
+			return NewLinkChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleDeadExternalLink,
+		Checker:     LinksCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "External URL is unreachable or returns an error status",
+		Example:     "[docs](https://example.com/removed-page)",
+	})
+	RegisterRule(Rule{
+		ID:          RuleBrokenRelative,
+		Checker:     LinksCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Relative link points to a file that does not exist",
+		Example:     "[setup](../setup.md) when ../setup.md was moved",
+	})
+	RegisterRule(Rule{
+		ID:          RuleBrokenAnchor,
+		Checker:     LinksCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Link anchor does not match any heading or id in the target page",
+		Example:     "[see below](#instalation)",
+	})
+}
+
+var (
+	inlineLinkPattern    = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	referenceLinkPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s+.*)?$`)
+	autoLinkPattern      = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	htmlLinkPattern      = regexp.MustCompile(`(?:href|src)\s*=\s*"([^"]+)"`)
+	htmlIDPattern        = regexp.MustCompile(`(?:id|name)\s*=\s*"([^"]+)"`)
+	explicitAnchor       = regexp.MustCompile(`\{#([^}\s]+)\}\s*$`)
+)
+
+// markdownLink is a link target found in a document
+type markdownLink struct {
+	target string
+	line   int
+	column int
+}
+
+// linkStatus is the outcome of checking an external URL
+type linkStatus struct {
+	OK        bool      `json:"ok"`
+	Warning   bool      `json:"warning,omitempty"`
+	Status    int       `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// LinkChecker implements the Checker interface for dead links and anchors
+type LinkChecker struct {
+	projectType  string
+	adapter      adapter.ProjectAdapter
+	client       *http.Client
+	anchorCache  map[string]map[string]bool
+	Allowlist    []string // URL glob patterns or prefixes that are never checked
+	SkipExternal bool
+	Concurrency  int
+	Retries      int
+	UseCache     bool
+}
+
+// NewLinkChecker creates a new link checker
+func NewLinkChecker() *LinkChecker {
+	return &LinkChecker{
+		projectType: "generic",
+		client:      &http.Client{Timeout: defaultLinkTimeout},
+		anchorCache: make(map[string]map[string]bool),
+		Concurrency: defaultLinkConcurrent,
+		Retries:     defaultLinkRetries,
+		UseCache:    true,
+	}
+}
+
+// Name returns the name of this checker
+func (l *LinkChecker) Name() string {
+	return "Link Checker"
+}
+
+// Type returns the type of this checker
+func (l *LinkChecker) Type() CheckerType {
+	return LinksCheckerType
+}
+
+// SetTimeout sets the timeout for each external request
+func (l *LinkChecker) SetTimeout(timeout time.Duration) {
+	l.client.Timeout = timeout
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (l *LinkChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	l.projectType = projectType
+	l.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks the links of a single file, including external URLs
+func (l *LinkChecker) CheckFile(filePath string) ([]Issue, error) {
+	issues, external, err := l.checkLocalLinks(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, l.checkExternalLinks(external)...), nil
+}
+
+// CheckFiles checks links in all files. External URLs are collected across
+// every file first so each unique URL is only requested once.
+func (l *LinkChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	result := &CheckResult{
+		TotalFiles:  len(filePaths),
+		Issues:      []Issue{},
+		ProjectType: l.projectType,
+		CheckerType: LinksCheckerType,
+	}
+
+	external := make(map[string][]Issue)
+	for _, filePath := range filePaths {
+		issues, links, err := l.checkLocalLinks(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to check %s: %v\n", filePath, err)
+			continue
+		}
+
+		result.CheckedFiles++
+		for _, issue := range issues {
+			result.AddIssue(issue)
+		}
+		for link, occurrences := range links {
+			external[link] = append(external[link], occurrences...)
+		}
+	}
+
+	for _, issue := range l.checkExternalLinks(external) {
+		result.AddIssue(issue)
+	}
+
+	applySuppressions(result)
+
+	return result, nil
+}
+
+// checkLocalLinks validates relative links and anchors in a file and returns
+// external URLs with placeholder issues for every place they occur
+func (l *LinkChecker) checkLocalLinks(filePath string) ([]Issue, map[string][]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil, nil
+	}
+	if l.adapter != nil && adapter.ShouldIgnoreFile(filePath, l.adapter.GetIgnorePatterns()) {
+		return nil, nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var issues []Issue
+	external := make(map[string][]Issue)
+
+	for _, link := range extractMarkdownLinks(string(content)) {
+		target := link.target
+		if l.isAllowed(target) {
+			continue
+		}
+
+		issue := Issue{
+			Type:     LinksCheckerType,
+			Severity: ErrorSeverity,
+			File:     filePath,
+			Line:     link.line,
+			Column:   link.column,
+			Word:     target,
+		}
+
+		switch {
+		case strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://"):
+			if !l.SkipExternal {
+				external[target] = append(external[target], issue)
+			}
+
+		case strings.HasPrefix(target, "#"):
+			anchor := strings.TrimPrefix(target, "#")
+			if anchor != "" && !l.anchorsFor(filePath, string(content))[strings.ToLower(anchor)] {
+				issue.RuleID = RuleBrokenAnchor
+				issue.Message = fmt.Sprintf("Anchor '#%s' not found in this page", anchor)
+				issues = append(issues, issue)
+			}
+
+		case isLocalLink(target):
+			pathPart, anchor, _ := strings.Cut(target, "#")
+			pathPart, _, _ = strings.Cut(pathPart, "?")
+			if decoded, err := url.PathUnescape(pathPart); err == nil {
+				pathPart = decoded
+			}
+
+			resolved, ok := resolveLocalTarget(filePath, pathPart)
+			if !ok {
+				issue.RuleID = RuleBrokenRelative
+				issue.Message = fmt.Sprintf("Link target '%s' does not exist", pathPart)
+				issues = append(issues, issue)
+				continue
+			}
+
+			if anchor != "" && resolved != "" && isMarkdownFile(resolved) {
+				targetContent, err := os.ReadFile(resolved)
+				if err == nil && !l.anchorsFor(resolved, string(targetContent))[strings.ToLower(anchor)] {
+					issue.RuleID = RuleBrokenAnchor
+					issue.Message = fmt.Sprintf("Anchor '#%s' not found in %s", anchor, resolved)
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+
+	return issues, external, nil
+}
+
+// checkExternalLinks requests every unique URL concurrently and returns an
+// issue for each occurrence of a broken one
+func (l *LinkChecker) checkExternalLinks(external map[string][]Issue) []Issue {
+	if len(external) == 0 {
+		return nil
+	}
+
+	cache := map[string]linkStatus{}
+	if l.UseCache {
+		cache = loadLinkCache()
+	}
+
+	var mu sync.Mutex
+	statuses := make(map[string]linkStatus, len(external))
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+
+	workers := l.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range jobs {
+				status := l.fetchWithRetry(link)
+				mu.Lock()
+				statuses[link] = status
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for link := range external {
+		if cached, ok := cache[link]; ok && cached.isFresh() {
+			statuses[link] = cached
+			continue
+		}
+		jobs <- link
+	}
+	close(jobs)
+	wg.Wait()
+
+	if l.UseCache {
+		for link, status := range statuses {
+			cache[link] = status
+		}
+		if err := saveLinkCache(cache); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to save link cache: %v\n", err)
+		}
+	}
+
+	var issues []Issue
+	for link, occurrences := range external {
+		status := statuses[link]
+		if status.OK {
+			continue
+		}
+		for _, issue := range occurrences {
+			issue.RuleID = RuleDeadExternalLink
+			if status.Warning {
+				issue.Severity = WarningSeverity
+			}
+			if status.Error != "" {
+				issue.Message = fmt.Sprintf("External link failed: %s", status.Error)
+			} else {
+				issue.Message = fmt.Sprintf("External link returned HTTP %d", status.Status)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// fetchWithRetry checks a URL, retrying transient failures with backoff
+func (l *LinkChecker) fetchWithRetry(link string) linkStatus {
+	backoff := time.Second
+	var status linkStatus
+	for attempt := 0; attempt <= l.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		status = l.fetch(link)
+		if status.OK || !isTransient(status) {
+			break
+		}
+	}
+	status.CheckedAt = time.Now()
+	return status
+}
+
+// fetch performs a HEAD request, falling back to GET for servers that
+// don't support HEAD
+func (l *LinkChecker) fetch(link string) linkStatus {
+	statusCode, err := l.request(http.MethodHead, link)
+	if err == nil && (statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusForbidden || statusCode == http.StatusNotImplemented) {
+		statusCode, err = l.request(http.MethodGet, link)
+	}
+	if err != nil {
+		return linkStatus{Error: err.Error()}
+	}
+
+	switch {
+	case statusCode < 400:
+		return linkStatus{OK: true, Status: statusCode}
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests:
+		// Often bot protection or rate limiting rather than a dead link
+		return linkStatus{Warning: true, Status: statusCode}
+	default:
+		return linkStatus{Status: statusCode}
+	}
+}
+
+// request issues a single HTTP request and returns the status code
+func (l *LinkChecker) request(method, link string) (int, error) {
+	req, err := http.NewRequest(method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", linkCheckerUserAgent)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// isTransient reports whether a failed check is worth retrying
+func isTransient(status linkStatus) bool {
+	return status.Error != "" || status.Status == http.StatusTooManyRequests || status.Status >= 500
+}
+
+// isFresh reports whether a cached status can still be trusted. Successes
+// last the TTL of the links cache, failures an hour at most.
+func (s linkStatus) isFresh() bool {
+	ttl := cache.TTL(cache.Links)
+	if !s.OK && (ttl == 0 || ttl > linkCacheFailureTTL) {
+		ttl = linkCacheFailureTTL
+	}
+	return ttl == 0 || time.Since(s.CheckedAt) < ttl
+}
+
+// isAllowed checks a link against the allowlist
+func (l *LinkChecker) isAllowed(link string) bool {
+	for _, pattern := range l.Allowlist {
+		if strings.HasPrefix(link, pattern) || matchURLPattern(pattern, link) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchURLPattern matches a URL against a pattern where '*' matches any
+// sequence of characters, including slashes
+func matchURLPattern(pattern, link string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return false
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("^"+expr+"$", link)
+	return err == nil && matched
+}
+
+// anchorsFor returns the set of anchors defined in a markdown document
+func (l *LinkChecker) anchorsFor(filePath, content string) map[string]bool {
+	if anchors, ok := l.anchorCache[filePath]; ok {
+		return anchors
+	}
+	anchors := extractAnchors(content)
+	l.anchorCache[filePath] = anchors
+	return anchors
+}
+
+// extractMarkdownLinks finds link targets outside of code
+func extractMarkdownLinks(content string) []markdownLink {
+	var links []markdownLink
+	inCodeBlock := false
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		// Blank out inline code so links inside it are ignored
+		line = inlineCodeSpan.ReplaceAllStringFunc(line, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+
+		for _, pattern := range []*regexp.Regexp{inlineLinkPattern, referenceLinkPattern, autoLinkPattern, htmlLinkPattern} {
+			for _, match := range pattern.FindAllStringSubmatchIndex(line, -1) {
+				target := line[match[2]:match[3]]
+				if target == "" || strings.Contains(target, "{{") {
+					continue
+				}
+				links = append(links, markdownLink{
+					target: target,
+					line:   i + 1,
+					column: match[2] + 1,
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// extractAnchors computes heading anchors (GitHub/Hugo style) and explicit ids
+func extractAnchors(content string) map[string]bool {
+	anchors := make(map[string]bool)
+	for _, anchor := range pageAnchors(content) {
+		anchors[anchor.id] = true
+	}
+	return anchors
+}
+
+// slugify converts heading text into an anchor the way Hugo and GitHub do
+func slugify(text string) string {
+	text = inlineLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		if start := strings.Index(link, "["); start >= 0 {
+			if end := strings.Index(link, "]"); end > start {
+				return link[start+1 : end]
+			}
+		}
+		return link
+	})
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// isLocalLink reports links that refer to files in the repository
+func isLocalLink(target string) bool {
+	if strings.Contains(target, "://") {
+		return false
+	}
+	for _, scheme := range []string{"mailto:", "tel:", "javascript:", "data:"} {
+		if strings.HasPrefix(target, scheme) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveLocalTarget finds the file a local link refers to. It returns the
+// resolved path (empty when it is a directory or a site-absolute link that
+// can't be mapped) and whether the target exists.
+func resolveLocalTarget(filePath, target string) (string, bool) {
+	if target == "" {
+		return filePath, true
+	}
+
+	var base string
+	if strings.HasPrefix(target, "/") {
+		// Site-absolute link: resolve against the Hugo content root for this
+		// file's language, or the static directory
+		contentRoot := hugoContentRoot(filePath)
+		if contentRoot == "" {
+			return "", true
+		}
+		siteRoot := filepath.Dir(filepath.Dir(contentRoot))
+		if fileExistsOrDir(filepath.Join(siteRoot, "static", target)) {
+			return "", true
+		}
+		trimmed := strings.TrimPrefix(target, "/")
+		// Drop a leading language prefix such as /zh-cn/docs/...
+		if parts := strings.SplitN(trimmed, "/", 2); len(parts) == 2 && filepath.Base(contentRoot) == parts[0] {
+			trimmed = parts[1]
+		}
+		base = filepath.Join(contentRoot, trimmed)
+	} else {
+		base = filepath.Join(filepath.Dir(filePath), target)
+	}
+
+	candidates := []string{base}
+	if filepath.Ext(base) == "" {
+		candidates = append(candidates,
+			base+".md",
+			filepath.Join(base, "_index.md"),
+			filepath.Join(base, "index.md"),
+		)
+	}
+	// Hugo pretty URLs are relative to the page itself, not its directory
+	if !strings.HasPrefix(target, "/") && !strings.HasSuffix(filePath, "_index.md") && !strings.HasSuffix(filePath, "index.md") {
+		pageDir := strings.TrimSuffix(filePath, filepath.Ext(filePath))
+		pretty := filepath.Join(pageDir, target)
+		candidates = append(candidates, pretty+".md", filepath.Join(pretty, "_index.md"), filepath.Join(pretty, "index.md"))
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil {
+			if info.IsDir() {
+				continue
+			}
+			return candidate, true
+		}
+	}
+	if info, err := os.Stat(base); err == nil && info.IsDir() {
+		return "", true
+	}
+	return "", false
+}
+
+// hugoContentRoot returns the content/<lang> directory containing the file
+func hugoContentRoot(filePath string) string {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(absPath), "/")
+	for i := len(parts) - 2; i > 0; i-- {
+		if parts[i-1] == "content" {
+			return filepath.FromSlash(strings.Join(parts[:i+1], "/"))
+		}
+	}
+	return ""
+}
+
+// fileExistsOrDir checks if a path exists
+func fileExistsOrDir(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// linkCacheKey is the entry of the links cache holding the statuses
+const linkCacheKey = "external"
+
+// loadLinkCache loads cached external link statuses
+func loadLinkCache() map[string]linkStatus {
+	statuses := make(map[string]linkStatus)
+	if _, err := cache.New(cache.Links).Load(linkCacheKey, &statuses); err != nil || statuses == nil {
+		return make(map[string]linkStatus)
+	}
+	return statuses
+}
+
+// saveLinkCache writes external link statuses, dropping expired entries
+func saveLinkCache(statuses map[string]linkStatus) error {
+	for link, status := range statuses {
+		if !status.isFresh() {
+			delete(statuses, link)
+		}
+	}
+	return cache.New(cache.Links).Put(linkCacheKey, statuses)
+}
@@ -0,0 +1,158 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestExtractMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // target at line:column
+	}{
+		{"inline link", "See [docs](/docs/home/) now", []string{"/docs/home/@1:12"}},
+		{"image and title", `![logo](img/logo.png "Logo")`, []string{"img/logo.png@1:9"}},
+		{"reference definition", "[k8s]: https://kubernetes.io", []string{"https://kubernetes.io@1:8"}},
+		{"autolink", "<https://kubernetes.io>", []string{"https://kubernetes.io@1:2"}},
+		{"HTML link", `<a href="#setup">setup</a>`, []string{"#setup@1:10"}},
+		{"code span", "Run `[x](y.md)`", nil},
+		{"fenced code", "```\n[x](y.md)\n```\n[z](z.md)", []string{"z.md@4:5"}},
+		{"shortcode target", `[ref]({{< ref "x.md" >}})`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, link := range extractMarkdownLinks(tt.content) {
+				got = append(got, fmt.Sprintf("%s@%d:%d", link.target, link.line, link.column))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractMarkdownLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Install kubectl":           "install-kubectl",
+		"What's new?":               "whats-new",
+		"Use [Pods](/docs/pods/)":   "use-pods",
+		"kube_proxy and kube-proxy": "kube_proxy-and-kube-proxy",
+		"准备开始":                      "准备开始",
+	}
+	for text, want := range tests {
+		if got := slugify(text); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestLinkAllowlist(t *testing.T) {
+	l := NewLinkChecker()
+	l.Allowlist = []string{"https://localhost", "https://*.example.com/*"}
+	tests := map[string]bool{
+		"https://localhost:8080/x":   true,
+		"https://docs.example.com/a": true,
+		"https://example.com/a":      false,
+		"https://kubernetes.io":      false,
+	}
+	for link, want := range tests {
+		if got := l.isAllowed(link); got != want {
+			t.Errorf("isAllowed(%s) = %v, want %v", link, got, want)
+		}
+	}
+}
+
+// writeFiles creates files with their contents under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckLocalLinks(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"content/zh-cn/docs/setup.md":           "## 安装 {#install}\n",
+		"content/zh-cn/docs/concepts/_index.md": "# Concepts\n",
+		"static/images/logo.png":                "",
+		"content/zh-cn/docs/page.md": "<!--\n## Overview\n-->\n## 概述 {#summary}\n\n" +
+			"[ok](#summary) [english](#overview)\n" +
+			"[file](setup.md#install) [anchor](setup.md#setup)\n" +
+			"[site](/zh-cn/docs/concepts/) [static](/images/logo.png) [missing](/docs/missing/)\n" +
+			"[external](https://kubernetes.io)\n",
+	})
+
+	l := NewLinkChecker()
+	issues, external, err := l.checkLocalLinks(filepath.Join(dir, "content", "zh-cn", "docs", "page.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.RuleID+" "+issue.Word)
+	}
+	want := []string{
+		RuleBrokenAnchor + " #overview",
+		RuleBrokenAnchor + " setup.md#setup",
+		RuleBrokenRelative + " /docs/missing/",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkLocalLinks() issues = %v, want %v", got, want)
+	}
+	if len(external) != 1 || len(external["https://kubernetes.io"]) != 1 {
+		t.Errorf("checkLocalLinks() external = %v, want kubernetes.io once", external)
+	}
+}
+
+func TestCheckExternalLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/forbidden":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	l := NewLinkChecker()
+	l.UseCache = false
+	l.Retries = 0
+	external := make(map[string][]Issue)
+	for _, path := range []string{"/ok", "/get-only", "/forbidden", "/gone"} {
+		external[server.URL+path] = []Issue{{Type: LinksCheckerType, Severity: ErrorSeverity, Word: server.URL + path}}
+	}
+
+	var got []string
+	for _, issue := range l.checkExternalLinks(external) {
+		got = append(got, fmt.Sprintf("%s %s %s", issue.Word[len(server.URL):], issue.Severity, issue.Message))
+	}
+	sort.Strings(got)
+	want := []string{
+		"/forbidden warning External link returned HTTP 401",
+		"/gone error External link returned HTTP 404",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkExternalLinks() = %v, want %v", got, want)
+	}
+}
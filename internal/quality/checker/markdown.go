@@ -0,0 +1,380 @@
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Markdown lint rule IDs follow markdownlint naming so they are familiar
+const (
+	RuleHeadingIncrement = "MD001"
+	RuleListIndent       = "MD007"
+	RuleLineLength       = "MD013"
+	RuleDuplicateHeading = "MD024"
+	RuleBareURL          = "MD034"
+)
+
+const (
+	defaultMaxLineLength   = 120
+	defaultListIndentWidth = 2
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(MarkdownCheckerType),
+		Description: "Markdown lint rules",
+		Factory: func() (Checker, error) {
+			return NewMarkdownChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleHeadingIncrement,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Heading levels should only increment by one level at a time",
+		Example:     "# Title followed directly by ### Section",
+	})
+	RegisterRule(Rule{
+		ID:          RuleListIndent,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Nested unordered list items should be indented by two spaces per level",
+		Example:     "- item\n   - nested with three spaces",
+	})
+	RegisterRule(Rule{
+		ID:          RuleLineLength,
+		Checker:     MarkdownCheckerType,
+		Severity:    InfoSeverity,
+		Description: "Line exceeds the maximum line length",
+	})
+	RegisterRule(Rule{
+		ID:          RuleDuplicateHeading,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Multiple headings with the same content",
+		Example:     "## Example ... ## Example",
+	})
+	RegisterRule(Rule{
+		ID:          RuleBareURL,
+		Checker:     MarkdownCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Bare URL used instead of a link",
+		Example:     "See https://kubernetes.io for details",
+	})
+}
+
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?\s*#*\s*$`)
+	unorderedItemPattern = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	orderedItemPattern   = regexp.MustCompile(`^(\s*)\d+[.)]\s+`)
+	bareURLPattern       = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+	inlineCodeSpan       = regexp.MustCompile("`[^`]*`")
+	headingAnchorSuffix  = regexp.MustCompile(`\s*\{#[^}]*\}$`)
+	linkReferenceDef     = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s`)
+)
+
+// MarkdownChecker implements the Checker interface with markdownlint-style rules
+type MarkdownChecker struct {
+	projectType   string
+	adapter       adapter.ProjectAdapter
+	MaxLineLength int
+	// HeadingCase is the case headings are written in (sentence or title);
+	// empty disables the rule
+	HeadingCase string
+	// MaxHeadingLength is the maximum heading length; 0 disables the rule
+	MaxHeadingLength int
+	// HeadingPunctuation lists the characters headings must not end with
+	HeadingPunctuation string
+	// HeadingProperNouns keep their casing in headings, in addition to the
+	// proper nouns of the project type
+	HeadingProperNouns []string
+}
+
+// NewMarkdownChecker creates a new markdown lint checker
+func NewMarkdownChecker() *MarkdownChecker {
+	return &MarkdownChecker{
+		projectType:        "generic",
+		MaxLineLength:      defaultMaxLineLength,
+		HeadingPunctuation: DefaultHeadingPunctuation,
+	}
+}
+
+// Name returns the name of this checker
+func (m *MarkdownChecker) Name() string {
+	return "Markdown Checker"
+}
+
+// Type returns the type of this checker
+func (m *MarkdownChecker) Type() CheckerType {
+	return MarkdownCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (m *MarkdownChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	m.projectType = projectType
+	m.adapter = projectAdapter
+	return validateHeadingCase(m.HeadingCase)
+}
+
+// CheckFile lints a single markdown file
+func (m *MarkdownChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if m.adapter != nil && adapter.ShouldIgnoreFile(filePath, m.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return m.lint(filePath, string(content)), nil
+}
+
+// Fingerprint identifies the lint settings
+func (m *MarkdownChecker) Fingerprint() string {
+	return fingerprintOf(m.MaxLineLength, m.HeadingCase, m.MaxHeadingLength, m.HeadingPunctuation, m.HeadingProperNouns)
+}
+
+// CheckFiles lints multiple markdown files
+func (m *MarkdownChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(m, m.projectType, filePaths)
+}
+
+// markdownListItem tracks an open list item for indentation checks
+type markdownListItem struct {
+	indent    int
+	unordered bool
+}
+
+// lint runs every markdown rule over the content
+func (m *MarkdownChecker) lint(filePath, content string) []Issue {
+	var issues []Issue
+	lines := strings.Split(content, "\n")
+
+	newIssue := func(ruleID string, line, column int, message string) Issue {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		return Issue{
+			Type:     MarkdownCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Message:  message,
+			RuleID:   ruleID,
+		}
+	}
+
+	inCodeBlock := false
+	inComment := false
+	fence := ""
+	lastHeadingLevel := 0
+	seenHeadings := make(map[string]int)
+	var listStack []markdownListItem
+
+	start := frontMatterEnd(lines)
+	for i := start; i < len(lines); i++ {
+		lineNum := i + 1
+
+		// Fenced code blocks are never linted
+		if inCodeBlock {
+			if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				inCodeBlock = false
+			}
+			continue
+		}
+
+		// Nor are HTML comments, where localized pages keep the English
+		// source
+		line, stillInComment := maskHTMLComments(lines[i], inComment)
+		inComment = stillInComment
+		if line != lines[i] {
+			line = strings.TrimRight(line, " ")
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = true
+			fence = trimmed[:3]
+			continue
+		}
+
+		if trimmed == "" {
+			continue
+		}
+
+		// Link reference definitions hold a bare URL by design and can't
+		// be wrapped
+		if linkReferenceDef.MatchString(line) {
+			continue
+		}
+
+		// Headings
+		if match := atxHeadingPattern.FindStringSubmatchIndex(line); match != nil {
+			level := match[3] - match[2]
+			text := ""
+			column := match[3] + 1
+			if match[4] >= 0 {
+				text = headingAnchorSuffix.ReplaceAllString(strings.TrimSpace(line[match[4]:match[5]]), "")
+				column = match[4] + 1
+			}
+			issues = append(issues, m.headingStyle(newIssue, lineNum, column, text)...)
+
+			if lastHeadingLevel > 0 && level > lastHeadingLevel+1 {
+				issues = append(issues, newIssue(RuleHeadingIncrement, lineNum, 1,
+					fmt.Sprintf("Heading level jumps from h%d to h%d", lastHeadingLevel, level)))
+			}
+			lastHeadingLevel = level
+
+			key := strings.ToLower(text)
+			if firstLine, ok := seenHeadings[key]; ok && key != "" {
+				issues = append(issues, newIssue(RuleDuplicateHeading, lineNum, 1,
+					fmt.Sprintf("Duplicate heading '%s' (first defined on line %d)", text, firstLine)))
+			} else {
+				seenHeadings[key] = lineNum
+			}
+			listStack = nil
+			continue
+		}
+
+		if text, ok := m.emphasisHeading(lines, i); ok {
+			issues = append(issues, newIssue(RuleEmphasisAsHeading, lineNum, 1,
+				fmt.Sprintf("Emphasis used instead of a heading: '%s'", text)))
+			continue
+		}
+
+		// List indentation
+		if match := unorderedItemPattern.FindStringSubmatch(line); match != nil && !isHorizontalRule(trimmed) {
+			indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+			listStack = popListItems(listStack, indent)
+
+			expected := 0
+			if len(listStack) > 0 {
+				parent := listStack[len(listStack)-1]
+				if parent.unordered {
+					expected = parent.indent + defaultListIndentWidth
+				} else {
+					expected = indent // ordered parents define their own content offset
+				}
+			}
+			if indent != expected {
+				issues = append(issues, newIssue(RuleListIndent, lineNum, 1,
+					fmt.Sprintf("Unordered list indentation is %d spaces, expected %d", indent, expected)))
+			}
+			listStack = append(listStack, markdownListItem{indent: indent, unordered: true})
+		} else if match := orderedItemPattern.FindStringSubmatch(line); match != nil {
+			indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+			listStack = popListItems(listStack, indent)
+			listStack = append(listStack, markdownListItem{indent: indent, unordered: false})
+		} else if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			listStack = nil
+		}
+
+		// Bare URLs
+		stripped := inlineCodeSpan.ReplaceAllStringFunc(line, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+		for _, loc := range bareURLPattern.FindAllStringIndex(stripped, -1) {
+			if isWrappedURL(stripped, loc[0]) {
+				continue
+			}
+			issues = append(issues, newIssue(RuleBareURL, lineNum, loc[0]+1,
+				fmt.Sprintf("Bare URL used: %s", stripped[loc[0]:loc[1]])))
+		}
+
+		// Line length, ignoring tables, shortcodes and lines that are a single link
+		if m.MaxLineLength > 0 && !isLineLengthExempt(trimmed) {
+			if length := utf8.RuneCountInString(line); length > m.MaxLineLength {
+				issues = append(issues, newIssue(RuleLineLength, lineNum, m.MaxLineLength+1,
+					fmt.Sprintf("Line length %d exceeds %d characters", length, m.MaxLineLength)))
+			}
+		}
+	}
+
+	return issues
+}
+
+// popListItems removes list items that are not parents of the given indent
+func popListItems(stack []markdownListItem, indent int) []markdownListItem {
+	for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+		stack = stack[:len(stack)-1]
+	}
+	return stack
+}
+
+// frontMatterEnd returns the index of the first line after YAML/TOML front matter
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// isWrappedURL checks whether a URL is already part of link or HTML syntax
+func isWrappedURL(line string, start int) bool {
+	if start == 0 {
+		return false
+	}
+	switch line[start-1] {
+	case '(', '<', '[', '"', '\'', '=':
+		return true
+	}
+	return false
+}
+
+// isHorizontalRule checks for thematic breaks like "---" or "* * *"
+func isHorizontalRule(trimmed string) bool {
+	compact := strings.ReplaceAll(trimmed, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	return strings.Trim(compact, string(compact[0])) == ""
+}
+
+// isLineLengthExempt reports lines that cannot reasonably be wrapped
+func isLineLengthExempt(trimmed string) bool {
+	if strings.HasPrefix(trimmed, "|") {
+		return true
+	}
+	if strings.HasPrefix(trimmed, "{{") {
+		return true
+	}
+	if !strings.Contains(trimmed, " ") {
+		return true
+	}
+	return strings.HasPrefix(trimmed, "[") && strings.Contains(trimmed, "](")
+}
+
+// isMarkdownFile checks the extension of a file
+func isMarkdownFile(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
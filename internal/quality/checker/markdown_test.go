@@ -0,0 +1,46 @@
+package checker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownLint(t *testing.T) {
+	long := "Some words " + strings.Repeat("x ", 60)
+	tests := []struct {
+		name    string
+		content string
+		want    []string // rule ID and line of each issue
+	}{
+		{"clean page", "# Title\n\n## Section\n\nText\n", nil},
+		{"heading increment", "# Title\n\n### Deep\n", []string{"MD001:3"}},
+		{"duplicate heading", "## Example\n\n## Example\n", []string{"MD024:3"}},
+		{"list indentation", "- a\n   - b\n", []string{"MD007:2"}},
+		{"bare URL", "See https://kubernetes.io for details\n", []string{"MD034:1"}},
+		{"inline link", "See [the site](https://kubernetes.io)\n", nil},
+		{"autolink", "See <https://kubernetes.io>\n", nil},
+		{"URL in a code span", "Run `curl https://kubernetes.io`\n", nil},
+		{"link reference definition", "See [k8s].\n\n[k8s]: https://kubernetes.io\n", nil},
+		{"indented link reference definition", "   [k8s]: https://kubernetes.io \"Kubernetes\"\n", nil},
+		{"long line", long + "\n", []string{"MD013:1"}},
+		{"long link reference definition", "[k8s]: https://kubernetes.io/" + strings.Repeat("x", 100) + " \"a title\"\n", nil},
+		{"fenced code", "```\nhttps://kubernetes.io\n```\n", nil},
+		{"commented English source", "# 标题\n\n<!--\n## Before you begin\n\nSee https://kubernetes.io\n" + long + "\n-->\n## 准备开始 {#before-you-begin}\n", nil},
+		{"one-line comment", "<!-- ## Steps -->\n## 步骤\n<!-- ## Steps -->\n## 其他\n", nil},
+		{"text after a comment", "<!-- note --> See https://kubernetes.io\n", []string{"MD034:1"}},
+		{"fence in a comment", "<!--\n```\n-->\nSee https://kubernetes.io\n", []string{"MD034:4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range NewMarkdownChecker().lint("a.md", tt.content) {
+				got = append(got, fmt.Sprintf("%s:%d", issue.RuleID, issue.Line))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package checker
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rdjsonResult is a reviewdog Diagnostic Result
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Source      rdjsonSource       `json:"source"`
+	Code        *rdjsonCode        `json:"code,omitempty"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+// rdjsonPosition counts columns in UTF-8 bytes, like mm
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+// OutputRDJSON outputs the check result in reviewdog's Diagnostic Format
+// (reviewdog -f=rdjson), with suggestions reviewdog can post as suggested
+// changes
+func (r *CheckResult) OutputRDJSON(w io.Writer) error {
+	result := rdjsonResult{
+		Source:      rdjsonSource{Name: toolName, URL: toolURI},
+		Diagnostics: r.rdjsonDiagnostics(),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// OutputRDJSONL outputs the check result as one reviewdog diagnostic per
+// line (reviewdog -f=rdjsonl)
+func (r *CheckResult) OutputRDJSONL(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, diagnostic := range r.rdjsonDiagnostics() {
+		if err := encoder.Encode(diagnostic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rdjsonDiagnostics converts the issues into reviewdog diagnostics
+func (r *CheckResult) rdjsonDiagnostics() []rdjsonDiagnostic {
+	diagnostics := make([]rdjsonDiagnostic, 0, len(r.Issues))
+	lines := newLineCache()
+
+	for _, issue := range r.Issues {
+		diagnostic := rdjsonDiagnostic{
+			Message:  issue.Message,
+			Location: rdjsonLocation{Path: rdjsonPath(issue.File)},
+			Severity: rdjsonSeverity(issue.Severity),
+			Source:   rdjsonSource{Name: toolName + "/" + string(issue.Type)},
+		}
+		if issue.RuleID != "" {
+			diagnostic.Code = &rdjsonCode{Value: issue.RuleID}
+		}
+
+		if issue.Line > 0 {
+			start := rdjsonPosition{Line: issue.Line, Column: issue.Column}
+			diagnostic.Location.Range = &rdjsonRange{Start: start}
+
+			// Suggestions replace the flagged word when the issue pinpoints it
+			line := lines.get(issue.File, issue.Line)
+			if issue.Column > 0 && issue.Word != "" && strings.HasPrefix(safeSlice(line, issue.Column-1), issue.Word) {
+				end := rdjsonPosition{Line: issue.Line, Column: issue.Column + len(issue.Word)}
+				diagnostic.Location.Range.End = &end
+				for _, suggestion := range issue.Suggestions {
+					diagnostic.Suggestions = append(diagnostic.Suggestions, rdjsonSuggestion{
+						Range: rdjsonRange{Start: start, End: &end},
+						Text:  suggestion,
+					})
+				}
+			} else if len(issue.Suggestions) > 0 {
+				diagnostic.Message += " (suggestions: " + joinStrings(issue.Suggestions, ", ") + ")"
+			}
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// rdjsonSeverity maps a severity to a reviewdog severity
+func rdjsonSeverity(severity Severity) string {
+	switch severity {
+	case ErrorSeverity:
+		return "ERROR"
+	case WarningSeverity:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// rdjsonPath makes a path relative to the working directory, where
+// reviewdog resolves it
+func rdjsonPath(file string) string {
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
@@ -0,0 +1,66 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRDJSONRanges(t *testing.T) {
+	file := writeReportSource(t, "# Title\n中文 teh word\n")
+	tests := []struct {
+		name        string
+		issue       Issue
+		wantRange   *rdjsonRange
+		wantMessage string
+		wantFixes   int
+	}{
+		{
+			name:        "word after CJK counts bytes",
+			issue:       Issue{Line: 2, Column: 8, Word: "teh", Suggestions: []string{"the"}},
+			wantRange:   &rdjsonRange{Start: rdjsonPosition{2, 8}, End: &rdjsonPosition{2, 11}},
+			wantMessage: "Unknown word",
+			wantFixes:   1,
+		},
+		{
+			name:        "word not at the column",
+			issue:       Issue{Line: 2, Column: 4, Word: "teh", Suggestions: []string{"the", "ten"}},
+			wantRange:   &rdjsonRange{Start: rdjsonPosition{2, 4}},
+			wantMessage: "Unknown word (suggestions: the, ten)",
+		},
+		{
+			name:        "whole file",
+			issue:       Issue{},
+			wantMessage: "Unknown word",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := tt.issue
+			issue.Type, issue.Severity, issue.File, issue.Message = SpellCheckerType, WarningSeverity, file, "Unknown word"
+			diagnostic := (&CheckResult{Issues: []Issue{issue}}).rdjsonDiagnostics()[0]
+			if got := diagnostic.Location.Range; !sameRDJSONRange(got, tt.wantRange) {
+				t.Errorf("range = %+v, want %+v", got, tt.wantRange)
+			}
+			if diagnostic.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", diagnostic.Message, tt.wantMessage)
+			}
+			if len(diagnostic.Suggestions) != tt.wantFixes {
+				t.Errorf("suggestions = %d, want %d", len(diagnostic.Suggestions), tt.wantFixes)
+			}
+			if strings.Contains(diagnostic.Location.Path, "\\") {
+				t.Errorf("path %q isn't slash separated", diagnostic.Location.Path)
+			}
+		})
+	}
+}
+
+// sameRDJSONRange reports whether two ranges are the same positions
+func sameRDJSONRange(a, b *rdjsonRange) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if (a.End == nil) != (b.End == nil) || (a.End != nil && *a.End != *b.End) {
+		return false
+	}
+	return a.Start == b.Start
+}
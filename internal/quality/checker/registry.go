@@ -0,0 +1,108 @@
+package checker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory creates a checker with its default settings
+type Factory func() (Checker, error)
+
+// Registration describes a checker available by name
+type Registration struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Factory     Factory
+}
+
+var (
+	checkersMu sync.RWMutex
+	checkers   = make(map[string]Registration)
+	aliases    = make(map[string]string)
+)
+
+// RegisterChecker makes a checker available by name. Built-in checkers
+// register themselves from init.
+func RegisterChecker(registration Registration) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+	checkers[registration.Name] = registration
+	for _, alias := range registration.Aliases {
+		aliases[alias] = registration.Name
+	}
+}
+
+// NewChecker creates the checker registered under name. Names that are not
+// registered are looked up as external plugins (mm-quality-<name> in PATH).
+func NewChecker(name string) (Checker, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	checkersMu.RLock()
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+	registration, ok := checkers[name]
+	checkersMu.RUnlock()
+
+	if ok {
+		return registration.Factory()
+	}
+
+	if external, err := NewExternalChecker(name); err == nil {
+		return external, nil
+	}
+	return nil, fmt.Errorf("unknown checker: %s (available: %s)", name, strings.Join(CheckerNames(), ", "))
+}
+
+// Checkers returns the registered checkers sorted by name
+func Checkers() []Registration {
+	checkersMu.RLock()
+	defer checkersMu.RUnlock()
+
+	all := make([]Registration, 0, len(checkers))
+	for _, registration := range checkers {
+		all = append(all, registration)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// CheckerNames returns the names of registered checkers and external plugins
+func CheckerNames() []string {
+	var names []string
+	for _, registration := range Checkers() {
+		names = append(names, registration.Name)
+	}
+	return append(names, ExternalCheckerNames()...)
+}
+
+// ExternalCheckerNames returns the names of the external checker plugins
+// found in PATH, sorted
+func ExternalCheckerNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		matches, _ := filepath.Glob(filepath.Join(dir, externalCheckerPrefix+"*"))
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+				continue
+			}
+			name := strings.TrimPrefix(filepath.Base(match), externalCheckerPrefix)
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,54 @@
+package checker
+
+import (
+	"sort"
+	"sync"
+)
+
+// Rule describes a single check that a checker can report
+type Rule struct {
+	ID          string      `json:"id"`
+	Checker     CheckerType `json:"checker"`
+	Severity    Severity    `json:"severity"`
+	Description string      `json:"description"`
+	Example     string      `json:"example,omitempty"`
+}
+
+var (
+	rulesMu sync.RWMutex
+	rules   = make(map[string]Rule)
+)
+
+// RegisterRule adds rule metadata to the registry. Checkers register their
+// rules from init so that output formats and docs can describe them.
+func RegisterRule(rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[rule.ID] = rule
+}
+
+// LookupRule returns the metadata for a rule ID
+func LookupRule(id string) (Rule, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	rule, ok := rules[id]
+	return rule, ok
+}
+
+// AllRules returns every registered rule sorted by checker and ID
+func AllRules() []Rule {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	all := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		all = append(all, rule)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Checker != all[j].Checker {
+			return all[i].Checker < all[j].Checker
+		}
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
@@ -0,0 +1,280 @@
+package checker
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "mm"
+	toolURI      = "https://github.com/samzong/mm"
+)
+
+// SARIF 2.1.0 structures, limited to the properties mm produces
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// OutputSARIF outputs the check result as a SARIF 2.1.0 log, suitable for
+// GitHub code scanning
+func (r *CheckResult) OutputSARIF(w io.Writer) error {
+	ruleIndex := make(map[string]int)
+	var sarifRules []sarifRule
+	results := make([]sarifResult, 0, len(r.Issues))
+	lines := newLineCache()
+
+	for _, issue := range r.Issues {
+		ruleID := issue.RuleID
+		if ruleID == "" {
+			ruleID = string(issue.Type)
+		}
+
+		index, ok := ruleIndex[ruleID]
+		if !ok {
+			index = len(sarifRules)
+			ruleIndex[ruleID] = index
+			sarifRules = append(sarifRules, newSARIFRule(ruleID, issue))
+		}
+
+		artifact := sarifArtifact(issue.File)
+		location := sarifPhysicalLocation{ArtifactLocation: artifact}
+
+		var region *sarifRegion
+		if issue.Line > 0 {
+			region = &sarifRegion{StartLine: issue.Line}
+			if issue.Column > 0 {
+				// mm columns are byte offsets; SARIF counts UTF-16 code units
+				line := lines.get(issue.File, issue.Line)
+				region.StartColumn = utf16Column(line, issue.Column)
+				if issue.Word != "" && strings.HasPrefix(safeSlice(line, issue.Column-1), issue.Word) {
+					region.EndColumn = utf16Column(line, issue.Column+len(issue.Word))
+				}
+			}
+			location.Region = region
+		}
+
+		result := sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: index,
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		}
+
+		// Suggestions become fixes when the issue pinpoints the replaced text
+		if region != nil && region.EndColumn > 0 {
+			for _, suggestion := range issue.Suggestions {
+				result.Fixes = append(result.Fixes, sarifFix{
+					Description: sarifMessage{Text: "Replace with '" + suggestion + "'"},
+					ArtifactChanges: []sarifArtifactChange{{
+						ArtifactLocation: artifact,
+						Replacements: []sarifReplacement{{
+							DeletedRegion:   *region,
+							InsertedContent: &sarifMessage{Text: suggestion},
+						}},
+					}},
+				})
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if sarifRules == nil {
+		sarifRules = []sarifRule{}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// newSARIFRule describes a rule using the registry, falling back to the issue
+func newSARIFRule(ruleID string, issue Issue) sarifRule {
+	rule, ok := LookupRule(ruleID)
+	if !ok {
+		rule = Rule{ID: ruleID, Checker: issue.Type, Severity: issue.Severity, Description: ruleID}
+	}
+
+	sarif := sarifRule{
+		ID:                   ruleID,
+		ShortDescription:     sarifMessage{Text: rule.Description},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		Properties:           map[string]string{"checker": string(rule.Checker)},
+	}
+	if rule.Example != "" {
+		sarif.Help = &sarifMessage{Text: "Example: " + rule.Example}
+	}
+	return sarif
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case ErrorSeverity:
+		return "error"
+	case WarningSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifArtifact builds an artifact location, relative to the source root when possible
+func sarifArtifact(file string) sarifArtifactLocation {
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	if filepath.IsAbs(file) {
+		return sarifArtifactLocation{URI: "file://" + filepath.ToSlash(file)}
+	}
+	return sarifArtifactLocation{URI: filepath.ToSlash(filepath.Clean(file)), URIBaseID: "%SRCROOT%"}
+}
+
+// utf16Column converts a 1-based byte column into a 1-based UTF-16 column
+func utf16Column(line string, column int) int {
+	if line == "" || column-1 > len(line) {
+		return column
+	}
+	return len(utf16.Encode([]rune(line[:column-1]))) + 1
+}
+
+// safeSlice returns s[start:] or "" when start is out of range
+func safeSlice(s string, start int) string {
+	if start < 0 || start > len(s) {
+		return ""
+	}
+	return s[start:]
+}
+
+// lineCache reads files on demand so positions can be converted
+type lineCache struct {
+	files map[string][]string
+}
+
+// newLineCache creates an empty line cache
+func newLineCache() *lineCache {
+	return &lineCache{files: make(map[string][]string)}
+}
+
+// get returns a 1-based line of a file, or "" if it cannot be read
+func (c *lineCache) get(file string, line int) string {
+	text, _ := c.lookup(file, line)
+	return text
+}
+
+// lookup returns a 1-based line of a file and whether it exists
+func (c *lineCache) lookup(file string, line int) (string, bool) {
+	lines, ok := c.files[file]
+	if !ok {
+		if content, err := readSource(file); err == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		c.files[file] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+	return lines[line-1], true
+}
@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeReportSource writes a file for the report tests, whose issues point
+// into it by line and byte column
+func writeReportSource(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "page.md")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestUTF16Column(t *testing.T) {
+	tests := []struct {
+		line   string
+		column int
+		want   int
+	}{
+		{"plain teh", 7, 7},
+		{"中文 teh", 8, 4},
+		{"中文 teh", 11, 7},
+		{"😀 teh", 6, 4},
+		{"", 3, 3},
+		{"short", 20, 20},
+	}
+	for _, tt := range tests {
+		if got := utf16Column(tt.line, tt.column); got != tt.want {
+			t.Errorf("utf16Column(%q, %d) = %d, want %d", tt.line, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestOutputSARIFRegions(t *testing.T) {
+	file := writeReportSource(t, "# Title\n中文 teh word\n")
+	tests := []struct {
+		name      string
+		issue     Issue
+		wantStart int
+		wantEnd   int
+		wantFixes int
+	}{
+		{"word after CJK", Issue{Line: 2, Column: 8, Word: "teh", Suggestions: []string{"the", "ten"}}, 4, 7, 2},
+		{"word not at the column", Issue{Line: 2, Column: 1, Word: "teh", Suggestions: []string{"the"}}, 1, 0, 0},
+		{"no column", Issue{Line: 2}, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := tt.issue
+			issue.Type, issue.Severity, issue.File, issue.RuleID = SpellCheckerType, WarningSeverity, file, "spell-check"
+			var out bytes.Buffer
+			if err := (&CheckResult{Issues: []Issue{issue}}).OutputSARIF(&out); err != nil {
+				t.Fatal(err)
+			}
+			var log sarifLog
+			if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+				t.Fatal(err)
+			}
+			result := log.Runs[0].Results[0]
+			region := result.Locations[0].PhysicalLocation.Region
+			if region == nil || region.StartLine != 2 || region.StartColumn != tt.wantStart || region.EndColumn != tt.wantEnd {
+				t.Errorf("region = %+v, want line 2, columns %d-%d", region, tt.wantStart, tt.wantEnd)
+			}
+			if len(result.Fixes) != tt.wantFixes {
+				t.Errorf("fixes = %d, want %d", len(result.Fixes), tt.wantFixes)
+			}
+		})
+	}
+}
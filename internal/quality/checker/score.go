@@ -0,0 +1,105 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// severityWeights weigh issues by severity in the quality score
+var severityWeights = map[Severity]float64{
+	ErrorSeverity:   3,
+	WarningSeverity: 1,
+	InfoSeverity:    0.25,
+}
+
+// scoreHalfDensity is the weighted issues per 1000 words at which the score
+// drops to 50
+const scoreHalfDensity = 10
+
+// Score rates the documentation quality of a result from 0 to 100
+type Score struct {
+	Words  int `json:"words"`
+	Issues int `json:"issues"`
+	// Density is the severity-weighted number of issues per 1000 words
+	Density float64 `json:"density"`
+	Value   int     `json:"score"`
+}
+
+// ComputeScore scores a result over documents of the given number of words.
+// Issues are weighted by severity (error 3, warning 1, info 0.25) and the
+// score falls from 100 as their density per 1000 words grows: a density of
+// 10 scores 50, 30 scores 25.
+func ComputeScore(result *CheckResult, words int) Score {
+	weighted := 0.0
+	for _, issue := range result.Issues {
+		weighted += severityWeights[issue.Severity]
+	}
+
+	score := Score{Words: words, Issues: len(result.Issues), Value: 100}
+	if words > 0 {
+		score.Density = math.Round(weighted*1000/float64(words)*100) / 100
+	} else if weighted > 0 {
+		score.Density = math.Inf(1)
+	}
+	if weighted > 0 {
+		score.Value = int(math.Floor(100 / (1 + score.Density/scoreHalfDensity)))
+	}
+	return score
+}
+
+// Color returns the shields.io color of the score
+func (s Score) Color() string {
+	switch {
+	case s.Value >= 90:
+		return "brightgreen"
+	case s.Value >= 75:
+		return "green"
+	case s.Value >= 60:
+		return "yellow"
+	case s.Value >= 40:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+// shieldsEndpoint is the JSON read by the shields.io endpoint badge
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// OutputShields writes the score as a shields.io endpoint badge
+// (https://shields.io/badges/endpoint-badge)
+func (s Score) OutputShields(w io.Writer, label string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(shieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       fmt.Sprintf("%d%%", s.Value),
+		Color:         s.Color(),
+	})
+}
+
+// CountWords counts the words in files
+func CountWords(filePaths []string) (int, error) {
+	words := 0
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read file %s: %w", filePath, err)
+		}
+		words += len(strings.FieldsFunc(string(content), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+		}))
+	}
+	return words, nil
+}
@@ -0,0 +1,541 @@
+package checker
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Shortcode rule IDs
+const (
+	RuleShortcodeSyntax       = "shortcode-syntax"
+	RuleShortcodeUnbalanced   = "shortcode-unbalanced"
+	RuleShortcodeUnknown      = "shortcode-unknown"
+	RuleShortcodeMissingParam = "shortcode-missing-param"
+)
+
+// builtinShortcodes are the shortcodes embedded in Hugo, and whether each
+// takes inner content
+var builtinShortcodes = map[string]bool{
+	"comment": true, "details": true, "highlight": true,
+	"figure": false, "gist": false, "instagram": false, "param": false, "qr": false,
+	"ref": false, "relref": false, "tweet": false, "vimeo": false, "x": false, "youtube": false,
+}
+
+// builtinRequiredParams are the parameters Hugo's embedded shortcodes fail
+// without; positions are given as numbers
+var builtinRequiredParams = map[string][]string{
+	"figure": {"src"},
+	"param":  {"0"},
+	"ref":    {"0"},
+	"relref": {"0"},
+}
+
+var (
+	shortcodeNamePattern  = regexp.MustCompile(`^[\w./-]+$`)
+	shortcodeParamPattern = regexp.MustCompile("^\\s*(?:([\\w-]+)\\s*=\\s*)?(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`|[^\\s\"`=]+)")
+	hugoConfigFiles       = []string{"hugo.toml", "hugo.yaml", "hugo.yml", "hugo.json", "config.toml", "config.yaml", "config.yml", "config.json"}
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(ShortcodesCheckerType),
+		Description: "Hugo shortcode syntax, pairing, names and parameters",
+		Factory: func() (Checker, error) {
+			return NewShortcodeChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleShortcodeSyntax,
+		Checker:     ShortcodesCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Shortcode is not terminated or its parameters are malformed",
+		Example:     `{{< figure src="a.png" }}`,
+	})
+	RegisterRule(Rule{
+		ID:          RuleShortcodeUnbalanced,
+		Checker:     ShortcodesCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Shortcode is not closed, or closed without being opened",
+		Example:     "{{< tabs >}} ... without {{< /tabs >}}",
+	})
+	RegisterRule(Rule{
+		ID:          RuleShortcodeUnknown,
+		Checker:     ShortcodesCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Shortcode has no template in layouts/shortcodes and is not built into Hugo",
+		Example:     "{{< note >}} without layouts/shortcodes/note.html",
+	})
+	RegisterRule(Rule{
+		ID:          RuleShortcodeMissingParam,
+		Checker:     ShortcodesCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Shortcode lacks a required parameter",
+		Example:     `{{< figure alt="Architecture" >}}`,
+	})
+}
+
+// shortcodeTag is a shortcode found in a page
+type shortcodeTag struct {
+	name        string
+	offset      int // of the opening delimiter
+	nameOffset  int
+	closing     bool
+	selfClosing bool
+	named       map[string]bool
+	positional  int
+}
+
+// shortcodeSite holds the shortcodes a Hugo site defines
+type shortcodeSite struct {
+	// known maps the shortcode names to whether they take inner content;
+	// nil when the site has no shortcode templates to check against
+	known map[string]bool
+}
+
+// ShortcodeChecker implements the Checker interface for Hugo shortcodes,
+// catching the mistakes that otherwise only fail the site build
+type ShortcodeChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	sites       map[string]*shortcodeSite
+	// Known are shortcodes defined outside the site, e.g. by Hugo modules
+	Known []string
+	// RequiredParams lists the parameters each shortcode requires by name,
+	// or by position for positional parameters ("0", "1", ...)
+	RequiredParams map[string][]string
+}
+
+// NewShortcodeChecker creates a new shortcode checker
+func NewShortcodeChecker() *ShortcodeChecker {
+	return &ShortcodeChecker{
+		projectType:    "generic",
+		sites:          make(map[string]*shortcodeSite),
+		RequiredParams: make(map[string][]string),
+	}
+}
+
+// Name returns the name of this checker
+func (s *ShortcodeChecker) Name() string {
+	return "Shortcode Checker"
+}
+
+// Type returns the type of this checker
+func (s *ShortcodeChecker) Type() CheckerType {
+	return ShortcodesCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (s *ShortcodeChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	s.projectType = projectType
+	s.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile checks the shortcodes of a single markdown file
+func (s *ShortcodeChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if s.adapter != nil && adapter.ShouldIgnoreFile(filePath, s.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return s.lint(filePath, string(content)), nil
+}
+
+// Fingerprint identifies the shortcode settings
+func (s *ShortcodeChecker) Fingerprint() string {
+	return fingerprintOf(s.Known, s.RequiredParams)
+}
+
+// crossFile marks the results as depending on the shortcode templates
+func (s *ShortcodeChecker) crossFile() {}
+
+// CheckFiles checks the shortcodes of multiple markdown files
+func (s *ShortcodeChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(s, s.projectType, filePaths)
+}
+
+// lint parses the shortcodes of a page and checks their pairing, names and
+// parameters
+func (s *ShortcodeChecker) lint(filePath, content string) []Issue {
+	var issues []Issue
+	lineStarts := computeLineStarts(content)
+
+	add := func(ruleID string, offset int, message string) *Issue {
+		severity := ErrorSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		line, column := offsetToPosition(lineStarts, offset)
+		issues = append(issues, Issue{
+			Type:     ShortcodesCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Message:  message,
+			RuleID:   ruleID,
+		})
+		return &issues[len(issues)-1]
+	}
+
+	start := 0
+	if end := frontMatterEnd(strings.Split(content, "\n")); end > 0 && end < len(lineStarts) {
+		start = lineStarts[end]
+	}
+	tags := parseShortcodes(content, start, func(offset int, message string) {
+		add(RuleShortcodeSyntax, offset, message)
+	})
+
+	site := s.siteFor(filePath)
+	var names []string
+	for name := range site.known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var open []shortcodeTag
+	for _, tag := range tags {
+		paired, known := site.known[tag.name]
+
+		if !tag.closing {
+			if site.known != nil && !known {
+				issue := add(RuleShortcodeUnknown, tag.nameOffset, fmt.Sprintf("Unknown shortcode '%s'", tag.name))
+				if suggestions := closestNames(tag.name, names, 2); len(suggestions) > 0 {
+					issue.Word = tag.name
+					issue.Suggestions = suggestions
+				}
+			}
+			for _, param := range s.requiredParams(tag.name) {
+				if !tag.hasParam(param) {
+					add(RuleShortcodeMissingParam, tag.offset, fmt.Sprintf("Shortcode '%s' requires parameter %s", tag.name, describeParam(param)))
+				}
+			}
+			if !tag.selfClosing {
+				open = append(open, tag)
+			}
+			continue
+		}
+
+		if known && !paired {
+			add(RuleShortcodeUnbalanced, tag.offset, fmt.Sprintf("Shortcode '%s' takes no inner content and can't be closed", tag.name))
+		}
+
+		// Close the innermost open shortcode of this name. Those opened
+		// after it must have been standalone, unless they take inner content.
+		match := -1
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i].name == tag.name {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			if !known || paired {
+				add(RuleShortcodeUnbalanced, tag.offset, fmt.Sprintf("Closing shortcode '/%s' has no opening shortcode", tag.name))
+			}
+			continue
+		}
+		for _, unclosed := range open[match+1:] {
+			if site.known[unclosed.name] {
+				add(RuleShortcodeUnbalanced, unclosed.offset, fmt.Sprintf("Shortcode '%s' is not closed before '/%s'", unclosed.name, tag.name))
+			}
+		}
+		open = open[:match]
+	}
+
+	for _, unclosed := range open {
+		if site.known[unclosed.name] {
+			add(RuleShortcodeUnbalanced, unclosed.offset, fmt.Sprintf("Shortcode '%s' is never closed with '/%s'", unclosed.name, unclosed.name))
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues
+}
+
+// requiredParams returns the configured required parameters of a
+// shortcode, or those of Hugo's embedded shortcode of that name
+func (s *ShortcodeChecker) requiredParams(name string) []string {
+	if params, ok := s.RequiredParams[name]; ok {
+		return params
+	}
+	return builtinRequiredParams[name]
+}
+
+// hasParam reports whether a shortcode is given a named parameter, or a
+// positional parameter when param is a position
+func (t shortcodeTag) hasParam(param string) bool {
+	if position, err := strconv.Atoi(param); err == nil {
+		return position < t.positional
+	}
+	return t.named[param]
+}
+
+// describeParam names a parameter for messages
+func describeParam(param string) string {
+	if position, err := strconv.Atoi(param); err == nil {
+		return fmt.Sprintf("#%d", position+1)
+	}
+	return "'" + param + "'"
+}
+
+// parseShortcodes finds the shortcodes of a page from offset start,
+// reporting malformed ones through syntaxError. Like Hugo, it also finds
+// shortcodes in code blocks; escaped {{</* */>}} shortcodes are skipped.
+func parseShortcodes(content string, start int, syntaxError func(offset int, message string)) []shortcodeTag {
+	var tags []shortcodeTag
+	for i := start; ; {
+		idx := strings.Index(content[i:], "{{")
+		if idx < 0 {
+			return tags
+		}
+		begin := i + idx
+		i = begin + 2
+		if i >= len(content) || (content[i] != '<' && content[i] != '%') {
+			continue
+		}
+		delimiter := content[i]
+		i++
+
+		if strings.HasPrefix(content[i:], "/*") {
+			if end := strings.Index(content[i:], "*/"+string(delimiter)+"}}"); end >= 0 {
+				i += end + 4
+			}
+			continue
+		}
+
+		end, next, problem := shortcodeEnd(content, i)
+		if problem != "" {
+			syntaxError(begin, problem)
+			i = next
+			continue
+		}
+		closer := byte('%')
+		if delimiter == '<' {
+			closer = '>'
+		}
+		if content[end] != closer {
+			syntaxError(begin, fmt.Sprintf("Shortcode opened with '{{%c' is closed with '%c}}'", delimiter, content[end]))
+		}
+
+		tag, problem := parseShortcodeTag(content, i, end)
+		tag.offset = begin
+		i = end + 3
+		if problem != "" {
+			syntaxError(begin, problem)
+		}
+		// Malformed parameters still open or close the shortcode
+		if tag.name != "" {
+			tags = append(tags, tag)
+		}
+	}
+}
+
+// shortcodeEnd finds the closing delimiter of a shortcode whose inside
+// starts at from, skipping quoted parameters. On failure it returns a
+// message and the offset to continue scanning at.
+func shortcodeEnd(content string, from int) (end, next int, problem string) {
+	for i := from; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '"' || c == '`':
+			closing := i + 1
+			for closing < len(content) && content[closing] != c {
+				if c == '"' && content[closing] == '\\' {
+					closing++
+				}
+				closing++
+			}
+			if closing >= len(content) {
+				return 0, from, fmt.Sprintf("Shortcode has an unterminated %c quoted parameter", c)
+			}
+			i = closing
+		case (c == '>' || c == '%') && strings.HasPrefix(content[i+1:], "}}"):
+			return i, 0, ""
+		case c == '{' && (strings.HasPrefix(content[i:], "{{<") || strings.HasPrefix(content[i:], "{{%")):
+			return 0, i, "Shortcode is not terminated"
+		}
+	}
+	return 0, len(content), "Shortcode is not terminated"
+}
+
+// parseShortcodeTag parses the name and parameters between the delimiters
+// of a shortcode
+func parseShortcodeTag(content string, from, end int) (shortcodeTag, string) {
+	tag := shortcodeTag{named: make(map[string]bool)}
+	inner := content[from:end]
+	if trimmed := strings.TrimRightFunc(inner, isSpace); strings.HasSuffix(trimmed, "/") {
+		tag.selfClosing = true
+		inner = strings.TrimSuffix(trimmed, "/")
+	}
+
+	rest := strings.TrimLeftFunc(inner, isSpace)
+	offset := from + len(inner) - len(rest)
+	if strings.HasPrefix(rest, "/") {
+		tag.closing = true
+		rest = strings.TrimLeftFunc(rest[1:], isSpace)
+		offset = from + len(inner) - len(rest)
+	}
+
+	name := rest
+	if fields := strings.Fields(rest); len(fields) > 0 {
+		name = fields[0]
+	}
+	if name == "" {
+		return tag, "Shortcode has no name"
+	}
+	if !shortcodeNamePattern.MatchString(name) {
+		return tag, fmt.Sprintf("Invalid shortcode name '%s'", name)
+	}
+	tag.name = name
+	tag.nameOffset = offset
+
+	params := rest[len(name):]
+	for strings.TrimSpace(params) != "" {
+		match := shortcodeParamPattern.FindStringSubmatch(params)
+		if match == nil {
+			return tag, fmt.Sprintf("Malformed parameters in shortcode '%s': %s", name, strings.TrimSpace(params))
+		}
+		if match[1] != "" {
+			tag.named[match[1]] = true
+		} else {
+			tag.positional++
+		}
+		params = params[len(match[0]):]
+	}
+	if tag.closing && (tag.positional > 0 || len(tag.named) > 0) {
+		return tag, fmt.Sprintf("Closing shortcode '/%s' takes no parameters", name)
+	}
+	if tag.positional > 0 && len(tag.named) > 0 {
+		return tag, fmt.Sprintf("Shortcode '%s' mixes named and positional parameters", name)
+	}
+	return tag, ""
+}
+
+// isSpace reports the white space allowed inside shortcodes
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// siteFor returns the shortcodes of the Hugo site containing a file
+func (s *ShortcodeChecker) siteFor(filePath string) *shortcodeSite {
+	root := hugoSiteRoot(filePath)
+	if site, ok := s.sites[root]; ok {
+		return site
+	}
+
+	site := &shortcodeSite{}
+	if root != "" {
+		site.known = loadShortcodeTemplates(root)
+	}
+	if len(s.Known) > 0 && site.known == nil {
+		site.known = make(map[string]bool)
+	}
+	if site.known != nil {
+		for name, paired := range builtinShortcodes {
+			if _, ok := site.known[name]; !ok {
+				site.known[name] = paired
+			}
+		}
+		for _, name := range s.Known {
+			if _, ok := site.known[name]; !ok {
+				site.known[name] = false
+			}
+		}
+	}
+	s.sites[root] = site
+	return site
+}
+
+// loadShortcodeTemplates reads the shortcode templates of a site and its
+// themes. A template that uses .Inner takes inner content. It returns nil
+// when there are none.
+func loadShortcodeTemplates(root string) map[string]bool {
+	dirs := []string{
+		filepath.Join(root, "layouts", "shortcodes"),
+		filepath.Join(root, "layouts", "_shortcodes"),
+	}
+	if themes, err := filepath.Glob(filepath.Join(root, "themes", "*", "layouts")); err == nil {
+		for _, layouts := range themes {
+			dirs = append(dirs, filepath.Join(layouts, "shortcodes"), filepath.Join(layouts, "_shortcodes"))
+		}
+	}
+
+	var known map[string]bool
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return nil
+			}
+			// Drop the extension and any output format or language, as in
+			// tabs.html or tabs.en.html
+			name := filepath.ToSlash(rel)
+			base := filepath.Base(name)
+			if dot := strings.Index(base, "."); dot > 0 {
+				name = strings.TrimSuffix(name, base) + base[:dot]
+			}
+
+			if known == nil {
+				known = make(map[string]bool)
+			}
+			template, err := os.ReadFile(path)
+			known[name] = known[name] || (err == nil && strings.Contains(string(template), ".Inner"))
+			return nil
+		})
+	}
+	return known
+}
+
+// hugoSiteRoot returns the nearest directory above a file with a Hugo
+// configuration, or "" outside a Hugo site
+func hugoSiteRoot(filePath string) string {
+	dir, err := filepath.Abs(filepath.Dir(filePath))
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, name := range hugoConfigFiles {
+			if fileExistsOrDir(filepath.Join(dir, name)) {
+				return dir
+			}
+		}
+		if fileExistsOrDir(filepath.Join(dir, "config", "_default")) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
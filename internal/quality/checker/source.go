@@ -0,0 +1,31 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string][]byte)
+)
+
+// SetSource makes checkers read content for path instead of the file on
+// disk, for content that is not saved such as stdin or an editor buffer
+func SetSource(path string, content []byte) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[filepath.Clean(path)] = content
+}
+
+// readSource reads a file, or the content set for it with SetSource
+func readSource(path string) ([]byte, error) {
+	sourcesMu.RLock()
+	content, ok := sources[filepath.Clean(path)]
+	sourcesMu.RUnlock()
+	if ok {
+		return content, nil
+	}
+	return os.ReadFile(path)
+}
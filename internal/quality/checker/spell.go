@@ -1,24 +1,67 @@
 package checker
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/samzong/mm/internal/quality/adapter"
 	"github.com/samzong/mm/internal/quality/dictionary"
+	"github.com/samzong/mm/internal/quality/extract"
+	"github.com/samzong/mm/internal/quality/locale"
+	"github.com/samzong/mm/internal/quality/spell"
 )
 
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(SpellCheckerType),
+		Description: "Spell checking against built-in and project dictionaries",
+		Factory: func() (Checker, error) {
+			return NewSpellChecker()
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          "spell-check",
+		Checker:     SpellCheckerType,
+		Severity:    ErrorSeverity,
+		Description: "Word not found in the loaded dictionaries",
+		Example:     "Teh pod is running",
+	})
+}
+
+// LangAuto detects the language of each document from its path and
+// content
+const LangAuto = "auto"
+
 // SpellChecker implements the Checker interface for spell checking
 type SpellChecker struct {
 	projectType string
 	adapter     adapter.ProjectAdapter
 	dictManager *dictionary.Manager
+	engine      spell.Engine
+	// EngineName selects the spell engine (builtin, aspell or hunspell)
+	EngineName string
+	// HunspellDict is the hunspell dictionary name or .dic path
+	HunspellDict string
+	// Lang is the language of the checked documents, or LangAuto
+	Lang string
+	// Dictionaries are loaded in addition to the project's dictionaries
+	Dictionaries []string
+	// NotebookCode also checks the comments and strings of notebook code cells
+	NotebookCode bool
+	// DataKeys are glob patterns of the keys whose values are checked in
+	// YAML, JSON and TOML files (default extract.DefaultDataKeys)
+	DataKeys []string
+	// byLang holds the checkers of the detected languages other than
+	// English; nil when the language can't be checked
+	byLang map[string]*SpellChecker
 }
 
 // NewSpellChecker creates a new spell checker instance
@@ -27,10 +70,12 @@ func NewSpellChecker() (*SpellChecker, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize dictionary manager: %w", err)
 	}
-	
+
 	return &SpellChecker{
 		projectType: "generic",
 		dictManager: dictManager,
+		EngineName:  spell.EngineBuiltin,
+		Lang:        LangAuto,
 	}, nil
 }
 
@@ -50,12 +95,48 @@ func (s *SpellChecker) SetProject(projectType string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
 	}
-	
+
 	s.projectType = projectType
 	s.adapter = projectAdapter
-	
-	// Load dictionaries for this project type
-	return s.dictManager.LoadDictionaries(projectAdapter.GetDictionaries())
+
+	// Load dictionaries for this project type and language. Documents of
+	// unknown language are checked as English.
+	lang := s.Lang
+	if lang == LangAuto {
+		lang = spell.DefaultLang
+	}
+	lang = spell.NormalizeLang(lang)
+	s.dictManager.SetLanguage(lang)
+	dictionaries := append(projectAdapter.GetDictionaries(), s.Dictionaries...)
+	if err := s.dictManager.LoadDictionaries(dictionaries); err != nil {
+		return err
+	}
+
+	// Stop the process of a previous pipe-based engine
+	if closer, ok := s.engine.(io.Closer); ok {
+		closer.Close()
+	}
+	for _, checker := range s.byLang {
+		if checker == nil {
+			continue
+		}
+		if closer, ok := checker.engine.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	s.byLang = nil
+
+	engine, err := spell.NewEngine(s.EngineName, spell.Options{
+		PersonalDict:         s.dictManager.GetPersonalDictPath(),
+		HunspellPersonalDict: s.dictManager.GetHunspellDictPath(),
+		Dictionary:           s.HunspellDict,
+		Lang:                 lang,
+	})
+	if err != nil {
+		return err
+	}
+	s.engine = engine
+	return nil
 }
 
 // CheckFile checks a single file for spelling errors
@@ -64,200 +145,172 @@ func (s *SpellChecker) CheckFile(filePath string) ([]Issue, error) {
 	if s.adapter != nil && adapter.ShouldIgnoreFile(filePath, s.adapter.GetIgnorePatterns()) {
 		return nil, nil
 	}
-	
+
 	// Read file content
-	content, err := os.ReadFile(filePath)
+	content, err := readSource(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
-	
+
+	if s.Lang == LangAuto {
+		checker, err := s.forLanguage(locale.Detect(filePath, content))
+		if err != nil || checker == nil {
+			return nil, err
+		}
+		if checker != s {
+			return checker.check(filePath, content)
+		}
+	}
+	return s.check(filePath, content)
+}
+
+// forLanguage returns the checker of a detected language: s itself for
+// English, Chinese, Japanese and Korean documents, whose English words are
+// checked, and for documents of unknown language. It returns nil when no
+// dictionary of the language is available; those documents are skipped
+// with a warning.
+func (s *SpellChecker) forLanguage(lang string) (*SpellChecker, error) {
+	if lang == "" || locale.IsCJK(lang) || locale.Base(lang) == spell.DefaultLang {
+		return s, nil
+	}
+
+	if checker, ok := s.byLang[lang]; ok {
+		return checker, nil
+	}
+	if s.byLang == nil {
+		s.byLang = make(map[string]*SpellChecker)
+	}
+
+	checker, err := NewSpellChecker()
+	if err != nil {
+		return nil, err
+	}
+	checker.EngineName = s.EngineName
+	checker.Lang = lang
+	checker.Dictionaries = s.Dictionaries
+	checker.DataKeys = s.DataKeys
+	checker.NotebookCode = s.NotebookCode
+	// HunspellDict is not passed on: it is the dictionary of English
+	// documents, and hunspell picks one by language otherwise
+	if err := checker.SetProject(s.projectType); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping %s documents: %v\n", lang, err)
+		checker = nil
+	}
+	s.byLang[lang] = checker
+	return checker, nil
+}
+
+// check spell checks the content of a file
+func (s *SpellChecker) check(filePath string, content []byte) ([]Issue, error) {
 	// Extract text content based on file type
-	textContent, err := s.extractTextContent(string(content), filepath.Ext(filePath))
+	textContent, err := extract.Prose(string(content), filepath.Ext(filePath), extract.Options{
+		DataKeys:     s.DataKeys,
+		NotebookCode: s.NotebookCode,
+		Markup:       adapterMarkup(s.adapter),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract text from %s: %w", filePath, err)
 	}
-	
-	// Run spell check using aspell
-	issues, err := s.runAspellCheck(filePath, textContent)
+
+	if s.engine == nil {
+		if err := s.SetProject(s.projectType); err != nil {
+			return nil, err
+		}
+	}
+
+	misspelled, err := s.engine.Misspelled(textContent)
 	if err != nil {
-		return nil, fmt.Errorf("aspell check failed for %s: %w", filePath, err)
+		return nil, fmt.Errorf("%s check failed for %s: %w", s.engine.Name(), filePath, err)
 	}
-	
-	return issues, nil
-}
 
-// CheckFiles checks multiple files for spelling errors
-func (s *SpellChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
-	result := &CheckResult{
-		TotalFiles:  len(filePaths),
-		CheckedFiles: 0,
-		TotalIssues: 0,
-		Issues:      []Issue{},
-		ProjectType: s.projectType,
-		CheckerType: SpellCheckerType,
-	}
-	
-	for _, filePath := range filePaths {
-		issues, err := s.CheckFile(filePath)
-		if err != nil {
-			// Log error but continue with other files
-			fmt.Fprintf(os.Stderr, "Warning: Failed to check %s: %v\n", filePath, err)
-			continue
-		}
-		
-		result.CheckedFiles++
-		for _, issue := range issues {
-			result.AddIssue(issue)
-		}
+	compounds, err := s.misspelledParts(misspelled)
+	if err != nil {
+		return nil, fmt.Errorf("%s check failed for %s: %w", s.engine.Name(), filePath, err)
 	}
-	
-	return result, nil
+
+	return s.buildIssues(filePath, textContent, misspelled, compounds), nil
 }
 
-// extractTextContent extracts readable text from different file formats
-func (s *SpellChecker) extractTextContent(content, fileExt string) (string, error) {
-	switch strings.ToLower(fileExt) {
-	case ".md", ".markdown":
-		return s.extractFromMarkdown(content), nil
-	case ".txt":
-		return content, nil
-	case ".rst":
-		return s.extractFromRST(content), nil
-	case ".html":
-		return s.extractFromHTML(content), nil
-	default:
-		return content, nil
+// adapterMarkup returns the framework syntax a project adapter asks to skip
+func adapterMarkup(projectAdapter adapter.ProjectAdapter) extract.Markup {
+	if projectAdapter == nil {
+		return extract.Markup{}
+	}
+	rules := projectAdapter.GetCustomRules()
+	return extract.Markup{
+		Shortcodes:  rules[adapter.RuleIgnoreShortcodes],
+		Admonitions: rules[adapter.RuleIgnoreAdmonitions],
+		Containers:  rules[adapter.RuleIgnoreContainers],
+		Roles:       rules[adapter.RuleIgnoreRoles],
 	}
 }
 
-// extractFromMarkdown extracts text content from markdown, ignoring code blocks and links
-func (s *SpellChecker) extractFromMarkdown(content string) string {
-	var result strings.Builder
-	lines := strings.Split(content, "\n")
-	inCodeBlock := false
-	codeBlockPattern := regexp.MustCompile("^```")
-	
-	for lineNum, line := range lines {
-		// Skip YAML front matter
-		if lineNum < 10 && strings.TrimSpace(line) == "---" {
-			continue
-		}
-		
-		// Handle code blocks
-		if codeBlockPattern.MatchString(line) {
-			inCodeBlock = !inCodeBlock
+// misspelledParts splits camelCase words into their parts and checks each
+// part on its own. The result maps every compound word to its misspelled
+// parts; a compound with none is not reported at all.
+func (s *SpellChecker) misspelledParts(words []string) (map[string][]spell.Subword, error) {
+	compounds := make(map[string][]spell.Subword)
+	var texts []string
+	for _, word := range words {
+		parts := spell.SplitIdentifier(word)
+		if len(parts) < 2 {
 			continue
 		}
-		if inCodeBlock {
-			continue
+		var unknown []spell.Subword
+		for _, part := range parts {
+			if !s.dictManager.IsWordKnown(part.Text) {
+				unknown = append(unknown, part)
+				texts = append(texts, part.Text)
+			}
 		}
-		
-		// Remove inline code
-		inlineCodePattern := regexp.MustCompile("`[^`]+`")
-		line = inlineCodePattern.ReplaceAllString(line, "")
-		
-		// Remove links but keep link text
-		linkPattern := regexp.MustCompile(`\[([^\]]+)\]\([^\)]+\)`)
-		line = linkPattern.ReplaceAllString(line, "$1")
-		
-		// Remove image references
-		imagePattern := regexp.MustCompile(`!\[[^\]]*\]\([^\)]+\)`)
-		line = imagePattern.ReplaceAllString(line, "")
-		
-		// Remove HTML tags
-		htmlPattern := regexp.MustCompile(`<[^>]+>`)
-		line = htmlPattern.ReplaceAllString(line, "")
-		
-		// Remove markdown formatting
-		line = strings.ReplaceAll(line, "**", "")
-		line = strings.ReplaceAll(line, "*", "")
-		line = strings.ReplaceAll(line, "__", "")
-		line = strings.ReplaceAll(line, "_", "")
-		line = strings.ReplaceAll(line, "##", "")
-		line = strings.ReplaceAll(line, "#", "")
-		
-		result.WriteString(line + "\n")
-	}
-	
-	return result.String()
-}
+		compounds[word] = unknown
+	}
+	if len(texts) == 0 {
+		return compounds, nil
+	}
 
-// extractFromRST extracts text content from reStructuredText
-func (s *SpellChecker) extractFromRST(content string) string {
-	// Basic RST text extraction (simplified)
-	lines := strings.Split(content, "\n")
-	var result strings.Builder
-	
-	for _, line := range lines {
-		// Skip directive lines
-		if strings.HasPrefix(strings.TrimSpace(line), ".. ") {
-			continue
+	// Check all parts with a single engine call
+	misspelled, err := s.engine.Misspelled(strings.Join(texts, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	bad := make(map[string]bool)
+	for _, word := range misspelled {
+		bad[word] = true
+	}
+
+	for word, parts := range compounds {
+		var misspelledParts []spell.Subword
+		for _, part := range parts {
+			if bad[part.Text] {
+				misspelledParts = append(misspelledParts, part)
+			}
 		}
-		
-		// Remove inline markup
-		line = regexp.MustCompile(`\*\*[^*]+\*\*`).ReplaceAllString(line, "")
-		line = regexp.MustCompile(`\*[^*]+\*`).ReplaceAllString(line, "")
-		line = regexp.MustCompile("``[^`]+``").ReplaceAllString(line, "")
-		
-		result.WriteString(line + "\n")
-	}
-	
-	return result.String()
+		compounds[word] = misspelledParts
+	}
+	return compounds, nil
 }
 
-// extractFromHTML extracts text content from HTML
-func (s *SpellChecker) extractFromHTML(content string) string {
-	// Remove HTML tags
-	htmlPattern := regexp.MustCompile(`<[^>]*>`)
-	return htmlPattern.ReplaceAllString(content, " ")
+// Fingerprint identifies the engine, language and loaded dictionaries
+func (s *SpellChecker) Fingerprint() string {
+	return fingerprintOf(s.EngineName, s.Lang, s.HunspellDict, s.DataKeys, s.NotebookCode, s.dictManager.Fingerprint())
 }
 
-// runAspellCheck runs aspell on the given text content
-func (s *SpellChecker) runAspellCheck(filePath, content string) ([]Issue, error) {
-	// Check if aspell is available
-	if _, err := exec.LookPath("aspell"); err != nil {
-		return nil, fmt.Errorf("aspell not found in PATH. Please install aspell")
-	}
-	
-	// Build aspell command
-	args := []string{
-		"--mode=none",
-		"--encoding=utf-8",
-		"--list",
-	}
-	
-	// Add custom dictionaries if available
-	personalDict := s.dictManager.GetPersonalDictPath()
-	if personalDict != "" {
-		args = append(args, "--personal="+personalDict)
-	}
-	
-	// Run aspell with stdin input
-	cmd := exec.Command("aspell", args...)
-	cmd.Stdin = strings.NewReader(content)
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("aspell command failed: %w", err)
-	}
-	
-	// Parse aspell output
-	return s.parseAspellOutput(filePath, content, string(output))
+// CheckFiles checks multiple files for spelling errors
+func (s *SpellChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(s, s.projectType, filePaths)
 }
 
-// parseAspellOutput parses aspell output and creates Issue objects
-func (s *SpellChecker) parseAspellOutput(filePath, content, aspellOutput string) ([]Issue, error) {
+// buildIssues creates Issue objects for every occurrence of the misspelled
+// words. Compound words only report their misspelled parts.
+func (s *SpellChecker) buildIssues(filePath, content string, misspelledWords []string, compounds map[string][]spell.Subword) []Issue {
 	var issues []Issue
-	
-	misspelledWords := strings.Fields(strings.TrimSpace(aspellOutput))
-	if len(misspelledWords) == 0 {
-		return issues, nil
-	}
-	
+
 	// Create a map to track already reported words (avoid duplicates)
 	reportedWords := make(map[string]bool)
-	
+
 	lines := strings.Split(content, "\n")
-	
+
 	for _, word := range misspelledWords {
 		// Use lowercase for deduplication since aspell returns lowercase
 		lowerWord := strings.ToLower(word)
@@ -265,144 +318,142 @@ func (s *SpellChecker) parseAspellOutput(filePath, content, aspellOutput string)
 			continue
 		}
 		reportedWords[lowerWord] = true
-		
+
 		// Find word positions in content (this will handle case-insensitive matching)
 		positions := s.findWordPositions(lines, word)
-		
-		for _, pos := range positions {
-			// Get the actual word from the content for the error message
-			actualWord := s.getActualWordAtPosition(lines, pos, word)
-			
-			// Get spelling suggestions
-			suggestions := s.getSpellingSuggestions(word)
-			
-			issue := Issue{
-				Type:        SpellCheckerType,
-				Severity:    ErrorSeverity,
-				File:        filePath,
-				Line:        pos.Line,
-				Column:      pos.Column,
-				Word:        actualWord, // Use the actual word from content, not the lowercase version
-				Message:     fmt.Sprintf("Misspelled word: '%s'", actualWord),
-				Suggestions: suggestions,
-				RuleID:      "spell-check",
+		if len(positions) == 0 {
+			continue
+		}
+
+		if parts, ok := compounds[word]; ok {
+			for _, part := range parts {
+				for _, pos := range positions {
+					end := part.Offset + len(part.Text)
+					if end > len(pos.Word) {
+						continue
+					}
+					actualPart := pos.Word[part.Offset:end]
+					issues = append(issues, s.newIssue(filePath, pos.Line, pos.Column+part.Offset, actualPart))
+				}
 			}
-			
-			issues = append(issues, issue)
+			continue
+		}
+
+		for _, pos := range positions {
+			// Use the actual word from the content, not the lowercase version
+			issues = append(issues, s.newIssue(filePath, pos.Line, pos.Column, pos.Word))
 		}
 	}
-	
-	return issues, nil
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+
+	return issues
+}
+
+// newIssue creates a misspelling issue, without suggestions until Suggest
+// fills them in
+func (s *SpellChecker) newIssue(filePath string, line, column int, word string) Issue {
+	return Issue{
+		Type:     SpellCheckerType,
+		Severity: ErrorSeverity,
+		File:     filePath,
+		Line:     line,
+		Column:   column,
+		Word:     word,
+		Message:  fmt.Sprintf("Misspelled word: '%s'", word),
+		RuleID:   "spell-check",
+	}
 }
 
 // WordPosition represents the position of a word in text
 type WordPosition struct {
 	Line   int
 	Column int
+	// Word is the word as written at this position
+	Word string
 }
 
 // findWordPositions finds all positions of a word in the content
 func (s *SpellChecker) findWordPositions(lines []string, word string) []WordPosition {
 	var positions []WordPosition
-	
-	// Create case-insensitive word boundary regex
-	wordPattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
-	
+
+	// Case-insensitive match; word boundaries are checked by hand because
+	// \b only knows ASCII letters
+	wordPattern := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(word))
+
 	for lineNum, line := range lines {
 		matches := wordPattern.FindAllStringIndex(line, -1)
 		for _, match := range matches {
+			if !atWordBoundary(line, match[0], match[1]) {
+				continue
+			}
+
 			// Get the actual word from the line to preserve original case
 			actualWord := line[match[0]:match[1]]
-			
+
 			// Check if this specific case variant is known in dictionary
 			if !s.dictManager.IsWordKnown(actualWord) && !s.dictManager.IsWordKnown(strings.ToLower(actualWord)) {
 				positions = append(positions, WordPosition{
-					Line:   lineNum + 1, // 1-based line numbering
+					Line:   lineNum + 1,  // 1-based line numbering
 					Column: match[0] + 1, // 1-based column numbering
+					Word:   actualWord,
 				})
 			}
 		}
 	}
-	
+
 	return positions
 }
 
-// getActualWordAtPosition extracts the actual word from the content at the given position
-func (s *SpellChecker) getActualWordAtPosition(lines []string, pos WordPosition, expectedWord string) string {
-	if pos.Line-1 >= len(lines) {
-		return expectedWord
-	}
-	
-	line := lines[pos.Line-1]
-	if pos.Column-1 >= len(line) {
-		return expectedWord
-	}
-	
-	// Find word boundaries around the position
-	start := pos.Column - 1
-	end := start
-	
-	// Find start of word
-	for start > 0 && isWordChar(line[start-1]) {
-		start--
-	}
-	
-	// Find end of word
-	for end < len(line) && isWordChar(line[end]) {
-		end++
-	}
-	
-	if start < end {
-		return line[start:end]
-	}
-	
-	return expectedWord
+// atWordBoundary reports whether line[start:end] is not part of a longer word
+func atWordBoundary(line string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(line[:start]); start > 0 && isWordRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(line[end:]); end < len(line) && isWordRune(r) {
+		return false
+	}
+	return true
 }
 
-// isWordChar checks if a character is part of a word
-func isWordChar(c byte) bool {
-	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '\'' || c == '-'
+// isWordRune checks if a rune is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
 }
 
-// getSpellingSuggestions gets spelling suggestions for a misspelled word
-func (s *SpellChecker) getSpellingSuggestions(word string) []string {
-	// Use aspell to get suggestions
-	cmd := exec.Command("aspell", "--mode=none", "--encoding=utf-8", "pipe")
-	
-	var stdin bytes.Buffer
-	stdin.WriteString("!" + word + "\n")
-	cmd.Stdin = &stdin
-	
-	output, err := cmd.Output()
-	if err != nil {
-		return nil
+// Suggest fills in the suggestions of a misspelling, from the engine of the
+// language of its file. Finding them is costly, so they are only found for
+// the issues written or fixed; the engines keep those of each word.
+func (s *SpellChecker) Suggest(issue *Issue) {
+	if issue.Type != SpellCheckerType || issue.RuleID != "spell-check" || issue.Word == "" || issue.Suggestions != nil {
+		return
 	}
-	
-	// Parse aspell pipe output
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "&") {
-			// Format: & word count offset: suggestion1, suggestion2, ...
-			parts := strings.Split(line, ":")
-			if len(parts) > 1 {
-				suggestions := strings.Split(strings.TrimSpace(parts[1]), ",")
-				var cleanSuggestions []string
-				for _, s := range suggestions {
-					cleanSuggestions = append(cleanSuggestions, strings.TrimSpace(s))
-				}
-				return cleanSuggestions[:min(5, len(cleanSuggestions))] // Return max 5 suggestions
-			}
+	checker := s
+	if s.Lang == LangAuto {
+		content, err := readSource(issue.File)
+		if err != nil {
+			return
+		}
+		if checker, err = s.forLanguage(locale.Detect(issue.File, content)); err != nil || checker == nil {
+			return
 		}
 	}
-	
-	return nil
+	if checker.engine == nil {
+		return
+	}
+	issue.Suggestions = checker.getSpellingSuggestions(issue.Word)
 }
 
-// Helper function for minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
+// getSpellingSuggestions gets spelling suggestions for a misspelled word
+func (s *SpellChecker) getSpellingSuggestions(word string) []string {
+	suggestions, err := s.engine.Suggest(word)
+	if err != nil {
+		return nil
 	}
-	return b
-}
\ No newline at end of file
+	return suggestions
+}
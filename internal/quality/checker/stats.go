@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// maxExampleFiles limits the example files listed for a word
+const maxExampleFiles = 3
+
+// WordStat counts how often a flagged word occurs across a run
+type WordStat struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+	// Files is the number of files the word occurs in
+	Files int `json:"files"`
+	// Examples are the first files the word occurs in
+	Examples []string `json:"examples"`
+}
+
+// WordStats ranks the flagged words of the result by how often they occur,
+// most frequent first
+func (r *CheckResult) WordStats() []WordStat {
+	var stats []WordStat
+	index := make(map[string]int)
+	seen := make(map[string]map[string]bool)
+
+	for _, issue := range r.Issues {
+		if issue.Word == "" {
+			continue
+		}
+
+		i, ok := index[issue.Word]
+		if !ok {
+			i = len(stats)
+			index[issue.Word] = i
+			seen[issue.Word] = make(map[string]bool)
+			stats = append(stats, WordStat{Word: issue.Word})
+		}
+
+		stats[i].Count++
+		if !seen[issue.Word][issue.File] {
+			seen[issue.Word][issue.File] = true
+			stats[i].Files++
+			if len(stats[i].Examples) < maxExampleFiles {
+				stats[i].Examples = append(stats[i].Examples, issue.File)
+			}
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Word < stats[j].Word
+	})
+	return stats
+}
+
+// OutputStats writes the most frequent flagged words, at most limit of them
+// when limit is positive
+func (r *CheckResult) OutputStats(w io.Writer, format string, limit int) error {
+	stats := r.WordStats()
+	total := len(stats)
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	if format == "json" {
+		if stats == nil {
+			stats = []WordStat{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	if total == 0 {
+		fmt.Fprintf(w, "✅ No flagged words in %d files\n", r.CheckedFiles)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%d distinct words flagged %d times in %d files:\n\n", total, r.countWords(), r.CheckedFiles)
+	fmt.Fprintf(w, "%7s %6s  %-24s %s\n", "COUNT", "FILES", "WORD", "EXAMPLES")
+	for _, stat := range stats {
+		examples := joinStrings(stat.Examples, ", ")
+		if stat.Files > len(stat.Examples) {
+			examples += ", ..."
+		}
+		fmt.Fprintf(w, "%7d %6d  %-24s %s\n", stat.Count, stat.Files, stat.Word, examples)
+	}
+	if total > len(stats) {
+		fmt.Fprintf(w, "\n... %d more words not shown\n", total-len(stats))
+	}
+	return nil
+}
+
+// countWords returns the number of issues about a word
+func (r *CheckResult) countWords() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Word != "" {
+			count++
+		}
+	}
+	return count
+}
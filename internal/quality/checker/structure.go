@@ -0,0 +1,282 @@
+package checker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+)
+
+// Structure rule IDs
+const (
+	RuleStructureMissing = "structure-missing"
+	RuleStructureExtra   = "structure-extra"
+)
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(StructureCheckerType),
+		Description: "Headings, code blocks, shortcodes and tables of translations against the English page",
+		Factory: func() (Checker, error) {
+			return NewStructureChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleStructureMissing,
+		Checker:     StructureCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Block of the English page is missing from the translation",
+		Example:     "## Cleaning up dropped with its section",
+	})
+	RegisterRule(Rule{
+		ID:          RuleStructureExtra,
+		Checker:     StructureCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Block of the translation is not in the English page",
+		Example:     "{{< note >}} the English page removed",
+	})
+}
+
+// pageBlock is a structural block of a page: a heading, code block,
+// shortcode or table
+type pageBlock struct {
+	kind   string
+	detail string
+	line   int
+	text   string
+}
+
+// key identifies blocks that correspond between translations
+func (b pageBlock) key() string {
+	return b.kind + " " + b.detail
+}
+
+// describe names the block in messages
+func (b pageBlock) describe() string {
+	switch b.kind {
+	case "heading":
+		return fmt.Sprintf("heading '%s %s'", b.detail, b.text)
+	case "code":
+		if b.detail == "" {
+			return "code block"
+		}
+		return b.detail + " code block"
+	case "shortcode":
+		return fmt.Sprintf("shortcode '%s'", b.detail)
+	}
+	return b.kind
+}
+
+// pageStructure returns the blocks of a page in order. HTML comments are
+// skipped, since k8s translations keep the English text in them, and so is
+// the content of code blocks.
+func pageStructure(content string) []pageBlock {
+	var blocks []pageBlock
+	lines := strings.Split(content, "\n")
+	fence := ""
+	inComment := false
+	inTable := false
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		line := lines[i]
+		if match := fenceOpenPattern.FindStringSubmatchIndex(line); match != nil && !inComment {
+			marker := line[match[4]:match[5]]
+			info := strings.TrimSpace(line[match[6]:match[7]])
+			if fence != "" {
+				if marker[0] == fence[0] && len(marker) >= len(fence) && info == "" {
+					fence = ""
+				}
+				continue
+			}
+			if marker[0] != '`' || !strings.Contains(info, "`") {
+				fence = marker
+				language, _ := fenceLanguage(info)
+				blocks = append(blocks, pageBlock{kind: "code", detail: strings.ToLower(language), line: i + 1})
+				inTable = false
+				continue
+			}
+		}
+		if fence != "" {
+			continue
+		}
+
+		// Keep the text outside comments
+		var visible strings.Builder
+		rest := line
+		for rest != "" {
+			if inComment {
+				end := strings.Index(rest, "-->")
+				if end < 0 {
+					break
+				}
+				inComment = false
+				rest = rest[end+3:]
+				continue
+			}
+			start := strings.Index(rest, "<!--")
+			if start < 0 {
+				visible.WriteString(rest)
+				break
+			}
+			visible.WriteString(rest[:start])
+			inComment = true
+			rest = rest[start+4:]
+		}
+		text := visible.String()
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			if !inComment {
+				inTable = false
+			}
+			continue
+		}
+
+		if heading := atxHeadingPattern.FindStringSubmatch(text); heading != nil && heading[2] != "" {
+			title := headingAnchorSuffix.ReplaceAllString(strings.TrimSpace(heading[2]), "")
+			blocks = append(blocks, pageBlock{kind: "heading", detail: heading[1], line: i + 1, text: title})
+		}
+		if strings.HasPrefix(trimmed, "|") {
+			if !inTable {
+				blocks = append(blocks, pageBlock{kind: "table", line: i + 1})
+			}
+			inTable = true
+		} else {
+			inTable = false
+		}
+		for _, tag := range parseShortcodes(text, 0, func(int, string) {}) {
+			if !tag.closing {
+				blocks = append(blocks, pageBlock{kind: "shortcode", detail: tag.name, line: i + 1})
+			}
+		}
+	}
+	return blocks
+}
+
+// StructureChecker implements the Checker interface by comparing the
+// structure of translated pages with their English page, a fast way to
+// spot sections dropped or left over during translation
+type StructureChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+}
+
+// NewStructureChecker creates a new structure checker
+func NewStructureChecker() *StructureChecker {
+	return &StructureChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (s *StructureChecker) Name() string {
+	return "Structure Checker"
+}
+
+// Type returns the type of this checker
+func (s *StructureChecker) Type() CheckerType {
+	return StructureCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (s *StructureChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	s.projectType = projectType
+	s.adapter = projectAdapter
+	return nil
+}
+
+// CheckFile compares a translated markdown file with its English page;
+// other files have nothing to compare with
+func (s *StructureChecker) CheckFile(filePath string) ([]Issue, error) {
+	if !isMarkdownFile(filePath) {
+		return nil, nil
+	}
+
+	if s.adapter != nil && adapter.ShouldIgnoreFile(filePath, s.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	source := upstreamPage(filePath)
+	if source == "" {
+		return nil, nil
+	}
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	upstream, err := readSource(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", source, err)
+	}
+
+	return s.compare(filePath, source, string(content), string(upstream)), nil
+}
+
+// crossFile marks the results as depending on the English pages
+func (s *StructureChecker) crossFile() {}
+
+// CheckFiles compares multiple translated files with their English pages
+func (s *StructureChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(s, s.projectType, filePaths)
+}
+
+// compare reports the blocks of the English page missing from the
+// translation and the blocks of the translation the English page lacks,
+// matching them by their longest common subsequence
+func (s *StructureChecker) compare(filePath, source, content, upstream string) []Issue {
+	ours, theirs := pageStructure(content), pageStructure(upstream)
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// theirs[i:] and ours[j:]
+	lcs := make([][]int32, len(theirs)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(ours)+1)
+	}
+	for i := len(theirs) - 1; i >= 0; i-- {
+		for j := len(ours) - 1; j >= 0; j-- {
+			if theirs[i].key() == ours[j].key() {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var issues []Issue
+	add := func(ruleID string, line int, message string) {
+		severity := WarningSeverity
+		if rule, ok := LookupRule(ruleID); ok {
+			severity = rule.Severity
+		}
+		issues = append(issues, Issue{
+			Type:     StructureCheckerType,
+			Severity: severity,
+			File:     filePath,
+			Line:     line,
+			Column:   1,
+			Message:  message,
+			RuleID:   ruleID,
+		})
+	}
+	// Missing blocks are reported after the last block both pages share
+	line := 1
+	for i, j := 0, 0; i < len(theirs) || j < len(ours); {
+		switch {
+		case i < len(theirs) && j < len(ours) && theirs[i].key() == ours[j].key():
+			line = ours[j].line
+			i++
+			j++
+		case j < len(ours) && (i == len(theirs) || lcs[i][j+1] >= lcs[i+1][j]):
+			add(RuleStructureExtra, ours[j].line, fmt.Sprintf("Extra %s, not in %s", ours[j].describe(), source))
+			j++
+		default:
+			add(RuleStructureMissing, line, fmt.Sprintf("Missing %s of %s:%d", theirs[i].describe(), source, theirs[i].line))
+			i++
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
@@ -0,0 +1,193 @@
+package checker
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/samzong/mm/internal/quality/extract"
+)
+
+// suppressionPattern matches inline suppression comments:
+//
+//	<!-- mm-quality-disable spell -->            until mm-quality-enable or end of file
+//	<!-- mm-quality-enable spell -->
+//	<!-- mm-quality-disable-next-line MD013 -->  the following line only
+//	<!-- mm-quality-disable-line links -->       the line of the comment
+//
+// Targets are checker types or rule IDs separated by spaces or commas; no
+// target means every checker. An enable turns its targets back on whatever
+// disable turned them off, and the latest directive of a target wins.
+var suppressionPattern = regexp.MustCompile(`<!--\s*mm-quality-(disable-next-line|disable-line|disable|enable)\b([^>]*?)\s*-->`)
+
+// lineSuppressionPattern matches the same directives in the # and // line
+// comments of source code, data files and notebook code cells:
+//
+//	# mm-quality-disable-next-line spell
+//	// mm-quality-disable-line spell
+var lineSuppressionPattern = regexp.MustCompile(`(?:#|//)\s*mm-quality-(disable-next-line|disable-line|disable|enable)\b([\w.,\- \t]*)`)
+
+// suppressionScope is a set of targets, where nil means everything
+type suppressionScope map[string]bool
+
+// matches checks whether the scope covers an issue
+func (s suppressionScope) matches(issue Issue) bool {
+	if s == nil {
+		return true
+	}
+	return s[strings.ToLower(string(issue.Type))] || (issue.RuleID != "" && s[strings.ToLower(issue.RuleID)])
+}
+
+// blockState is the state of the disable and enable blocks at a line: the
+// order of the last directive of each target, positive for disable and
+// negative for enable, with "" standing for every checker
+type blockState map[string]int
+
+// suppresses checks whether the latest directive covering an issue, by its
+// checker, its rule or every checker, disables it
+func (b blockState) suppresses(issue Issue) bool {
+	last := b[""]
+	for _, target := range []string{string(issue.Type), issue.RuleID} {
+		if order := b[strings.ToLower(target)]; target != "" && abs(order) > abs(last) {
+			last = order
+		}
+	}
+	return last > 0
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// fileSuppressions holds the suppression scopes of each line in a file
+type fileSuppressions struct {
+	lines map[int][]suppressionScope
+	// blocks are the disable blocks each line is in, for the lines in any
+	blocks map[int]blockState
+}
+
+// suppressed checks whether an issue is covered by a suppression comment
+func (f *fileSuppressions) suppressed(issue Issue) bool {
+	for _, scope := range f.lines[issue.Line] {
+		if scope.matches(issue) {
+			return true
+		}
+	}
+	return f.blocks[issue.Line].suppresses(issue)
+}
+
+// parseSuppressions scans the content of a file for suppression comments:
+// HTML comments, and # and // line comments in files that have them
+func parseSuppressions(content, filePath string) *fileSuppressions {
+	result := &fileSuppressions{
+		lines:  make(map[int][]suppressionScope),
+		blocks: make(map[int]blockState),
+	}
+	if !strings.Contains(content, "mm-quality-") {
+		return result
+	}
+	patterns := []*regexp.Regexp{suppressionPattern}
+	if ext := filepath.Ext(filePath); extract.IsCode(ext) || extract.IsData(ext) || extract.IsNotebook(filePath) {
+		patterns = append(patterns, lineSuppressionPattern)
+	}
+
+	// The state is copied when a directive changes it, so lines share it
+	var state blockState
+	order := 0
+	lines := strings.Split(content, "\n")
+
+	for i, line := range lines {
+		lineNum := i + 1
+		var pending []suppressionScope
+
+		for _, pattern := range patterns {
+			for _, match := range pattern.FindAllStringSubmatch(line, -1) {
+				targets, scope := parseSuppressionTargets(match[2])
+				switch match[1] {
+				case "disable", "enable":
+					order++
+					next := blockState{}
+					for target, last := range state {
+						next[target] = last
+					}
+					if len(targets) == 0 {
+						// A bare directive overrides every earlier one
+						next = blockState{"": order}
+						if match[1] == "enable" {
+							next = nil
+						}
+					}
+					for _, target := range targets {
+						next[target] = order
+						if match[1] == "enable" {
+							next[target] = -order
+						}
+					}
+					state = next
+				case "disable-line":
+					pending = append(pending, scope)
+				case "disable-next-line":
+					result.lines[lineNum+1] = append(result.lines[lineNum+1], scope)
+				}
+				// The directive itself is never reported
+				pending = append(pending, nil)
+			}
+		}
+
+		if state != nil {
+			result.blocks[lineNum] = state
+		}
+		result.lines[lineNum] = append(result.lines[lineNum], pending...)
+	}
+
+	return result
+}
+
+// parseSuppressionTargets returns the targets of a directive and their
+// scope
+func parseSuppressionTargets(targets string) ([]string, suppressionScope) {
+	fields := strings.FieldsFunc(strings.ToLower(targets), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	scope := make(suppressionScope, len(fields))
+	for _, field := range fields {
+		scope[field] = true
+	}
+	return fields, scope
+}
+
+// applySuppressions removes issues covered by suppression comments in their
+// files and counts them in result.Suppressed
+func applySuppressions(result *CheckResult) {
+	cache := make(map[string]*fileSuppressions)
+	kept := result.Issues[:0]
+
+	for _, issue := range result.Issues {
+		suppressions, ok := cache[issue.File]
+		if !ok {
+			content, err := readSource(issue.File)
+			if err != nil {
+				suppressions = &fileSuppressions{}
+			} else {
+				suppressions = parseSuppressions(string(content), issue.File)
+			}
+			cache[issue.File] = suppressions
+		}
+
+		if suppressions.suppressed(issue) {
+			result.Suppressed++
+			continue
+		}
+		kept = append(kept, issue)
+	}
+
+	result.SetIssues(kept)
+}
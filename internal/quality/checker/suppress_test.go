@@ -0,0 +1,54 @@
+package checker
+
+import "testing"
+
+func TestParseSuppressions(t *testing.T) {
+	spell := Issue{Type: SpellCheckerType, RuleID: "spell-check"}
+	links := Issue{Type: LinksCheckerType, RuleID: "broken-link"}
+	markdown := Issue{Type: MarkdownCheckerType, RuleID: "MD013"}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		issue   Issue
+		line    int
+		want    bool
+	}{
+		{"no comments", "a.md", "text\ntext", spell, 1, false},
+		{"disable block", "a.md", "<!-- mm-quality-disable spell -->\ntext", spell, 2, true},
+		{"disable block other checker", "a.md", "<!-- mm-quality-disable spell -->\ntext", links, 2, false},
+		{"disable by rule ID", "a.md", "<!-- mm-quality-disable MD013 -->\ntext", markdown, 2, true},
+		{"rule IDs ignore case", "a.md", "<!-- mm-quality-disable md013 -->\ntext", markdown, 2, true},
+		{"enable closes block", "a.md", "<!-- mm-quality-disable spell -->\ntext\n<!-- mm-quality-enable spell -->\ntext", spell, 4, false},
+		{"enable part of a list", "a.md", "<!-- mm-quality-disable spell,links -->\n<!-- mm-quality-enable spell -->\ntext", spell, 3, false},
+		{"rest of the list stays off", "a.md", "<!-- mm-quality-disable spell,links -->\n<!-- mm-quality-enable spell -->\ntext", links, 3, true},
+		{"enable a list of separate disables", "a.md", "<!-- mm-quality-disable spell -->\n<!-- mm-quality-disable links -->\n<!-- mm-quality-enable spell links -->\ntext", links, 4, false},
+		{"enable the checker of a disabled rule", "a.md", "<!-- mm-quality-disable spell-check -->\n<!-- mm-quality-enable spell -->\ntext", spell, 3, false},
+		{"disable a rule after enabling its checker", "a.md", "<!-- mm-quality-enable spell -->\n<!-- mm-quality-disable spell-check -->\ntext", spell, 3, true},
+		{"bare disable", "a.md", "<!-- mm-quality-disable -->\ntext", links, 2, true},
+		{"enable inside bare disable", "a.md", "<!-- mm-quality-disable -->\n<!-- mm-quality-enable spell -->\ntext", spell, 3, false},
+		{"others stay off in bare disable", "a.md", "<!-- mm-quality-disable -->\n<!-- mm-quality-enable spell -->\ntext", links, 3, true},
+		{"bare enable", "a.md", "<!-- mm-quality-disable spell links -->\n<!-- mm-quality-enable -->\ntext", links, 3, false},
+		{"next line", "a.md", "<!-- mm-quality-disable-next-line spell -->\ntext\ntext", spell, 2, true},
+		{"next line only", "a.md", "<!-- mm-quality-disable-next-line spell -->\ntext\ntext", spell, 3, false},
+		{"same line", "a.md", "text <!-- mm-quality-disable-line links -->", links, 1, true},
+		{"directive line", "a.md", "<!-- mm-quality-enable spell -->", spell, 1, true},
+		{"hash comment in markdown", "a.md", "# mm-quality-disable-next-line spell\ntext", spell, 2, false},
+		{"hash comment in Python", "a.py", "# mm-quality-disable-next-line spell\nx = 'teh'", spell, 2, true},
+		{"slash comment in Go", "a.go", "// mm-quality-disable spell\nvar s = \"teh\"", spell, 2, true},
+		{"trailing comment in Go", "a.go", "var s = \"teh\" // mm-quality-disable-line spell", spell, 1, true},
+		{"hash comment in YAML", "a.yaml", "# mm-quality-disable-next-line spell\ntitle: teh", spell, 2, true},
+		{"code cell of a notebook", "a.ipynb", "    \"# mm-quality-disable-next-line spell\\n\",\n    \"x = 'teh'\"", spell, 2, true},
+		{"targets end at the JSON string", "a.ipynb", "    \"# mm-quality-disable-next-line links\\n\",\n    \"x = 'teh'\"", spell, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := tt.issue
+			issue.Line = tt.line
+			if got := parseSuppressions(tt.content, tt.file).suppressed(issue); got != tt.want {
+				t.Errorf("suppressed(%s line %d) = %v, want %v", issue.Type, tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package checker
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"gopkg.in/yaml.v3"
+)
+
+// Terminology rule IDs
+const (
+	RuleForbiddenTerm = "term-forbidden"
+	RuleTermCasing    = "term-casing"
+)
+
+// DefaultGlossaryFile is looked up in the working directory when no glossary is given
+const DefaultGlossaryFile = ".mm/glossary.yaml"
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(TerminologyCheckerType),
+		Aliases:     []string{"terms"},
+		Description: "Terminology against the glossary in " + DefaultGlossaryFile,
+		Factory: func() (Checker, error) {
+			return NewTerminologyChecker(nil), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleForbiddenTerm,
+		Checker:     TerminologyCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Term is a forbidden variant of an approved glossary term",
+		Example:     "容器注册表 → 容器镜像仓库",
+	})
+	RegisterRule(Rule{
+		ID:          RuleTermCasing,
+		Checker:     TerminologyCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Term does not use the casing defined in the glossary",
+		Example:     "kubernetes → Kubernetes",
+	})
+}
+
+// GlossaryTerm is a single glossary entry
+type GlossaryTerm struct {
+	// Source is the original (usually English) term, for reference only
+	Source string `yaml:"source,omitempty"`
+	// Approved is the term documents should use
+	Approved string `yaml:"approved"`
+	// Forbidden lists variants that must be replaced by Approved
+	Forbidden []string `yaml:"forbidden,omitempty"`
+	// MatchCase reports occurrences of Approved written with different casing
+	MatchCase bool `yaml:"match_case,omitempty"`
+	// Note is shown with the issue
+	Note string `yaml:"note,omitempty"`
+}
+
+// Glossary is the on-disk glossary format
+type Glossary struct {
+	Terms []GlossaryTerm `yaml:"terms"`
+}
+
+// LoadGlossary reads a YAML glossary file
+func LoadGlossary(path string) (*Glossary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glossary %s: %w", path, err)
+	}
+
+	var glossary Glossary
+	if err := yaml.Unmarshal(data, &glossary); err != nil {
+		return nil, fmt.Errorf("failed to parse glossary %s: %w", path, err)
+	}
+
+	for i, term := range glossary.Terms {
+		if term.Approved == "" {
+			return nil, fmt.Errorf("glossary %s: term %d has no approved form", path, i+1)
+		}
+	}
+	return &glossary, nil
+}
+
+// compiledTerm holds the matchers for one glossary entry
+type compiledTerm struct {
+	term      GlossaryTerm
+	approved  *regexp.Regexp
+	forbidden []*regexp.Regexp
+}
+
+// TerminologyChecker implements the Checker interface using a glossary
+type TerminologyChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	terms       []compiledTerm
+	loaded      bool
+	// GlossaryFile is loaded by SetProject when the checker was created
+	// without a glossary
+	GlossaryFile string
+}
+
+// NewTerminologyChecker creates a terminology checker for the given glossary
+func NewTerminologyChecker(glossary *Glossary) *TerminologyChecker {
+	c := &TerminologyChecker{projectType: "generic"}
+	if glossary != nil {
+		c.setGlossary(glossary)
+	}
+	return c
+}
+
+// setGlossary compiles the matchers of a glossary
+func (c *TerminologyChecker) setGlossary(glossary *Glossary) {
+	c.terms = nil
+	for _, term := range glossary.Terms {
+		compiled := compiledTerm{
+			term:     term,
+			approved: termPattern(term.Approved, true),
+		}
+		for _, variant := range term.Forbidden {
+			compiled.forbidden = append(compiled.forbidden, termPattern(variant, true))
+		}
+		c.terms = append(c.terms, compiled)
+	}
+	c.loaded = true
+}
+
+// termPattern builds a matcher for a term. Latin terms only match whole
+// words; Chinese terms match anywhere since Chinese has no word boundaries.
+func termPattern(term string, caseInsensitive bool) *regexp.Regexp {
+	expr := regexp.QuoteMeta(term)
+	if isLatinWord(term[0]) {
+		expr = `\b` + expr
+	}
+	if isLatinWord(term[len(term)-1]) {
+		expr += `\b`
+	}
+	if caseInsensitive {
+		expr = `(?i)` + expr
+	}
+	return regexp.MustCompile(expr)
+}
+
+// isLatinWord checks for an ASCII word character
+func isLatinWord(b byte) bool {
+	return b == '_' || isDigit(b) || (b|0x20 >= 'a' && b|0x20 <= 'z')
+}
+
+// Name returns the name of this checker
+func (c *TerminologyChecker) Name() string {
+	return "Terminology Checker"
+}
+
+// Type returns the type of this checker
+func (c *TerminologyChecker) Type() CheckerType {
+	return TerminologyCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (c *TerminologyChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+
+	if !c.loaded {
+		glossaryFile := c.GlossaryFile
+		if glossaryFile == "" {
+			glossaryFile = DefaultGlossaryFile
+		}
+		glossary, err := LoadGlossary(glossaryFile)
+		if err != nil {
+			return err
+		}
+		c.setGlossary(glossary)
+	}
+	return nil
+}
+
+// CheckFile checks a single file for non-approved terminology
+func (c *TerminologyChecker) CheckFile(filePath string) ([]Issue, error) {
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var issues []Issue
+	forEachProseLine(string(content), func(lineNum int, line string) {
+		masked := maskSpans(line)
+		for _, term := range c.terms {
+			issues = append(issues, c.checkLine(filePath, lineNum, masked, term)...)
+		}
+	})
+	return issues, nil
+}
+
+// Fingerprint identifies the glossary in use
+func (c *TerminologyChecker) Fingerprint() string {
+	terms := make([]GlossaryTerm, len(c.terms))
+	for i, t := range c.terms {
+		terms[i] = t.term
+	}
+	return fingerprintOf(terms)
+}
+
+// CheckFiles checks multiple files for non-approved terminology
+func (c *TerminologyChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
+
+// checkLine reports forbidden variants and miscased approved terms in a line
+func (c *TerminologyChecker) checkLine(filePath string, lineNum int, line string, term compiledTerm) []Issue {
+	var issues []Issue
+	newIssue := func(ruleID string, column int, word, message string) Issue {
+		if term.term.Note != "" {
+			message += " (" + term.term.Note + ")"
+		}
+		return Issue{
+			Type:        TerminologyCheckerType,
+			Severity:    WarningSeverity,
+			File:        filePath,
+			Line:        lineNum,
+			Column:      column,
+			Word:        word,
+			Message:     message,
+			Suggestions: []string{term.term.Approved},
+			RuleID:      ruleID,
+		}
+	}
+
+	approvedSpans := term.approved.FindAllStringIndex(line, -1)
+
+	for _, pattern := range term.forbidden {
+		for _, loc := range pattern.FindAllStringIndex(line, -1) {
+			// A variant that is part of the approved term is not a violation
+			if withinSpans(loc, approvedSpans) {
+				continue
+			}
+			word := line[loc[0]:loc[1]]
+			issues = append(issues, newIssue(RuleForbiddenTerm, loc[0]+1, word,
+				fmt.Sprintf("Use '%s' instead of '%s'", term.term.Approved, word)))
+		}
+	}
+
+	if term.term.MatchCase {
+		for _, loc := range approvedSpans {
+			word := line[loc[0]:loc[1]]
+			if word != term.term.Approved {
+				issues = append(issues, newIssue(RuleTermCasing, loc[0]+1, word,
+					fmt.Sprintf("Write '%s' as '%s'", word, term.term.Approved)))
+			}
+		}
+	}
+
+	return issues
+}
+
+// withinSpans checks whether loc lies inside one of spans
+func withinSpans(loc []int, spans [][]int) bool {
+	for _, span := range spans {
+		if loc[0] >= span[0] && loc[1] <= span[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package checker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/adapter"
+	"github.com/samzong/mm/internal/quality/zhconv"
+)
+
+// RuleZhTraditional is reported for traditional characters in simplified Chinese
+const RuleZhTraditional = "zh-traditional"
+
+// traditionalLocales hold pages written in traditional Chinese on purpose
+var traditionalLocales = map[string]bool{
+	"zh-tw":   true,
+	"zh-hk":   true,
+	"zh-mo":   true,
+	"zh-hant": true,
+}
+
+func init() {
+	RegisterChecker(Registration{
+		Name:        string(ChineseVariantCheckerType),
+		Description: "Traditional Chinese characters in simplified Chinese docs",
+		Factory: func() (Checker, error) {
+			return NewChineseVariantChecker(), nil
+		},
+	})
+
+	RegisterRule(Rule{
+		ID:          RuleZhTraditional,
+		Checker:     ChineseVariantCheckerType,
+		Severity:    WarningSeverity,
+		Description: "Traditional Chinese characters in simplified Chinese text",
+		Example:     "這個節點 → 这个节点",
+	})
+}
+
+// ChineseVariantChecker implements the Checker interface for traditional
+// characters that slipped into simplified Chinese documents
+type ChineseVariantChecker struct {
+	projectType string
+	adapter     adapter.ProjectAdapter
+	opencc      *zhconv.OpenCC
+	// OpenCCConfig enables suggestions from the opencc command with the given
+	// config (t2s, tw2sp, ...); empty uses the built-in character table
+	OpenCCConfig string
+}
+
+// NewChineseVariantChecker creates a new simplified/traditional checker
+func NewChineseVariantChecker() *ChineseVariantChecker {
+	return &ChineseVariantChecker{projectType: "generic"}
+}
+
+// Name returns the name of this checker
+func (c *ChineseVariantChecker) Name() string {
+	return "Chinese Variant Checker"
+}
+
+// Type returns the type of this checker
+func (c *ChineseVariantChecker) Type() CheckerType {
+	return ChineseVariantCheckerType
+}
+
+// SetProject sets the project type and loads appropriate configuration
+func (c *ChineseVariantChecker) SetProject(projectType string) error {
+	projectAdapter, err := adapter.GetAdapter(projectType)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter for project type %s: %w", projectType, err)
+	}
+
+	c.projectType = projectType
+	c.adapter = projectAdapter
+
+	if c.OpenCCConfig != "" && c.opencc == nil {
+		opencc, err := zhconv.NewOpenCC(c.OpenCCConfig)
+		if err != nil {
+			return err
+		}
+		c.opencc = opencc
+	}
+	return nil
+}
+
+// CheckFile checks a single file for traditional Chinese characters
+func (c *ChineseVariantChecker) CheckFile(filePath string) ([]Issue, error) {
+	if c.adapter != nil && adapter.ShouldIgnoreFile(filePath, c.adapter.GetIgnorePatterns()) {
+		return nil, nil
+	}
+	if inTraditionalLocale(filePath) {
+		return nil, nil
+	}
+
+	content, err := readSource(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	// Nothing to check in files without Chinese text
+	if !hanPresence.Match(content) {
+		return nil, nil
+	}
+
+	var issues []Issue
+	forEachProseLine(string(content), func(lineNum int, line string) {
+		masked := maskSpans(line)
+		for _, loc := range traditionalSpans(masked) {
+			word := masked[loc[0]:loc[1]]
+			issues = append(issues, Issue{
+				Type:        ChineseVariantCheckerType,
+				Severity:    WarningSeverity,
+				File:        filePath,
+				Line:        lineNum,
+				Column:      loc[0] + 1,
+				Word:        word,
+				Message:     fmt.Sprintf("Traditional Chinese '%s' in simplified Chinese text", word),
+				Suggestions: []string{zhconv.ToSimplified(word)},
+				RuleID:      RuleZhTraditional,
+			})
+		}
+	})
+
+	if c.opencc != nil && len(issues) > 0 {
+		words := make([]string, len(issues))
+		for i, issue := range issues {
+			words[i] = issue.Word
+		}
+		converted, err := c.opencc.Convert(words)
+		if err != nil {
+			return nil, err
+		}
+		for i := range issues {
+			issues[i].Suggestions = []string{converted[i]}
+		}
+	}
+	return issues, nil
+}
+
+// traditionalSpans finds the spans of Chinese text holding traditional
+// characters. A span runs from the first to the last traditional character
+// of a stretch of Chinese characters, so a phrase is reported once.
+func traditionalSpans(line string) [][]int {
+	var spans [][]int
+	start, end := -1, -1
+	flush := func() {
+		if start >= 0 {
+			spans = append(spans, []int{start, end})
+		}
+		start, end = -1, -1
+	}
+
+	for i := 0; i < len(line); {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		switch {
+		case zhconv.IsTraditional(r):
+			if start < 0 {
+				start = i
+			}
+			end = i + size
+		case !isHan(r):
+			flush()
+		}
+		i += size
+	}
+	flush()
+	return spans
+}
+
+// inTraditionalLocale reports whether a file lives under a traditional
+// Chinese content directory such as content/zh-tw
+func inTraditionalLocale(filePath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(filePath), "/") {
+		if traditionalLocales[strings.ToLower(part)] {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingerprint identifies the conversion in use
+func (c *ChineseVariantChecker) Fingerprint() string {
+	return fingerprintOf(c.OpenCCConfig)
+}
+
+// CheckFiles checks multiple files for traditional Chinese characters
+func (c *ChineseVariantChecker) CheckFiles(filePaths []string) (*CheckResult, error) {
+	return checkFiles(c, c.projectType, filePaths)
+}
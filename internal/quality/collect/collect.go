@@ -0,0 +1,178 @@
+package collect
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samzong/mm/internal/glob"
+	"github.com/samzong/mm/internal/quality/extract"
+)
+
+// IgnoreFileName lists the paths the quality commands skip, one glob
+// pattern per line, like .gitignore
+const IgnoreFileName = ".mmignore"
+
+// DocumentExtensions are the extensions of the files collected by default
+var DocumentExtensions = []string{".md", ".mdx", ".txt", ".rst", ".html", ".ipynb"}
+
+// ignoreRule is a glob pattern relative to a base directory. A negated
+// rule re-includes what earlier rules ignored.
+type ignoreRule struct {
+	base    string
+	pattern string
+	negate  bool
+}
+
+// Collector gathers the files to check from files and directories,
+// skipping hidden and ignored paths
+type Collector struct {
+	extensions map[string]bool
+	rules      []ignoreRule
+	// negated is set when a rule re-includes paths, which may lie below
+	// ignored directories
+	negated bool
+}
+
+// New creates a collector that collects files with the given extensions
+// from directories
+func New(extensions ...[]string) *Collector {
+	c := &Collector{extensions: make(map[string]bool)}
+	for _, list := range extensions {
+		for _, ext := range list {
+			c.extensions[strings.ToLower(ext)] = true
+		}
+	}
+	return c
+}
+
+// Ignore adds glob patterns relative to dir. Later patterns take
+// precedence; a pattern starting with ! re-includes matching paths.
+func (c *Collector) Ignore(dir string, patterns []string) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		base = dir
+	}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "/")
+		if pattern == "" {
+			continue
+		}
+		c.rules = append(c.rules, ignoreRule{base: base, pattern: pattern, negate: negate})
+		c.negated = c.negated || negate
+	}
+}
+
+// IgnoreFile adds the patterns of an ignore file, relative to its directory
+func (c *Collector) IgnoreFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	c.Ignore(filepath.Dir(path), patterns)
+	return nil
+}
+
+// Ignored reports whether a file is ignored
+func (c *Collector) Ignored(path string) bool {
+	return c.ignored(path, false)
+}
+
+// ignored applies the rules in order, the last matching one deciding. A
+// directory also matches patterns for everything below it, such as
+// node_modules/**.
+func (c *Collector) ignored(path string, dir bool) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	ignored := false
+	for _, rule := range c.rules {
+		rel, err := filepath.Rel(rule.base, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		matched := glob.Match(rule.pattern, rel)
+		if !matched && dir {
+			matched = glob.Match(strings.TrimSuffix(rule.pattern, "/**"), rel)
+		}
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// Collect returns the files to check under path. Directories are walked
+// for files with the collected extensions, skipping hidden and ignored
+// entries. A file named directly is accepted when it has a collected
+// extension or is a source or data file, and skipped when ignored.
+func (c *Collector) Collect(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		ext := strings.ToLower(filepath.Ext(path))
+		if !c.extensions[ext] && !extract.IsCode(path) && !extract.IsData(path) {
+			return nil, fmt.Errorf("unsupported file type: %s", ext)
+		}
+		if c.Ignored(path) {
+			return nil, nil
+		}
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// The path given is walked even when it is "." or a hidden directory
+		if filePath == path {
+			return nil
+		}
+
+		if d.IsDir() {
+			// Ignored directories are only skipped as a whole when no rule
+			// could re-include something below them
+			if strings.HasPrefix(d.Name(), ".") || (!c.negated && c.ignored(filePath, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || !c.extensions[strings.ToLower(filepath.Ext(filePath))] {
+			return nil
+		}
+		if !c.Ignored(filePath) {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -96,15 +96,14 @@ func (d *GenericProjectDetector) Priority() int {
 	return 1 // Lowest priority
 }
 
-// DetectProject detects the project type in the given root path
+// DetectProject detects the project type in the given root path. A
+// well-known repository is recognized by its git remote first.
 func DetectProject(rootPath string) (string, error) {
-	// List of all detectors, ordered by priority
-	detectors := []ProjectDetector{
-		&K8sWebsiteDetector{},
-		&GoProjectDetector{},
-		&DockerProjectDetector{},
-		&GenericProjectDetector{},
+	if projectType, ok := DetectRemote(rootPath); ok {
+		return projectType, nil
 	}
+
+	detectors := append(fileDetectors(), &GenericProjectDetector{})
 	
 	// Find the highest priority detector that matches
 	var bestDetector ProjectDetector
@@ -124,12 +123,31 @@ func DetectProject(rootPath string) (string, error) {
 	return bestDetector.Name(), nil
 }
 
+// fileDetectors returns the detectors that recognize a project by its files
+func fileDetectors() []ProjectDetector {
+	return []ProjectDetector{
+		&K8sWebsiteDetector{},
+		&GoProjectDetector{},
+		&DockerProjectDetector{},
+		&HugoDetector{},
+		&DocusaurusDetector{},
+		&MkDocsDetector{},
+		&SphinxDetector{},
+		&VitePressDetector{},
+	}
+}
+
 // GetSupportedProjects returns a list of all supported project types
 func GetSupportedProjects() []string {
 	return []string{
 		string(K8sWebsiteProject),
 		string(GoProject),
 		string(DockerProject),
+		string(HugoProject),
+		string(DocusaurusProject),
+		string(MkDocsProject),
+		string(SphinxProject),
+		string(VitePressProject),
 		string(GenericProject),
 	}
 }
@@ -0,0 +1,103 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFiles creates files under dir, given by slash-separated paths
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDetectProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"kubernetes website", map[string]string{"scripts/lsync.sh": "", "content/en/_index.md": "", "hugo.toml": ""}, "k8s"},
+		{"hugo config", map[string]string{"hugo.toml": ""}, "hugo"},
+		{"hugo legacy config", map[string]string{"config.toml": "", "content/_index.md": ""}, "hugo"},
+		{"config without content", map[string]string{"config.toml": ""}, "generic"},
+		{"docusaurus in website", map[string]string{"website/docusaurus.config.js": ""}, "docusaurus"},
+		{"mkdocs", map[string]string{"mkdocs.yml": ""}, "mkdocs"},
+		{"sphinx source dir", map[string]string{"docs/source/conf.py": "extensions = ['sphinx.ext.autodoc']\n"}, "sphinx"},
+		{"conf.py of another tool", map[string]string{"conf.py": "DEBUG = True\n"}, "generic"},
+		{"vitepress", map[string]string{"docs/.vitepress/config.mts": ""}, "vitepress"},
+		{"go module", map[string]string{"go.mod": "module example.com/x\n"}, "go"},
+		{"empty", map[string]string{"README.md": ""}, "generic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			if got, _ := DetectProject(dir); got != tt.want {
+				t.Errorf("DetectProject() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLayout(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":                         "module example.com/x\n",
+		"website/hugo.toml":              "",
+		"website/content/_index.md":      "",
+		"node_modules/pkg/mkdocs.yml":    "",
+		"internal/testdata/mkdocs.yml":   "",
+		"tools/handbook/mkdocs.yml":      "",
+		"tools/handbook/docs/index.md":   "",
+		"website/themes/docsy/hugo.toml": "",
+	})
+
+	project, subtrees := DetectLayout(dir, SubtreeDepth)
+	if project != "go" {
+		t.Errorf("DetectLayout() root = %s, want go", project)
+	}
+	want := Subtrees{
+		{Dir: filepath.Join(dir, "tools", "handbook"), Project: "mkdocs"},
+		{Dir: filepath.Join(dir, "website"), Project: "hugo"},
+	}
+	if !reflect.DeepEqual(subtrees, want) {
+		t.Errorf("DetectLayout() subtrees = %v, want %v", subtrees, want)
+	}
+
+	tests := map[string]string{
+		"website/content/_index.md":    "hugo",
+		"tools/handbook/docs/index.md": "mkdocs",
+		"README.md":                    "go",
+	}
+	for file, want := range tests {
+		if got := subtrees.ProjectOf(filepath.Join(dir, filepath.FromSlash(file)), "go"); got != want {
+			t.Errorf("ProjectOf(%s) = %s, want %s", file, got, want)
+		}
+	}
+}
+
+func TestRemoteRepository(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/kubernetes/website.git\n": "kubernetes/website",
+		"git@github.com:Kubernetes/Website.git":       "kubernetes/website",
+		"ssh://git@github.com/docker/docs":            "docker/docs",
+		"https://github.com/helm/helm-www/":           "helm/helm-www",
+		"not a url":                                   "",
+	}
+	for url, want := range tests {
+		if got := RemoteRepository(url); got != want {
+			t.Errorf("RemoteRepository(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Documentation framework project types
+const (
+	HugoProject       ProjectType = "hugo"
+	DocusaurusProject ProjectType = "docusaurus"
+	MkDocsProject     ProjectType = "mkdocs"
+	SphinxProject     ProjectType = "sphinx"
+	VitePressProject  ProjectType = "vitepress"
+)
+
+// docsDirs are where documentation sites commonly live in a repository
+var docsDirs = []string{".", "docs", "doc", "website", "site"}
+
+// HugoDetector detects Hugo sites
+type HugoDetector struct{}
+
+func (d *HugoDetector) Name() string {
+	return string(HugoProject)
+}
+
+func (d *HugoDetector) Detect(rootPath string) bool {
+	return inDocsDirs(rootPath, d.DetectDir)
+}
+
+func (d *HugoDetector) DetectDir(dir string) bool {
+	if anyFileExists(dir, "hugo.toml", "hugo.yaml", "hugo.json") {
+		return true
+	}
+	// config.* is Hugo's when there is a content directory next to it
+	return anyFileExists(dir, "config.toml", "config.yaml") && dirExists(filepath.Join(dir, "content"))
+}
+
+func (d *HugoDetector) Priority() int {
+	return 70 // Below the Kubernetes website, which is a Hugo site
+}
+
+// DocusaurusDetector detects Docusaurus sites
+type DocusaurusDetector struct{}
+
+func (d *DocusaurusDetector) Name() string {
+	return string(DocusaurusProject)
+}
+
+func (d *DocusaurusDetector) Detect(rootPath string) bool {
+	return inDocsDirs(rootPath, d.DetectDir)
+}
+
+func (d *DocusaurusDetector) DetectDir(dir string) bool {
+	return anyFileExists(dir, "docusaurus.config.js", "docusaurus.config.ts", "docusaurus.config.mjs")
+}
+
+func (d *DocusaurusDetector) Priority() int {
+	return 80
+}
+
+// MkDocsDetector detects MkDocs sites
+type MkDocsDetector struct{}
+
+func (d *MkDocsDetector) Name() string {
+	return string(MkDocsProject)
+}
+
+func (d *MkDocsDetector) Detect(rootPath string) bool {
+	return inDocsDirs(rootPath, d.DetectDir)
+}
+
+func (d *MkDocsDetector) DetectDir(dir string) bool {
+	return anyFileExists(dir, "mkdocs.yml", "mkdocs.yaml")
+}
+
+func (d *MkDocsDetector) Priority() int {
+	return 80
+}
+
+// SphinxDetector detects Sphinx projects by a conf.py that uses Sphinx
+type SphinxDetector struct{}
+
+func (d *SphinxDetector) Name() string {
+	return string(SphinxProject)
+}
+
+func (d *SphinxDetector) Detect(rootPath string) bool {
+	return inDocsDirs(rootPath, d.DetectDir)
+}
+
+func (d *SphinxDetector) DetectDir(dir string) bool {
+	// conf.py is in the docs directory or its source/ directory
+	for _, confDir := range []string{dir, filepath.Join(dir, "source")} {
+		content, err := os.ReadFile(filepath.Join(confDir, "conf.py"))
+		if err != nil {
+			continue
+		}
+		text := string(content)
+		if strings.Contains(text, "sphinx") || strings.Contains(text, "html_theme") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *SphinxDetector) Priority() int {
+	return 80
+}
+
+// VitePressDetector detects VitePress sites
+type VitePressDetector struct{}
+
+func (d *VitePressDetector) Name() string {
+	return string(VitePressProject)
+}
+
+func (d *VitePressDetector) Detect(rootPath string) bool {
+	return inDocsDirs(rootPath, d.DetectDir)
+}
+
+func (d *VitePressDetector) DetectDir(dir string) bool {
+	return anyFileExists(filepath.Join(dir, ".vitepress"), "config.js", "config.ts", "config.mjs", "config.mts")
+}
+
+func (d *VitePressDetector) Priority() int {
+	return 80
+}
+
+// inDocsDirs reports whether detect matches the root or one of the
+// directories documentation sites commonly live in
+func inDocsDirs(rootPath string, detect func(dir string) bool) bool {
+	for _, dir := range docsDirs {
+		if detect(filepath.Join(rootPath, dir)) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyFileExists checks if dir contains a file with one of the names
+func anyFileExists(dir string, names ...string) bool {
+	for _, name := range names {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
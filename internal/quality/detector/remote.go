@@ -0,0 +1,53 @@
+package detector
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// KnownRepositories maps well-known documentation repositories, as
+// owner/name, to their project type. They are recognized by their git
+// remote, even in a sparse checkout without the files detection looks for.
+var KnownRepositories = map[string]ProjectType{
+	"kubernetes/website":      K8sWebsiteProject,
+	"docker/docs":             DockerProject,
+	"docker/docker.github.io": DockerProject,
+	"helm/helm-www":           DocusaurusProject,
+	"golang/website":          GoProject,
+	"gohugoio/hugodocs":       HugoProject,
+}
+
+// detectionRemotes are the remotes looked at, origin first; forks usually
+// name the repository they were forked from upstream
+var detectionRemotes = []string{"origin", "upstream"}
+
+// remoteRepoPattern matches the owner/name of HTTPS, SSH and scp-like
+// remote URLs
+var remoteRepoPattern = regexp.MustCompile(`[:/]([^/:]+/[^/]+?)(?:\.git)?/?$`)
+
+// DetectRemote returns the project type of a well-known repository checked
+// out at rootPath, found by its git remotes
+func DetectRemote(rootPath string) (string, bool) {
+	for _, remote := range detectionRemotes {
+		cmd := exec.Command("git", "-C", rootPath, "remote", "get-url", remote)
+		out, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		if projectType, ok := KnownRepositories[RemoteRepository(string(out))]; ok {
+			return string(projectType), true
+		}
+	}
+	return "", false
+}
+
+// RemoteRepository returns the lowercase owner/name of a git remote URL,
+// such as kubernetes/website for git@github.com:kubernetes/website.git
+func RemoteRepository(url string) string {
+	match := remoteRepoPattern.FindStringSubmatch(strings.TrimSpace(url))
+	if match == nil {
+		return ""
+	}
+	return strings.ToLower(match[1])
+}
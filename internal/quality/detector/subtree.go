@@ -0,0 +1,136 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SubtreeDepth is how many directory levels below the root are searched for
+// projects of their own, enough for website/ and packages/sdk/
+const SubtreeDepth = 3
+
+// skippedDirs are never searched for subtree projects
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"third_party":  true,
+	"testdata":     true,
+}
+
+// Subtree is a directory of a repository that is a project of its own, such
+// as a Hugo site in website/ of a Go repository
+type Subtree struct {
+	Dir     string
+	Project string
+}
+
+// Subtrees are the subtree projects of a repository
+type Subtrees []Subtree
+
+// dirDetector is implemented by detectors that also look for a project in
+// the docs directories below the root. DetectDir only looks at dir itself.
+type dirDetector interface {
+	DetectDir(dir string) bool
+}
+
+// DetectLayout detects the project types of a repository that may hold
+// several projects, such as a Go module with a Hugo site in website/. The
+// root project is detected from the files of rootPath alone and the
+// subtrees up to depth levels below it by theirs. Without subtrees the
+// root project is that of DetectProject.
+func DetectLayout(rootPath string, depth int) (string, Subtrees) {
+	if projectType, ok := DetectRemote(rootPath); ok {
+		// A well-known repository is a single project
+		return projectType, nil
+	}
+
+	rootProject, ok := detectDir(rootPath)
+	if !ok {
+		rootProject = string(GenericProject)
+	}
+	subtrees := DetectSubtrees(rootPath, rootProject, depth)
+	if len(subtrees) == 0 {
+		projectType, _ := DetectProject(rootPath)
+		return projectType, nil
+	}
+	return rootProject, subtrees
+}
+
+// DetectSubtrees finds the directories up to depth levels below rootPath
+// whose project type differs from that of the directory containing them,
+// rootProject for the root
+func DetectSubtrees(rootPath, rootProject string, depth int) Subtrees {
+	var subtrees Subtrees
+	var walk func(dir, parentProject string, level int)
+	walk = func(dir, parentProject string, level int) {
+		if level > depth {
+			return
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || skippedDirs[name] {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			project := parentProject
+			if detected, ok := detectDir(path); ok && detected != parentProject {
+				project = detected
+				subtrees = append(subtrees, Subtree{Dir: path, Project: project})
+			}
+			walk(path, project, level+1)
+		}
+	}
+	walk(rootPath, rootProject, 1)
+	return subtrees
+}
+
+// detectDir detects the project type of a directory by its files alone,
+// without falling back to the generic type
+func detectDir(dir string) (string, bool) {
+	var best ProjectDetector
+	for _, detector := range fileDetectors() {
+		matched := false
+		if d, ok := detector.(dirDetector); ok {
+			matched = d.DetectDir(dir)
+		} else {
+			matched = detector.Detect(dir)
+		}
+		if matched && (best == nil || detector.Priority() > best.Priority()) {
+			best = detector
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	return best.Name(), true
+}
+
+// ProjectOf returns the project type of the innermost subtree containing
+// path, or fallback when it lies in none
+func (s Subtrees) ProjectOf(path, fallback string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fallback
+	}
+
+	project, depth := fallback, -1
+	for _, subtree := range s {
+		dir, err := filepath.Abs(subtree.Dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(dir) > depth {
+			project, depth = subtree.Project, len(dir)
+		}
+	}
+	return project
+}
@@ -0,0 +1,101 @@
+package dictionary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AddWords appends words to a dictionary file, creating it if needed. Words
+// already in the file (case-insensitive) are left alone. It returns the
+// words that were added.
+func AddWords(path string, words []string) ([]string, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range lines {
+		existing[strings.ToLower(strings.TrimSpace(line))] = true
+	}
+
+	var added []string
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if !ValidWord(word) {
+			return nil, fmt.Errorf("invalid dictionary word %q: words may only contain letters and apostrophes", word)
+		}
+		if existing[strings.ToLower(word)] {
+			continue
+		}
+		existing[strings.ToLower(word)] = true
+		lines = append(lines, word)
+		added = append(added, word)
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+	return added, writeLines(path, lines)
+}
+
+// RemoveWords deletes words from a dictionary file (case-insensitive),
+// keeping comments and all other lines. It returns the words that were
+// removed.
+func RemoveWords(path string, words []string) ([]string, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	remove := make(map[string]bool)
+	for _, word := range words {
+		remove[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+
+	var kept, removed []string
+	for _, line := range lines {
+		word := strings.TrimSpace(line)
+		if remove[strings.ToLower(word)] {
+			removed = append(removed, word)
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	return removed, writeLines(path, kept)
+}
+
+// readLines returns the lines of a file, or none if it does not exist
+func readLines(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary %s: %w", path, err)
+	}
+
+	text := strings.TrimRight(string(content), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// writeLines writes lines to a file, creating its directory if needed
+func writeLines(path string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create dictionary directory: %w", err)
+	}
+	content := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write dictionary %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Word list formats supported by import and export
+const (
+	// FormatText is a plain list with one word per line, as mm uses
+	FormatText = "text"
+	// FormatCSpell is a cSpell configuration (cspell.json or cspell.yaml)
+	FormatCSpell = "cspell"
+	// FormatAspell is an aspell personal dictionary (personal_ws-1.1 header)
+	FormatAspell = "aspell"
+	// FormatHunspell is a hunspell .dic file (word count, then word/flags)
+	FormatHunspell = "hunspell"
+)
+
+// Formats lists the supported word list formats
+var Formats = []string{FormatText, FormatCSpell, FormatAspell, FormatHunspell}
+
+// cspellConfig holds the word lists of a cSpell configuration
+type cspellConfig struct {
+	Version     string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Language    string   `json:"language,omitempty" yaml:"language,omitempty"`
+	Words       []string `json:"words" yaml:"words"`
+	IgnoreWords []string `json:"ignoreWords,omitempty" yaml:"ignoreWords,omitempty"`
+}
+
+// ParseWords reads the words of a word list in the given format
+func ParseWords(format string, data []byte) ([]string, error) {
+	switch format {
+	case FormatText:
+		return parseLines(data, nil), nil
+	case FormatCSpell:
+		return parseCSpell(data)
+	case FormatAspell:
+		return parseLines(data, func(line string) bool {
+			return strings.HasPrefix(line, "personal_ws-")
+		}), nil
+	case FormatHunspell:
+		return parseHunspell(data), nil
+	default:
+		return nil, fmt.Errorf("unsupported dictionary format: %s (supported: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// FormatWords writes words as a word list in the given format
+func FormatWords(format string, words []string, lang string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatText:
+		for _, word := range words {
+			fmt.Fprintln(&buf, word)
+		}
+	case FormatCSpell:
+		config := cspellConfig{Version: "0.2", Language: lang, Words: words}
+		if config.Words == nil {
+			config.Words = []string{}
+		}
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode cSpell configuration: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case FormatAspell:
+		fmt.Fprintf(&buf, "personal_ws-1.1 %s %d\n", lang, len(words))
+		for _, word := range words {
+			fmt.Fprintln(&buf, word)
+		}
+	case FormatHunspell:
+		fmt.Fprintln(&buf, len(words))
+		for _, word := range words {
+			fmt.Fprintln(&buf, word)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dictionary format: %s (supported: %s)", format, strings.Join(Formats, ", "))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseLines returns the non-empty, non-comment lines of data, dropping the
+// lines skip reports as headers
+func parseLines(data []byte, skip func(line string) bool) []string {
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if skip != nil && skip(line) {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words
+}
+
+// parseCSpell reads the words and ignoreWords of a cSpell configuration.
+// JSON configurations may contain comments.
+func parseCSpell(data []byte) ([]string, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		data = stripJSONComments(data)
+	}
+
+	// YAML is a superset of JSON, so one decoder reads both forms
+	var config cspellConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse cSpell configuration: %w", err)
+	}
+	return append(config.Words, config.IgnoreWords...), nil
+}
+
+// parseHunspell reads the words of a hunspell .dic file, dropping the word
+// count line and affix flags
+func parseHunspell(data []byte) []string {
+	var words []string
+	for i, line := range parseLines(data, nil) {
+		if i == 0 && isNumber(line) {
+			continue
+		}
+		// Hunspell uses / to separate affix flags and a tab before
+		// morphological fields; \/ is a literal slash
+		word, _, _ := strings.Cut(line, "\t")
+		if idx := unescapedSlash(word); idx >= 0 {
+			word = word[:idx]
+		}
+		word = strings.ReplaceAll(strings.TrimSpace(word), `\/`, "/")
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// unescapedSlash returns the index of the first / not preceded by \, or -1
+func unescapedSlash(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '/' && (i == 0 || s[i-1] != '\\') {
+			return i
+		}
+	}
+	return -1
+}
+
+// isNumber reports whether s consists of ASCII digits only
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// stripJSONComments removes // and /* */ comments outside of JSON strings
+func stripJSONComments(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out.WriteByte(data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out.WriteByte('\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out.Bytes()
+			}
+			i += end + 3
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
@@ -2,16 +2,42 @@ package dictionary
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"unicode"
 )
 
+// embeddedSource is the source of the dictionaries built into mm
+const embeddedSource = "embedded"
+
+//go:embed dictionaries/*.txt
+var embeddedDictionaries embed.FS
+
+// Dictionary is a loaded word list
+type Dictionary struct {
+	// Name is the dictionary as configured, e.g. dictionaries/k8s.txt
+	Name string
+	// Path is the file the words were read from
+	Path string
+	// Source describes where the file was found
+	Source string
+	Words  []string
+}
+
 // Manager handles dictionary loading and management
 type Manager struct {
 	personalDictPath string
+	hunspellDictPath string
 	loadedWords      map[string]bool
+	loaded           []Dictionary
+	lang             string
 }
 
 // NewManager creates a new dictionary manager
@@ -21,41 +47,44 @@ func NewManager() (*Manager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user home directory: %w", err)
 	}
-	
+
 	cacheDir := filepath.Join(homeDir, ".cache", "mm")
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
-	
+
 	// Create dictionaries directory for user customization
 	dictDir := filepath.Join(cacheDir, "dictionaries")
 	if err := os.MkdirAll(dictDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create dictionaries directory: %w", err)
 	}
-	
+
 	// Create example file if not exists
 	if err := createExampleDictionary(dictDir); err != nil {
 		// Log warning but don't fail
 		fmt.Fprintf(os.Stderr, "Warning: Failed to create example dictionary: %v\n", err)
 	}
-	
+
 	personalDictPath := filepath.Join(cacheDir, "personal.dict")
-	
+	hunspellDictPath := filepath.Join(cacheDir, "personal.hunspell")
+
 	return &Manager{
 		personalDictPath: personalDictPath,
+		hunspellDictPath: hunspellDictPath,
 		loadedWords:      make(map[string]bool),
+		lang:             "en",
 	}, nil
 }
 
 // createExampleDictionary creates an example custom dictionary
 func createExampleDictionary(dictDir string) error {
 	examplePath := filepath.Join(dictDir, "custom-example.txt")
-	
+
 	// Don't overwrite if already exists
 	if _, err := os.Stat(examplePath); err == nil {
 		return nil
 	}
-	
+
 	exampleContent := `# Custom Dictionary Example
 # Add your project-specific terms here
 # One word per line, comments start with #
@@ -76,15 +105,42 @@ devops
 # ux
 # qa
 `
-	
+
 	return os.WriteFile(examplePath, []byte(exampleContent), 0644)
 }
 
+// SetLanguage sets the language of the documents being checked
+func (m *Manager) SetLanguage(lang string) {
+	if lang != "" {
+		m.lang = lang
+	}
+}
+
+// localizeDictionaries swaps the English base dictionary for the one of the
+// configured language, adding it when the project has none
+func (m *Manager) localizeDictionaries(dictPaths []string) []string {
+	base, _, _ := strings.Cut(m.lang, "_")
+	if base == "en" {
+		return dictPaths
+	}
+
+	baseDict := "dictionaries/base-" + base + ".txt"
+	localized := []string{baseDict}
+	for _, dictPath := range dictPaths {
+		if dictPath != "dictionaries/base-en.txt" && dictPath != baseDict {
+			localized = append(localized, dictPath)
+		}
+	}
+	return localized
+}
+
 // LoadDictionaries loads dictionaries from the specified paths
 func (m *Manager) LoadDictionaries(dictPaths []string) error {
 	// Clear previously loaded words
 	m.loadedWords = make(map[string]bool)
-	
+	m.loaded = nil
+	dictPaths = m.localizeDictionaries(dictPaths)
+
 	// Load each dictionary
 	for _, dictPath := range dictPaths {
 		if err := m.loadDictionary(dictPath); err != nil {
@@ -92,231 +148,335 @@ func (m *Manager) LoadDictionaries(dictPaths []string) error {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to load dictionary %s: %v\n", dictPath, err)
 		}
 	}
-	
+
+	// Load the dictionary committed to the repository ahead of user ones
+	if err := m.loadRepoDictionary(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to load repository dictionary: %v\n", err)
+	}
+
 	// Auto-load user custom dictionaries
 	if err := m.loadUserCustomDictionaries(); err != nil {
 		// Log warning but don't fail
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load user custom dictionaries: %v\n", err)
 	}
-	
+
 	// Create/update personal dictionary file
 	return m.updatePersonalDictionary()
 }
 
+// UserDictionaryDir returns the directory of user custom dictionaries
+// (~/.cache/mm/dictionaries)
+func UserDictionaryDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cache", "mm", "dictionaries"), nil
+}
+
+// PersonalWordsPath returns the user dictionary that words are added to by
+// default. It is loaded like every other user custom dictionary.
+func PersonalWordsPath() (string, error) {
+	dictDir, err := UserDictionaryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dictDir, "personal.txt"), nil
+}
+
+// RepoDictionaryFile is the repository dictionary, relative to the repo root
+const RepoDictionaryFile = ".mm/dictionary.txt"
+
+// RepoDictionaryPath returns the repository dictionary path for the working
+// directory: .mm/dictionary.txt at the root of the enclosing git repository,
+// or in the working directory outside of one
+func RepoDictionaryPath() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return filepath.Join(repoRoot(wd), RepoDictionaryFile), nil
+}
+
+// repoRoot walks up from dir to the directory containing .git, returning dir
+// itself when there is none
+func repoRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// loadRepoDictionary loads the repository dictionary if there is one
+func (m *Manager) loadRepoDictionary() error {
+	path, err := RepoDictionaryPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	if m.isLoaded(path) {
+		return nil
+	}
+
+	words, err := readWords(path)
+	if err != nil {
+		return err
+	}
+	m.addDictionary(Dictionary{Name: RepoDictionaryFile, Path: path, Source: "repository", Words: words})
+	if os.Getenv("MM_VERBOSE") == "1" {
+		fmt.Fprintf(os.Stderr, "Loaded repository dictionary %s\n", path)
+	}
+	return nil
+}
+
 // loadUserCustomDictionaries automatically loads all .txt files from ~/.cache/mm/dictionaries/
 func (m *Manager) loadUserCustomDictionaries() error {
-	homeDir, err := os.UserHomeDir()
+	dictDir, err := UserDictionaryDir()
 	if err != nil {
 		return err
 	}
-	
-	dictDir := filepath.Join(homeDir, ".cache", "mm", "dictionaries")
-	
+
 	// Check if directory exists
 	if _, err := os.Stat(dictDir); os.IsNotExist(err) {
 		return nil // No custom dictionaries, that's fine
 	}
-	
+
 	// Read all .txt files in the directory
 	files, err := filepath.Glob(filepath.Join(dictDir, "*.txt"))
 	if err != nil {
 		return err
 	}
-	
+
 	for _, file := range files {
 		// Skip if it's already loaded via project dictionaries
-		relPath := "dictionaries/" + filepath.Base(file)
-		alreadyLoaded := false
-		for word := range m.loadedWords {
-			if word == relPath {
-				alreadyLoaded = true
-				break
-			}
+		if m.isLoaded(file) {
+			continue
 		}
-		
-		if !alreadyLoaded {
-			if err := m.loadSingleCustomDictionary(file); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to load custom dictionary %s: %v\n", file, err)
-			} else if os.Getenv("MM_VERBOSE") == "1" {
-				fmt.Fprintf(os.Stderr, "Loaded custom dictionary %s\n", filepath.Base(file))
-			}
+
+		words, err := readWords(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load custom dictionary %s: %v\n", file, err)
+			continue
+		}
+		m.addDictionary(Dictionary{
+			Name:   filepath.Base(file),
+			Path:   file,
+			Source: "user custom",
+			Words:  words,
+		})
+		if os.Getenv("MM_VERBOSE") == "1" {
+			fmt.Fprintf(os.Stderr, "Loaded custom dictionary %s\n", filepath.Base(file))
 		}
 	}
-	
+
 	return nil
 }
 
-// loadSingleCustomDictionary loads a single custom dictionary file
-func (m *Manager) loadSingleCustomDictionary(filePath string) error {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return err
-	}
-	
-	// Parse dictionary content (same logic as loadDictionary)
-	scanner := bufio.NewScanner(strings.NewReader(string(content)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		
-		// Skip words with hyphens (aspell doesn't support them in personal dictionaries)
-		if strings.Contains(line, "-") {
-			continue
-		}
-		
-		// Skip words with underscores (aspell doesn't support them either)
-		if strings.Contains(line, "_") {
-			continue
-		}
-		
-		// Skip words with numbers (aspell doesn't support them in personal dictionaries)
-		hasNumber := false
-		for _, char := range line {
-			if char >= '0' && char <= '9' {
-				hasNumber = true
-				break
-			}
+// isLoaded reports whether the file at path has already been loaded
+func (m *Manager) isLoaded(path string) bool {
+	for _, dict := range m.loaded {
+		if sameFile(dict.Path, path) {
+			return true
 		}
-		if hasNumber {
-			continue
-		}
-		
+	}
+	return false
+}
+
+// sameFile reports whether two paths refer to the same file
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+// addDictionary records a loaded dictionary and its words
+func (m *Manager) addDictionary(dict Dictionary) {
+	m.loaded = append(m.loaded, dict)
+	for _, word := range dict.Words {
 		// Add word to loaded words (case-insensitive)
-		word := strings.ToLower(line)
-		m.loadedWords[word] = true
+		m.loadedWords[strings.ToLower(word)] = true
 	}
-	
-	return scanner.Err()
 }
 
 // loadDictionary loads a single dictionary file using priority order
 func (m *Manager) loadDictionary(dictPath string) error {
-	var content []byte
-	var err error
-	var source string
-	
+	path, source, err := ResolveDictionary(dictPath)
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv("MM_VERBOSE") == "1" {
+		fmt.Fprintf(os.Stderr, "Loaded dictionary %s from %s\n", dictPath, source)
+	}
+
+	var words []string
+	if source == embeddedSource {
+		words, err = readEmbeddedWords(path)
+	} else {
+		words, err = readWords(path)
+	}
+	if err != nil {
+		return err
+	}
+	m.addDictionary(Dictionary{Name: dictPath, Path: path, Source: source, Words: words})
+	return nil
+}
+
+// ResolveDictionary finds the file of a configured dictionary. Paths under
+// dictionaries/ are looked up in the user cache, among the dictionaries
+// built into mm, next to the executable and in the working directory, in
+// that order; other paths are used as is. The path of a built-in dictionary
+// is its name, with source "embedded".
+func ResolveDictionary(dictPath string) (path, source string, err error) {
+	if !strings.HasPrefix(dictPath, "dictionaries/") {
+		// Direct path
+		if _, err := os.Stat(dictPath); err == nil {
+			return dictPath, "direct path", nil
+		}
+		return "", "", fmt.Errorf("dictionary file not found: %s", dictPath)
+	}
+
 	// Priority 1: User cache directory (~/.cache/mm/dictionaries/)
-	if strings.HasPrefix(dictPath, "dictionaries/") {
-		homeDir, homeErr := os.UserHomeDir()
-		if homeErr == nil {
-			userDictPath := filepath.Join(homeDir, ".cache", "mm", dictPath)
-			if content, err = os.ReadFile(userDictPath); err == nil {
-				source = "user cache"
-				goto parseContent
-			}
+	if homeDir, homeErr := os.UserHomeDir(); homeErr == nil {
+		path := filepath.Join(homeDir, ".cache", "mm", dictPath)
+		if _, err := os.Stat(path); err == nil {
+			return path, "user cache", nil
 		}
 	}
-	
-	// Priority 2: Embedded dictionaries (built-in) - Skip for now, implement later
-	// TODO: Implement embedded dictionaries with proper go:embed
-	
+
+	// Priority 2: Embedded dictionaries (built-in)
+	if _, err := fs.Stat(embeddedDictionaries, dictPath); err == nil {
+		return dictPath, embeddedSource, nil
+	}
+
 	// Priority 3: Project-level dictionaries (./dictionaries/ for backward compatibility)
-	if strings.HasPrefix(dictPath, "dictionaries/") {
-		// Try relative to executable
-		if execPath, execErr := os.Executable(); execErr == nil {
-			execDir := filepath.Dir(execPath)
-			actualPath := filepath.Join(execDir, dictPath)
-			if content, err = os.ReadFile(actualPath); err == nil {
-				source = "executable dir"
-				goto parseContent
-			}
-		}
-		
-		// Try relative to working directory
-		if content, err = os.ReadFile(dictPath); err == nil {
-			source = "working dir"
-			goto parseContent
-		}
-	} else {
-		// Direct path
-		if content, err = os.ReadFile(dictPath); err == nil {
-			source = "direct path"
-			goto parseContent
+	type candidate struct{ path, source string }
+	var candidates []candidate
+	if execPath, execErr := os.Executable(); execErr == nil {
+		candidates = append(candidates, candidate{filepath.Join(filepath.Dir(execPath), dictPath), "executable dir"})
+	}
+	candidates = append(candidates, candidate{dictPath, "working dir"})
+
+	for _, c := range candidates {
+		if _, err := os.Stat(c.path); err == nil {
+			return c.path, c.source, nil
 		}
 	}
-	
-	return fmt.Errorf("dictionary file not found: %s (tried user cache, embedded, project dir)", dictPath)
+	return "", "", fmt.Errorf("dictionary file not found: %s (tried user cache, embedded, project dir)", dictPath)
+}
 
-parseContent:
-	if os.Getenv("MM_VERBOSE") == "1" {
-		fmt.Fprintf(os.Stderr, "Loaded dictionary %s from %s\n", dictPath, source)
+// readWords reads the valid words of a dictionary file
+func readWords(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
-	
-	// Parse dictionary content
+	return parseWords(content)
+}
+
+// readEmbeddedWords reads the valid words of a built-in dictionary
+func readEmbeddedWords(name string) ([]string, error) {
+	content, err := embeddedDictionaries.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseWords(content)
+}
+
+// parseWords returns the valid words of dictionary content, one per line
+func parseWords(content []byte) ([]string, error) {
+	var words []string
 	scanner := bufio.NewScanner(strings.NewReader(string(content)))
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		
+
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		
-		// Skip words with hyphens (aspell doesn't support them in personal dictionaries)
-		if strings.Contains(line, "-") {
-			continue
-		}
-		
-		// Skip words with underscores (aspell doesn't support them either)
-		if strings.Contains(line, "_") {
-			continue
-		}
-		
-		// Skip words with numbers (aspell doesn't support them in personal dictionaries)
-		hasNumber := false
-		for _, char := range line {
-			if char >= '0' && char <= '9' {
-				hasNumber = true
-				break
-			}
+
+		if ValidWord(line) {
+			words = append(words, line)
 		}
-		if hasNumber {
-			continue
+	}
+
+	return words, scanner.Err()
+}
+
+// ValidWord reports whether a word can be stored in a dictionary. Words may
+// only contain letters and apostrophes; aspell personal dictionaries do not
+// support hyphens, underscores or digits, and the spell checker never
+// produces words with other characters.
+func ValidWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, char := range word {
+		if !unicode.IsLetter(char) && char != '\'' {
+			return false
 		}
-		
-		// Add word to loaded words (case-insensitive)
-		word := strings.ToLower(line)
-		m.loadedWords[word] = true
 	}
-	
-	return scanner.Err()
+	return true
 }
 
-// updatePersonalDictionary creates/updates the personal dictionary file for aspell
+// updatePersonalDictionary creates/updates the personal dictionary files
+// for aspell and hunspell
 func (m *Manager) updatePersonalDictionary() error {
-	file, err := os.Create(m.personalDictPath)
+	// aspell wants a header; hunspell takes a plain word list
+	if err := m.writePersonalDictionary(m.personalDictPath, fmt.Sprintf("personal_ws-1.1 %s 0", m.lang)); err != nil {
+		return err
+	}
+	return m.writePersonalDictionary(m.hunspellDictPath, "")
+}
+
+// writePersonalDictionary writes all loaded words to path, one per line,
+// after header when there is one
+func (m *Manager) writePersonalDictionary(path, header string) error {
+	file, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("failed to create personal dictionary: %w", err)
 	}
 	defer file.Close()
-	
-	// Write personal dictionary header
-	fmt.Fprintln(file, "personal_ws-1.1 en 0")
-	
-	// Write all loaded words
+
+	if header != "" {
+		fmt.Fprintln(file, header)
+	}
 	for word := range m.loadedWords {
 		fmt.Fprintln(file, word)
 	}
-	
 	return nil
 }
 
-// GetPersonalDictPath returns the path to the personal dictionary file
+// GetPersonalDictPath returns the path to the personal dictionary file of
+// aspell
 func (m *Manager) GetPersonalDictPath() string {
 	return m.personalDictPath
 }
 
+// GetHunspellDictPath returns the path to the personal dictionary file of
+// hunspell, a plain word list
+func (m *Manager) GetHunspellDictPath() string {
+	return m.hunspellDictPath
+}
+
 // AddWord adds a word to the personal dictionary
 func (m *Manager) AddWord(word string) error {
 	word = strings.ToLower(strings.TrimSpace(word))
 	if word == "" {
 		return fmt.Errorf("empty word")
 	}
-	
+
 	m.loadedWords[word] = true
 	return m.updatePersonalDictionary()
 }
@@ -326,7 +486,44 @@ func (m *Manager) IsWordKnown(word string) bool {
 	return m.loadedWords[strings.ToLower(word)]
 }
 
+// Dictionaries returns the loaded dictionaries in load order
+func (m *Manager) Dictionaries() []Dictionary {
+	return m.loaded
+}
+
+// Which returns the loaded dictionaries that contain a word
+func (m *Manager) Which(word string) []Dictionary {
+	var found []Dictionary
+	for _, dict := range m.loaded {
+		for _, w := range dict.Words {
+			if strings.EqualFold(w, word) {
+				found = append(found, dict)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// Fingerprint returns a hash of the language and the loaded words that
+// changes whenever a dictionary does
+func (m *Manager) Fingerprint() string {
+	words := make([]string, 0, len(m.loadedWords))
+	for word := range m.loadedWords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	h := sha256.New()
+	h.Write([]byte(m.lang))
+	for _, word := range words {
+		h.Write([]byte{0})
+		h.Write([]byte(word))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // GetLoadedWordsCount returns the number of loaded words
 func (m *Manager) GetLoadedWordsCount() int {
 	return len(m.loadedWords)
-}
\ No newline at end of file
+}
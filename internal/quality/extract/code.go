@@ -0,0 +1,213 @@
+package extract
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// codeSyntax describes the comments and string literals of a language
+type codeSyntax struct {
+	// lineComments start a comment running to the end of the line
+	lineComments []string
+	// blockComments are start and end delimiters of comments
+	blockComments [][2]string
+	// quotes delimit strings with backslash escapes
+	quotes string
+	// rawQuotes delimit strings without escapes
+	rawQuotes string
+	// tripleQuotes enables Python's ''' and """ strings
+	tripleQuotes bool
+	// hashAtWordStart makes # start a comment only at the start of a word,
+	// as in shell where it may appear inside words
+	hashAtWordStart bool
+}
+
+var (
+	goSyntax = codeSyntax{
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        `"`,
+		rawQuotes:     "`",
+	}
+	jsSyntax = codeSyntax{
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        "\"'`",
+	}
+	pythonSyntax = codeSyntax{
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+		tripleQuotes: true,
+	}
+	shellSyntax = codeSyntax{
+		lineComments:    []string{"#"},
+		quotes:          `"`,
+		rawQuotes:       "'",
+		hashAtWordStart: true,
+	}
+)
+
+// codeSyntaxes maps file extensions to the syntax of their language
+var codeSyntaxes = map[string]codeSyntax{
+	".go":   goSyntax,
+	".js":   jsSyntax,
+	".jsx":  jsSyntax,
+	".mjs":  jsSyntax,
+	".ts":   jsSyntax,
+	".tsx":  jsSyntax,
+	".py":   pythonSyntax,
+	".sh":   shellSyntax,
+	".bash": shellSyntax,
+}
+
+// CodeExtensions returns the extensions of the source files Code handles
+func CodeExtensions() []string {
+	exts := make([]string, 0, len(codeSyntaxes))
+	for ext := range codeSyntaxes {
+		exts = append(exts, ext)
+	}
+	return exts
+}
+
+// IsCode reports whether a file is source code Code can extract text from
+func IsCode(filePath string) bool {
+	_, ok := codeSyntaxes[strings.ToLower(filepath.Ext(filePath))]
+	return ok
+}
+
+// Code keeps the comments and string literals of source code and blanks
+// everything else, so that lines and columns of the result match the
+// source. Strings without whitespace are blanked too: they are mostly
+// identifiers, paths and format strings rather than prose.
+func Code(content, ext string) string {
+	syntax, ok := codeSyntaxes[strings.ToLower(ext)]
+	if !ok {
+		return content
+	}
+
+	out := []byte(blank(content))
+	keep := func(start, end int) {
+		copy(out[start:end], content[start:end])
+	}
+
+	for i := 0; i < len(content); {
+		// Shebang lines are not comments
+		if i == 0 && strings.HasPrefix(content, "#!") {
+			i = lineEnd(content, i)
+			continue
+		}
+
+		if start, ok := syntax.lineCommentAt(content, i); ok {
+			end := lineEnd(content, i)
+			keep(start, end)
+			i = end
+			continue
+		}
+
+		if end, ok := syntax.blockCommentAt(content, i); ok {
+			keep(i, end)
+			i = end
+			continue
+		}
+
+		if syntax.tripleQuotes && (strings.HasPrefix(content[i:], `"""`) || strings.HasPrefix(content[i:], "'''")) {
+			delim := content[i : i+3]
+			end := strings.Index(content[i+3:], delim)
+			if end < 0 {
+				end = len(content)
+			} else {
+				end += i + 3
+			}
+			// Docstrings are prose even without whitespace
+			keep(i+3, end)
+			i = min(end+3, len(content))
+			continue
+		}
+
+		c := content[i]
+		if strings.IndexByte(syntax.quotes, c) >= 0 || strings.IndexByte(syntax.rawQuotes, c) >= 0 {
+			raw := strings.IndexByte(syntax.rawQuotes, c) >= 0
+			end := stringEnd(content, i+1, c, raw)
+			if body := content[i+1 : end]; strings.ContainsAny(body, " \t") {
+				keep(i+1, end)
+			}
+			i = min(end+1, len(content))
+			continue
+		}
+
+		i++
+	}
+
+	return string(out)
+}
+
+// lineCommentAt reports whether a line comment starts at i and where its
+// text starts
+func (s codeSyntax) lineCommentAt(content string, i int) (int, bool) {
+	for _, prefix := range s.lineComments {
+		if !strings.HasPrefix(content[i:], prefix) {
+			continue
+		}
+		if prefix == "#" && s.hashAtWordStart && i > 0 && !isSpace(content[i-1]) {
+			continue
+		}
+		return i + len(prefix), true
+	}
+	return 0, false
+}
+
+// blockCommentAt reports whether a block comment starts at i and where it ends
+func (s codeSyntax) blockCommentAt(content string, i int) (int, bool) {
+	for _, delims := range s.blockComments {
+		if !strings.HasPrefix(content[i:], delims[0]) {
+			continue
+		}
+		start := i + len(delims[0])
+		end := strings.Index(content[start:], delims[1])
+		if end < 0 {
+			return len(content), true
+		}
+		return start + end + len(delims[1]), true
+	}
+	return 0, false
+}
+
+// stringEnd returns the index of the closing quote of a string starting at
+// start, or the end of the line or content for unterminated strings
+func stringEnd(content string, start int, quote byte, raw bool) int {
+	for i := start; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '\\' && !raw:
+			i++
+		case c == quote:
+			return i
+		case c == '\n' && quote != '`' && !raw:
+			return i
+		}
+	}
+	return len(content)
+}
+
+// lineEnd returns the index of the end of the line containing i
+func lineEnd(content string, i int) int {
+	if end := strings.IndexByte(content[i:], '\n'); end >= 0 {
+		return i + end
+	}
+	return len(content)
+}
+
+// blank replaces every byte but line breaks with a space
+func blank(content string) string {
+	out := []byte(content)
+	for i, c := range out {
+		if c != '\n' {
+			out[i] = ' '
+		}
+	}
+	return string(out)
+}
+
+// isSpace reports whether c is whitespace
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,323 @@
+package extract
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDataKeys match the keys of human-readable values in data files
+var DefaultDataKeys = []string{
+	"*title",
+	"*description",
+	"*summary",
+	"*message",
+	"help",
+	"usage",
+	"text",
+	"label",
+	"caption",
+	"comment",
+	"alt",
+	"tooltip",
+}
+
+// dataExts are the extensions of the data files Data handles
+var dataExts = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+	".toml": true,
+}
+
+// DataExtensions returns the extensions of the data files Data handles
+func DataExtensions() []string {
+	exts := make([]string, 0, len(dataExts))
+	for ext := range dataExts {
+		exts = append(exts, ext)
+	}
+	return exts
+}
+
+// IsData reports whether a file is a data file Data can extract text from
+func IsData(filePath string) bool {
+	return dataExts[strings.ToLower(filepath.Ext(filePath))]
+}
+
+// Data keeps the string values of YAML, JSON and TOML files whose keys
+// match one of the glob patterns, ignoring case, and blanks everything else
+// so that lines and columns of the result match the source. Keys are never
+// kept, nor values that look like identifiers, paths or references.
+func Data(content, ext string, keys []string) (string, error) {
+	if len(keys) == 0 {
+		keys = DefaultDataKeys
+	}
+	matcher := keyMatcher(keys)
+
+	text := newBlanked(content)
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml", ".json":
+		// JSON is YAML, so both are read by the YAML parser
+		if err := extractYAML(text, matcher); err != nil {
+			return "", err
+		}
+	case ".toml":
+		extractTOML(text, matcher)
+	default:
+		return content, nil
+	}
+	return text.String(), nil
+}
+
+// keyMatcher returns a function matching keys against glob patterns
+func keyMatcher(patterns []string) func(string) bool {
+	return func(key string) bool {
+		key = strings.ToLower(key)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isProse reports whether a value reads as text rather than an identifier,
+// path, URL or reference: it has spaces or is a single plain word
+func isProse(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	if strings.ContainsAny(value, " \t\n") {
+		return !strings.HasPrefix(value, "#/") && !strings.Contains(value, "://")
+	}
+	for _, r := range value {
+		if !unicode.IsLetter(r) && r != '\'' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// blanked is source content with only the kept ranges showing through
+type blanked struct {
+	content string
+	lines   []int // byte offsets of line starts
+	out     []byte
+}
+
+// newBlanked starts with all content blanked
+func newBlanked(content string) *blanked {
+	b := &blanked{content: content, out: []byte(blank(content)), lines: []int{0}}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			b.lines = append(b.lines, i+1)
+		}
+	}
+	return b
+}
+
+// keep shows content[start:end] through
+func (b *blanked) keep(start, end int) {
+	end = min(end, len(b.content))
+	if start < end {
+		copy(b.out[start:end], b.content[start:end])
+	}
+}
+
+// offset converts a 1-based line and rune column to a byte offset
+func (b *blanked) offset(line, column int) int {
+	if line < 1 || line > len(b.lines) {
+		return len(b.content)
+	}
+	offset := b.lines[line-1]
+	for i := 1; i < column && offset < len(b.content) && b.content[offset] != '\n'; i++ {
+		_, size := utf8.DecodeRuneInString(b.content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// lineEndAt returns the offset of the end of the line containing offset
+func (b *blanked) lineEndAt(offset int) int {
+	return lineEnd(b.content, offset)
+}
+
+func (b *blanked) String() string {
+	return string(b.out)
+}
+
+// extractYAML keeps the matching values of every document of a YAML file
+func extractYAML(text *blanked, matches func(string) bool) error {
+	var docs []*yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(text.content))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+
+	// Values end where the next node starts
+	var starts []int
+	for _, doc := range docs {
+		collectStarts(doc, text, &starts)
+	}
+	for _, doc := range docs {
+		walkYAML(doc, text, matches, starts)
+	}
+	return nil
+}
+
+// collectStarts records the offsets at which nodes start
+func collectStarts(node *yaml.Node, text *blanked, starts *[]int) {
+	if node.Line > 0 {
+		*starts = append(*starts, text.offset(node.Line, node.Column))
+	}
+	for _, child := range node.Content {
+		collectStarts(child, text, starts)
+	}
+}
+
+// walkYAML keeps the scalars found under matching keys
+func walkYAML(node *yaml.Node, text *blanked, matches func(string) bool, starts []int) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if matches(key.Value) {
+				keepYAMLValue(value, text, starts)
+				continue
+			}
+			walkYAML(value, text, matches, starts)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		walkYAML(child, text, matches, starts)
+	}
+}
+
+// keepYAMLValue keeps a string scalar, or the string scalars of a sequence
+func keepYAMLValue(node *yaml.Node, text *blanked, starts []int) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			keepYAMLValue(item, text, starts)
+		}
+		return
+	case yaml.ScalarNode:
+	default:
+		return
+	}
+
+	if node.Tag != "!!str" || !isProse(node.Value) {
+		return
+	}
+
+	start := text.offset(node.Line, node.Column)
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// The text of block scalars starts on the next line
+		text.keep(text.lineEndAt(start), nextStart(starts, start, len(text.content)))
+	case node.Style == 0 && strings.HasPrefix(text.content[start:text.lineEndAt(start)], node.Value):
+		text.keep(start, start+len(node.Value))
+	case node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0:
+		quote := text.content[start]
+		end := stringEnd(text.content, start+1, quote, quote == '\'')
+		if end < len(text.content) && text.content[end] == '\n' {
+			// Quoted scalars may run over several lines
+			end = nextStart(starts, start, len(text.content))
+		}
+		text.keep(start+1, end)
+	default:
+		text.keep(start, nextStart(starts, start, len(text.content)))
+	}
+}
+
+// nextStart returns the first node start after offset, or end
+func nextStart(starts []int, offset, end int) int {
+	next := end
+	for _, start := range starts {
+		if start > offset && start < next {
+			next = start
+		}
+	}
+	return next
+}
+
+// extractTOML keeps the strings assigned to matching keys of a TOML file
+func extractTOML(text *blanked, matches func(string) bool) {
+	content := text.content
+	for i := 0; i < len(content); {
+		end := lineEnd(content, i)
+		line := content[i:end]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "[") {
+			i = end + 1
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			i = end + 1
+			continue
+		}
+		if !matches(tomlKey(line[:eq])) {
+			i = end + 1
+			continue
+		}
+
+		// Keep every string on the line, following multi-line strings
+		pos := i + eq + 1
+		for pos < len(content) && content[pos] != '\n' {
+			c := content[pos]
+			switch {
+			case c == '#':
+				pos = lineEnd(content, pos)
+			case strings.HasPrefix(content[pos:], `"""`) || strings.HasPrefix(content[pos:], "'''"):
+				delim := content[pos : pos+3]
+				stop := len(content)
+				if n := strings.Index(content[pos+3:], delim); n >= 0 {
+					stop = pos + 3 + n
+				}
+				if isProse(content[pos+3 : stop]) {
+					text.keep(pos+3, stop)
+				}
+				pos = min(stop+3, len(content))
+				end = lineEnd(content, pos)
+			case c == '"' || c == '\'':
+				stop := stringEnd(content, pos+1, c, c == '\'')
+				if isProse(content[pos+1 : stop]) {
+					text.keep(pos+1, stop)
+				}
+				pos = min(stop+1, len(content))
+			default:
+				pos++
+			}
+		}
+		i = end + 1
+	}
+}
+
+// tomlKey returns the last part of a dotted, possibly quoted TOML key
+func tomlKey(key string) string {
+	key = strings.TrimSpace(key)
+	if dot := strings.LastIndexByte(key, '.'); dot >= 0 && !strings.ContainsAny(key[dot:], `"'`) {
+		key = key[dot+1:]
+	}
+	return strings.Trim(strings.TrimSpace(key), `"'`)
+}
@@ -0,0 +1,78 @@
+// Package extract finds the prose in documents, source and data files for
+// the quality checkers. Extractors blank what is not prose instead of
+// removing it, so lines and columns found in their output are those of the
+// original file.
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Options tune the extraction of prose
+type Options struct {
+	// DataKeys are glob patterns of the keys whose values are prose in data
+	// files and front matter (default DefaultDataKeys)
+	DataKeys []string
+	// NotebookCode also extracts comments and strings of notebook code cells
+	NotebookCode bool
+	// Markup is the framework syntax blanked in documents
+	Markup Markup
+}
+
+// Prose extracts the prose of a file by its extension. Content of unknown
+// types is returned as is.
+func Prose(content, ext string, opts Options) (string, error) {
+	switch ext = strings.ToLower(ext); ext {
+	case ".md", ".markdown", ".mdx", ".rst":
+		content = blankMarkup(content, opts.Markup)
+	}
+
+	switch ext {
+	case ".md", ".markdown":
+		return Markdown(content, opts.DataKeys), nil
+	case ".mdx":
+		return MDX(content, opts.DataKeys), nil
+	case ".rst":
+		return RST(content), nil
+	case ".html", ".htm":
+		return HTML(content), nil
+	case ".ipynb":
+		return Notebook(content, opts.NotebookCode)
+	}
+
+	switch {
+	case IsCode(ext):
+		// Source code is checked in its comments and string literals
+		return Code(content, ext), nil
+	case IsData(ext):
+		// Data files are checked in the values of text keys
+		return Data(content, ext, opts.DataKeys)
+	}
+	return content, nil
+}
+
+var (
+	rstStrongPattern   = regexp.MustCompile(`\*\*[^*]+\*\*`)
+	rstEmphasisPattern = regexp.MustCompile(`\*[^*]+\*`)
+	rstLiteralPattern  = regexp.MustCompile("``[^`]+``")
+)
+
+// RST extracts the text of reStructuredText, blanking directives, inline
+// literals and emphasized text
+func RST(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		// Skip directive lines
+		if strings.HasPrefix(strings.TrimSpace(line), ".. ") {
+			lines[i] = blank(line)
+			continue
+		}
+
+		// Remove inline markup
+		line = rstStrongPattern.ReplaceAllStringFunc(line, blank)
+		line = rstEmphasisPattern.ReplaceAllStringFunc(line, blank)
+		lines[i] = rstLiteralPattern.ReplaceAllStringFunc(line, blank)
+	}
+	return strings.Join(lines, "\n")
+}
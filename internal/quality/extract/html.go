@@ -0,0 +1,98 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// skippedElements hold code or no text to check
+var skippedElements = map[string]bool{
+	"script": true,
+	"style":  true,
+	"code":   true,
+	"pre":    true,
+	"kbd":    true,
+	"samp":   true,
+}
+
+// textAttributePattern matches attributes holding text shown to readers
+var textAttributePattern = regexp.MustCompile(`(?i)\s(?:alt|title|aria-label|placeholder)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// entityPattern matches character references
+var entityPattern = regexp.MustCompile(`&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+
+// HTML keeps the text of an HTML document and of its alt, title,
+// aria-label and placeholder attributes, and blanks everything else so that
+// lines and columns of the result match the source. Scripts, styles and
+// code are skipped. Character references are decoded in place: a letter is
+// written where its reference starts, anything else is blanked.
+func HTML(content string) string {
+	text := newBlanked(content)
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+
+	offset := 0
+	skip := 0
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		start := offset
+		raw := string(tokenizer.Raw())
+		offset += len(raw)
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] {
+				if tokenType == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip == 0 {
+				for _, match := range textAttributePattern.FindAllStringSubmatchIndex(raw, -1) {
+					valueStart, valueEnd := match[2], match[3]
+					if valueStart < 0 {
+						valueStart, valueEnd = match[4], match[5]
+					}
+					text.keepDecoded(start+valueStart, start+valueEnd)
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] && skip > 0 {
+				skip--
+			}
+		case html.TextToken:
+			if skip == 0 {
+				text.keepDecoded(start, offset)
+			}
+		}
+	}
+
+	return text.String()
+}
+
+// keepDecoded shows content[start:end] through with its character
+// references decoded in place
+func (b *blanked) keepDecoded(start, end int) {
+	b.keep(start, end)
+
+	for _, match := range entityPattern.FindAllStringIndex(b.content[start:end], -1) {
+		refStart, refEnd := start+match[0], start+match[1]
+		for i := refStart; i < refEnd; i++ {
+			b.out[i] = ' '
+		}
+
+		decoded := html.UnescapeString(b.content[refStart:refEnd])
+		r, size := utf8.DecodeRuneInString(decoded)
+		if size == len(decoded) && unicode.IsLetter(r) && size <= refEnd-refStart {
+			copy(b.out[refStart:], decoded)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package extract
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// markdownParser parses CommonMark with the GitHub extensions, so that
+// tables and bare URLs are recognized
+var markdownParser = goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
+// Markdown keeps the prose of a Markdown document and blanks code, HTML,
+// link destinations and markup, so that lines and columns of the result
+// match the source. Front matter is kept in the values of the keys matching
+// frontMatterKeys, as in Data.
+func Markdown(content string, frontMatterKeys []string) string {
+	return markdownText(content, content, frontMatterKeys, true)
+}
+
+// markdownText extracts the prose of source, a copy of content with parts
+// blanked, and the front matter of content. Without indentedCode, indented
+// lines are prose rather than code blocks, as in MDX.
+func markdownText(content, source string, frontMatterKeys []string, indentedCode bool) string {
+	matter, end, ext := frontMatter(content)
+	if end > 0 {
+		source = blank(source[:end]) + source[end:]
+	}
+
+	result := newBlanked(source)
+	if end > 0 {
+		if values, err := Data(matter, ext, frontMatterKeys); err == nil {
+			copy(result.out, values)
+		}
+	}
+
+	keepText := func(start, stop int) {
+		result.keep(start, stop)
+		// An underscore separates the words of snake_case names
+		for i := start; i < stop; i++ {
+			if result.out[i] == '_' {
+				result.out[i] = ' '
+			}
+		}
+	}
+
+	doc := markdownParser.Parse(text.NewReader([]byte(source)))
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := node.(type) {
+		case *ast.CodeBlock:
+			if !indentedCode {
+				lines := node.Lines()
+				for i := 0; i < lines.Len(); i++ {
+					line := lines.At(i)
+					keepText(line.Start, line.Stop)
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.FencedCodeBlock, *ast.CodeSpan, *ast.HTMLBlock, *ast.RawHTML, *ast.AutoLink:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			keepText(node.Segment.Start, node.Segment.Stop)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return result.String()
+}
+
+// frontMatter finds YAML (---) or TOML (+++) front matter at the start of
+// a document. It returns the front matter with its delimiters blanked, the
+// offset where the body starts and the extension of the front matter
+// format, or an end of 0 when there is none.
+func frontMatter(content string) (string, int, string) {
+	firstEnd := lineEnd(content, 0)
+	delimiter := strings.TrimRight(content[:firstEnd], "\r")
+	ext := ""
+	switch delimiter {
+	case "---":
+		ext = ".yaml"
+	case "+++":
+		ext = ".toml"
+	default:
+		return "", 0, ""
+	}
+
+	for start := firstEnd + 1; start < len(content); {
+		end := lineEnd(content, start)
+		if strings.TrimRight(content[start:end], "\r") == delimiter {
+			matter := blank(content[:firstEnd]) + content[firstEnd:start] + blank(content[start:end])
+			return matter, end, ext
+		}
+		start = end + 1
+	}
+	return "", 0, ""
+}
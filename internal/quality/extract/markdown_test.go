@@ -0,0 +1,50 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"prose", "Some text\n", "Some text\n"},
+		{"fenced code", "Text\n\n```\nkubectl get podz\n```\n", "Text\n\n   \n                \n   \n"},
+		{"indented code", "Text\n\n    kubectl get podz --namespaec foo\n", "Text\n\n                                    \n"},
+		{"list continuation", "- item\n\n    more text\n", "  item\n\n    more text\n"},
+		{"code span", "Run `kubectl` now\n", "Run           now\n"},
+		{"link destination", "[the docs](https://example.com/x)\n", " the docs                        \n"},
+		{"snake case", "max_surge\n", "max surge\n"},
+		{"front matter", "---\ntitle: Hello\nweight: 10\n---\nBody\n", "   \n       Hello\n          \n   \nBody\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Markdown(tt.content, []string{"title"})
+			if got != tt.want {
+				t.Errorf("Markdown() = %q, want %q", got, tt.want)
+			}
+			if len(got) != len(tt.content) {
+				t.Errorf("Markdown() changed the length from %d to %d", len(tt.content), len(got))
+			}
+		})
+	}
+}
+
+func TestMDX(t *testing.T) {
+	content := "import Tabs from '@theme/Tabs';\n\n<Tabs groupId=\"os\">\n  Install the {props.name} tool\n</Tabs>\n"
+	got := MDX(content, nil)
+	for _, hidden := range []string{"import", "Tabs", "groupId", "props"} {
+		if strings.Contains(got, hidden) {
+			t.Errorf("MDX() kept %q: %q", hidden, got)
+		}
+	}
+	if !strings.Contains(got, "  Install the ") || !strings.Contains(got, " tool") {
+		t.Errorf("MDX() dropped the indented JSX text: %q", got)
+	}
+	if len(got) != len(content) {
+		t.Errorf("MDX() changed the length from %d to %d", len(content), len(got))
+	}
+}
@@ -0,0 +1,63 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Markup names the syntax extensions of documentation frameworks that are
+// blanked before extraction, as set by project adapters
+type Markup struct {
+	// Shortcodes are Hugo shortcode tags, {{< name param >}} and
+	// {{% name %}}; the content between paired tags is kept
+	Shortcodes bool
+	// Admonitions are MkDocs admonition markers, !!! note and ??? tip;
+	// their quoted titles are kept
+	Admonitions bool
+	// Containers are Docusaurus and VitePress container fences, :::tip;
+	// their titles are kept
+	Containers bool
+	// Roles are Sphinx and MyST roles, :ref:`title <target>` and
+	// {doc}`target`, and reStructuredText directive options; explicit titles
+	// are kept
+	Roles bool
+}
+
+var (
+	shortcodeTagPattern  = regexp.MustCompile(`(?s)\{\{[<%].*?[%>]\}\}`)
+	admonitionPattern    = regexp.MustCompile(`(?m)^[ \t]*(?:!!!|\?\?\?\+?)[ \t]+[\w-]+(?:[ \t]+[\w-]+)*`)
+	containerPattern     = regexp.MustCompile(`(?m)^[ \t]*:{3,}[ \t]*[\w-]*`)
+	rolePattern          = regexp.MustCompile("(?::[\\w:+.-]+:|\\{[\\w:+.-]+\\})`([^`]*)`")
+	roleTargetPattern    = regexp.MustCompile(`<[^<>]*>\s*$`)
+	directiveOptionsLine = regexp.MustCompile(`(?m)^[ \t]+:[\w-]+:.*$`)
+)
+
+// blankMarkup blanks the framework syntax of content enabled in markup
+func blankMarkup(content string, markup Markup) string {
+	if markup.Shortcodes {
+		content = shortcodeTagPattern.ReplaceAllStringFunc(content, blank)
+	}
+	if markup.Admonitions {
+		content = admonitionPattern.ReplaceAllStringFunc(content, blank)
+	}
+	if markup.Containers {
+		content = containerPattern.ReplaceAllStringFunc(content, blank)
+	}
+	if markup.Roles {
+		content = directiveOptionsLine.ReplaceAllStringFunc(content, blank)
+		content = rolePattern.ReplaceAllStringFunc(content, blankRole)
+	}
+	return content
+}
+
+// blankRole blanks a role but for an explicit title, the text before a
+// <target>. Without one the role text is a target, not prose.
+func blankRole(role string) string {
+	open := strings.IndexByte(role, '`')
+	text := role[open+1 : len(role)-1]
+	target := roleTargetPattern.FindStringIndex(text)
+	if target == nil {
+		return blank(role)
+	}
+	return blank(role[:open+1]) + text[:target[0]] + blank(text[target[0]:]) + " "
+}
@@ -0,0 +1,151 @@
+package extract
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// IsMDX reports whether a file is an MDX document
+func IsMDX(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".mdx")
+}
+
+// MDX keeps the prose of an MDX document, as used by Docusaurus: the
+// Markdown text and the text children of JSX elements. Import and export
+// statements, JSX tags with their props and {expressions} are blanked.
+func MDX(content string, frontMatterKeys []string) string {
+	source := []byte(content)
+	blankRange := func(start, end int) {
+		for i := start; i < end && i < len(source); i++ {
+			if source[i] != '\n' {
+				source[i] = ' '
+			}
+		}
+	}
+
+	start := 0
+	if _, end, _ := frontMatter(content); end > 0 {
+		start = end
+	}
+
+	fence := ""
+	lineStart := true
+	for i := start; i < len(content); {
+		if lineStart {
+			end := lineEnd(content, i)
+			line := content[i:end]
+			trimmed := strings.TrimLeft(line, " \t")
+
+			// Code blocks are left to the Markdown parser
+			if fence != "" {
+				if strings.HasPrefix(trimmed, fence) {
+					fence = ""
+				}
+				i = end + 1
+				continue
+			}
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fence = trimmed[:3]
+				i = end + 1
+				continue
+			}
+
+			// ESM statements run to the next blank line
+			if strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "export ") {
+				stop := paragraphEnd(content, i)
+				blankRange(i, stop)
+				i = stop
+				continue
+			}
+			lineStart = false
+		}
+
+		switch c := content[i]; {
+		case c == '\n':
+			lineStart = true
+			i++
+		case c == '`':
+			i = codeSpanEnd(content, i)
+		case c == '{':
+			end := braceEnd(content, i)
+			blankRange(i, end)
+			i = end
+		case c == '<' && i+1 < len(content) && isTagStart(content[i+1]):
+			end := tagEnd(content, i)
+			blankRange(i, end)
+			i = end
+		default:
+			i++
+		}
+	}
+
+	return markdownText(content, string(source), frontMatterKeys, false)
+}
+
+// isTagStart reports whether c may follow < in a JSX tag
+func isTagStart(c byte) bool {
+	return c == '/' || c == '>' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// paragraphEnd returns the offset of the blank line ending the paragraph
+// starting at i, or the end of content
+func paragraphEnd(content string, i int) int {
+	for i < len(content) {
+		end := lineEnd(content, i)
+		if strings.TrimSpace(content[i:end]) == "" {
+			return i
+		}
+		i = end + 1
+	}
+	return len(content)
+}
+
+// codeSpanEnd returns the offset after the inline code span starting at i,
+// or after its opening backticks when it is not closed
+func codeSpanEnd(content string, i int) int {
+	n := 0
+	for i+n < len(content) && content[i+n] == '`' {
+		n++
+	}
+	delimiter := content[i : i+n]
+	if end := strings.Index(content[i+n:], delimiter); end >= 0 {
+		return i + n + end + n
+	}
+	return i + n
+}
+
+// braceEnd returns the offset after the } closing the expression starting
+// at i, skipping strings and nested braces
+func braceEnd(content string, i int) int {
+	depth := 0
+	for j := i; j < len(content); j++ {
+		switch c := content[j]; c {
+		case '"', '\'', '`':
+			j = stringEnd(content, j+1, c, false)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(content)
+}
+
+// tagEnd returns the offset after the > closing the JSX tag starting at i,
+// skipping quoted props and {expressions}
+func tagEnd(content string, i int) int {
+	for j := i + 1; j < len(content); j++ {
+		switch c := content[j]; c {
+		case '"', '\'':
+			j = stringEnd(content, j+1, c, false)
+		case '{':
+			j = braceEnd(content, j) - 1
+		case '>':
+			return j + 1
+		}
+	}
+	return len(content)
+}
@@ -0,0 +1,155 @@
+package extract
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsNotebook reports whether a file is a Jupyter notebook
+func IsNotebook(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".ipynb")
+}
+
+// Notebook keeps the text of the markdown cells of a Jupyter notebook, and
+// with codeComments the comments and strings of its code cells. The text
+// of every source line is placed on the line of the notebook JSON it comes
+// from.
+func Notebook(content string, codeComments bool) (string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return "", fmt.Errorf("failed to parse notebook: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return "", nil
+	}
+	notebook := root.Content[0]
+
+	codeExt := "." + notebookLanguage(notebook)
+	if ext := mappingValue(mappingValue(mappingValue(notebook, "metadata"), "language_info"), "file_extension"); ext != nil {
+		codeExt = ext.Value
+	}
+
+	lines := strings.Split(blank(content), "\n")
+	cells := mappingValue(notebook, "cells")
+	if cells == nil {
+		return strings.Join(lines, "\n"), nil
+	}
+
+	for _, cell := range cells.Content {
+		cellType := mappingValue(cell, "cell_type")
+		source := mappingValue(cell, "source")
+		if cellType == nil || source == nil {
+			continue
+		}
+
+		var text string
+		switch {
+		case cellType.Value == "markdown":
+			text = Markdown(joinSource(source), nil)
+		case cellType.Value == "code" && codeComments && IsCode(codeExt):
+			text = Code(joinSource(source), codeExt)
+		default:
+			continue
+		}
+
+		placeCellText(lines, source, text)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// notebookLanguage returns the kernel language of a notebook, python by
+// default
+func notebookLanguage(notebook *yaml.Node) string {
+	metadata := mappingValue(notebook, "metadata")
+	for _, key := range []string{"language_info", "kernelspec"} {
+		if info := mappingValue(metadata, key); info != nil {
+			for _, field := range []string{"name", "language"} {
+				if language := mappingValue(info, field); language != nil && language.Value != "" {
+					switch strings.ToLower(language.Value) {
+					case "python", "python3":
+						return "py"
+					case "javascript":
+						return "js"
+					case "typescript":
+						return "ts"
+					case "bash", "sh":
+						return "sh"
+					case "go":
+						return "go"
+					}
+				}
+			}
+		}
+	}
+	return "py"
+}
+
+// joinSource returns the source of a cell, a string or a list of lines
+func joinSource(source *yaml.Node) string {
+	if source.Kind == yaml.ScalarNode {
+		return source.Value
+	}
+	var text strings.Builder
+	for _, line := range source.Content {
+		text.WriteString(line.Value)
+	}
+	return text.String()
+}
+
+// placeCellText writes the lines of text extracted from a cell on the
+// notebook lines holding the source they come from
+func placeCellText(lines []string, source *yaml.Node, text string) {
+	parts := source.Content
+	if source.Kind == yaml.ScalarNode {
+		parts = []*yaml.Node{source}
+	}
+
+	textLines := strings.Split(text, "\n")
+	next := 0
+	for _, part := range parts {
+		// A part normally holds one source line, but may hold several
+		count := strings.Count(part.Value, "\n")
+		if !strings.HasSuffix(part.Value, "\n") {
+			count++
+		}
+
+		var placed []string
+		for i := 0; i < count && next < len(textLines); i++ {
+			placed = append(placed, textLines[next])
+			next++
+		}
+		// Columns are 1-based, so the text starts at the column just
+		// inside the opening quote
+		overlay(lines, part.Line-1, part.Column, strings.Join(placed, " "))
+	}
+}
+
+// overlay writes text into a line starting at a 0-based rune column
+func overlay(lines []string, line, column int, text string) {
+	if line < 0 || line >= len(lines) || strings.TrimSpace(text) == "" {
+		return
+	}
+	runes := []rune(lines[line])
+	for len(runes) < column+len([]rune(text)) {
+		runes = append(runes, ' ')
+	}
+	copy(runes[column:], []rune(text))
+	lines[line] = string(runes)
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samzong/mm/internal/quality/checker"
+)
+
+// Edit replaces the word an issue points at with a new text
+type Edit struct {
+	Issue       checker.Issue
+	Replacement string
+}
+
+// FileResult describes the outcome of applying edits to one file
+type FileResult struct {
+	File    string
+	Applied []Edit
+	Skipped []Edit // stale positions or overlapping edits
+}
+
+// HighConfidence returns the replacement for an issue when it is safe to
+// apply without asking: the issue has a single suggestion, or its top
+// suggestion is one edit away while the runner-up is not
+func HighConfidence(issue checker.Issue) (string, bool) {
+	if issue.Word == "" || len(issue.Suggestions) == 0 {
+		return "", false
+	}
+
+	top := issue.Suggestions[0]
+	if len(issue.Suggestions) == 1 && strings.EqualFold(issue.Word, top) {
+		// A casing fix keeps the casing of the suggestion
+		return top, true
+	}
+	if len(issue.Suggestions) == 1 {
+		return MatchCase(issue.Word, top), true
+	}
+
+	word := strings.ToLower(issue.Word)
+	if checker.EditDistance(word, strings.ToLower(top)) != 1 {
+		return "", false
+	}
+	if checker.EditDistance(word, strings.ToLower(issue.Suggestions[1])) <= 1 {
+		return "", false
+	}
+	return MatchCase(issue.Word, top), true
+}
+
+// MatchCase adjusts a lowercase suggestion to the capitalization of the
+// original word, e.g. "Kuberntes" → "Kubernetes"
+func MatchCase(original, suggestion string) string {
+	if suggestion != strings.ToLower(suggestion) {
+		return suggestion
+	}
+	if original == strings.ToUpper(original) && utf8.RuneCountInString(original) > 1 {
+		return strings.ToUpper(suggestion)
+	}
+	if first, _ := utf8.DecodeRuneInString(original); unicode.IsUpper(first) {
+		s, n := utf8.DecodeRuneInString(suggestion)
+		return string(unicode.ToUpper(s)) + suggestion[n:]
+	}
+	return suggestion
+}
+
+// Apply rewrites a file with the given edits. Edits are checked against the
+// current content so stale or overlapping ones are skipped instead of
+// corrupting the file. The file is replaced atomically; with backup set the
+// original is kept as <file>.backup.
+func Apply(filePath string, edits []Edit, backup bool) (*FileResult, error) {
+	result := &FileResult{File: filePath}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+
+	// Apply from the end of the file so earlier positions stay valid
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Issue.Line != edits[j].Issue.Line {
+			return edits[i].Issue.Line > edits[j].Issue.Line
+		}
+		return edits[i].Issue.Column > edits[j].Issue.Column
+	})
+
+	lastLine, lastStart := 0, 0
+	for _, edit := range edits {
+		issue := edit.Issue
+		if issue.Line < 1 || issue.Line > len(lines) || issue.Column < 1 {
+			result.Skipped = append(result.Skipped, edit)
+			continue
+		}
+
+		line := lines[issue.Line-1]
+		start := issue.Column - 1
+		end := start + len(issue.Word)
+		if end > len(line) || line[start:end] != issue.Word {
+			result.Skipped = append(result.Skipped, edit)
+			continue
+		}
+		if issue.Line == lastLine && end > lastStart {
+			result.Skipped = append(result.Skipped, edit)
+			continue
+		}
+
+		lines[issue.Line-1] = line[:start] + edit.Replacement + line[end:]
+		lastLine, lastStart = issue.Line, start
+		result.Applied = append(result.Applied, edit)
+	}
+
+	if len(result.Applied) == 0 {
+		return result, nil
+	}
+
+	if backup {
+		if err := os.WriteFile(filePath+".backup", content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	if err := writeAtomic(filePath, []byte(strings.Join(lines, ""))); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeAtomic writes data to a temporary file next to path and renames it
+// over path, preserving the file mode
+func writeAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".mm-fix-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package locale
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/samzong/mm/internal/quality/zhconv"
+)
+
+// Default is the language of documents whose language can't be detected
+const Default = "en"
+
+// known are the locales docs sites translate into. Two-letter codes are
+// only taken from a path when they name a locale directory or file suffix,
+// since many directory names (go, it, ...) are also language codes.
+var known = map[string]bool{
+	"en": true, "en-us": true, "en-gb": true,
+	"zh": true, "zh-cn": true, "zh-hans": true, "zh-tw": true, "zh-hk": true, "zh-mo": true, "zh-hant": true,
+	"ja": true, "ko": true,
+	"fr": true, "de": true, "es": true, "it": true, "pt": true, "pt-br": true, "pt-pt": true,
+	"ru": true, "uk": true, "pl": true, "cs": true, "nl": true, "sv": true, "no": true, "da": true, "fi": true,
+	"tr": true, "ar": true, "fa": true, "he": true, "hi": true, "bn": true, "id": true, "ms": true,
+	"vi": true, "th": true,
+}
+
+// localeParents are the directories whose children are locale directories,
+// as in Hugo (content/zh-cn), Docusaurus (i18n/zh-cn) and MkDocs (docs/zh)
+var localeParents = map[string]bool{
+	"content": true, "i18n": true, "docs": true, "locales": true, "locale": true,
+	"translations": true, "l10n": true, "lang": true,
+}
+
+// traditionalLocales are written in traditional Chinese
+var traditionalLocales = map[string]bool{
+	"zh-tw": true, "zh-hk": true, "zh-mo": true, "zh-hant": true,
+}
+
+// Detect returns the locale of a document, such as en, zh-cn or pt-br,
+// from its path and otherwise its content. It returns "" when neither
+// tells.
+func Detect(path string, content []byte) string {
+	if locale := FromPath(path); locale != "" {
+		return locale
+	}
+	return FromContent(string(content))
+}
+
+// FromPath returns the locale named by a path: a locale directory such as
+// content/zh-cn/ or i18n/ja/, a regional locale anywhere (zh-cn, pt-br) or
+// a file suffix such as _index.zh-cn.md or README.ja.md
+func FromPath(path string) string {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i, part := range parts[:len(parts)-1] {
+		locale := normalize(part)
+		if !known[locale] {
+			continue
+		}
+		if strings.Contains(locale, "-") || (i > 0 && localeParents[strings.ToLower(parts[i-1])]) {
+			return locale
+		}
+	}
+
+	name := parts[len(parts)-1]
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		if locale := normalize(name[dot+1:]); known[locale] {
+			return locale
+		}
+	}
+	return ""
+}
+
+// FromContent guesses the locale of text by its script: Chinese, Japanese
+// or Korean when a fair share of it is CJK, and "" otherwise, as
+// Latin-script languages can't be told apart this way
+func FromContent(text string) string {
+	var letters, han, traditional, kana, hangul int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+			if zhconv.IsTraditional(r) {
+				traditional++
+			}
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.IsLetter(r):
+			letters++
+		}
+	}
+
+	// A CJK character carries about as much as a Latin word of five
+	// letters, so English terms in Chinese text don't outweigh it
+	cjk := han + kana + hangul
+	if cjk == 0 || cjk*5 < letters {
+		return ""
+	}
+	switch {
+	case kana > 0 && kana*10 >= han:
+		return "ja"
+	case hangul > han:
+		return "ko"
+	case traditional*10 > han:
+		return "zh-tw"
+	default:
+		return "zh-cn"
+	}
+}
+
+// IsCJK reports whether a locale is Chinese, Japanese or Korean
+func IsCJK(locale string) bool {
+	base := Base(locale)
+	return base == "zh" || base == "ja" || base == "ko"
+}
+
+// IsChinese reports whether a locale is a Chinese one
+func IsChinese(locale string) bool {
+	return Base(locale) == "zh"
+}
+
+// IsTraditional reports whether a locale is written in traditional Chinese
+func IsTraditional(locale string) bool {
+	return traditionalLocales[locale]
+}
+
+// Base returns the language of a locale, e.g. pt for pt-br
+func Base(locale string) string {
+	base, _, _ := strings.Cut(normalize(locale), "-")
+	return base
+}
+
+// normalize lowercases a locale and uses - as separator
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
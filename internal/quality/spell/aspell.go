@@ -0,0 +1,36 @@
+package spell
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// AspellEngine checks spelling through a long-lived aspell pipe process
+type AspellEngine struct {
+	*pipeSession
+}
+
+// NewAspellEngine creates an engine backed by aspell
+func NewAspellEngine(lang, personalDict string) (*AspellEngine, error) {
+	if _, err := exec.LookPath("aspell"); err != nil {
+		return nil, fmt.Errorf("aspell not found in PATH. Please install aspell or use the builtin engine")
+	}
+
+	args := []string{
+		"--mode=none",
+		"--encoding=utf-8",
+		"--lang=" + lang,
+	}
+
+	// Add custom dictionaries if available
+	if personalDict != "" {
+		args = append(args, "--personal="+personalDict)
+	}
+
+	return &AspellEngine{newPipeSession("aspell", append(args, "pipe")...)}, nil
+}
+
+// Name returns the engine name
+func (a *AspellEngine) Name() string {
+	return EngineAspell
+}
@@ -0,0 +1,376 @@
+package spell
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed words/*.txt
+var wordFiles embed.FS
+
+// suffixRules strip common English suffixes. Each rule maps a suffix to the
+// endings that may replace it on the stem ("" keeps the bare stem).
+var suffixRules = []struct {
+	suffix  string
+	endings []string
+}{
+	{"iness", []string{"y"}},
+	{"ness", []string{""}},
+	{"ments", []string{""}},
+	{"ment", []string{""}},
+	{"ably", []string{"able", "e", ""}},
+	{"able", []string{"", "e"}},
+	{"less", []string{""}},
+	{"ful", []string{""}},
+	{"ily", []string{"y"}},
+	{"ly", []string{"", "le"}},
+	{"ies", []string{"y"}},
+	{"ied", []string{"y"}},
+	{"ier", []string{"y"}},
+	{"iest", []string{"y"}},
+	{"ing", []string{"", "e"}},
+	{"ers", []string{"", "e"}},
+	{"est", []string{"", "e"}},
+	{"ed", []string{"", "e"}},
+	{"er", []string{"", "e"}},
+	{"es", []string{""}},
+	{"s", []string{""}},
+}
+
+// prefixes are stripped before looking a word up
+var prefixes = []string{"un", "re", "pre", "non", "de", "dis", "mis", "over", "sub", "multi", "auto", "inter", "co"}
+
+// BuiltinEngine is a pure-Go spell engine backed by an embedded word list
+type BuiltinEngine struct {
+	words        map[string]int // word to frequency rank
+	misspellings map[string]string
+
+	// ranked and deletes index the words for suggestions, built on the
+	// first Suggest
+	indexOnce sync.Once
+	ranked    []string
+	deletes   []deleteEntry
+
+	suggestMu   sync.Mutex
+	suggestions map[string][]string
+}
+
+var (
+	builtinMu      sync.Mutex
+	builtinEngines = make(map[string]*BuiltinEngine)
+)
+
+// NewBuiltinEngine returns the built-in engine for a language. Word lists are
+// loaded once and shared; regional variants use the base language list.
+func NewBuiltinEngine(lang string) (*BuiltinEngine, error) {
+	lang = baseLang(NormalizeLang(lang))
+
+	builtinMu.Lock()
+	defer builtinMu.Unlock()
+
+	if engine, ok := builtinEngines[lang]; ok {
+		return engine, nil
+	}
+	if _, err := fs.Stat(wordFiles, "words/"+lang+".txt"); err != nil {
+		return nil, fmt.Errorf("the builtin engine has no word list for language %s; use --engine=aspell or --engine=hunspell", lang)
+	}
+
+	engine, err := loadBuiltinEngine(lang)
+	if err != nil {
+		return nil, err
+	}
+	builtinEngines[lang] = engine
+	return engine, nil
+}
+
+// loadBuiltinEngine reads the embedded word lists for a language
+func loadBuiltinEngine(lang string) (*BuiltinEngine, error) {
+	engine := &BuiltinEngine{
+		words:        make(map[string]int),
+		misspellings: make(map[string]string),
+		suggestions:  make(map[string][]string),
+	}
+
+	rank := 0
+	err := readWordFile(wordFiles, "words/"+lang+".txt", func(line string) {
+		if _, ok := engine.words[line]; !ok {
+			engine.words[line] = rank
+			rank++
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load built-in %s word list: %w", lang, err)
+	}
+
+	err = readWordFile(wordFiles, "words/"+lang+"-misspellings.txt", func(line string) {
+		if wrong, right, ok := strings.Cut(line, " "); ok {
+			engine.misspellings[wrong] = strings.TrimSpace(right)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load built-in %s misspellings: %w", lang, err)
+	}
+
+	return engine, nil
+}
+
+// readWordFile calls fn for every non-empty, non-comment line of a file
+func readWordFile(fsys fs.FS, name string, fn func(line string)) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fn(line)
+	}
+	return scanner.Err()
+}
+
+// Name returns the engine name
+func (b *BuiltinEngine) Name() string {
+	return EngineBuiltin
+}
+
+// Misspelled returns the words of text that are not in the word list
+func (b *BuiltinEngine) Misspelled(text string) ([]string, error) {
+	var misspelled []string
+	for _, word := range uniqueWords(text) {
+		if !b.Known(word) {
+			misspelled = append(misspelled, word)
+		}
+	}
+	return misspelled, nil
+}
+
+// Known checks a word against the word list, allowing possessives and
+// common prefixes and suffixes of known words. Listed misspellings are never
+// known, even when they look like a known word with a suffix ("occured").
+func (b *BuiltinEngine) Known(word string) bool {
+	word = strings.ToLower(word)
+	word = strings.TrimSuffix(word, "'s")
+	word = strings.TrimSuffix(word, "'")
+	if _, ok := b.misspellings[word]; ok {
+		return false
+	}
+
+	if b.knownWithSuffix(word) {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if rest := strings.TrimPrefix(word, prefix); rest != word && len(rest) >= 3 && b.knownWithSuffix(rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownWithSuffix checks a word or a stem obtained by removing one suffix
+func (b *BuiltinEngine) knownWithSuffix(word string) bool {
+	if _, ok := b.words[word]; ok {
+		return true
+	}
+
+	for _, rule := range suffixRules {
+		stem := strings.TrimSuffix(word, rule.suffix)
+		if stem == word || len(stem) < 2 {
+			continue
+		}
+		for _, ending := range rule.endings {
+			if _, ok := b.words[stem+ending]; ok {
+				return true
+			}
+		}
+		// Doubled final consonant: "stopped" → "stop"
+		if n := len(stem); n > 2 && stem[n-1] == stem[n-2] {
+			if _, ok := b.words[stem[:n-1]]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxEdits is the most edits a suggestion may be away from the word
+const maxEdits = 2
+
+// Suggest returns known words within two edits of word, preferring common
+// misspelling corrections, fewer edits and more frequent words. The
+// suggestions of each word are kept for when it comes again.
+func (b *BuiltinEngine) Suggest(word string) ([]string, error) {
+	lower := strings.ToLower(word)
+
+	b.suggestMu.Lock()
+	found, ok := b.suggestions[lower]
+	b.suggestMu.Unlock()
+	if !ok {
+		found = b.suggest(lower)
+		b.suggestMu.Lock()
+		b.suggestions[lower] = found
+		b.suggestMu.Unlock()
+	}
+
+	suggestions := make([]string, len(found))
+	for i, suggestion := range found {
+		suggestions[i] = matchCase(word, suggestion)
+	}
+	return suggestions, nil
+}
+
+// suggest finds the suggestions of a lowercase word with the deletions
+// index: a known word within two edits shares with the word a string at
+// most two deletions away from each
+func (b *BuiltinEngine) suggest(word string) []string {
+	b.indexOnce.Do(b.buildDeletesIndex)
+
+	type candidate struct {
+		word     string
+		distance int
+		rank     int
+	}
+	found := make(map[string]candidate)
+	if correction, ok := b.misspellings[word]; ok {
+		found[correction] = candidate{word: correction, distance: 0, rank: -1}
+	}
+	for _, variant := range deletions(word, maxEdits) {
+		hash := hashString(variant)
+		i := sort.Search(len(b.deletes), func(i int) bool { return b.deletes[i].hash >= hash })
+		// Hashes may collide; the edit distance sorts the words out
+		for ; i < len(b.deletes) && b.deletes[i].hash == hash; i++ {
+			known := b.ranked[b.deletes[i].rank]
+			if _, seen := found[known]; seen {
+				continue
+			}
+			if distance := editDistance(word, known); distance > 0 && distance <= maxEdits {
+				found[known] = candidate{word: known, distance: distance, rank: int(b.deletes[i].rank)}
+			}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(found))
+	for _, c := range found {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].rank != candidates[j].rank {
+			return candidates[i].rank < candidates[j].rank
+		}
+		return candidates[i].word < candidates[j].word
+	})
+
+	var suggestions []string
+	for _, c := range candidates {
+		suggestions = append(suggestions, c.word)
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
+
+// deleteEntry is a string up to two deletions away from a known word, by
+// hash, with the rank of the word
+type deleteEntry struct {
+	hash uint64
+	rank int32
+}
+
+// buildDeletesIndex indexes the known words by themselves and the strings
+// up to two deletions away from them, sorted by hash
+func (b *BuiltinEngine) buildDeletesIndex() {
+	b.ranked = make([]string, len(b.words))
+	for word, rank := range b.words {
+		b.ranked[rank] = word
+	}
+	for rank, word := range b.ranked {
+		for _, variant := range deletions(word, maxEdits) {
+			b.deletes = append(b.deletes, deleteEntry{hash: hashString(variant), rank: int32(rank)})
+		}
+	}
+	sort.Slice(b.deletes, func(i, j int) bool { return b.deletes[i].hash < b.deletes[j].hash })
+}
+
+// hashString returns the 64-bit FNV-1a hash of a string
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// deletions returns word and the distinct strings up to n deletions away
+// from it
+func deletions(word string, n int) []string {
+	seen := map[string]bool{word: true}
+	result := []string{word}
+	level := []string{word}
+	for ; n > 0; n-- {
+		var next []string
+		for _, w := range level {
+			for i := range w {
+				variant := w[:i] + w[i+1:]
+				if !seen[variant] {
+					seen[variant] = true
+					result = append(result, variant)
+					next = append(next, variant)
+				}
+			}
+		}
+		level = next
+	}
+	return result
+}
+
+// editDistance returns the number of deletions, insertions, replacements
+// and transpositions of adjacent letters that turn a into b
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+// matchCase applies the capitalization of the original word to a suggestion
+func matchCase(original, suggestion string) string {
+	if original == strings.ToUpper(original) && len(original) > 1 {
+		return strings.ToUpper(suggestion)
+	}
+	if first, _ := utf8.DecodeRuneInString(original); unicode.IsUpper(first) {
+		r, n := utf8.DecodeRuneInString(suggestion)
+		return string(unicode.ToUpper(r)) + suggestion[n:]
+	}
+	return suggestion
+}
@@ -0,0 +1,141 @@
+package spell
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testEngine returns a builtin engine over a small word list, ranked in
+// order
+func testEngine(words ...string) *BuiltinEngine {
+	engine := &BuiltinEngine{
+		words:        make(map[string]int),
+		misspellings: map[string]string{"recieve": "receive"},
+		suggestions:  make(map[string][]string),
+	}
+	for rank, word := range words {
+		engine.words[word] = rank
+	}
+	return engine
+}
+
+func TestBuiltinKnown(t *testing.T) {
+	engine := testEngine("stop", "happy", "configure", "namespace", "able")
+	tests := map[string]bool{
+		"stop":          true,
+		"Stop":          true,
+		"stopped":       true,
+		"stops":         true,
+		"happiness":     true,
+		"reconfigured":  true,
+		"namespace's":   true,
+		"unconfigure":   true,
+		"namespaec":     false,
+		"misconfigurez": false,
+	}
+	for word, want := range tests {
+		if got := engine.Known(word); got != want {
+			t.Errorf("Known(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestBuiltinSuggest(t *testing.T) {
+	engine := testEngine("the", "receive", "pod", "pods", "node", "nodes", "namespace")
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"teh", []string{"the"}},
+		{"recieve", []string{"receive"}},
+		{"namespaec", []string{"namespace"}},
+		{"Nodse", []string{"Node", "Nodes", "Pods"}},
+		{"PDO", []string{"POD", "PODS"}},
+		{"xyzzy", nil},
+	}
+	for _, tt := range tests {
+		got, err := engine.Suggest(tt.word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Suggest(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"pod", "pod", 0},
+		{"pod", "pods", 1},
+		{"teh", "the", 1},
+		{"namespaec", "namespace", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBuiltinEngineEnglish(t *testing.T) {
+	engine, err := NewBuiltinEngine("en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	misspelled, err := engine.Misspelled("The namespace can't recieve configured pods")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(misspelled, []string{"recieve"}) {
+		t.Errorf("Misspelled() = %v, want [recieve]", misspelled)
+	}
+	if suggestions, _ := engine.Suggest("recieve"); len(suggestions) == 0 || suggestions[0] != "receive" {
+		t.Errorf("Suggest(recieve) = %v, want receive first", suggestions)
+	}
+	if _, err := NewBuiltinEngine("xx"); err == nil {
+		t.Errorf("NewBuiltinEngine(xx) found a word list")
+	}
+}
+
+func TestBuiltinEngineRejectsMisspellings(t *testing.T) {
+	engine, err := NewBuiltinEngine("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	misspellings := []string{"tset", "teh", "snice", "recieve", "dont", "isnt", "extention", "targetted", "definedn"}
+	for wrong := range engine.misspellings {
+		misspellings = append(misspellings, wrong)
+	}
+	for _, word := range misspellings {
+		if engine.Known(word) {
+			t.Errorf("Known(%q) = true, want a misspelling", word)
+		}
+	}
+	for _, word := range []string{"test", "don't", "isn't", "extension", "targeted", "defined"} {
+		if !engine.Known(word) {
+			t.Errorf("Known(%q) = false", word)
+		}
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := map[string][]Subword{
+		"kubeletConfigFile": {{"kubelet", 0}, {"Config", 7}, {"File", 13}},
+		"HTTPServer":        {{"HTTP", 0}, {"Server", 4}},
+		"pod":               {{"pod", 0}},
+	}
+	for word, want := range tests {
+		if got := SplitIdentifier(word); !reflect.DeepEqual(got, want) {
+			t.Errorf("SplitIdentifier(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
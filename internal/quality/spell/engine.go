@@ -0,0 +1,131 @@
+package spell
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Engine names
+const (
+	EngineBuiltin  = "builtin"
+	EngineAspell   = "aspell"
+	EngineHunspell = "hunspell"
+)
+
+// maxSuggestions is the number of suggestions engines return
+const maxSuggestions = 5
+
+// Engine is a spell checking backend
+type Engine interface {
+	// Name returns the engine name
+	Name() string
+	// Misspelled returns the unique words of text the engine does not know,
+	// in order of first appearance
+	Misspelled(text string) ([]string, error)
+	// Suggest returns corrections for a misspelled word, best first
+	Suggest(word string) ([]string, error)
+}
+
+// Options configure a spell engine
+type Options struct {
+	// PersonalDict is an aspell-format word list of extra known words
+	PersonalDict string
+	// HunspellPersonalDict is the same words as a plain word list, the
+	// personal dictionary format of hunspell
+	HunspellPersonalDict string
+	// Dictionary is the hunspell dictionary name (en_US) or the path to its
+	// .dic file
+	Dictionary string
+	// Lang is the document language (en, fr, de, pt_BR, ...)
+	Lang string
+}
+
+// DefaultLang is the language checked when none is configured
+const DefaultLang = "en"
+
+// NormalizeLang turns a language tag such as "pt-br" into the form aspell
+// and hunspell use ("pt_BR")
+func NormalizeLang(lang string) string {
+	lang = strings.TrimSpace(strings.ReplaceAll(lang, "-", "_"))
+	if lang == "" {
+		return DefaultLang
+	}
+	base, region, ok := strings.Cut(lang, "_")
+	if !ok {
+		return strings.ToLower(base)
+	}
+	return strings.ToLower(base) + "_" + strings.ToUpper(region)
+}
+
+// baseLang returns the language part of a normalized language tag
+func baseLang(lang string) string {
+	base, _, _ := strings.Cut(lang, "_")
+	return base
+}
+
+// NewEngine creates the engine with the given name
+func NewEngine(name string, options Options) (Engine, error) {
+	lang := NormalizeLang(options.Lang)
+
+	switch strings.ToLower(name) {
+	case EngineBuiltin, "":
+		return NewBuiltinEngine(lang)
+	case EngineAspell:
+		return NewAspellEngine(lang, options.PersonalDict)
+	case EngineHunspell:
+		return NewHunspellEngine(lang, options.Dictionary, options.HunspellPersonalDict)
+	default:
+		return nil, fmt.Errorf("unsupported spell engine: %s (supported: %s, %s, %s)", name, EngineBuiltin, EngineAspell, EngineHunspell)
+	}
+}
+
+// wordPattern matches words the way aspell tokenizes them: Latin-script
+// letters, including accented ones, with optional inner apostrophes
+var wordPattern = regexp.MustCompile(`\p{Latin}+(?:'\p{Latin}+)*`)
+
+// uniqueWords returns the unique words of text in order of first appearance
+func uniqueWords(text string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, word := range wordPattern.FindAllString(text, -1) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Subword is a part of an identifier-like word
+type Subword struct {
+	Text string
+	// Offset is the byte offset of the part in the word
+	Offset int
+}
+
+// SplitIdentifier splits a camelCase or PascalCase word into its parts:
+// "kubeletConfigFile" becomes kubelet, Config, File and "HTTPServer" becomes
+// HTTP, Server. Other words are returned as a single part.
+func SplitIdentifier(word string) []Subword {
+	runes := []rune(word)
+	var parts []Subword
+	start, offset, startOffset := 0, 0, 0
+
+	for i, r := range runes {
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Split before an upper case letter that follows a lower case
+			// one, or that starts a word after an acronym
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower) {
+				parts = append(parts, Subword{Text: string(runes[start:i]), Offset: startOffset})
+				start, startOffset = i, offset
+			}
+		}
+		offset += utf8.RuneLen(r)
+	}
+	return append(parts, Subword{Text: string(runes[start:]), Offset: startOffset})
+}
@@ -0,0 +1,68 @@
+package spell
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// defaultHunspellDictionaries maps languages to the dictionary used when
+// only a language is configured
+var defaultHunspellDictionaries = map[string]string{
+	"en": "en_US",
+	"fr": "fr_FR",
+	"de": "de_DE",
+	"es": "es_ES",
+	"pt": "pt_PT",
+	"it": "it_IT",
+	"nl": "nl_NL",
+	"pl": "pl_PL",
+	"ru": "ru_RU",
+}
+
+// HunspellEngine checks spelling through a long-lived hunspell pipe process
+// using a .dic/.aff dictionary pair
+type HunspellEngine struct {
+	*pipeSession
+}
+
+// NewHunspellEngine creates an engine that shells out to hunspell. The
+// dictionary is either a name hunspell can find in its search path (en_US)
+// or the path to a .dic file with its .aff file next to it. Without one the
+// dictionary is derived from the language. personalDict is a plain word
+// list, without the header of aspell personal dictionaries.
+func NewHunspellEngine(lang, dictionary, personalDict string) (*HunspellEngine, error) {
+	if _, err := exec.LookPath("hunspell"); err != nil {
+		return nil, fmt.Errorf("hunspell not found in PATH. Please install hunspell or use the builtin engine")
+	}
+
+	if dictionary == "" {
+		dictionary = lang
+		if d, ok := defaultHunspellDictionaries[lang]; ok {
+			dictionary = d
+		}
+	}
+	if strings.HasSuffix(dictionary, ".dic") || strings.ContainsRune(dictionary, filepath.Separator) {
+		base := strings.TrimSuffix(dictionary, ".dic")
+		for _, ext := range []string{".dic", ".aff"} {
+			if _, err := os.Stat(base + ext); err != nil {
+				return nil, fmt.Errorf("hunspell dictionary file %s not found: %w", base+ext, err)
+			}
+		}
+		// hunspell expects the path without the extension
+		dictionary = base
+	}
+
+	args := []string{"-a", "-i", "utf-8", "-d", dictionary}
+	if personalDict != "" {
+		args = append(args, "-p", personalDict)
+	}
+	return &HunspellEngine{newPipeSession("hunspell", args...)}, nil
+}
+
+// Name returns the engine name
+func (h *HunspellEngine) Name() string {
+	return EngineHunspell
+}
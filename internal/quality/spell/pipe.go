@@ -0,0 +1,192 @@
+package spell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// pipeSession is a long-lived aspell or hunspell process speaking the
+// ispell pipe protocol. All text is streamed through the one process and the
+// suggestions of every misspelled word are kept, so Suggest does not start
+// another process.
+type pipeSession struct {
+	name string
+	args []string
+
+	mu          sync.Mutex
+	cmd         *exec.Cmd
+	stdin       io.WriteCloser
+	stdout      *bufio.Reader
+	suggestions map[string][]string
+}
+
+// newPipeSession prepares a session; the process starts on first use
+func newPipeSession(name string, args ...string) *pipeSession {
+	return &pipeSession{
+		name:        name,
+		args:        args,
+		suggestions: make(map[string][]string),
+	}
+}
+
+// start launches the process and reads its version banner
+func (p *pipeSession) start() error {
+	cmd := exec.Command(p.name, p.args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open %s stdin: %w", p.name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open %s stdout: %w", p.name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", p.name, err)
+	}
+
+	p.cmd = cmd
+	p.stdin = stdin
+	p.stdout = bufio.NewReader(stdout)
+
+	// The first line is the version banner
+	if _, err := p.stdout.ReadString('\n'); err != nil {
+		p.stop()
+		return fmt.Errorf("%s pipe failed to start: %w", p.name, err)
+	}
+	// Terse mode: correct words produce no output
+	if _, err := io.WriteString(p.stdin, "!\n"); err != nil {
+		p.stop()
+		return fmt.Errorf("%s pipe failed: %w", p.name, err)
+	}
+	return nil
+}
+
+// stop ends the process
+func (p *pipeSession) stop() {
+	if p.cmd == nil {
+		return
+	}
+	p.stdin.Close()
+	p.cmd.Wait()
+	p.cmd = nil
+}
+
+// Close ends the process if it is running
+func (p *pipeSession) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stop()
+	return nil
+}
+
+// Misspelled streams text through the process line by line and returns the
+// unique misspelled words in order of first appearance
+func (p *pipeSession) Misspelled(text string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd == nil {
+		if err := p.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var misspelled []string
+	for _, line := range strings.Split(text, "\n") {
+		words, err := p.checkLine(line)
+		if err != nil {
+			p.stop()
+			return nil, err
+		}
+		for _, word := range words {
+			if !seen[word] {
+				seen[word] = true
+				misspelled = append(misspelled, word)
+			}
+		}
+	}
+	return misspelled, nil
+}
+
+// checkLine sends one line and parses the response, which ends with an
+// empty line. A leading ^ keeps the line from being read as a command.
+func (p *pipeSession) checkLine(line string) ([]string, error) {
+	line = strings.ReplaceAll(line, "\r", "")
+	if _, err := io.WriteString(p.stdin, "^"+line+"\n"); err != nil {
+		return nil, fmt.Errorf("%s pipe failed: %w", p.name, err)
+	}
+
+	var words []string
+	for {
+		response, err := p.stdout.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%s pipe failed: %w", p.name, err)
+		}
+		response = strings.TrimRight(response, "\r\n")
+		if response == "" {
+			return words, nil
+		}
+
+		switch response[0] {
+		case '&':
+			// & word count offset: suggestion1, suggestion2, ...
+			fields := strings.Fields(response)
+			if len(fields) < 2 {
+				continue
+			}
+			words = append(words, fields[1])
+			p.suggestions[fields[1]] = parsePipeSuggestions(response)
+		case '#':
+			// # word offset
+			fields := strings.Fields(response)
+			if len(fields) < 2 {
+				continue
+			}
+			words = append(words, fields[1])
+			p.suggestions[fields[1]] = nil
+		}
+	}
+}
+
+// Suggest returns the suggestions of a word, from the ones collected while
+// checking when possible
+func (p *pipeSession) Suggest(word string) ([]string, error) {
+	p.mu.Lock()
+	suggestions, ok := p.suggestions[word]
+	p.mu.Unlock()
+	if ok {
+		return suggestions, nil
+	}
+
+	if _, err := p.Misspelled(word); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.suggestions[word], nil
+}
+
+// parsePipeSuggestions parses the suggestions of an ispell-style pipe
+// response as written by aspell and hunspell.
+// Format: & word count offset: suggestion1, suggestion2, ...
+func parsePipeSuggestions(response string) []string {
+	_, list, ok := strings.Cut(response, ":")
+	if !ok {
+		return nil
+	}
+
+	var suggestions []string
+	for _, suggestion := range strings.Split(list, ",") {
+		suggestions = append(suggestions, strings.TrimSpace(suggestion))
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,108 @@
+// Package zhconv finds traditional Chinese characters in simplified Chinese
+// text and converts them, with a built-in character table or with OpenCC.
+package zhconv
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed t2s.txt
+var t2sTable string
+
+var (
+	tableOnce    sync.Once
+	simplifiedOf map[rune]rune
+)
+
+// loadTable parses the embedded traditional to simplified table
+func loadTable() {
+	simplifiedOf = make(map[rune]rune)
+	scanner := bufio.NewScanner(strings.NewReader(t2sTable))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		traditional, _ := utf8.DecodeRuneInString(fields[0])
+		simplified, _ := utf8.DecodeRuneInString(fields[1])
+		simplifiedOf[traditional] = simplified
+	}
+}
+
+// Simplified returns the simplified form of a traditional-only character
+func Simplified(r rune) (rune, bool) {
+	tableOnce.Do(loadTable)
+	s, ok := simplifiedOf[r]
+	return s, ok
+}
+
+// IsTraditional reports whether r is a traditional character not used in
+// simplified Chinese
+func IsTraditional(r rune) bool {
+	_, ok := Simplified(r)
+	return ok
+}
+
+// ToSimplified converts the traditional characters of s one by one
+func ToSimplified(s string) string {
+	return strings.Map(func(r rune) rune {
+		if simplified, ok := Simplified(r); ok {
+			return simplified
+		}
+		return r
+	}, s)
+}
+
+// DefaultOpenCCConfig converts traditional to simplified characters
+const DefaultOpenCCConfig = "t2s"
+
+// OpenCC converts text with the opencc command, which also knows phrases
+// and regional vocabulary (e.g. the tw2sp config turns 伺服器 into 服务器)
+type OpenCC struct {
+	config string
+}
+
+// NewOpenCC creates a converter for an OpenCC config such as t2s, tw2sp or
+// hk2s
+func NewOpenCC(config string) (*OpenCC, error) {
+	if _, err := exec.LookPath("opencc"); err != nil {
+		return nil, fmt.Errorf("opencc not found in PATH. Please install OpenCC or check without --opencc")
+	}
+	if config == "" {
+		config = DefaultOpenCCConfig
+	}
+	if !strings.HasSuffix(config, ".json") {
+		config += ".json"
+	}
+	return &OpenCC{config: config}, nil
+}
+
+// Convert converts every text with a single opencc run
+func (o *OpenCC) Convert(texts []string) ([]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	cmd := exec.Command("opencc", "-c", o.config)
+	cmd.Stdin = strings.NewReader(strings.Join(texts, "\n") + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run opencc: %w", err)
+	}
+
+	converted := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(converted) != len(texts) {
+		return nil, fmt.Errorf("opencc returned %d lines for %d texts", len(converted), len(texts))
+	}
+	return converted, nil
+}
@@ -0,0 +1,524 @@
+// Package tui implements the full-screen terminal interfaces of mm, drawn
+// with ANSI escape sequences in raw terminal mode.
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Column is a column of the picker table
+type Column struct {
+	Title string
+	// Width is the display width of the column; the last column takes the
+	// rest of the line
+	Width int
+	// Right aligns the column to the right, for numbers
+	Right bool
+}
+
+// Item is a row of the picker
+type Item struct {
+	// Cells are the values of the columns
+	Cells []string
+	// Text is what the search query matches, the last cell by default
+	Text string
+	// Value is the caller's data of the row
+	Value any
+}
+
+// Sort is an order of the rows, cycled through with Ctrl-S
+type Sort struct {
+	Name string
+	Less func(a, b Item) bool
+}
+
+// Picker is a full-screen list to choose rows from, with fuzzy search,
+// multi-select, sortable rows and a preview of the current row
+type Picker struct {
+	Title   string
+	Columns []Column
+	Items   []Item
+	Sorts   []Sort
+	// Multi allows selecting several rows with Tab
+	Multi bool
+	// Preview returns the text shown for the current row with Ctrl-P
+	Preview func(item Item) (string, error)
+}
+
+// IsTerminal reports whether standard input and output are a terminal, as
+// the picker needs
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// pickerState is the state of a running picker
+type pickerState struct {
+	*Picker
+	query    []rune
+	sortBy   int
+	reverse  bool
+	visible  []int // indexes into Items, filtered and sorted
+	cursor   int   // index into visible
+	offset   int   // first visible row on screen
+	selected map[int]bool
+	preview  bool
+	previews map[int][]string
+	scroll   int // first line of the preview
+}
+
+// Run shows the picker until the user confirms or cancels. It returns the
+// selected rows, or the current row when none is selected; nil means
+// canceled.
+func (p *Picker) Run() ([]Item, error) {
+	if !IsTerminal() {
+		return nil, fmt.Errorf("the picker needs a terminal")
+	}
+	stdin := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	// Use the alternate screen and hide the cursor while picking
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		_ = term.Restore(stdin, oldState)
+	}()
+
+	s := &pickerState{
+		Picker:   p,
+		selected: make(map[int]bool),
+		previews: make(map[int][]string),
+	}
+	s.refresh()
+
+	buf := make([]byte, 256)
+	for {
+		s.draw()
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			done, items := s.handle(k)
+			if done {
+				return items, nil
+			}
+		}
+	}
+}
+
+// key is a key press: a named key or a typed rune
+type key struct {
+	name string
+	r    rune
+}
+
+// csiKeys names the escape sequences of the keys the picker uses, by what
+// follows ESC [ or ESC O
+var csiKeys = map[string]string{
+	"A": "up", "B": "down", "Z": "shift-tab",
+	"H": "home", "F": "end", "1~": "home", "4~": "end",
+	"5~": "pgup", "6~": "pgdown",
+}
+
+// controlKeys names the control characters the picker uses. Every
+// printable character goes to the query, so the commands are on these.
+var controlKeys = map[byte]string{
+	1: "ctrl-a", 3: "ctrl-c", 8: "backspace", 9: "tab", 10: "enter",
+	13: "enter", 14: "down", 16: "ctrl-p", 18: "ctrl-r", 19: "ctrl-s",
+	21: "ctrl-u", 0x7f: "backspace",
+}
+
+// parseKeys splits terminal input into key presses. Keys the picker
+// doesn't use have an empty name and no rune.
+func parseKeys(input []byte) []key {
+	var keys []key
+	for len(input) > 0 {
+		switch {
+		case input[0] == 0x1b && len(input) >= 3 && (input[1] == '[' || input[1] == 'O'):
+			// The sequence ends with a byte in @..~
+			end := 2
+			for end < len(input)-1 && (input[end] < 0x40 || input[end] > 0x7e) {
+				end++
+			}
+			keys = append(keys, key{name: csiKeys[string(input[2:end+1])]})
+			input = input[end+1:]
+		case input[0] == 0x1b:
+			keys = append(keys, key{name: "esc"})
+			input = input[1:]
+		case input[0] < 0x20 || input[0] == 0x7f:
+			keys = append(keys, key{name: controlKeys[input[0]]})
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			keys = append(keys, key{r: r})
+			input = input[size:]
+		}
+	}
+	return keys
+}
+
+// handle applies a key press; done is true once the picker closes
+func (s *pickerState) handle(k key) (done bool, items []Item) {
+	switch k.name {
+	case "esc", "ctrl-c":
+		return true, nil
+	case "enter":
+		return true, s.result()
+	case "up":
+		s.move(-1)
+	case "down":
+		s.move(1)
+	case "home":
+		s.move(-len(s.visible))
+	case "end":
+		s.move(len(s.visible))
+	case "pgup":
+		s.scroll = max(s.scroll-s.previewHeight(), 0)
+	case "pgdown":
+		s.scroll += s.previewHeight()
+	case "tab", "shift-tab":
+		// Select the row and go to the next one, or back with Shift-Tab
+		if s.Multi && len(s.visible) > 0 {
+			i := s.visible[s.cursor]
+			s.selected[i] = !s.selected[i]
+			if k.name == "tab" {
+				s.move(1)
+			} else {
+				s.move(-1)
+			}
+		}
+	case "ctrl-p":
+		if s.Preview != nil {
+			s.preview = !s.preview
+			s.scroll = 0
+		}
+	case "ctrl-s":
+		if len(s.Sorts) > 0 {
+			s.sortBy = (s.sortBy + 1) % len(s.Sorts)
+			s.refresh()
+		}
+	case "ctrl-r":
+		s.reverse = !s.reverse
+		s.refresh()
+	case "ctrl-a":
+		if s.Multi {
+			all := true
+			for _, i := range s.visible {
+				all = all && s.selected[i]
+			}
+			for _, i := range s.visible {
+				s.selected[i] = !all
+			}
+		}
+	case "ctrl-u":
+		s.query = nil
+		s.refresh()
+	case "backspace":
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.refresh()
+		}
+	case "":
+		if unicode.IsPrint(k.r) {
+			s.query = append(s.query, k.r)
+			s.refresh()
+		}
+	}
+	return false, nil
+}
+
+// result returns the selected rows in the current order, or the current row
+func (s *pickerState) result() []Item {
+	var items []Item
+	for _, i := range s.visible {
+		if s.selected[i] {
+			items = append(items, s.Items[i])
+		}
+	}
+	// Rows selected before the query hid them are kept too
+	for i := range s.Items {
+		if s.selected[i] && !containsInt(s.visible, i) {
+			items = append(items, s.Items[i])
+		}
+	}
+	if len(items) == 0 && len(s.visible) > 0 {
+		items = append(items, s.Items[s.visible[s.cursor]])
+	}
+	return items
+}
+
+// move moves the cursor by delta rows
+func (s *pickerState) move(delta int) {
+	s.cursor = min(max(s.cursor+delta, 0), max(len(s.visible)-1, 0))
+	s.scroll = 0
+}
+
+// refresh filters and sorts the rows after the query or order changed
+func (s *pickerState) refresh() {
+	query := strings.ToLower(string(s.query))
+	s.visible = s.visible[:0]
+	for i, item := range s.Items {
+		if fuzzyMatch(strings.ToLower(itemText(item)), query) {
+			s.visible = append(s.visible, i)
+		}
+	}
+	if len(s.Sorts) > 0 {
+		less := s.Sorts[s.sortBy].Less
+		sort.SliceStable(s.visible, func(a, b int) bool {
+			x, y := s.Items[s.visible[a]], s.Items[s.visible[b]]
+			if s.reverse {
+				return less(y, x)
+			}
+			return less(x, y)
+		})
+	}
+	s.cursor = min(s.cursor, max(len(s.visible)-1, 0))
+	s.scroll = 0
+}
+
+// itemText returns the text the query matches
+func itemText(item Item) string {
+	if item.Text != "" || len(item.Cells) == 0 {
+		return item.Text
+	}
+	return item.Cells[len(item.Cells)-1]
+}
+
+// fuzzyMatch reports whether the runes of query appear in text in order
+func fuzzyMatch(text, query string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// draw renders the picker on the whole screen
+func (s *pickerState) draw() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width < 20 || height < 8 {
+		width, height = 80, 24
+	}
+
+	var lines []string
+	status := fmt.Sprintf("%d/%d", len(s.visible), len(s.Items))
+	if n := countTrue(s.selected); n > 0 {
+		status += fmt.Sprintf(", %d selected", n)
+	}
+	if len(s.Sorts) > 0 {
+		status += ", sort: " + s.Sorts[s.sortBy].Name
+		if s.reverse {
+			status += " (reversed)"
+		}
+	}
+	lines = append(lines, "\x1b[1m"+s.Title+"\x1b[0m  ("+status+")")
+	lines = append(lines, "> "+string(s.query)+"\x1b[7m \x1b[0m")
+	lines = append(lines, "\x1b[1m"+s.row("    ", columnTitles(s.Columns), width)+"\x1b[0m")
+
+	// Rows take the screen above the preview and the help line
+	rowsHeight := height - len(lines) - 1
+	if s.preview {
+		rowsHeight -= s.previewHeight() + 1
+	}
+	rowsHeight = max(rowsHeight, 1)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+rowsHeight {
+		s.offset = s.cursor - rowsHeight + 1
+	}
+	for n := 0; n < rowsHeight; n++ {
+		if s.offset+n >= len(s.visible) {
+			lines = append(lines, "")
+			continue
+		}
+		i := s.visible[s.offset+n]
+		prefix := "  "
+		if s.Multi {
+			prefix = "[ ]"
+			if s.selected[i] {
+				prefix = "[x]"
+			}
+		}
+		line := s.row(prefix+" ", s.Items[i].Cells, width)
+		if s.offset+n == s.cursor {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		lines = append(lines, line)
+	}
+
+	if s.preview {
+		lines = append(lines, "\x1b[2m"+strings.Repeat("─", width)+"\x1b[0m")
+		preview := s.previewLines()
+		s.scroll = min(s.scroll, max(len(preview)-1, 0))
+		for n := 0; n < s.previewHeight(); n++ {
+			if s.scroll+n < len(preview) {
+				lines = append(lines, colorDiff(truncate(preview[s.scroll+n], width)))
+			} else {
+				lines = append(lines, "")
+			}
+		}
+	}
+
+	help := "↑/↓ move  type to search  ctrl-s sort  ctrl-r reverse  enter confirm  esc quit"
+	if s.Multi {
+		help = "tab select  ctrl-a all  " + help
+	}
+	if s.Preview != nil {
+		help = "ctrl-p preview  pgup/pgdn scroll  " + help
+	}
+	lines = append(lines, "\x1b[2m"+truncate(help, width)+"\x1b[0m")
+
+	fmt.Print("\x1b[H\x1b[2J" + strings.Join(lines, "\r\n"))
+}
+
+// previewHeight returns the lines the preview takes, half of the screen
+func (s *pickerState) previewHeight() int {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || height < 8 {
+		height = 24
+	}
+	return height / 2
+}
+
+// previewLines returns the preview of the current row, computed once
+func (s *pickerState) previewLines() []string {
+	if len(s.visible) == 0 {
+		return nil
+	}
+	i := s.visible[s.cursor]
+	if lines, ok := s.previews[i]; ok {
+		return lines
+	}
+	text, err := s.Preview(s.Items[i])
+	if err != nil {
+		text = "Error: " + err.Error()
+	}
+	text = strings.ReplaceAll(strings.TrimRight(text, "\n"), "\t", "    ")
+	lines := strings.Split(text, "\n")
+	s.previews[i] = lines
+	return lines
+}
+
+// row lays out cells in the columns, after a prefix
+func (s *pickerState) row(prefix string, cells []string, width int) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for c, column := range s.Columns {
+		cell := ""
+		if c < len(cells) {
+			cell = cells[c]
+		}
+		if c == len(s.Columns)-1 {
+			b.WriteString(cell)
+			break
+		}
+		cell = truncate(cell, column.Width)
+		pad := strings.Repeat(" ", column.Width-displayWidth(cell))
+		if column.Right {
+			b.WriteString(pad + cell + "  ")
+		} else {
+			b.WriteString(cell + pad + "  ")
+		}
+	}
+	return truncate(b.String(), width)
+}
+
+// columnTitles returns the titles of the columns
+func columnTitles(columns []Column) []string {
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	return titles
+}
+
+// colorDiff colors the added and deleted lines of a unified diff
+func colorDiff(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return "\x1b[1m" + line + "\x1b[0m"
+	case strings.HasPrefix(line, "+"):
+		return "\x1b[32m" + line + "\x1b[0m"
+	case strings.HasPrefix(line, "-"):
+		return "\x1b[31m" + line + "\x1b[0m"
+	case strings.HasPrefix(line, "@@"):
+		return "\x1b[36m" + line + "\x1b[0m"
+	}
+	return line
+}
+
+// truncate cuts text to a display width
+func truncate(text string, width int) string {
+	if displayWidth(text) <= width {
+		return text
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := runeWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
+
+// displayWidth returns the columns text takes in a terminal
+func displayWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the columns a rune takes: two for East Asian wide
+// characters, none for combining marks
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r):
+		return 0
+	case r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7A3, r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFE30 && r <= 0xFE4F, r >= 0xFF00 && r <= 0xFF60,
+		r >= 0xFFE0 && r <= 0xFFE6, r >= 0x20000 && r <= 0x3FFFD:
+		return 2
+	}
+	return 1
+}
+
+// containsInt reports whether values contains v
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// countTrue counts the true values of a set
+func countTrue(set map[int]bool) int {
+	n := 0
+	for _, v := range set {
+		if v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// Span is a piece of text drawn with an ANSI style, such as "\x1b[32m";
+// plain text has no style
+type Span struct {
+	Text  string
+	Style string
+}
+
+// IsTerminalOutput reports whether standard output is a terminal, where
+// colors can be used
+func IsTerminalOutput() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Width returns the width of the terminal on standard output, 80 when it
+// isn't a terminal
+func Width() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
+
+// Wrap lays spans out in lines of at most width columns, breaking between
+// words where possible and wherever the text has line breaks. Text without
+// spaces, like Chinese, breaks anywhere. A width of zero or less keeps the
+// lines as they are.
+func Wrap(spans []Span, width int) []string {
+	var lines []string
+	var line strings.Builder
+	used := 0
+	// Each line opens and resets its styles, so lines stand alone
+	current := ""
+	endLine := func() {
+		text := line.String()
+		if current != "" {
+			text += "\x1b[0m"
+			current = ""
+		} else {
+			text = strings.TrimRight(text, " ")
+		}
+		lines = append(lines, text)
+		line.Reset()
+		used = 0
+	}
+	write := func(text, style string) {
+		if style != current {
+			if current != "" {
+				line.WriteString("\x1b[0m")
+			}
+			line.WriteString(style)
+			current = style
+		}
+		line.WriteString(text)
+		used += displayWidth(text)
+	}
+
+	for _, span := range spans {
+		for _, piece := range splitPieces(span.Text) {
+			w := displayWidth(piece)
+			switch {
+			case piece == "\n":
+				endLine()
+			case width <= 0:
+				write(piece, span.Style)
+			case strings.TrimSpace(piece) == "":
+				// Spaces don't start or overflow a line
+				if used > 0 && used+w <= width {
+					write(piece, span.Style)
+				} else if used > 0 {
+					endLine()
+				}
+			default:
+				if used > 0 && used+w > width {
+					// Wide text like Chinese fills the line, other words
+					// move to the next one
+					if first := []rune(piece)[0]; runeWidth(first) == 2 && used+2 <= width {
+						head, rest := cutWidth(piece, width-used)
+						write(head, span.Style)
+						piece = rest
+					}
+					endLine()
+				}
+				for displayWidth(piece) > width {
+					head, rest := cutWidth(piece, width-used)
+					write(head, span.Style)
+					endLine()
+					piece = rest
+				}
+				write(piece, span.Style)
+			}
+		}
+	}
+	if used > 0 || line.Len() > 0 || len(lines) == 0 {
+		endLine()
+	}
+	return lines
+}
+
+// splitPieces splits text into words, runs of spaces and line breaks
+func splitPieces(text string) []string {
+	var pieces []string
+	start := 0
+	kind := func(r rune) int {
+		switch {
+		case r == '\n':
+			return 0
+		case unicode.IsSpace(r):
+			return 1
+		}
+		return 2
+	}
+	runes := []rune(text)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || kind(runes[i]) != kind(runes[start]) || kind(runes[i]) == 0 {
+			pieces = append(pieces, string(runes[start:i]))
+			start = i
+		}
+	}
+	return pieces
+}
+
+// cutWidth splits text after at most width columns, keeping at least one
+// rune in the head
+func cutWidth(text string, width int) (string, string) {
+	used := 0
+	for i, r := range text {
+		w := runeWidth(r)
+		if used+w > width && i > 0 {
+			return text[:i], text[i:]
+		}
+		used += w
+	}
+	return text, ""
+}
+
+// SideBySide lays out two columns of lines next to each other, the left
+// one padded to leftWidth columns, separated by a vertical bar
+func SideBySide(left, right []string, leftWidth int) []string {
+	rows := max(len(left), len(right))
+	lines := make([]string, rows)
+	for i := 0; i < rows; i++ {
+		l, r := "", ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		pad := max(leftWidth-visibleWidth(l), 0)
+		lines[i] = l + strings.Repeat(" ", pad) + " │ " + r
+	}
+	return lines
+}
+
+// visibleWidth returns the display width of text, skipping ANSI escape
+// sequences
+func visibleWidth(text string) int {
+	width := 0
+	inEscape := false
+	for _, r := range text {
+		switch {
+		case r == '\x1b':
+			inEscape = true
+		case inEscape:
+			if r >= '@' && r <= '~' && r != '[' {
+				inEscape = false
+			}
+		default:
+			width += runeWidth(r)
+		}
+	}
+	return width
+}